@@ -1,25 +1,167 @@
 package temporalcli
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/exec"
 	"os/user"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/blang/semver/v4"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/pflag"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"github.com/temporalio/cli/temporalcli/internal/printer"
 	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/log"
+	"go.temporal.io/server/api/adminservice/v1"
+	"golang.org/x/oauth2/clientcredentials"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
-func (c *ClientOptions) dialClient(cctx *CommandContext) (client.Client, error) {
+func (c *ClientOptions) dialClient(cctx *CommandContext, extraDialOptions ...grpc.DialOption) (client.Client, error) {
+	if cctx.offline {
+		return nil, fmt.Errorf("command is offline-only and cannot connect to a server")
+	}
+	clientOptions, err := c.buildClientOptions(cctx, extraDialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Dial(clientOptions)
+}
+
+// Checks for invalid or conflicting options without running the credential
+// helper, reading files, or connecting, so it is safe to run offline.
+func (c *ClientOptions) validate(cctx *CommandContext) error {
+	// API key or OAuth
+	if c.OauthTokenUrl != "" {
+		if c.ApiKey != "" {
+			return fmt.Errorf("cannot use --api-key with --oauth-token-url")
+		} else if c.OauthClientId == "" || c.OauthClientSecret == "" {
+			return fmt.Errorf("--oauth-client-id and --oauth-client-secret required with --oauth-token-url")
+		}
+	}
+	if c.CredentialHelper != "" {
+		if c.ApiKey != "" || c.OauthTokenUrl != "" {
+			return fmt.Errorf("cannot use --credential-helper with --api-key or --oauth-token-url")
+		}
+		if args, err := splitCommandLine(c.CredentialHelper); err != nil {
+			return fmt.Errorf("invalid credential helper: %w", err)
+		} else if len(args) == 0 {
+			return fmt.Errorf("credential helper is empty")
+		}
+	}
+
+	// Headers and codecs
+	for _, kv := range c.GrpcMeta {
+		if !strings.Contains(kv, "=") {
+			return fmt.Errorf("gRPC meta of %q does not have '='", kv)
+		}
+	}
+	if _, err := parseDataConverterPlugins(c.DataConverterPlugin); err != nil {
+		return err
+	}
+
+	// Connection
+	if c.GrpcMaxMessageSize < 0 {
+		return fmt.Errorf("gRPC max message size cannot be negative")
+	}
+	if addresses := addressList(c.Address); len(addresses) > 1 {
+		for _, address := range addresses {
+			if _, _, err := net.SplitHostPort(address); err != nil || strings.Contains(address, "/") {
+				return fmt.Errorf("invalid address %q in address list, must be host:port", address)
+			}
+		}
+	}
+	if c.GrpcLoadBalancing.Value != "" && c.GrpcServiceConfig != "" {
+		return fmt.Errorf("cannot use --grpc-load-balancing with --grpc-service-config")
+	} else if c.GrpcServiceConfig != "" && !json.Valid([]byte(c.GrpcServiceConfig)) {
+		return fmt.Errorf("gRPC service config is not valid JSON")
+	}
+	if _, unix := unixSocketPath(c.Address); unix {
+		if c.Proxy != "" {
+			return fmt.Errorf("cannot use --proxy with a unix socket address")
+		}
+	} else if _, err := c.proxyURL(cctx); err != nil {
+		return err
+	}
+
+	// TLS
+	for _, names := range c.TlsCipherSuites {
+		// Env values are a single comma-separated string
+		for _, name := range strings.Split(names, ",") {
+			if cipherSuiteID(strings.TrimSpace(name)) == 0 {
+				return fmt.Errorf("unknown TLS cipher suite %q", name)
+			}
+		}
+	}
+	if c.TlsSpiffeSocket != "" &&
+		(c.TlsCertPath != "" || c.TlsCertData != "" || c.TlsKeyPath != "" || c.TlsKeyData != "") {
+		return fmt.Errorf("cannot specify --tls-spiffe-socket with client cert or key options")
+	} else if c.TlsSpiffeSocket != "" {
+		if err := workloadapi.ValidateAddress(c.TlsSpiffeSocket); err != nil {
+			return fmt.Errorf("invalid --tls-spiffe-socket: %w", err)
+		}
+	}
+	if c.TlsSpiffeServerId != "" {
+		if c.TlsSpiffeSocket == "" {
+			return fmt.Errorf("cannot specify --tls-spiffe-server-id without --tls-spiffe-socket")
+		} else if _, err := spiffeid.FromString(c.TlsSpiffeServerId); err != nil {
+			return fmt.Errorf("invalid --tls-spiffe-server-id: %w", err)
+		}
+	}
+	if c.TlsCertPath != "" && c.TlsCertData != "" {
+		return fmt.Errorf("cannot specify both --tls-cert-path and --tls-cert-data")
+	}
+	if c.TlsCaPath != "" && c.TlsCaData != "" {
+		return fmt.Errorf("cannot specify both --tls-ca-path and --tls-ca-data")
+	}
+	return nil
+}
+
+// Builds the SDK client options, failing on invalid or conflicting options,
+// without connecting.
+func (c *ClientOptions) buildClientOptions(
+	cctx *CommandContext,
+	extraDialOptions ...grpc.DialOption,
+) (client.Options, error) {
+	if err := c.validate(cctx); err != nil {
+		return client.Options{}, err
+	}
 	clientOptions := client.Options{
 		HostPort:  c.Address,
 		Namespace: c.Namespace,
@@ -28,60 +170,374 @@ func (c *ClientOptions) dialClient(cctx *CommandContext) (client.Client, error)
 		// We do not put codec on data converter here, it is applied via
 		// interceptor. Same for failure conversion.
 		// XXX: If this is altered to be more dynamic, have to also update
-		// everywhere DataConverterWithRawValue is used.
+		// everywhere DataConverterWithRawValue is used. Data converter plugins
+		// replace it below.
 		DataConverter: DataConverterWithRawValue,
 	}
 
-	// API key
-	if c.ApiKey != "" {
-		clientOptions.Credentials = client.NewAPIKeyStaticCredentials(c.ApiKey)
+	// Read-only, which is outermost so nothing else runs for refused calls
+	if cctx.readOnly {
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(readOnlyInterceptor))
 	}
 
-	// Headers
-	if len(c.GrpcMeta) > 0 {
-		headers := make(stringMapHeadersProvider, len(c.GrpcMeta))
-		for _, kv := range c.GrpcMeta {
-			pieces := strings.SplitN(kv, "=", 2)
-			if len(pieces) != 2 {
-				return nil, fmt.Errorf("gRPC meta of %q does not have '='", kv)
+	// API key or OAuth
+	if c.OauthTokenUrl != "" {
+		conf := &clientcredentials.Config{
+			ClientID:     c.OauthClientId,
+			ClientSecret: c.OauthClientSecret,
+			TokenURL:     c.OauthTokenUrl,
+		}
+		// This source caches the token until it expires
+		tokenSource := conf.TokenSource(cctx)
+		clientOptions.Credentials = client.NewAPIKeyDynamicCredentials(func(context.Context) (string, error) {
+			token, err := tokenSource.Token()
+			if err != nil {
+				return "", fmt.Errorf("failed getting OAuth token: %w", err)
 			}
-			headers[pieces[0]] = pieces[1]
+			return token.AccessToken, nil
+		})
+	} else if c.ApiKey != "" {
+		clientOptions.Credentials = client.NewAPIKeyStaticCredentials(c.ApiKey)
+	}
+
+	// Credential helper, which is outermost so it can retry the whole call
+	if c.CredentialHelper != "" {
+		// Already validated
+		args, _ := splitCommandLine(c.CredentialHelper)
+		helper := &credentialHelper{args: args}
+		if err := helper.refresh(cctx); err != nil {
+			return client.Options{}, err
 		}
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(helper.intercept))
+	}
+
+	// Headers, with explicit gRPC meta overriding routing headers
+	headers := stringMapHeadersProvider{}
+	if c.TargetCluster != "" {
+		headers[targetClusterHeader] = c.TargetCluster
+	}
+	if c.RoutingKey != "" {
+		headers[routingKeyHeader] = c.RoutingKey
+	}
+	for _, kv := range c.GrpcMeta {
+		name, value, _ := strings.Cut(kv, "=")
+		headers[name] = value
+	}
+	if len(headers) > 0 {
 		clientOptions.HeadersProvider = headers
 	}
 
+	// Proto descriptors, which are outermost so they decode after all codecs
+	if len(c.ProtoDescriptors) > 0 {
+		files, err := loadProtoDescriptors(c.ProtoDescriptors)
+		if err != nil {
+			return client.Options{}, err
+		}
+		interceptor, err := converter.NewPayloadCodecGRPCClientInterceptor(
+			converter.PayloadCodecGRPCClientInterceptorOptions{
+				Codecs: []converter.PayloadCodec{&protoDescriptorsPayloadCodec{files: files}},
+			},
+		)
+		if err != nil {
+			return client.Options{}, fmt.Errorf("failed creating proto descriptors interceptor: %w", err)
+		}
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(interceptor))
+	}
+
+	// Data converter plugins, which are also outermost. They are on the data
+	// converter for values read from payloads and on an interceptor so payloads
+	// are shown readably everywhere.
+	if len(c.DataConverterPlugin) > 0 {
+		// Already validated
+		plugins, _ := parseDataConverterPlugins(c.DataConverterPlugin)
+		codec := &pluginPayloadCodec{plugins: map[string]*pluginPayloadConverter{}}
+		converters := make([]converter.PayloadConverter, len(plugins))
+		for i, plugin := range plugins {
+			codec.plugins[plugin.encoding] = plugin
+			converters[i] = plugin
+		}
+		clientOptions.DataConverter = newDataConverterWithRawValue(converters...)
+		interceptor, err := converter.NewPayloadCodecGRPCClientInterceptor(
+			converter.PayloadCodecGRPCClientInterceptorOptions{Codecs: []converter.PayloadCodec{codec}},
+		)
+		if err != nil {
+			return client.Options{}, fmt.Errorf("failed creating data converter plugin interceptor: %w", err)
+		}
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(interceptor))
+	}
+
 	// Remote codec
 	if c.CodecEndpoint != "" {
 		interceptor, err := payloadCodecInterceptor(c.Namespace, c.CodecEndpoint, c.CodecAuth)
 		if err != nil {
-			return nil, fmt.Errorf("failed creating payload codec interceptor: %w", err)
+			return client.Options{}, fmt.Errorf("failed creating payload codec interceptor: %w", err)
 		}
 		clientOptions.ConnectionOptions.DialOptions = append(
 			clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(interceptor))
 	}
 
+	// Page size reduction when throttled, which is outside the retries so
+	// those are exhausted at the current page size first
+	if cctx.pageThrottler == nil {
+		cctx.pageThrottler = newPageThrottler(c.GrpcRetryBackoff.Duration())
+	}
+	clientOptions.ConnectionOptions.DialOptions = append(
+		clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(cctx.pageThrottler.intercept))
+
+	// Retries, which are outside the per-call timeout so each attempt gets it
+	if c.GrpcRetryMaxAttempts > 1 {
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions,
+			grpc.WithChainUnaryInterceptor(retryInterceptor(c.GrpcRetryMaxAttempts, c.GrpcRetryBackoff.Duration())))
+	}
+
+	// Per-call timeout
+	if c.GrpcCallTimeout.Duration() > 0 {
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions,
+			grpc.WithChainUnaryInterceptor(callTimeoutInterceptor(c.GrpcCallTimeout.Duration())))
+	}
+
 	// Fixed header overrides
 	clientOptions.ConnectionOptions.DialOptions = append(
 		clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(fixedHeaderOverrideInterceptor))
 
+	// RPC recording for --verbose
+	if cctx.rpcRecorder != nil {
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(cctx.rpcRecorder.intercept))
+	}
+
+	// RPC logging for --grpc-debug, after the others so it sees their headers
+	if cctx.grpcDebug {
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithChainUnaryInterceptor(grpcDebugInterceptor(cctx.Logger)))
+	}
+
 	// Additional gRPC options
 	clientOptions.ConnectionOptions.DialOptions = append(
 		clientOptions.ConnectionOptions.DialOptions, cctx.Options.AdditionalClientGRPCDialOptions...)
+	clientOptions.ConnectionOptions.DialOptions = append(
+		clientOptions.ConnectionOptions.DialOptions, extraDialOptions...)
+
+	// Keepalive and message size, where zero values use the SDK defaults
+	clientOptions.ConnectionOptions.KeepAliveTime = c.GrpcKeepaliveTime.Duration()
+	clientOptions.ConnectionOptions.KeepAliveTimeout = c.GrpcKeepaliveTimeout.Duration()
+	clientOptions.ConnectionOptions.MaxPayloadSize = c.GrpcMaxMessageSize
+
+	// Compression, where the server compresses responses the same way
+	if c.GrpcCompression.Value == "gzip" {
+		clientOptions.ConnectionOptions.DialOptions = append(clientOptions.ConnectionOptions.DialOptions,
+			grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	}
 
 	// TLS
 	var err error
 	if clientOptions.ConnectionOptions.TLS, err = c.tlsConfig(); err != nil {
-		return nil, err
+		return client.Options{}, err
 	}
 
-	return client.Dial(clientOptions)
+	// Multiple addresses, which the SDK's round-robin policy balances requests
+	// across, skipping ones that are down
+	if addresses := addressList(c.Address); len(addresses) > 1 {
+		var state resolver.State
+		for _, address := range addresses {
+			host, _, _ := net.SplitHostPort(address)
+			// Each is verified against its own host name unless overridden
+			state.Addresses = append(state.Addresses, resolver.Address{Addr: address, ServerName: host})
+		}
+		r := manual.NewBuilderWithScheme("temporal-addresses")
+		r.InitialState(state)
+		clientOptions.HostPort = r.Scheme() + ":///" + addresses[0]
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithResolvers(r))
+	}
+
+	// Load balancing policy or service config, replacing the SDK's default
+	// round-robin config. A single host:port is resolved with DNS so requests can
+	// be balanced across every address it resolves to instead of only one.
+	if c.GrpcLoadBalancing.Value != "" || c.GrpcServiceConfig != "" {
+		serviceConfig := c.GrpcServiceConfig
+		if c.GrpcLoadBalancing.Value != "" {
+			serviceConfig = fmt.Sprintf(`{"loadBalancingConfig": [{%q: {}}]}`, c.GrpcLoadBalancing.Value)
+		}
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithDefaultServiceConfig(serviceConfig))
+		if _, unix := unixSocketPath(c.Address); !unix && !strings.Contains(c.Address, ",") &&
+			!strings.Contains(c.Address, "://") {
+			clientOptions.HostPort = "dns:///" + c.Address
+		}
+	}
+
+	// Unix socket, dialed directly so it is never proxied. The target is only
+	// used as the authority, e.g. for the default TLS server name.
+	if socketPath, ok := unixSocketPath(c.Address); ok {
+		clientOptions.HostPort = "passthrough:///localhost"
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions,
+			grpc.WithNoProxy(),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			}))
+		return clientOptions, nil
+	}
+
+	// Proxy, replacing gRPC's own HTTPS_PROXY handling
+	if proxyURL, err := c.proxyURL(cctx); err != nil {
+		return client.Options{}, err
+	} else if proxyURL != nil {
+		dialer, err := proxyDialer(proxyURL)
+		if err != nil {
+			return client.Options{}, err
+		}
+		clientOptions.ConnectionOptions.DialOptions = append(
+			clientOptions.ConnectionOptions.DialOptions, grpc.WithNoProxy(), grpc.WithContextDialer(dialer))
+	}
+
+	return clientOptions, nil
+}
+
+// Returns each address of a comma-separated address list.
+func addressList(address string) []string {
+	var addresses []string
+	for _, address := range strings.Split(address, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// Returns the host:port of an address, which may be a dns:/// target.
+func addressHostPort(address string) string {
+	return strings.TrimPrefix(address, "dns:///")
+}
+
+// Returns the socket path if the address is a unix socket, in the form
+// unix:///absolute/path or unix:relative/path.
+func unixSocketPath(address string) (string, bool) {
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		return path, path != ""
+	} else if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		return path, path != ""
+	}
+	return "", false
+}
+
+// Dials a client and also returns an admin service client on the same
+// connection, so the admin calls get every option the client does. The admin
+// service is not part of the public API, so callers should expect servers that
+// do not support it.
+func (c *ClientOptions) dialAdminClient(
+	cctx *CommandContext,
+) (client.Client, adminservice.AdminServiceClient, error) {
+	// The SDK does not expose its connection, but dialing makes a system info
+	// call which lets us capture it
+	var conn atomic.Pointer[grpc.ClientConn]
+	cl, err := c.dialClient(cctx, grpc.WithChainUnaryInterceptor(func(
+		ctx context.Context,
+		method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		conn.CompareAndSwap(nil, cc)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}))
+	if err != nil {
+		return nil, nil, err
+	} else if conn.Load() == nil {
+		cl.Close()
+		return nil, nil, fmt.Errorf("failed obtaining connection for admin service")
+	}
+	return cl, adminservice.NewAdminServiceClient(conn.Load()), nil
+}
+
+// A feature that older servers may not support
+type serverFeature struct {
+	name       string
+	minVersion semver.Version
+	// If set, the server must also report this capability
+	capability func(*workflowservice.GetSystemInfoResponse_Capabilities) bool
+}
+
+var (
+	serverFeatureWorkflowUpdate = serverFeature{
+		name:       "workflow update",
+		minVersion: semver.MustParse("1.21.0"),
+	}
+	serverFeatureSchedules = serverFeature{
+		name:       "schedules",
+		minVersion: semver.MustParse("1.20.0"),
+		capability: (*workflowservice.GetSystemInfoResponse_Capabilities).GetSupportsSchedules,
+	}
+	serverFeatureBuildIDVersioning = serverFeature{
+		name:       "worker build ID versioning",
+		minVersion: semver.MustParse("1.21.0"),
+		capability: (*workflowservice.GetSystemInfoResponse_Capabilities).GetBuildIdBasedVersioning,
+	}
+)
+
+// Returns a descriptive error if the server is too old for or does not report
+// the capability for the given feature, instead of letting the call fail with
+// a raw unimplemented error.
+func (c *ClientOptions) checkServerFeature(cctx *CommandContext, cl client.Client, feature serverFeature) error {
+	if c.SkipCapabilityCheck {
+		return nil
+	}
+	const skipHint = "use --skip-capability-check to try anyway"
+	info, err := cl.WorkflowService().GetSystemInfo(cctx, &workflowservice.GetSystemInfoRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("server doesn't support %v, requires >= %v (%v)", feature.name, feature.minVersion, skipHint)
+	} else if err != nil {
+		return fmt.Errorf("failed getting server capabilities: %w", err)
+	}
+	// Servers may not report a version, and pre-release suffixes are ignored
+	if version, err := semver.ParseTolerant(info.ServerVersion); err == nil {
+		version = semver.Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch}
+		if version.LT(feature.minVersion) {
+			return fmt.Errorf("server %v doesn't support %v, requires >= %v (%v)",
+				version, feature.name, feature.minVersion, skipHint)
+		}
+	}
+	if feature.capability != nil && !feature.capability(info.Capabilities) {
+		return fmt.Errorf("server doesn't report support for %v (%v)", feature.name, skipHint)
+	}
+	return nil
+}
+
+// Builds client options solely from the values of the given env config,
+// ignoring flags and environment variables.
+func clientOptionsFromEnvConfig(cctx *CommandContext, envName string) (*ClientOptions, error) {
+	values, ok := cctx.EnvConfigValues[envName]
+	if !ok {
+		return nil, fmt.Errorf("environment %q not found", envName)
+	}
+	var opts ClientOptions
+	flags := pflag.NewFlagSet(envName, pflag.ContinueOnError)
+	opts.buildFlags(cctx, flags)
+	for k, v := range values {
+		if flag := flags.Lookup(k); flag != nil {
+			v, err := cctx.resolveEnvConfigValue(envName, v)
+			if err != nil {
+				return nil, fmt.Errorf("failed getting %v from environment %q: %w", k, envName, err)
+			} else if err := flag.Value.Set(v); err != nil {
+				return nil, fmt.Errorf("failed setting %v from environment %q: %w", k, envName, err)
+			}
+		}
+	}
+	return &opts, nil
 }
 
 func (c *ClientOptions) tlsConfig() (*tls.Config, error) {
 	// We need TLS if any of these TLS options are set
 	if !c.Tls &&
 		c.TlsCaPath == "" && c.TlsCertPath == "" && c.TlsKeyPath == "" &&
-		c.TlsCaData == "" && c.TlsCertData == "" && c.TlsKeyData == "" {
+		c.TlsCaData == "" && c.TlsCertData == "" && c.TlsKeyData == "" &&
+		c.TlsMinVersion.Value == "" && len(c.TlsCipherSuites) == 0 && c.TlsSpiffeSocket == "" {
 		return nil, nil
 	}
 
@@ -90,15 +546,57 @@ func (c *ClientOptions) tlsConfig() (*tls.Config, error) {
 		InsecureSkipVerify: c.TlsDisableHostVerification,
 	}
 
-	if c.TlsCertPath != "" {
-		if c.TlsCertData != "" {
-			return nil, fmt.Errorf("cannot specify both --tls-cert-path and --tls-cert-data")
+	switch c.TlsMinVersion.Value {
+	case "1.0":
+		conf.MinVersion = tls.VersionTLS10
+	case "1.1":
+		conf.MinVersion = tls.VersionTLS11
+	case "1.2":
+		conf.MinVersion = tls.VersionTLS12
+	case "1.3":
+		conf.MinVersion = tls.VersionTLS13
+	}
+	if len(c.TlsCipherSuites) > 0 {
+		for _, names := range c.TlsCipherSuites {
+			for _, name := range strings.Split(names, ",") {
+				conf.CipherSuites = append(conf.CipherSuites, cipherSuiteID(strings.TrimSpace(name)))
+			}
 		}
-		clientCert, err := tls.LoadX509KeyPair(c.TlsCertPath, c.TlsKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed loading client cert key pair: %w", err)
+	}
+
+	if c.TlsSpiffeSocket != "" {
+		source := &spiffeX509Source{addr: c.TlsSpiffeSocket}
+		if _, err := source.GetX509SVID(); err != nil {
+			return nil, err
 		}
-		conf.Certificates = append(conf.Certificates, clientCert)
+		// Validated already
+		serverID, _ := spiffeid.FromString(c.TlsSpiffeServerId)
+		if c.TlsCaPath == "" && c.TlsCaData == "" && !c.TlsDisableHostVerification {
+			// Servers in a mesh usually only have an SVID too
+			tlsconfig.HookMTLSClientConfig(conf, source, source, source.serverAuthorizer(serverID, c.TlsServerName))
+		} else {
+			conf.GetClientCertificate = tlsconfig.GetClientCertificate(source)
+			if !serverID.IsZero() {
+				conf.VerifyConnection = func(state tls.ConnectionState) error {
+					if id, err := x509svid.IDFromCert(state.PeerCertificates[0]); err != nil {
+						return fmt.Errorf("server certificate has no SPIFFE ID: %w", err)
+					} else if id != serverID {
+						return fmt.Errorf("server SPIFFE ID %v is not the expected %v", id, serverID)
+					}
+					return nil
+				}
+			}
+		}
+	}
+
+	if c.TlsCertPath != "" {
+		// Files are reloaded on change so long-running commands outlive
+		// short-lived certs
+		keyPair := &reloadingKeyPair{certPath: c.TlsCertPath, keyPath: c.TlsKeyPath}
+		if _, err := keyPair.get(nil); err != nil {
+			return nil, err
+		}
+		conf.GetClientCertificate = keyPair.get
 	} else if c.TlsCertData != "" {
 		clientCert, err := tls.X509KeyPair([]byte(c.TlsCertData), []byte(c.TlsKeyData))
 		if err != nil {
@@ -108,9 +606,6 @@ func (c *ClientOptions) tlsConfig() (*tls.Config, error) {
 	}
 
 	if c.TlsCaPath != "" {
-		if c.TlsCaData != "" {
-			return nil, fmt.Errorf("cannot specify both --tls-ca-path and --tls-ca-data")
-		}
 		conf.RootCAs = x509.NewCertPool()
 		if b, err := os.ReadFile(c.TlsCaPath); err != nil {
 			return nil, fmt.Errorf("failed reading CA cert from %v: %w", c.TlsCaPath, err)
@@ -126,6 +621,261 @@ func (c *ClientOptions) tlsConfig() (*tls.Config, error) {
 	return conf, nil
 }
 
+// Returns the ID of the named TLS cipher suite, or 0 if unknown.
+func cipherSuiteID(name string) uint16 {
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		if suite.Name == name {
+			return suite.ID
+		}
+	}
+	return 0
+}
+
+// Client cert key pair from files that is reloaded when either file changes
+// or the cert has expired.
+type reloadingKeyPair struct {
+	certPath string
+	keyPath  string
+
+	mtx       sync.Mutex
+	cert      *tls.Certificate
+	certMtime time.Time
+	keyMtime  time.Time
+}
+
+func (r *reloadingKeyPair) get(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	certStat, err := os.Stat(r.certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading client cert: %w", err)
+	}
+	keyStat, err := os.Stat(r.keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading client key: %w", err)
+	}
+	if r.cert != nil && certStat.ModTime().Equal(r.certMtime) && keyStat.ModTime().Equal(r.keyMtime) &&
+		time.Now().Before(r.cert.Leaf.NotAfter) {
+		return r.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading client cert key pair: %w", err)
+	} else if cert.Leaf == nil {
+		// Not set by older Go versions
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return nil, fmt.Errorf("failed parsing client cert: %w", err)
+		}
+	}
+	r.cert, r.certMtime, r.keyMtime = &cert, certStat.ModTime(), keyStat.ModTime()
+	return r.cert, nil
+}
+
+// Retries read-only calls that fail with transient errors. Paged requests the
+// server throttles are left to the pageThrottler, which also reduces their page
+// size.
+func retryInterceptor(maxAttempts int, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		if !isReadOnlyMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		msg, _ := req.(proto.Message)
+		paged := pageSizeField(msg) != nil
+		for attempt := 1; ; attempt++ {
+			attemptCtx := ctx
+			if attempt > 1 {
+				// Same header the SDK retrier uses, so --verbose counts these
+				attemptCtx = metadata.AppendToOutgoingContext(ctx, "x-retry-attempty", strconv.Itoa(attempt))
+			}
+			err := invoker(attemptCtx, method, req, reply, cc, opts...)
+			code := status.Code(err)
+			if attempt >= maxAttempts || (code != codes.Unavailable && (code != codes.ResourceExhausted || paged)) {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+}
+
+const (
+	// Assumed page size of requests that leave it to the server
+	pageThrottlerDefaultPageSize = 1000
+	pageThrottlerMinPageSize     = 10
+	pageThrottlerMaxAttempts     = 10
+	pageThrottlerMaxBackoff      = 30 * time.Second
+)
+
+// Halves the page size of list and history requests the server rejects with
+// ResourceExhausted and retries them after a backoff, so large exports slow
+// down instead of failing partway through. This is the only retrying of
+// ResourceExhausted for these requests. Page sizes stay reduced for later
+// requests of the same method, and a summary is printed at the end of the
+// command.
+type pageThrottler struct {
+	backoff time.Duration
+
+	lock      sync.Mutex
+	pageSizes map[string]int32
+	throttled int
+	waited    time.Duration
+}
+
+func newPageThrottler(backoff time.Duration) *pageThrottler {
+	return &pageThrottler{backoff: backoff, pageSizes: map[string]int32{}}
+}
+
+func (t *pageThrottler) intercept(
+	ctx context.Context,
+	method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	msg, _ := req.(proto.Message)
+	field := pageSizeField(msg)
+	if field == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	// The request is updated in place so callers that reuse it for later pages
+	// keep the reduced size
+	m := msg.ProtoReflect()
+	backoff := t.backoff
+	for attempt := 1; ; attempt++ {
+		t.lock.Lock()
+		if size := t.pageSizes[method]; size > 0 && (m.Get(field).Int() == 0 || m.Get(field).Int() > int64(size)) {
+			m.Set(field, protoreflect.ValueOfInt32(size))
+		}
+		t.lock.Unlock()
+		attemptCtx := ctx
+		if attempt > 1 {
+			// Same header as retryInterceptor, so --verbose counts these
+			attemptCtx = metadata.AppendToOutgoingContext(ctx, "x-retry-attempty", strconv.Itoa(attempt))
+		}
+		err := invoker(attemptCtx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.ResourceExhausted || attempt >= pageThrottlerMaxAttempts {
+			return err
+		}
+		t.lock.Lock()
+		size := int32(m.Get(field).Int())
+		if size == 0 {
+			size = pageThrottlerDefaultPageSize
+		}
+		t.pageSizes[method] = max(size/2, pageThrottlerMinPageSize)
+		t.throttled++
+		t.waited += backoff
+		t.lock.Unlock()
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, pageThrottlerMaxBackoff)
+	}
+}
+
+// Returns the int32 page size field of paged requests, or nil if there is
+// none.
+func pageSizeField(msg proto.Message) protoreflect.FieldDescriptor {
+	if msg == nil {
+		return nil
+	}
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	if fields.ByName("next_page_token") == nil {
+		return nil
+	}
+	for _, name := range []protoreflect.Name{"page_size", "maximum_page_size"} {
+		if field := fields.ByName(name); field != nil && field.Kind() == protoreflect.Int32Kind {
+			return field
+		}
+	}
+	return nil
+}
+
+func (t *pageThrottler) printSummary(w io.Writer) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.throttled == 0 {
+		return nil
+	}
+	methods := make([]string, 0, len(t.pageSizes))
+	for method := range t.pageSizes {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+	sizes := make([]string, len(methods))
+	for i, method := range methods {
+		sizes[i] = fmt.Sprintf("%v to %v", method[strings.LastIndex(method, "/")+1:], t.pageSizes[method])
+	}
+	_, err := fmt.Fprintf(w, "Server throttled %v request(s), waited %v in total and reduced page size of %v\n",
+		t.throttled, t.waited, strings.Join(sizes, ", "))
+	return err
+}
+
+// Applies a timeout to each call that is not a long poll.
+func callTimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		switch req := req.(type) {
+		case *workflowservice.GetWorkflowExecutionHistoryRequest:
+			if req.WaitNewEvent {
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+		case *workflowservice.UpdateWorkflowExecutionRequest, *workflowservice.PollWorkflowExecutionUpdateRequest:
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// Fails calls to RPCs that may change state.
+func readOnlyInterceptor(
+	ctx context.Context,
+	method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	if !isReadOnlyMethod(method) {
+		// Not a status the client will retry
+		return status.Errorf(codes.FailedPrecondition, "refusing to call %v in read-only mode", method)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+var readOnlyMethodPrefixes = []string{"Describe", "Get", "List", "Count", "Scan"}
+
+var readOnlyMethods = map[string]bool{
+	"QueryWorkflow":                true,
+	"PollWorkflowExecutionUpdate":  true,
+	"/grpc.health.v1.Health/Check": true,
+}
+
+func isReadOnlyMethod(fullMethod string) bool {
+	if readOnlyMethods[fullMethod] {
+		return true
+	}
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if readOnlyMethods[name] {
+		return true
+	}
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func fixedHeaderOverrideInterceptor(
 	ctx context.Context,
 	method string, req, reply any,
@@ -167,6 +917,246 @@ func payloadCodecInterceptor(namespace, codecEndpoint, codecAuth string) (grpc.U
 	)
 }
 
+// Payload converter for a custom encoding that runs a plugin command to convert
+// payloads to JSON. Only converting from payloads is supported.
+type pluginPayloadConverter struct {
+	encoding string
+	args     []string
+}
+
+// Parses plugins given as encoding=command.
+func parseDataConverterPlugins(specs []string) ([]*pluginPayloadConverter, error) {
+	plugins := make([]*pluginPayloadConverter, 0, len(specs))
+	for _, spec := range specs {
+		encoding, command, ok := strings.Cut(spec, "=")
+		if !ok || encoding == "" {
+			return nil, fmt.Errorf("data converter plugin of %q is not encoding=command", spec)
+		}
+		args, err := splitCommandLine(command)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data converter plugin for %v: %w", encoding, err)
+		} else if len(args) == 0 {
+			return nil, fmt.Errorf("data converter plugin for %v is empty", encoding)
+		}
+		plugins = append(plugins, &pluginPayloadConverter{encoding: encoding, args: args})
+	}
+	return plugins, nil
+}
+
+func (*pluginPayloadConverter) ToPayload(any) (*common.Payload, error) {
+	return nil, nil
+}
+
+func (p *pluginPayloadConverter) FromPayload(payload *common.Payload, valuePtr any) error {
+	b, err := p.toJSON(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, valuePtr)
+}
+
+func (p *pluginPayloadConverter) ToString(payload *common.Payload) string {
+	b, err := p.toJSON(payload)
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return string(b)
+}
+
+func (p *pluginPayloadConverter) Encoding() string {
+	return p.encoding
+}
+
+func (p *pluginPayloadConverter) toJSON(payload *common.Payload) ([]byte, error) {
+	in, err := protojson.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling payload for data converter plugin: %w", err)
+	}
+	cmd := exec.Command(p.args[0], append(p.args[1:], "to-json")...)
+	cmd.Stdin = bytes.NewReader(in)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("data converter plugin for %v failed: %w: %s",
+			p.encoding, err, strings.TrimSpace(stderr.String()))
+	} else if !json.Valid(out) {
+		return nil, fmt.Errorf("data converter plugin for %v did not output JSON", p.encoding)
+	}
+	return bytes.TrimSpace(out), nil
+}
+
+// Codec that only decodes, converting payloads with data converter plugin
+// encodings to JSON payloads so they are shown readably.
+type pluginPayloadCodec struct {
+	plugins map[string]*pluginPayloadConverter
+}
+
+func (*pluginPayloadCodec) Encode(payloads []*common.Payload) ([]*common.Payload, error) {
+	return payloads, nil
+}
+
+func (p *pluginPayloadCodec) Decode(payloads []*common.Payload) ([]*common.Payload, error) {
+	ret := make([]*common.Payload, len(payloads))
+	for i, payload := range payloads {
+		ret[i] = payload
+		plugin := p.plugins[string(payload.Metadata["encoding"])]
+		if plugin == nil {
+			continue
+		}
+		data, err := plugin.toJSON(payload)
+		if err != nil {
+			// Decoding happens inside the RPC, so give failures a status the
+			// client will not retry
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		ret[i] = &common.Payload{Metadata: map[string][]byte{"encoding": []byte("json/plain")}, Data: data}
+	}
+	return ret, nil
+}
+
+// Runs an external command for credentials, like Docker and kubectl credential
+// helpers. Credentials are refreshed when the server rejects them.
+type credentialHelper struct {
+	args []string
+
+	lock  sync.RWMutex
+	creds credentialHelperOutput
+}
+
+type credentialHelperOutput struct {
+	ApiKey  string            `json:"apiKey"`
+	Headers map[string]string `json:"headers"`
+}
+
+func (c *credentialHelper) refresh(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, c.args[0], c.args[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("credential helper failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var creds credentialHelperOutput
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return fmt.Errorf("failed unmarshaling credential helper output: %w", err)
+	}
+	c.lock.Lock()
+	c.creds = creds
+	c.lock.Unlock()
+	return nil
+}
+
+func (c *credentialHelper) intercept(
+	ctx context.Context,
+	method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	call := func() error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		md = md.Copy()
+		c.lock.RLock()
+		for k, v := range c.creds.Headers {
+			md.Set(k, v)
+		}
+		if c.creds.ApiKey != "" {
+			md.Set("authorization", "Bearer "+c.creds.ApiKey)
+		}
+		c.lock.RUnlock()
+		return invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+	}
+	err := call()
+	if code := status.Code(err); code == codes.Unauthenticated || code == codes.PermissionDenied {
+		if refreshErr := c.refresh(ctx); refreshErr != nil {
+			return fmt.Errorf("%w (and failed refreshing credentials: %v)", err, refreshErr)
+		}
+		err = call()
+	}
+	return err
+}
+
+// Loads FileDescriptorSet files into a registry. Imports missing from the sets,
+// such as well-known types, are taken from the types built into the CLI.
+func loadProtoDescriptors(paths []string) (*protoregistry.Files, error) {
+	var set descriptorpb.FileDescriptorSet
+	seen := map[string]bool{}
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading proto descriptors: %w", err)
+		}
+		var fileSet descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(b, &fileSet); err != nil {
+			return nil, fmt.Errorf("failed parsing proto descriptors in %v: %w", path, err)
+		}
+		for _, file := range fileSet.File {
+			if !seen[file.GetName()] {
+				seen[file.GetName()] = true
+				set.File = append(set.File, file)
+			}
+		}
+	}
+	for i := 0; i < len(set.File); i++ {
+		for _, dep := range set.File[i].Dependency {
+			if seen[dep] {
+				continue
+			}
+			if file, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+				seen[dep] = true
+				set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+			}
+		}
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proto descriptors: %w", err)
+	}
+	return files, nil
+}
+
+// Codec that only decodes, converting binary protobuf payloads of known message
+// types to proto JSON payloads so they are shown readably.
+type protoDescriptorsPayloadCodec struct {
+	files *protoregistry.Files
+}
+
+func (*protoDescriptorsPayloadCodec) Encode(payloads []*common.Payload) ([]*common.Payload, error) {
+	return payloads, nil
+}
+
+func (p *protoDescriptorsPayloadCodec) Decode(payloads []*common.Payload) ([]*common.Payload, error) {
+	ret := make([]*common.Payload, len(payloads))
+	for i, payload := range payloads {
+		ret[i] = payload
+		// Payloads that can't be converted are left as they are
+		if string(payload.Metadata["encoding"]) != "binary/protobuf" {
+			continue
+		}
+		messageType := payload.Metadata["messageType"]
+		desc, err := p.files.FindDescriptorByName(protoreflect.FullName(messageType))
+		if err != nil {
+			continue
+		}
+		msgDesc, ok := desc.(protoreflect.MessageDescriptor)
+		if !ok {
+			continue
+		}
+		msg := dynamicpb.NewMessage(msgDesc)
+		if err := proto.Unmarshal(payload.Data, msg); err != nil {
+			continue
+		}
+		data, err := protojson.MarshalOptions{Resolver: dynamicpb.NewTypes(p.files)}.Marshal(msg)
+		if err != nil {
+			continue
+		}
+		ret[i] = &common.Payload{
+			Metadata: map[string][]byte{"encoding": []byte("json/protobuf"), "messageType": messageType},
+			Data:     data,
+		}
+	}
+	return ret, nil
+}
+
 func clientIdentity() string {
 	hostname, err := os.Hostname()
 	if err != nil {
@@ -179,20 +1169,32 @@ func clientIdentity() string {
 	return "temporal-cli:" + username + "@" + hostname
 }
 
+// Headers that gateways in front of multiple clusters can route on
+const (
+	targetClusterHeader = "temporal-target-cluster"
+	routingKeyHeader    = "temporal-routing-key"
+)
+
 type stringMapHeadersProvider map[string]string
 
 func (s stringMapHeadersProvider) GetHeaders(context.Context) (map[string]string, error) {
 	return s, nil
 }
 
-var DataConverterWithRawValue = converter.NewCompositeDataConverter(
-	rawValuePayloadConverter{},
-	converter.NewNilPayloadConverter(),
-	converter.NewByteSlicePayloadConverter(),
-	converter.NewProtoJSONPayloadConverter(),
-	converter.NewProtoPayloadConverter(),
-	converter.NewJSONPayloadConverter(),
-)
+var DataConverterWithRawValue = newDataConverterWithRawValue()
+
+// Creates a data converter supporting raw values, with the given converters for
+// other encodings, e.g. from data converter plugins.
+func newDataConverterWithRawValue(extra ...converter.PayloadConverter) converter.DataConverter {
+	return converter.NewCompositeDataConverter(append([]converter.PayloadConverter{
+		rawValuePayloadConverter{},
+		converter.NewNilPayloadConverter(),
+		converter.NewByteSlicePayloadConverter(),
+		converter.NewProtoJSONPayloadConverter(),
+		converter.NewProtoPayloadConverter(),
+		converter.NewJSONPayloadConverter(),
+	}, extra...)...)
+}
 
 type RawValue struct{ Payload *common.Payload }
 
@@ -218,3 +1220,130 @@ func (rawValuePayloadConverter) Encoding() string {
 	// Should never be used
 	return "raw-value-encoding"
 }
+
+// Records every RPC made by clients so a summary can be printed at the end of
+// the command for --verbose.
+type rpcRecorder struct {
+	start time.Time
+	lock  sync.Mutex
+	calls []*rpcCall
+	// Retry attempts reuse the same request, so this lets them be attributed to
+	// the original call
+	callsByRequest map[any]*rpcCall
+}
+
+type rpcCall struct {
+	method   string
+	start    time.Time
+	duration time.Duration
+	retries  int
+	sent     int
+	received int
+	code     codes.Code
+}
+
+func newRPCRecorder() *rpcRecorder {
+	return &rpcRecorder{start: time.Now(), callsByRequest: map[any]*rpcCall{}}
+}
+
+func (r *rpcRecorder) intercept(
+	ctx context.Context,
+	method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	end := time.Now()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// The SDK's retrier sets this header on every attempt after the first
+	md, _ := metadata.FromOutgoingContext(ctx)
+	call := r.callsByRequest[req]
+	if call != nil && len(md.Get("x-retry-attempty")) > 0 {
+		call.retries++
+	} else {
+		call = &rpcCall{method: method, start: start}
+		r.calls = append(r.calls, call)
+		r.callsByRequest[req] = call
+	}
+	call.duration = end.Sub(call.start)
+	call.code = status.Code(err)
+	if msg, ok := req.(proto.Message); ok {
+		call.sent += proto.Size(msg)
+	}
+	if msg, ok := reply.(proto.Message); ok && err == nil {
+		call.received += proto.Size(msg)
+	}
+	return err
+}
+
+// Logs each RPC with its headers, request, and response or error. The logger
+// redacts credentials unless --no-redact is set.
+func grpcDebugInterceptor(logger *slog.Logger) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		logger.Info("gRPC request", "method", method, "headers", md, "request", protoJSONString(req))
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			logger.Info("gRPC error", "method", method, "duration", time.Since(start), "error", err)
+		} else {
+			logger.Info("gRPC response", "method", method, "duration", time.Since(start), "response", protoJSONString(reply))
+		}
+		return err
+	}
+}
+
+func protoJSONString(v any) string {
+	if msg, ok := v.(proto.Message); ok {
+		return protojson.MarshalOptions{}.Format(msg)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func (r *rpcRecorder) printSummary(w io.Writer) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	total := time.Since(r.start)
+	type row struct {
+		Method   string
+		Duration string
+		Retries  int
+		Sent     string
+		Received string
+		Status   string
+	}
+	rows := make([]row, len(r.calls))
+	var rpcTime time.Duration
+	for i, call := range r.calls {
+		rpcTime += call.duration
+		rows[i] = row{
+			Method:   call.method[strings.LastIndex(call.method, "/")+1:],
+			Duration: call.duration.Round(time.Millisecond).String(),
+			Retries:  call.retries,
+			Sent:     humanize.Bytes(uint64(call.sent)),
+			Received: humanize.Bytes(uint64(call.received)),
+			Status:   call.code.String(),
+		}
+	}
+	p := &printer.Printer{Output: w}
+	p.Println()
+	if len(rows) > 0 {
+		if err := p.PrintStructured(rows, printer.StructuredOptions{Table: &printer.TableOptions{}}); err != nil {
+			return err
+		}
+	}
+	// RPCs may be concurrent, so time outside of them is only approximate
+	clientTime := total - rpcTime
+	if clientTime < 0 {
+		clientTime = 0
+	}
+	p.Printlnf("%v RPC(s) took %v of %v total, about %v spent in the CLI", len(rows),
+		rpcTime.Round(time.Millisecond), total.Round(time.Millisecond), clientTime.Round(time.Millisecond))
+	return nil
+}
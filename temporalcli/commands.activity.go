@@ -1,10 +1,16 @@
 package temporalcli
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/temporalio/cli/temporalcli/internal/printer"
 	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/failure/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 )
 
@@ -35,6 +41,112 @@ func (c *TemporalActivityCompleteCommand) run(cctx *CommandContext, args []strin
 	return nil
 }
 
+func (c *TemporalActivityDescribeCommand) run(cctx *CommandContext, args []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	resp, err := cl.DescribeWorkflowExecution(cctx, c.WorkflowId, c.RunId)
+	if err != nil {
+		return fmt.Errorf("failed describing workflow: %w", err)
+	}
+	var info *workflow.PendingActivityInfo
+	for _, a := range resp.PendingActivities {
+		if a.ActivityId == c.ActivityId {
+			info = a
+			break
+		}
+	}
+	if info == nil {
+		return fmt.Errorf("activity %q is not pending in workflow %v", c.ActivityId, c.WorkflowId)
+	}
+
+	// Timeouts and task queue are only on the scheduled event. Use the last one
+	// for the activity ID in case it was ever reused.
+	var scheduled *history.ActivityTaskScheduledEventAttributes
+	iter := cl.GetWorkflowHistory(cctx, c.WorkflowId, resp.WorkflowExecutionInfo.Execution.RunId,
+		false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("failed getting history: %w", err)
+		}
+		if attrs := event.GetActivityTaskScheduledEventAttributes(); attrs.GetActivityId() == c.ActivityId {
+			scheduled = attrs
+		}
+	}
+
+	if cctx.JSONOutput {
+		// Add the scheduled event attributes and friendly heartbeat details to the
+		// pending info JSON
+		var obj map[string]any
+		b, err := cctx.MarshalProtoJSON(info)
+		if err != nil {
+			return fmt.Errorf("failed marshaling pending activity: %w", err)
+		} else if err := json.Unmarshal(b, &obj); err != nil {
+			return fmt.Errorf("failed unmarshaling: %w", err)
+		}
+		if scheduled != nil {
+			if b, err = cctx.MarshalProtoJSON(scheduled); err != nil {
+				return fmt.Errorf("failed marshaling scheduled event: %w", err)
+			}
+			obj["scheduledEventAttributes"] = json.RawMessage(b)
+		}
+		if info.HeartbeatDetails != nil {
+			if obj["heartbeatDetailsDecoded"], err = cctx.MarshalFriendlyJSONPayloads(info.HeartbeatDetails); err != nil {
+				return fmt.Errorf("failed marshaling heartbeat details: %w", err)
+			}
+		}
+		return cctx.Printer.PrintStructured(obj, printer.StructuredOptions{})
+	}
+
+	assignedBuildID := info.GetLastIndependentlyAssignedBuildId()
+	if info.GetUseWorkflowBuildId() != nil {
+		assignedBuildID = resp.WorkflowExecutionInfo.GetAssignedBuildId()
+	}
+	return cctx.Printer.PrintStructured(struct {
+		ActivityId             string
+		Type                   string
+		State                  enums.PendingActivityState
+		TaskQueue              string `cli:",cardOmitEmpty"`
+		Attempt                int32
+		MaximumAttempts        int32
+		ScheduledTime          time.Time
+		LastStartedTime        time.Time         `cli:",cardOmitEmpty"`
+		LastHeartbeatTime      time.Time         `cli:",cardOmitEmpty"`
+		ExpirationTime         time.Time         `cli:",cardOmitEmpty"`
+		ScheduleToCloseTimeout time.Duration     `cli:",cardOmitEmpty"`
+		ScheduleToStartTimeout time.Duration     `cli:",cardOmitEmpty"`
+		StartToCloseTimeout    time.Duration     `cli:",cardOmitEmpty"`
+		HeartbeatTimeout       time.Duration     `cli:",cardOmitEmpty"`
+		AssignedBuildId        string            `cli:",cardOmitEmpty"`
+		LastWorkerIdentity     string            `cli:",cardOmitEmpty"`
+		LastFailure            *failure.Failure  `cli:",cardOmitEmpty"`
+		HeartbeatDetails       []*common.Payload `cli:",cardOmitEmpty"`
+	}{
+		ActivityId:             info.ActivityId,
+		Type:                   info.ActivityType.GetName(),
+		State:                  info.State,
+		TaskQueue:              scheduled.GetTaskQueue().GetName(),
+		Attempt:                info.Attempt,
+		MaximumAttempts:        info.MaximumAttempts,
+		ScheduledTime:          timestampToTime(info.ScheduledTime),
+		LastStartedTime:        timestampToTime(info.LastStartedTime),
+		LastHeartbeatTime:      timestampToTime(info.LastHeartbeatTime),
+		ExpirationTime:         timestampToTime(info.ExpirationTime),
+		ScheduleToCloseTimeout: scheduled.GetScheduleToCloseTimeout().AsDuration(),
+		ScheduleToStartTimeout: scheduled.GetScheduleToStartTimeout().AsDuration(),
+		StartToCloseTimeout:    scheduled.GetStartToCloseTimeout().AsDuration(),
+		HeartbeatTimeout:       scheduled.GetHeartbeatTimeout().AsDuration(),
+		AssignedBuildId:        assignedBuildID,
+		LastWorkerIdentity:     info.LastWorkerIdentity,
+		LastFailure:            info.LastFailure,
+		HeartbeatDetails:       info.HeartbeatDetails.GetPayloads(),
+	}, printer.StructuredOptions{})
+}
+
 func (c *TemporalActivityFailCommand) run(cctx *CommandContext, args []string) error {
 	cl, err := c.Parent.ClientOptions.dialClient(cctx)
 	if err != nil {
@@ -2,10 +2,12 @@ package temporalcli_test
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/client"
 )
 
@@ -106,6 +108,66 @@ func (s *SharedServerSuite) TestActivity_Fail_InvalidDetail() {
 	s.Nil(failed)
 }
 
+func (s *SharedServerSuite) TestActivity_Describe() {
+	s.Worker().OnDevActivity(func(ctx context.Context, a any) (any, error) {
+		activity.RecordHeartbeat(ctx, "heartbeat-detail")
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.Eventually(func() bool {
+		resp, err := s.Client.DescribeWorkflowExecution(s.Context, run.GetID(), run.GetRunID())
+		s.NoError(err)
+		return len(resp.PendingActivities) > 0 && resp.PendingActivities[0].HeartbeatDetails != nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Text
+	res := s.Execute(
+		"activity", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--activity-id", "dev-activity-id",
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "ActivityId", "dev-activity-id")
+	s.ContainsOnSameLine(out, "State", "Started")
+	s.ContainsOnSameLine(out, "TaskQueue", s.Worker().Options.TaskQueue)
+	s.ContainsOnSameLine(out, "StartToCloseTimeout")
+	s.ContainsOnSameLine(out, "HeartbeatDetails", "heartbeat-detail")
+
+	// JSON
+	res = s.Execute(
+		"activity", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--activity-id", "dev-activity-id",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var jsonOut map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal("dev-activity-id", jsonOut["activityId"])
+	s.Equal("heartbeat-detail", jsonOut["heartbeatDetailsDecoded"])
+	s.Equal(s.Worker().Options.TaskQueue,
+		jsonOut["scheduledEventAttributes"].(map[string]any)["taskQueue"].(map[string]any)["name"])
+
+	// Unknown activity
+	res = s.Execute(
+		"activity", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--activity-id", "does-not-exist",
+	)
+	s.ErrorContains(res.Err, `activity "does-not-exist" is not pending`)
+}
+
 // Test helpers
 
 func (s *SharedServerSuite) waitActivityStarted() client.WorkflowRun {
@@ -1,11 +1,14 @@
 package temporalcli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
@@ -36,17 +39,33 @@ func (c TemporalBatchDescribeCommand) run(cctx *CommandContext, args []string) e
 	}
 	defer cl.Close()
 
+	resp, err := describeBatchJob(cctx, cl, c.Parent.Namespace, c.JobId)
+	if err != nil {
+		return err
+	}
+	return printBatchDescribe(cctx, resp)
+}
+
+func describeBatchJob(
+	cctx *CommandContext,
+	cl client.Client,
+	namespace string,
+	jobID string,
+) (*workflowservice.DescribeBatchOperationResponse, error) {
 	resp, err := cl.WorkflowService().DescribeBatchOperation(cctx, &workflowservice.DescribeBatchOperationRequest{
-		Namespace: c.Parent.Namespace,
-		JobId:     c.JobId,
+		Namespace: namespace,
+		JobId:     jobID,
 	})
 	var notFound *serviceerror.NotFound
 	if errors.As(err, &notFound) {
-		return fmt.Errorf("could not find Batch Job '%v'", c.JobId)
+		return nil, fmt.Errorf("could not find Batch Job '%v'", jobID)
 	} else if err != nil {
-		return fmt.Errorf("failed to describe batch job: %w", err)
+		return nil, fmt.Errorf("failed to describe batch job: %w", err)
 	}
+	return resp, nil
+}
 
+func printBatchDescribe(cctx *CommandContext, resp *workflowservice.DescribeBatchOperationResponse) error {
 	if cctx.JSONOutput {
 		return cctx.Printer.PrintStructured(resp, printer.StructuredOptions{})
 	}
@@ -74,9 +93,30 @@ func (c TemporalBatchListCommand) run(cctx *CommandContext, args []string) error
 	cctx.Printer.StartList()
 	defer cctx.Printer.EndList()
 
+	// Parse filters up front so bad values fail before listing
+	var states []enums.BatchOperationState
+	for _, v := range c.State {
+		state, err := stringToProtoEnum[enums.BatchOperationState](
+			v, enums.BatchOperationState_shorthandValue, enums.BatchOperationState_value)
+		if err != nil {
+			return fmt.Errorf("invalid state: %w", err)
+		}
+		states = append(states, state)
+	}
+	var opTypes []enums.BatchOperationType
+	for _, v := range c.OperationType {
+		opType, err := stringToProtoEnum[enums.BatchOperationType](
+			v, enums.BatchOperationType_shorthandValue, enums.BatchOperationType_value)
+		if err != nil {
+			return fmt.Errorf("invalid operation type: %w", err)
+		}
+		opTypes = append(opTypes, opType)
+	}
+
 	pageFetcher := c.pageFetcher(cctx, cl)
 	var nextPageToken []byte
 	var jobsProcessed int
+	var printedHeader bool
 	for pageIndex := 0; ; pageIndex++ {
 		page, err := pageFetcher(nextPageToken)
 		if err != nil {
@@ -92,6 +132,18 @@ func (c TemporalBatchListCommand) run(cctx *CommandContext, args []string) error
 			if c.Limit > 0 && jobsProcessed >= c.Limit {
 				break
 			}
+			if len(states) > 0 && !slices.Contains(states, job.State) {
+				continue
+			}
+			if len(opTypes) > 0 {
+				// Operation type is only available on describe
+				desc, err := describeBatchJob(cctx, cl, c.Parent.Namespace, job.JobId)
+				if err != nil {
+					return err
+				} else if !slices.Contains(opTypes, desc.OperationType) {
+					continue
+				}
+			}
 			jobsProcessed++
 			// For JSON we are going to dump one line of JSON per execution
 			if cctx.JSONOutput {
@@ -106,11 +158,13 @@ func (c TemporalBatchListCommand) run(cctx *CommandContext, args []string) error
 				})
 			}
 		}
-		// Print table, headers only on first table
+		// Print table, headers only on first table (which may not be the first
+		// page when filtering)
 		if len(textTable) > 0 {
 			_ = cctx.Printer.PrintStructured(textTable, printer.StructuredOptions{
-				Table: &printer.TableOptions{NoHeader: pageIndex > 0},
+				Table: &printer.TableOptions{NoHeader: printedHeader},
 			})
+			printedHeader = true
 		}
 		// Stop if next page token non-existing or list reached limit
 		nextPageToken = page.GetNextPageToken()
@@ -139,6 +193,23 @@ func (c TemporalBatchTerminateCommand) run(cctx *CommandContext, args []string)
 	}
 	defer cl.Close()
 
+	// Show what is being terminated before confirming
+	resp, err := describeBatchJob(cctx, cl, c.Parent.Namespace, c.JobId)
+	if err != nil {
+		return err
+	}
+	yes, err := cctx.promptYes(fmt.Sprintf(
+		"Terminate Batch Job '%v' (type: %v, state: %v, completed: %d/%d, failed: %d/%d)? y/N",
+		c.JobId, resp.OperationType, resp.State,
+		resp.CompleteOperationCount, resp.TotalOperationCount,
+		resp.FailureOperationCount, resp.TotalOperationCount,
+	), c.Yes)
+	if err != nil {
+		return err
+	} else if !yes {
+		return fmt.Errorf("user denied confirmation")
+	}
+
 	_, err = cl.WorkflowService().StopBatchOperation(cctx, &workflowservice.StopBatchOperationRequest{
 		Namespace: c.Parent.Namespace,
 		JobId:     c.JobId,
@@ -158,6 +229,54 @@ func (c TemporalBatchTerminateCommand) run(cctx *CommandContext, args []string)
 	return nil
 }
 
+func (c TemporalBatchWaitCommand) run(cctx *CommandContext, args []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	ctx := context.Context(cctx)
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout.Duration())
+		defer cancel()
+	}
+	var resp *workflowservice.DescribeBatchOperationResponse
+	// Progress is shown as a bar if possible, otherwise as a line per check
+	bar := cctx.startProgress("Batch Job running", 0)
+	defer bar.Finish()
+	for {
+		if resp, err = describeBatchJob(cctx, cl, c.Parent.Namespace, c.JobId); err != nil {
+			return err
+		} else if resp.State != enums.BATCH_OPERATION_STATE_RUNNING {
+			break
+		}
+		if bar != nil {
+			bar.Set(resp.CompleteOperationCount+resp.FailureOperationCount, resp.TotalOperationCount)
+		} else {
+			cctx.Printer.Printlnf("Batch Job running, %d/%d completed, %d failed...",
+				resp.CompleteOperationCount, resp.TotalOperationCount, resp.FailureOperationCount)
+		}
+		select {
+		case <-ctx.Done():
+			if cctx.Err() != nil {
+				return fmt.Errorf("interrupted waiting for Batch Job '%v'", c.JobId)
+			}
+			return fmt.Errorf("timed out after %v waiting for Batch Job '%v'", c.Timeout.Duration(), c.JobId)
+		case <-time.After(time.Second):
+		}
+	}
+	bar.Finish()
+
+	if err := printBatchDescribe(cctx, resp); err != nil {
+		return err
+	} else if resp.State == enums.BATCH_OPERATION_STATE_FAILED {
+		return fmt.Errorf("batch job '%v' failed", c.JobId)
+	}
+	return nil
+}
+
 // Converts the timestamp to Go's native time.Time.
 // Returns the zero time.Time value for nil timestamp.
 func toTime(timestamp *timestamppb.Timestamp) (t time.Time) {
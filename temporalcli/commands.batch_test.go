@@ -141,6 +141,40 @@ func (s *SharedServerSuite) TestBatchJob_List() {
 			s.ContainsOnSameLine(out, "JobId", "State", "StartTime", "CloseTime") // header
 		})
 
+		t.Run("as text with filters", func(t *testing.T) {
+			res := s.Execute(
+				"batch", "list",
+				"--address", s.Address(),
+				"--namespace", "batch-empty",
+				"--state", "Completed",
+				"--operation-type", "terminate")
+			s.NoError(res.Err)
+			s.Equal(4, strings.Count(res.Stdout.String(), "\n"), "expect 3 data rows + 1 header row")
+
+			res = s.Execute(
+				"batch", "list",
+				"--address", s.Address(),
+				"--namespace", "batch-empty",
+				"--state", "Running")
+			s.NoError(res.Err)
+			s.Empty(res.Stdout.String())
+
+			res = s.Execute(
+				"batch", "list",
+				"--address", s.Address(),
+				"--namespace", "batch-empty",
+				"--operation-type", "Cancel")
+			s.NoError(res.Err)
+			s.Empty(res.Stdout.String())
+
+			res = s.Execute(
+				"batch", "list",
+				"--address", s.Address(),
+				"--namespace", "batch-empty",
+				"--state", "bad")
+			s.ErrorContains(res.Err, "invalid state")
+		})
+
 		t.Run("as json", func(t *testing.T) {
 			res := s.Execute(
 				"batch", "list",
@@ -191,12 +225,27 @@ func (s *SharedServerSuite) TestBatchJob_Terminate() {
 					"batch", "terminate",
 					"--address", s.Address(),
 					"--job-id", jobId,
-					"--reason", "testing")
+					"--reason", "testing",
+					"--yes")
 				return res.Err == nil
 			}, 5*time.Second, 100*time.Millisecond)
 
 			s.Empty(res.Stderr.String())
-			s.Equal("Terminated Batch Job '"+jobId+"'\n", res.Stdout.String())
+			s.Contains(res.Stdout.String(), "Terminate Batch Job '"+jobId+"' (type: Terminate")
+			s.True(strings.HasSuffix(res.Stdout.String(), "Terminated Batch Job '"+jobId+"'\n"))
+		})
+
+		t.Run("denied", func(t *testing.T) {
+			jobId := "TestBatchJob_Terminate_Denied"
+			s.startBatchJob(jobId, s.Namespace())
+
+			s.CommandHarness.Stdin.WriteString("n\n")
+			res := s.Execute(
+				"batch", "terminate",
+				"--address", s.Address(),
+				"--job-id", jobId,
+				"--reason", "testing")
+			s.EqualError(res.Err, "user denied confirmation")
 		})
 
 		t.Run("as json", func(t *testing.T) {
@@ -210,6 +259,7 @@ func (s *SharedServerSuite) TestBatchJob_Terminate() {
 					"--address", s.Address(),
 					"--job-id", jobId,
 					"--reason", "testing",
+					"--yes",
 					"-o", "json")
 				return res.Err == nil
 			}, 5*time.Second, 100*time.Millisecond)
@@ -220,6 +270,26 @@ func (s *SharedServerSuite) TestBatchJob_Terminate() {
 	})
 }
 
+func (s *SharedServerSuite) TestBatchJob_Wait() {
+	res := s.Execute(
+		"batch", "wait",
+		"--address", s.Address(),
+		"--job-id", "not-found")
+	s.EqualError(res.Err, "could not find Batch Job 'not-found'")
+
+	// Job has no matching workflows, so finishes quickly
+	jobId := "TestBatchJob_Wait"
+	s.startBatchJob(jobId, s.Namespace())
+	res = s.Execute(
+		"batch", "wait",
+		"--address", s.Address(),
+		"--job-id", jobId,
+		"--timeout", "10s")
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "State", "Completed")
+	s.ContainsOnSameLine(res.Stdout.String(), "CompletedCount", "0/0")
+}
+
 // kickstart batch job (using Client directly to provide a `JobId`)
 func (s *SharedServerSuite) startBatchJob(jobId, namespace string) {
 	_, err := s.Client.WorkflowService().StartBatchOperation(
@@ -0,0 +1,247 @@
+package temporalcli
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/pflag"
+	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type debugConnectionReport struct {
+	EnvConfigName     string                  `json:"envConfigName,omitempty"`
+	EnvConfigFile     string                  `json:"envConfigFile,omitempty"`
+	Options           []debugConnectionOption `json:"options"`
+	ResolvedAddresses []string                `json:"resolvedAddresses,omitempty"`
+	ResolveError      string                  `json:"resolveError,omitempty"`
+	RemoteAddress     string                  `json:"remoteAddress,omitempty"`
+	DialDuration      time.Duration           `json:"dialDurationNanos,omitempty"`
+	TLS               *debugConnectionTLS     `json:"tls,omitempty"`
+	ServerVersion     string                  `json:"serverVersion,omitempty"`
+	Capabilities      map[string]bool         `json:"capabilities,omitempty"`
+	Latencies         []time.Duration         `json:"latenciesNanos,omitempty"`
+	Error             string                  `json:"error,omitempty"`
+}
+
+type debugConnectionOption struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type debugConnectionTLS struct {
+	Version            string    `json:"version"`
+	CipherSuite        string    `json:"cipherSuite"`
+	ServerName         string    `json:"serverName,omitempty"`
+	NegotiatedProtocol string    `json:"negotiatedProtocol,omitempty"`
+	CertSubject        string    `json:"certSubject,omitempty"`
+	CertIssuer         string    `json:"certIssuer,omitempty"`
+	CertDNSNames       []string  `json:"certDnsNames,omitempty"`
+	CertNotAfter       time.Time `json:"certNotAfter,omitempty"`
+}
+
+func (c *TemporalDebugConnectionCommand) run(cctx *CommandContext, args []string) error {
+	if c.Pings < 1 {
+		return fmt.Errorf("pings must be at least 1")
+	}
+	report := &debugConnectionReport{}
+	if cctx.EnvConfigValues != nil {
+		report.EnvConfigName = cctx.Options.EnvConfigName
+		report.EnvConfigFile = cctx.Options.EnvConfigFile
+	}
+	err := c.diagnose(cctx, report)
+	if err != nil {
+		report.Error = err.Error()
+	}
+	if printErr := c.printReport(cctx, report); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+// Populates the report as far as it can get, returning the error that stopped
+// it, if any.
+func (c *TemporalDebugConnectionCommand) diagnose(cctx *CommandContext, report *debugConnectionReport) error {
+	clientOpts := &c.Parent.ClientOptions
+
+	// Options set explicitly, plus the ones needed to know where we connect
+	c.Parent.Command.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		source := cctx.flagSource(flag)
+		if source == "default" && flag.Name != "address" && flag.Name != "namespace" {
+			return
+		}
+		value := cctx.redactor.redact(flag.Value.String())
+		if slices.Contains(redactedFlags, flag.Name) && !cctx.redactor.disabled {
+			value = redactedValue
+		}
+		report.Options = append(report.Options, debugConnectionOption{Name: flag.Name, Value: value, Source: source})
+	})
+
+	// Resolve the hosts unless it's a unix socket or the proxy resolves them
+	if _, ok := unixSocketPath(clientOpts.Address); !ok && clientOpts.Proxy == "" {
+		for _, address := range addressList(clientOpts.Address) {
+			host, _, err := net.SplitHostPort(addressHostPort(address))
+			if err != nil {
+				host = addressHostPort(address)
+			}
+			ctx, cancel := context.WithTimeout(cctx, 10*time.Second)
+			resolved, err := net.DefaultResolver.LookupHost(ctx, host)
+			cancel()
+			if err != nil {
+				report.ResolveError = err.Error()
+				break
+			}
+			report.ResolvedAddresses = append(report.ResolvedAddresses, resolved...)
+		}
+	}
+
+	// Dial the same as any other command, recording the peer of the last call
+	var peerLock sync.Mutex
+	var lastPeer peer.Peer
+	recordPeer := grpc.WithChainUnaryInterceptor(func(
+		ctx context.Context,
+		method string, req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		var p peer.Peer
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+		if p.Addr != nil {
+			peerLock.Lock()
+			lastPeer = p
+			peerLock.Unlock()
+		}
+		return err
+	})
+	start := time.Now()
+	cl, err := clientOpts.dialClient(cctx, recordPeer)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+	report.DialDuration = time.Since(start)
+
+	// Ping, taking server info from the first response
+	for i := 0; i < c.Pings; i++ {
+		start := time.Now()
+		resp, err := cl.WorkflowService().GetSystemInfo(cctx, &workflowservice.GetSystemInfoRequest{})
+		if err != nil {
+			return fmt.Errorf("failed getting system info: %w", err)
+		}
+		report.Latencies = append(report.Latencies, time.Since(start))
+		if i == 0 {
+			report.ServerVersion = resp.ServerVersion
+			report.Capabilities = map[string]bool{}
+			caps := resp.GetCapabilities().ProtoReflect()
+			fields := caps.Descriptor().Fields()
+			for j := 0; j < fields.Len(); j++ {
+				if field := fields.Get(j); field.Kind() == protoreflect.BoolKind {
+					report.Capabilities[field.JSONName()] = caps.Get(field).Bool()
+				}
+			}
+		}
+	}
+
+	peerLock.Lock()
+	defer peerLock.Unlock()
+	if lastPeer.Addr != nil {
+		report.RemoteAddress = lastPeer.Addr.String()
+	}
+	if tlsInfo, ok := lastPeer.AuthInfo.(credentials.TLSInfo); ok {
+		state := tlsInfo.State
+		report.TLS = &debugConnectionTLS{
+			Version:            tls.VersionName(state.Version),
+			CipherSuite:        tls.CipherSuiteName(state.CipherSuite),
+			ServerName:         state.ServerName,
+			NegotiatedProtocol: state.NegotiatedProtocol,
+		}
+		if len(state.PeerCertificates) > 0 {
+			cert := state.PeerCertificates[0]
+			report.TLS.CertSubject = cert.Subject.String()
+			report.TLS.CertIssuer = cert.Issuer.String()
+			report.TLS.CertDNSNames = cert.DNSNames
+			report.TLS.CertNotAfter = cert.NotAfter
+		}
+	}
+	return nil
+}
+
+func (c *TemporalDebugConnectionCommand) printReport(cctx *CommandContext, report *debugConnectionReport) error {
+	if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(report, printer.StructuredOptions{})
+	}
+
+	if report.EnvConfigName != "" {
+		cctx.Printer.Printlnf("Env config %q from %v", report.EnvConfigName, report.EnvConfigFile)
+	}
+	cctx.Printer.Println(color.MagentaString("Options:"))
+	err := cctx.Printer.PrintStructured(report.Options, printer.StructuredOptions{Table: &printer.TableOptions{}})
+	if err != nil {
+		return err
+	}
+
+	cctx.Printer.Println()
+	cctx.Printer.Println(color.MagentaString("Connection:"))
+	if report.ResolveError != "" {
+		cctx.Printer.Printlnf("  Failed resolving address: %v", report.ResolveError)
+	} else if len(report.ResolvedAddresses) > 0 {
+		cctx.Printer.Printlnf("  Address resolves to: %v", strings.Join(report.ResolvedAddresses, ", "))
+	}
+	if report.DialDuration > 0 {
+		cctx.Printer.Printlnf("  Connected to %v in %v", report.RemoteAddress, report.DialDuration.Round(time.Millisecond))
+	}
+	if report.TLS != nil {
+		cctx.Printer.Printlnf("  TLS: %v, %v", report.TLS.Version, report.TLS.CipherSuite)
+		if report.TLS.ServerName != "" {
+			cctx.Printer.Printlnf("  TLS server name: %v", report.TLS.ServerName)
+		}
+		if report.TLS.CertSubject != "" {
+			cctx.Printer.Printlnf("  Server certificate: %v (issuer: %v, expires: %v)",
+				report.TLS.CertSubject, report.TLS.CertIssuer, cctx.Printer.FormatTime(report.TLS.CertNotAfter))
+		}
+		if len(report.TLS.CertDNSNames) > 0 {
+			cctx.Printer.Printlnf("  Server certificate DNS names: %v", strings.Join(report.TLS.CertDNSNames, ", "))
+		}
+	} else if report.DialDuration > 0 {
+		cctx.Printer.Println("  TLS: disabled")
+	}
+	if len(report.Latencies) > 0 {
+		latencies := make([]string, len(report.Latencies))
+		for i, latency := range report.Latencies {
+			latencies[i] = latency.Round(10 * time.Microsecond).String()
+		}
+		cctx.Printer.Printlnf("  Round-trip latency: %v", strings.Join(latencies, ", "))
+	}
+	if report.Error != "" {
+		cctx.Printer.Printlnf("  %v", color.RedString("Failed: %v", report.Error))
+	}
+
+	if report.ServerVersion != "" {
+		cctx.Printer.Println()
+		cctx.Printer.Println(color.MagentaString("Server:"))
+		cctx.Printer.Printlnf("  Version: %v", report.ServerVersion)
+		names := make([]string, 0, len(report.Capabilities))
+		for name := range report.Capabilities {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cctx.Printer.Printlnf("  %v: %v", name, report.Capabilities[name])
+		}
+	}
+	return nil
+}
@@ -2,17 +2,23 @@ package temporalcli
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"gopkg.in/yaml.v3"
 )
 
 func (c *TemporalEnvCommand) envNameAndKey(cctx *CommandContext, args []string, keyFlag string) (string, string, error) {
 	if len(args) > 0 {
 		cctx.Logger.Warn("Arguments to env commands are deprecated; please use --env and --key (or -k) instead")
 
-		if c.Parent.Env != "default" || keyFlag != "" {
+		if c.Parent.Env != cctx.defaultEnvName() || keyFlag != "" {
 			return "", "", fmt.Errorf("cannot specify both an argument and flags; please use flags instead")
 		}
 
@@ -41,18 +47,103 @@ func (c *TemporalEnvDeleteCommand) run(cctx *CommandContext, args []string) erro
 	if err != nil {
 		return err
 	}
+	// Deleting the environment selected with "env use" just because it was not
+	// given would be a nasty surprise
+	_, envVarSet := cctx.Options.LookupEnv(temporalEnv)
+	if key == "" && cctx.currentEnv != "" && len(args) == 0 && !envVarSet &&
+		!c.Parent.Parent.Command.PersistentFlags().Changed("env") {
+		return fmt.Errorf("environment to delete must be given with --env")
+	}
 
 	// Env is guaranteed to already be present
 	env, _ := cctx.EnvConfigValues[envName]
 	// User can remove single flag or all in env
+	var removed []string
 	if key != "" {
 		cctx.Logger.Info("Deleting env property", "env", envName, "property", key)
+		if value, ok := env[key]; ok {
+			removed = append(removed, value)
+		}
 		delete(env, key)
 	} else {
 		cctx.Logger.Info("Deleting env", "env", env)
+		for _, value := range env {
+			removed = append(removed, value)
+		}
 		delete(cctx.EnvConfigValues, envName)
 	}
-	return cctx.WriteEnvConfigToFile()
+	if err := cctx.WriteEnvConfigToFile(); err != nil {
+		return err
+	}
+	// Deleting the environment "env use" selected goes back to the default one
+	if key == "" && envName == cctx.currentEnv {
+		if err := cctx.saveCurrentEnv(""); err != nil {
+			return err
+		}
+	}
+	// Keyring items are only removed once the config is written and no other
+	// value still references them
+	for _, value := range removed {
+		if !envConfigReferences(cctx.EnvConfigValues, value) {
+			cctx.deleteEnvConfigKeyringItem(value)
+		}
+	}
+	return nil
+}
+
+func envConfigReferences(envs map[string]map[string]string, value string) bool {
+	for _, env := range envs {
+		for _, v := range env {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *TemporalEnvExportCommand) run(cctx *CommandContext, args []string) error {
+	// The only local flags besides the format are the client options, which
+	// have already been resolved with the same precedence as when connecting
+	var lines []string
+	var flagErr error
+	c.Command.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if flagErr != nil || flag.Name == "format" {
+			return
+		} else if cctx.flagSource(flag) == "default" && flag.Name != "address" && flag.Name != "namespace" {
+			return
+		}
+		anns := flag.Annotations[flagEnvVarAnnotation]
+		if len(anns) != 1 {
+			cctx.Logger.Warn("Option has no environment variable and is not exported", "option", flag.Name)
+			return
+		}
+		value := flag.Value.String()
+		// Env vars only set a single value of a slice
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			values := slice.GetSlice()
+			if len(values) != 1 {
+				flagErr = fmt.Errorf("option %v has %v values, but %v can only set one", flag.Name, len(values), anns[0])
+				return
+			}
+			value = values[0]
+		}
+		switch c.Format.Value {
+		case "shell":
+			lines = append(lines, fmt.Sprintf("export %v='%v'", anns[0], strings.ReplaceAll(value, "'", `'\''`)))
+		case "powershell":
+			lines = append(lines, fmt.Sprintf("$env:%v = '%v'", anns[0], strings.ReplaceAll(value, "'", "''")))
+		default:
+			flagErr = fmt.Errorf("unrecognized format %q", c.Format.Value)
+		}
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+	for _, line := range lines {
+		cctx.Printer.Println(line)
+	}
+	return nil
 }
 
 func (c *TemporalEnvGetCommand) run(cctx *CommandContext, args []string) error {
@@ -82,6 +173,133 @@ func (c *TemporalEnvGetCommand) run(cctx *CommandContext, args []string) error {
 	return cctx.Printer.PrintStructured(props, printer.StructuredOptions{Table: &printer.TableOptions{}})
 }
 
+// Legacy tctl environment variables and the properties they become
+var legacyEnvVarProperties = []struct{ envVar, property string }{
+	{"TEMPORAL_CLI_ADDRESS", "address"},
+	{"TEMPORAL_CLI_NAMESPACE", "namespace"},
+	{"TEMPORAL_CLI_TLS_CERT", "tls-cert-path"},
+	{"TEMPORAL_CLI_TLS_KEY", "tls-key-path"},
+	{"TEMPORAL_CLI_TLS_CA", "tls-ca-path"},
+	{"TEMPORAL_CLI_TLS_DISABLE_HOST_VERIFICATION", "tls-disable-host-verification"},
+	{"TEMPORAL_CLI_TLS_SERVER_NAME", "tls-server-name"},
+	{"TEMPORAL_CLI_CODEC_ENDPOINT", "codec-endpoint"},
+	{"TEMPORAL_CLI_CODEC_AUTH", "codec-auth"},
+	{"TEMPORAL_CLI_HEADERS_PROVIDER_PLUGIN", ""},
+	{"TEMPORAL_CLI_DATA_CONVERTER_PLUGIN", ""},
+}
+
+// tctl context keys that are CLI options, after replacing underscores with
+// dashes
+var tctlContextProperties = []string{
+	"address",
+	"namespace",
+	"tls-cert-path",
+	"tls-key-path",
+	"tls-ca-path",
+	"tls-disable-host-verification",
+	"tls-server-name",
+	"codec-endpoint",
+	"codec-auth",
+}
+
+func (c *TemporalEnvImportCommand) run(cctx *CommandContext, args []string) error {
+	if c.FromTctl == "" && !c.FromEnv {
+		return fmt.Errorf("either --from-tctl or --from-env must be specified")
+	}
+	envFlagChanged := c.Command.Flags().Lookup("env").Changed
+
+	type imported struct {
+		Env      string `json:"env"`
+		Property string `json:"property"`
+		Result   string `json:"result"`
+	}
+	var results []imported
+	importProperty := func(envName, property, value string) {
+		if cctx.EnvConfigValues == nil {
+			cctx.EnvConfigValues = map[string]map[string]string{}
+		}
+		if cctx.EnvConfigValues[envName] == nil {
+			cctx.EnvConfigValues[envName] = map[string]string{}
+		}
+		result := "imported"
+		if _, ok := cctx.EnvConfigValues[envName][property]; ok && !c.Overwrite {
+			result = "skipped, already set"
+		} else {
+			// Values are not logged since they may be secrets
+			cctx.Logger.Info("Importing env property", "env", envName, "property", property)
+			cctx.EnvConfigValues[envName][property] = value
+		}
+		results = append(results, imported{Env: envName, Property: property, Result: result})
+	}
+
+	if c.FromTctl != "" {
+		b, err := os.ReadFile(c.FromTctl)
+		if err != nil {
+			return fmt.Errorf("failed reading tctl config: %w", err)
+		}
+		var tctlConfig struct {
+			Contexts map[string]map[string]any `yaml:"contexts"`
+		}
+		if err := yaml.Unmarshal(b, &tctlConfig); err != nil {
+			return fmt.Errorf("failed unmarshaling tctl config: %w", err)
+		}
+		contextNames := make([]string, 0, len(tctlConfig.Contexts))
+		for contextName := range tctlConfig.Contexts {
+			if !envFlagChanged || contextName == c.Parent.Parent.Env {
+				contextNames = append(contextNames, contextName)
+			}
+		}
+		if len(contextNames) == 0 {
+			if envFlagChanged {
+				return fmt.Errorf("tctl config has no context %q", c.Parent.Parent.Env)
+			}
+			return fmt.Errorf("tctl config has no contexts")
+		}
+		sort.Strings(contextNames)
+		for _, contextName := range contextNames {
+			tctlContext := tctlConfig.Contexts[contextName]
+			keys := make([]string, 0, len(tctlContext))
+			for k := range tctlContext {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				property := strings.ReplaceAll(k, "_", "-")
+				if !slices.Contains(tctlContextProperties, property) {
+					results = append(results, imported{Env: contextName, Property: k, Result: "skipped, unsupported"})
+				} else if tctlContext[k] != nil {
+					importProperty(contextName, property, fmt.Sprint(tctlContext[k]))
+				}
+			}
+		}
+	}
+
+	if c.FromEnv {
+		var found bool
+		for _, legacy := range legacyEnvVarProperties {
+			value, ok := cctx.Options.LookupEnv(legacy.envVar)
+			if !ok || value == "" {
+				continue
+			}
+			found = true
+			if legacy.property == "" {
+				results = append(results, imported{Env: c.Parent.Parent.Env, Property: legacy.envVar,
+					Result: "skipped, unsupported"})
+			} else {
+				importProperty(c.Parent.Parent.Env, legacy.property, value)
+			}
+		}
+		if !found {
+			return fmt.Errorf("no TEMPORAL_CLI_* environment variables are set")
+		}
+	}
+
+	if err := cctx.WriteEnvConfigToFile(); err != nil {
+		return err
+	}
+	return cctx.Printer.PrintStructured(results, printer.StructuredOptions{Table: &printer.TableOptions{}})
+}
+
 func (c *TemporalEnvListCommand) run(cctx *CommandContext, args []string) error {
 	type env struct {
 		Name string `json:"name"`
@@ -126,7 +344,157 @@ func (c *TemporalEnvSetCommand) run(cctx *CommandContext, args []string) error {
 	if cctx.EnvConfigValues[envName] == nil {
 		cctx.EnvConfigValues[envName] = map[string]string{}
 	}
-	cctx.Logger.Info("Setting env property", "env", envName, "property", key, "value", value)
+	if c.Keyring {
+		// Read from stdin so the secret is not in shell history
+		if value == "" {
+			b, err := io.ReadAll(cctx.Options.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed reading value from stdin: %w", err)
+			}
+			value = strings.TrimRight(string(b), "\r\n")
+		}
+		item := envName + "/" + key
+		if err := cctx.Options.Keyring.Set(item, value); err != nil {
+			return fmt.Errorf("failed storing value in keyring: %w", err)
+		}
+		cctx.Logger.Info("Setting env property from keyring", "env", envName, "property", key, "item", item)
+		value = keyringValuePrefix + item
+	} else {
+		cctx.Logger.Info("Setting env property", "env", envName, "property", key, "value", value)
+	}
 	cctx.EnvConfigValues[envName][key] = value
 	return cctx.WriteEnvConfigToFile()
 }
+
+func (c *TemporalEnvUseCommand) run(cctx *CommandContext, args []string) error {
+	if len(args) == 0 {
+		type env struct {
+			Name string `json:"name"`
+		}
+		return cctx.Printer.PrintStructured(env{Name: cctx.defaultEnvName()}, printer.StructuredOptions{})
+	}
+	envName := args[0]
+	if _, ok := cctx.EnvConfigValues[envName]; !ok && envName != "default" {
+		return fmt.Errorf("environment %q not found", envName)
+	}
+	// Selecting "default" clears the selection instead of storing it
+	if envName == "default" {
+		envName = ""
+	}
+	return cctx.saveCurrentEnv(envName)
+}
+
+func (c *TemporalEnvValidateCommand) run(cctx *CommandContext, args []string) error {
+	envNames := make([]string, 0, len(cctx.EnvConfigValues))
+	if c.Command.Flags().Lookup("env").Changed {
+		envNames = append(envNames, c.Parent.Parent.Env)
+	} else {
+		for envName := range cctx.EnvConfigValues {
+			envNames = append(envNames, envName)
+		}
+		sort.Strings(envNames)
+	}
+
+	// Properties are the options of any command
+	var optionNames []string
+	var collectOptions func(cmd *cobra.Command)
+	collectOptions = func(cmd *cobra.Command) {
+		addName := func(flag *pflag.Flag) {
+			if !slices.Contains(optionNames, flag.Name) {
+				optionNames = append(optionNames, flag.Name)
+			}
+		}
+		cmd.Flags().VisitAll(addName)
+		cmd.PersistentFlags().VisitAll(addName)
+		for _, child := range cmd.Commands() {
+			collectOptions(child)
+		}
+	}
+	collectOptions(c.Command.Root())
+
+	type problem struct {
+		Env      string `json:"env"`
+		Property string `json:"property,omitempty"`
+		Problem  string `json:"problem"`
+	}
+	var problems []problem
+	for _, envName := range envNames {
+		env := cctx.EnvConfigValues[envName]
+		addProblem := func(property string, err error) {
+			problems = append(problems, problem{Env: envName, Property: property, Problem: err.Error()})
+		}
+		keys := make([]string, 0, len(env))
+		for k := range env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			switch k {
+			case disabledCommandsProperty, requiredSearchAttributesProperty, requiredMemoKeysProperty:
+			case expandEnvVarsProperty:
+				if env[k] != "true" && env[k] != "false" {
+					addProblem(k, fmt.Errorf("must be true or false"))
+				}
+			case workflowTypeRegistryProperty:
+				if file, err := cctx.resolveEnvConfigValue(envName, env[k]); err != nil {
+					addProblem(k, err)
+				} else if _, err := loadWorkflowTypeRegistry(file); err != nil {
+					addProblem(k, err)
+				}
+			default:
+				if !slices.Contains(optionNames, k) {
+					err := fmt.Errorf("unknown property")
+					if suggestions := closestNames(k, optionNames, 1); len(suggestions) > 0 {
+						err = fmt.Errorf("unknown property, did you mean %v?", suggestions[0])
+					}
+					addProblem(k, err)
+					continue
+				}
+				value, err := cctx.resolveEnvConfigValue(envName, env[k])
+				if err != nil {
+					addProblem(k, err)
+					continue
+				}
+				// Files are read lazily when connecting, so check them here
+				if value != "" && (k == "tls-cert-path" || k == "tls-key-path" || k == "tls-ca-path") {
+					if _, err := os.ReadFile(value); err != nil {
+						addProblem(k, fmt.Errorf("cannot read file: %w", err))
+					}
+				}
+			}
+		}
+
+		// The same option handling as connecting
+		clientOpts, err := clientOptionsFromEnvConfig(cctx, envName)
+		if err != nil {
+			addProblem("", err)
+			continue
+		} else if err := clientOpts.validate(cctx); err != nil {
+			addProblem("", err)
+			continue
+		}
+		if c.Dial {
+			// Connecting is opted into, so the command is not offline-only
+			cctx.offline = false
+			cl, err := clientOpts.dialClient(cctx)
+			if err != nil {
+				addProblem("", fmt.Errorf("failed connecting: %w", err))
+				continue
+			}
+			cl.Close()
+		}
+	}
+
+	if len(problems) == 0 {
+		if cctx.JSONOutput {
+			return cctx.Printer.PrintStructured([]problem{}, printer.StructuredOptions{})
+		}
+		cctx.Printer.Printlnf("No problems found in %v environment(s)", len(envNames))
+		return nil
+	}
+	err := cctx.Printer.PrintStructured(problems, printer.StructuredOptions{Table: &printer.TableOptions{}})
+	if err != nil {
+		return fmt.Errorf("failed printing: %w", err)
+	}
+	return fmt.Errorf("found %v problem(s)", len(problems))
+}
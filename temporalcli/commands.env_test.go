@@ -1,9 +1,12 @@
 package temporalcli_test
 
 import (
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/temporalio/cli/temporalcli"
 	"gopkg.in/yaml.v3"
 )
 
@@ -112,3 +115,374 @@ func TestEnv_InputValidation(t *testing.T) {
 	res = h.Execute("env", "set", "myenv1.foo")
 	h.ErrorContains(res.Err, `no value provided`)
 }
+
+func TestEnv_Use(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	h.Options.EnvConfigFile = filepath.Join(t.TempDir(), "env.yaml")
+	h.NoError(h.Execute("env", "set", "--env", "prod", "-k", "foo", "-v", "prod-foo").Err)
+	h.NoError(h.Execute("env", "set", "--env", "staging", "-k", "foo", "-v", "staging-foo").Err)
+
+	// Nothing selected yet
+	res := h.Execute("env", "use")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "default")
+
+	// Unknown envs cannot be selected
+	res = h.Execute("env", "use", "nope")
+	h.ErrorContains(res.Err, `environment "nope" not found`)
+
+	// Selected env is used and persisted alongside the envs
+	h.NoError(h.Execute("env", "use", "prod").Err)
+	b, err := os.ReadFile(h.Options.EnvConfigFile)
+	h.NoError(err)
+	h.Contains(string(b), "current:\n    env:\n        name: prod\n")
+	// Older CLIs read the file as maps of maps of maps
+	var older map[string]map[string]map[string]string
+	h.NoError(yaml.Unmarshal(b, &older))
+	h.Equal("prod-foo", older["env"]["prod"]["foo"])
+	res = h.Execute("env", "get", "-k", "foo")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "prod-foo")
+	res = h.Execute("env", "use", "-o", "json")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), `"name": "prod"`)
+
+	// --env and TEMPORAL_ENV take precedence
+	res = h.Execute("env", "get", "--env", "staging", "-k", "foo")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "staging-foo")
+	h.Options.LookupEnv = mapEnvLookup{"TEMPORAL_ENV": "staging"}.LookupEnv
+	res = h.Execute("env", "get", "-k", "foo")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "staging-foo")
+	h.Options.LookupEnv = nil
+
+	// Deleting the selected env requires naming it and goes back to default
+	res = h.Execute("env", "delete")
+	h.ErrorContains(res.Err, "environment to delete must be given with --env")
+	h.NoError(h.Execute("env", "delete", "-k", "foo").Err)
+	h.NoError(h.Execute("env", "delete", "--env", "prod").Err)
+	res = h.Execute("env", "use")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "default")
+	h.NoError(h.Execute("env", "use", "staging").Err)
+	h.NoError(h.Execute("env", "use", "default").Err)
+	b, err = os.ReadFile(h.Options.EnvConfigFile)
+	h.NoError(err)
+	h.NotContains(string(b), "current")
+
+	// Stores that cannot persist it are reported
+	h.Options.EnvConfigStore = &memConfigStore{env: map[string]map[string]string{"prod": {}}}
+	res = h.Execute("env", "use", "prod")
+	h.ErrorContains(res.Err, "cannot persist the current environment")
+}
+
+type memConfigStore struct {
+	env   map[string]map[string]string
+	saves int
+}
+
+func (m *memConfigStore) LoadEnvConfig() (map[string]map[string]string, error) {
+	return m.env, nil
+}
+
+func (m *memConfigStore) SaveEnvConfig(env map[string]map[string]string) error {
+	m.env = env
+	m.saves++
+	return nil
+}
+
+type mapEnvLookup map[string]string
+
+func (m mapEnvLookup) LookupEnv(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+func TestEnv_CustomStoreAndLookup(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	store := &memConfigStore{env: map[string]map[string]string{"myenv1": {"foo": "bar"}}}
+	h.Options.EnvConfigStore = store
+	h.Options.LookupEnv = mapEnvLookup{"TEMPORAL_ENV": "myenv1"}.LookupEnv
+
+	// Env name from the lookup, values from the store
+	res := h.Execute("env", "get", "-k", "foo")
+	h.NoError(res.Err)
+	h.ContainsOnSameLine(res.Stdout.String(), "foo", "bar")
+
+	// Writes go to the store
+	res = h.Execute("env", "set", "--env", "myenv2", "-k", "baz", "-v", "qux")
+	h.NoError(res.Err)
+	h.Equal(1, store.saves)
+	h.Equal("qux", store.env["myenv2"]["baz"])
+}
+
+type memKeyring map[string]string
+
+func (m memKeyring) Get(item string) (string, error) {
+	v, ok := m[item]
+	if !ok {
+		return "", temporalcli.ErrKeyringItemNotFound
+	}
+	return v, nil
+}
+
+func (m memKeyring) Set(item, secret string) error {
+	m[item] = secret
+	return nil
+}
+
+func (m memKeyring) Delete(item string) error {
+	if _, ok := m[item]; !ok {
+		return temporalcli.ErrKeyringItemNotFound
+	}
+	delete(m, item)
+	return nil
+}
+
+func TestEnv_Keyring(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	keyring := memKeyring{}
+	h.Options.Keyring = keyring
+	store := &memConfigStore{}
+	h.Options.EnvConfigStore = store
+
+	// Value from the flag or stdin goes to the keyring, only the reference is
+	// stored and logged
+	res := h.Execute("env", "set", "--env", "prod", "-k", "address", "-v", "127.0.0.1:1", "--keyring")
+	h.NoError(res.Err)
+	h.Stdin.WriteString("my-secret-key\n")
+	res = h.Execute("env", "set", "--env", "prod", "-k", "api-key", "--keyring")
+	h.NoError(res.Err)
+	h.NotContains(res.Stdout.String()+res.Stderr.String(), "my-secret-key")
+	h.Equal(memKeyring{"prod/address": "127.0.0.1:1", "prod/api-key": "my-secret-key"}, keyring)
+	h.Equal(map[string]string{"address": "keyring:prod/address", "api-key": "keyring:prod/api-key"}, store.env["prod"])
+
+	// Commands use the values from the keyring
+	res = h.Execute("debug", "connection", "--env", "prod", "--grpc-call-timeout", "1s")
+	h.ErrorContains(res.Err, "connection refused")
+	h.ContainsOnSameLine(res.Stdout.String(), "address", "127.0.0.1:1", `env config "prod"`)
+	h.NotContains(res.Stdout.String(), "my-secret-key")
+
+	// Missing items fail
+	store.env["prod"]["namespace"] = "keyring:prod/missing"
+	res = h.Execute("debug", "connection", "--env", "prod")
+	h.ErrorContains(res.Err, `keyring item "prod/missing" not found`)
+
+	// Deleting removes from the keyring
+	res = h.Execute("env", "delete", "--env", "prod", "-k", "api-key")
+	h.NoError(res.Err)
+	h.Equal(memKeyring{"prod/address": "127.0.0.1:1"}, keyring)
+	// But not items other envs still reference
+	store.env["staging"] = map[string]string{"address": "keyring:prod/address"}
+	res = h.Execute("env", "delete", "--env", "prod")
+	h.NoError(res.Err)
+	h.Equal(memKeyring{"prod/address": "127.0.0.1:1"}, keyring)
+	res = h.Execute("env", "delete", "--env", "staging")
+	h.NoError(res.Err)
+	h.Empty(keyring)
+}
+
+func TestEnv_Export(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	h.Options.EnvConfigStore = &memConfigStore{env: map[string]map[string]string{
+		"prod": {
+			"address":           "prod.example.com:7233",
+			"namespace":         "prod",
+			"api-key":           "it's-secret",
+			"grpc-meta":         "foo=bar",
+			"tls-cipher-suites": "TLS_AES_128_GCM_SHA256",
+		},
+	}}
+	h.Options.LookupEnv = mapEnvLookup{"TEMPORAL_NAMESPACE": "from-env-var"}.LookupEnv
+
+	// Flags override env vars which override the env config
+	res := h.Execute("env", "export", "--env", "prod", "--tls-server-name", "prod.example.com")
+	h.NoError(res.Err)
+	h.Equal(`export TEMPORAL_ADDRESS='prod.example.com:7233'
+export TEMPORAL_API_KEY='it'\''s-secret'
+export TEMPORAL_NAMESPACE='from-env-var'
+export TEMPORAL_TLS_CIPHER_SUITES='TLS_AES_128_GCM_SHA256'
+export TEMPORAL_TLS_SERVER_NAME='prod.example.com'
+`, res.Stdout.String())
+	h.ContainsOnSameLine(res.Stderr.String(), "Option has no environment variable and is not exported", "grpc-meta")
+
+	res = h.Execute("env", "export", "--env", "prod", "--format", "powershell")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "$env:TEMPORAL_API_KEY = 'it''s-secret'\n")
+
+	// Only a single value of a slice can be exported
+	res = h.Execute("env", "export", "--env", "prod", "--tls-cipher-suites", "A", "--tls-cipher-suites", "B")
+	h.ErrorContains(res.Err, "option tls-cipher-suites has 2 values, but TEMPORAL_TLS_CIPHER_SUITES can only set one")
+}
+
+func TestEnv_Interpolation(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	store := &memConfigStore{env: map[string]map[string]string{
+		"prod": {
+			"expand-env-vars": "true",
+			"address":         "${MY_HOST}:7233",
+			"namespace":       "${MY_NAMESPACE:-fallback}",
+			"tls-server-name": "$${MY_HOST}",
+		},
+		"literal": {
+			"codec-auth": "Bearer ${not-a-var",
+		},
+	}}
+	h.Options.EnvConfigStore = store
+	h.Options.LookupEnv = mapEnvLookup{"MY_HOST": "prod.example.com", "MY_NAMESPACE": ""}.LookupEnv
+
+	res := h.Execute("env", "export", "--env", "prod")
+	h.NoError(res.Err)
+	h.Equal(`export TEMPORAL_ADDRESS='prod.example.com:7233'
+export TEMPORAL_NAMESPACE='fallback'
+export TEMPORAL_TLS_SERVER_NAME='${MY_HOST}'
+`, res.Stdout.String())
+
+	// Values are stored unexpanded
+	res = h.Execute("env", "get", "--env", "prod", "-k", "address")
+	h.NoError(res.Err)
+	h.ContainsOnSameLine(res.Stdout.String(), "address", "${MY_HOST}:7233")
+
+	// Values are only expanded if the env opts in
+	res = h.Execute("env", "export", "--env", "literal")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "export TEMPORAL_CODEC_AUTH='Bearer ${not-a-var'\n")
+
+	// Unset variables without a default fail
+	store.env["prod"]["codec-endpoint"] = "http://${MY_CODEC_HOST}/codec"
+	res = h.Execute("env", "export", "--env", "prod")
+	h.ErrorContains(res.Err, "environment variable MY_CODEC_HOST is not set")
+	store.env["prod"]["codec-endpoint"] = "http://${MY_CODEC_HOST"
+	res = h.Execute("env", "export", "--env", "prod")
+	h.ErrorContains(res.Err, "unterminated variable")
+}
+
+func TestEnv_Import(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	store := &memConfigStore{env: map[string]map[string]string{"staging": {"namespace": "keep-me"}}}
+	h.Options.EnvConfigStore = store
+	h.Options.LookupEnv = mapEnvLookup{
+		"TEMPORAL_CLI_ADDRESS":                 "127.0.0.1:7233",
+		"TEMPORAL_CLI_TLS_CERT":                "/certs/client.pem",
+		"TEMPORAL_CLI_HEADERS_PROVIDER_PLUGIN": "my-plugin",
+	}.LookupEnv
+	tctlFile := filepath.Join(t.TempDir(), "tctl.yaml")
+	h.NoError(os.WriteFile(tctlFile, []byte(`
+version: next
+active: staging
+contexts:
+  local:
+    address: localhost:7233
+    namespace: default
+  staging:
+    address: staging.example.com:7233
+    namespace: staging
+    tls_disable_host_verification: true
+    data-converter-plugin: my-plugin
+`), 0644))
+
+	res := h.Execute("env", "import")
+	h.ErrorContains(res.Err, "either --from-tctl or --from-env must be specified")
+
+	// All contexts, keeping existing properties
+	res = h.Execute("env", "import", "--from-tctl", tctlFile)
+	h.NoError(res.Err)
+	h.Equal(map[string]string{"address": "localhost:7233", "namespace": "default"}, store.env["local"])
+	h.Equal(map[string]string{
+		"address":                       "staging.example.com:7233",
+		"namespace":                     "keep-me",
+		"tls-disable-host-verification": "true",
+	}, store.env["staging"])
+	h.ContainsOnSameLine(res.Stdout.String(), "staging", "namespace", "skipped, already set")
+	h.ContainsOnSameLine(res.Stdout.String(), "staging", "data-converter-plugin", "skipped, unsupported")
+
+	// A single context, overwriting
+	res = h.Execute("env", "import", "--from-tctl", tctlFile, "--env", "staging", "--overwrite")
+	h.NoError(res.Err)
+	h.Equal("staging", store.env["staging"]["namespace"])
+	h.NotContains(res.Stdout.String(), "local")
+	res = h.Execute("env", "import", "--from-tctl", tctlFile, "--env", "prod")
+	h.ErrorContains(res.Err, `tctl config has no context "prod"`)
+
+	// Environment variables
+	res = h.Execute("env", "import", "--from-env", "--env", "prod", "-o", "json")
+	h.NoError(res.Err)
+	h.Equal(map[string]string{"address": "127.0.0.1:7233", "tls-cert-path": "/certs/client.pem"}, store.env["prod"])
+	h.Contains(res.Stdout.String(), `"property": "TEMPORAL_CLI_HEADERS_PROVIDER_PLUGIN"`)
+}
+
+func TestEnv_Validate(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	h.Options.EnvConfigStore = &memConfigStore{env: map[string]map[string]string{
+		"good": {
+			"address":                    "127.0.0.1:1",
+			"grpc-call-timeout":          "1s",
+			"advisory-disabled-commands": "workflow delete",
+		},
+		"bad": {
+			"adress":          "127.0.0.1:1",
+			"api-key":         "my-key",
+			"oauth-token-url": "http://127.0.0.1:1/token",
+			"tls-cert-path":   "does-not-exist.pem",
+		},
+		"bad-value": {"grpc-call-timeout": "soon"},
+		// Credential helpers are not run, so one that would fail is no problem
+		"helper":     {"credential-helper": "false"},
+		"bad-helper": {"credential-helper": `"unterminated`},
+	}}
+
+	res := h.Execute("env", "validate", "-o", "json")
+	h.ErrorContains(res.Err, "found 5 problem(s)")
+	var problems []map[string]string
+	h.NoError(json.Unmarshal(res.Stdout.Bytes(), &problems))
+	h.Equal([]map[string]string{
+		{"env": "bad", "property": "adress", "problem": `unknown property, did you mean "address"?`},
+		{"env": "bad", "property": "tls-cert-path", "problem": problems[1]["problem"]},
+		{"env": "bad", "problem": "cannot use --api-key with --oauth-token-url"},
+		{"env": "bad-helper", "problem": `invalid credential helper: unterminated " quote`},
+		{"env": "bad-value", "problem": `failed setting grpc-call-timeout from environment "bad-value": ` +
+			`time: invalid duration "soon"`},
+	}, problems)
+	h.Contains(problems[1]["problem"], "cannot read file")
+
+	// A single environment, optionally connecting
+	res = h.Execute("env", "validate", "--env", "good")
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "No problems found in 1 environment(s)")
+	res = h.Execute("env", "validate", "--env", "good", "--dial")
+	h.ErrorContains(res.Err, "found 1 problem(s)")
+	h.ContainsOnSameLine(res.Stdout.String(), "good", "failed connecting")
+}
+
+func TestEnv_DisabledCommands(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	h.Options.EnvConfigFile = filepath.Join(t.TempDir(), "env.yaml")
+	res := h.Execute("env", "set", "--env", "prod", "-k", "advisory-disabled-commands",
+		"-v", "workflow terminate, temporal operator namespace,env delete")
+	h.NoError(res.Err)
+
+	// Disabled commands and their subcommands fail before doing anything
+	res = h.Execute("workflow", "terminate", "--env", "prod", "-w", "some-workflow")
+	h.ErrorContains(res.Err, `command "workflow terminate" is disabled for environment "prod"`)
+	res = h.Execute("operator", "namespace", "delete", "--env", "prod", "some-namespace")
+	h.ErrorContains(res.Err, `command "operator namespace" is disabled for environment "prod"`)
+	res = h.Execute("env", "delete", "--env", "prod")
+	h.ErrorContains(res.Err, `command "env delete" is disabled`)
+
+	// Other commands and environments are unaffected
+	res = h.Execute("env", "get", "--env", "prod")
+	h.NoError(res.Err)
+	res = h.Execute("env", "set", "--env", "dev", "-k", "foo", "-v", "bar")
+	h.NoError(res.Err)
+	res = h.Execute("env", "delete", "--env", "dev")
+	h.NoError(res.Err)
+}
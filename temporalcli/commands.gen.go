@@ -22,23 +22,44 @@ type TemporalCommand struct {
 	EnvFile                 string
 	LogLevel                StringEnum
 	LogFormat               string
+	LogFile                 string
+	LogFileLevel            StringEnum
+	LogFileMaxSize          string
 	Output                  StringEnum
+	Fields                  []string
+	Jq                      string
+	OutputFile              string
+	Append                  bool
+	Compress                StringEnum
 	TimeFormat              StringEnum
 	Color                   StringEnum
 	NoJsonShorthandPayloads bool
+	RawEnums                bool
+	Verbose                 bool
+	GrpcDebug               bool
+	NoRedact                bool
+	ReadOnly                bool
+	NoProgress              bool
 }
 
 func NewTemporalCommand(cctx *CommandContext) *TemporalCommand {
 	var s TemporalCommand
 	s.Command.Use = "temporal"
 	s.Command.Short = "Temporal command-line interface and development server."
-	s.Command.Long = ""
+	if hasHighlighting {
+		s.Command.Long = "Default flags can be set with the \x1b[1mTEMPORAL_COMMAND_DEFAULTS\x1b[0m environment variable for all commands, or with\n\x1b[1mTEMPORAL_COMMAND_DEFAULTS_<COMMAND>\x1b[0m for a single command, e.g. \x1b[1mTEMPORAL_COMMAND_DEFAULTS_WORKFLOW_LIST\x1b[0m for\n\x1b[1mtemporal workflow list\x1b[0m. The value is a space-separated list of flags:\n\n\x1b[1mexport TEMPORAL_COMMAND_DEFAULTS=\"--output json --time-format raw\"\x1b[0m\n\nFlags from the command line, environment variables, or the env file take precedence over these defaults. Flags in\n\x1b[1mTEMPORAL_COMMAND_DEFAULTS\x1b[0m that a command does not have are ignored."
+	} else {
+		s.Command.Long = "Default flags can be set with the `TEMPORAL_COMMAND_DEFAULTS` environment variable for all commands, or with\n`TEMPORAL_COMMAND_DEFAULTS_<COMMAND>` for a single command, e.g. `TEMPORAL_COMMAND_DEFAULTS_WORKFLOW_LIST` for\n`temporal workflow list`. The value is a space-separated list of flags:\n\n```\nexport TEMPORAL_COMMAND_DEFAULTS=\"--output json --time-format raw\"\n```\n\nFlags from the command line, environment variables, or the env file take precedence over these defaults. Flags in\n`TEMPORAL_COMMAND_DEFAULTS` that a command does not have are ignored."
+	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.AddCommand(&NewTemporalActivityCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalBatchCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalDebugCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalEnvCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalGenCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalOperatorCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalScheduleCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalServeApiCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalServerCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalTaskQueueCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowCommand(cctx, &s).Command)
@@ -48,13 +69,31 @@ func NewTemporalCommand(cctx *CommandContext) *TemporalCommand {
 	s.LogLevel = NewStringEnum([]string{"debug", "info", "warn", "error", "never"}, "info")
 	s.Command.PersistentFlags().Var(&s.LogLevel, "log-level", "Log level. Default is \"info\" for most commands and \"warn\" for `server start-dev`. Accepted values: debug, info, warn, error, never.")
 	s.Command.PersistentFlags().StringVar(&s.LogFormat, "log-format", "", "Log format. Options are \"text\" and \"json\". Default is \"text\".")
-	s.Output = NewStringEnum([]string{"text", "json", "jsonl", "none"}, "text")
-	s.Command.PersistentFlags().VarP(&s.Output, "output", "o", "Data output format. Note, this does not affect logging. Accepted values: text, json, jsonl, none.")
+	s.Command.PersistentFlags().StringVar(&s.LogFile, "log-file", "", "Also write logs to this file, appending to it. The file has its own level so long-running commands can keep a complete record without flooding the terminal. Uses --log-format.")
+	s.LogFileLevel = NewStringEnum([]string{"debug", "info", "warn", "error"}, "debug")
+	s.Command.PersistentFlags().Var(&s.LogFileLevel, "log-file-level", "Log level for --log-file. Accepted values: debug, info, warn, error.")
+	s.Command.PersistentFlags().StringVar(&s.LogFileMaxSize, "log-file-max-size", "50MB", "Size at which --log-file is rotated, e.g. \"50MB\". The last 3 rotated files are kept next to it with the suffixes .1 through .3. Use 0 to never rotate.")
+	s.Output = NewStringEnum([]string{"text", "json", "jsonl", "yaml", "none", "timeline", "junit", "tap", "csv", "markdown", "go-template=TEMPLATE"}, "text")
+	s.Command.PersistentFlags().VarP(&s.Output, "output", "o", "Data output format. Note, this does not affect logging. The timeline format is only supported by workflow show. The junit and tap formats are only supported by workflow execute and workflow attach. The yaml format has the same structure as json, with one document per item of a list. The csv format has the same columns as text output, or those given with --fields. The markdown format prints tables and cards as Markdown tables for pasting into issues and documents. The go-template format renders each item of the JSON output through the given Go template, e.g. -o 'go-template={{.workflowId}} {{.status}}'. Accepted values: text, json, jsonl, yaml, none, timeline, junit, tap, csv, markdown, go-template=TEMPLATE.")
+	s.Command.PersistentFlags().StringArrayVar(&s.Fields, "fields", nil, "Columns to include in table, card, csv, and markdown output, in order, e.g. WorkflowId,TaskQueue,StartTime. Names are the column headers or card labels of the text output and are case-insensitive. In text output, fields a table or card does not have are skipped, as are tables and cards with none of them, but at least one must have one. Can be given multiple times or comma-separated.")
+	s.Command.PersistentFlags().StringVar(&s.Jq, "jq", "", "Filter the JSON output through this jq expression before printing, e.g. '.workflowId'. Output is JSON unless --output is jsonl. String results are printed without quotes. Commands that print a list apply the expression to each item, as if jsonl output were piped to jq.")
+	s.Command.PersistentFlags().StringVar(&s.OutputFile, "output-file", "", "Write data output to this file instead of stdout. Output is written to a temporary file in the same directory and only moved into place once the command succeeds. If the command fails or is interrupted, any output written so far is kept in the file with a \".partial\" suffix.")
+	s.Command.PersistentFlags().BoolVar(&s.Append, "append", false, "Append to the file given by --output-file instead of replacing it.")
+	s.Compress = NewStringEnum([]string{"none", "gzip", "zstd"}, "none")
+	s.Command.PersistentFlags().Var(&s.Compress, "compress", "Compress data written to the file given by --output-file. Commands reading history files decompress them automatically. Accepted values: none, gzip, zstd.")
 	s.TimeFormat = NewStringEnum([]string{"relative", "iso", "raw"}, "relative")
-	s.Command.PersistentFlags().Var(&s.TimeFormat, "time-format", "Time format. Accepted values: relative, iso, raw.")
+	s.Command.PersistentFlags().Var(&s.TimeFormat, "time-format", "Time format. Times in csv and markdown output are iso unless this is set. Accepted values: relative, iso, raw.")
 	s.Color = NewStringEnum([]string{"always", "never", "auto"}, "auto")
 	s.Command.PersistentFlags().Var(&s.Color, "color", "Set coloring. Accepted values: always, never, auto.")
 	s.Command.PersistentFlags().BoolVar(&s.NoJsonShorthandPayloads, "no-json-shorthand-payloads", false, "Always show all payloads as raw payloads even if they are JSON.")
+	s.Command.PersistentFlags().BoolVar(&s.RawEnums, "raw-enums", false, "Show enums in text output with their full names, e.g. WORKFLOW_EXECUTION_STATUS_COMPLETED instead of Completed. JSON output always uses full names.")
+	s.Command.PersistentFlags().BoolVar(&s.Verbose, "verbose", false, "Print a summary to stderr at the end of the command of every RPC made, with its duration, retry count, and bytes sent and received.")
+	s.Command.PersistentFlags().BoolVar(&s.GrpcDebug, "grpc-debug", false, "Log every request to the server with its headers, request, and response or error.")
+	s.Command.PersistentFlags().BoolVar(&s.NoRedact, "no-redact", false, "Do not redact API keys, authorization headers, and TLS private keys from logs, --grpc-debug and --verbose output, and errors. Only use this for debugging where the output is not shared.")
+	s.Command.PersistentFlags().BoolVar(&s.ReadOnly, "read-only", false, "Refuse to send any request to the server that could change state, e.g. for safely exploring production. Only describe, get, list, count, scan, and query requests are sent.")
+	cctx.BindFlagEnvVar(s.Command.PersistentFlags().Lookup("read-only"), "TEMPORAL_READ_ONLY")
+	s.Command.PersistentFlags().BoolVar(&s.NoProgress, "no-progress", false, "Do not show progress bars on stderr during long-running operations. Progress is never shown when stderr is not a terminal.")
+	cctx.BindFlagEnvVar(s.Command.PersistentFlags().Lookup("no-progress"), "TEMPORAL_NO_PROGRESS")
 	s.initCommand(cctx)
 	return &s
 }
@@ -73,6 +112,7 @@ func NewTemporalActivityCommand(cctx *CommandContext, parent *TemporalCommand) *
 	s.Command.Long = ""
 	s.Command.Args = cobra.NoArgs
 	s.Command.AddCommand(&NewTemporalActivityCompleteCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalActivityDescribeCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalActivityFailCommand(cctx, &s).Command)
 	s.ClientOptions.buildFlags(cctx, s.Command.PersistentFlags())
 	return &s
@@ -113,6 +153,36 @@ func NewTemporalActivityCompleteCommand(cctx *CommandContext, parent *TemporalAc
 	return &s
 }
 
+type TemporalActivityDescribeCommand struct {
+	Parent  *TemporalActivityCommand
+	Command cobra.Command
+	WorkflowReferenceOptions
+	ActivityId string
+}
+
+func NewTemporalActivityDescribeCommand(cctx *CommandContext, parent *TemporalActivityCommand) *TemporalActivityDescribeCommand {
+	var s TemporalActivityDescribeCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "describe [flags]"
+	s.Command.Short = "Describe a pending Activity."
+	if hasHighlighting {
+		s.Command.Long = "Show the state of a single pending Activity of a Workflow Execution, including attempts, timeouts, decoded heartbeat\ndetails, and assigned Build Id.\n\n\x1b[1mtemporal activity describe --activity-id=MyActivityId --workflow-id=MyWorkflowId\x1b[0m"
+	} else {
+		s.Command.Long = "Show the state of a single pending Activity of a Workflow Execution, including attempts, timeouts, decoded heartbeat\ndetails, and assigned Build Id.\n\n`temporal activity describe --activity-id=MyActivityId --workflow-id=MyWorkflowId`"
+	}
+	s.Command.Args = cobra.NoArgs
+	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().StringVar(&s.ActivityId, "activity-id", "", "The Activity to describe. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "activity-id")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalActivityFailCommand struct {
 	Parent  *TemporalActivityCommand
 	Command cobra.Command
@@ -165,6 +235,7 @@ func NewTemporalBatchCommand(cctx *CommandContext, parent *TemporalCommand) *Tem
 	s.Command.AddCommand(&NewTemporalBatchDescribeCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalBatchListCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalBatchTerminateCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalBatchWaitCommand(cctx, &s).Command)
 	s.ClientOptions.buildFlags(cctx, s.Command.PersistentFlags())
 	return &s
 }
@@ -198,9 +269,11 @@ func NewTemporalBatchDescribeCommand(cctx *CommandContext, parent *TemporalBatch
 }
 
 type TemporalBatchListCommand struct {
-	Parent  *TemporalBatchCommand
-	Command cobra.Command
-	Limit   int
+	Parent        *TemporalBatchCommand
+	Command       cobra.Command
+	Limit         int
+	State         []string
+	OperationType []string
 }
 
 func NewTemporalBatchListCommand(cctx *CommandContext, parent *TemporalBatchCommand) *TemporalBatchListCommand {
@@ -216,6 +289,8 @@ func NewTemporalBatchListCommand(cctx *CommandContext, parent *TemporalBatchComm
 	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.Flags().IntVar(&s.Limit, "limit", 0, "Limit the number of items to print.")
+	s.Command.Flags().StringArrayVar(&s.State, "state", nil, "Only list Batch Jobs in this state. Options: Running, Completed, Failed. Can be given multiple times.")
+	s.Command.Flags().StringArrayVar(&s.OperationType, "operation-type", nil, "Only list Batch Jobs of this operation type. Options: Terminate, Cancel, Signal, Delete, Reset. Can be given multiple times. Each Batch Job is described to get its type, so this is slower.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -229,6 +304,7 @@ type TemporalBatchTerminateCommand struct {
 	Command cobra.Command
 	JobId   string
 	Reason  string
+	Yes     bool
 }
 
 func NewTemporalBatchTerminateCommand(cctx *CommandContext, parent *TemporalBatchCommand) *TemporalBatchTerminateCommand {
@@ -238,15 +314,92 @@ func NewTemporalBatchTerminateCommand(cctx *CommandContext, parent *TemporalBatc
 	s.Command.Use = "terminate [flags]"
 	s.Command.Short = "Terminate a Batch Job"
 	if hasHighlighting {
-		s.Command.Long = "The temporal batch terminate command terminates a Batch Job with the provided Job Id.\nFor future reference, provide a reason for terminating the Batch Job.\n\n\x1b[1mtemporal batch terminate --job-id=MyJobId --reason=JobReason\x1b[0m"
+		s.Command.Long = "The temporal batch terminate command terminates a Batch Job with the provided Job Id.\nFor future reference, provide a reason for terminating the Batch Job.\nThe Batch Job's progress is shown before asking for confirmation.\n\n\x1b[1mtemporal batch terminate --job-id=MyJobId --reason=JobReason\x1b[0m"
 	} else {
-		s.Command.Long = "The temporal batch terminate command terminates a Batch Job with the provided Job Id.\nFor future reference, provide a reason for terminating the Batch Job.\n\n`temporal batch terminate --job-id=MyJobId --reason=JobReason`"
+		s.Command.Long = "The temporal batch terminate command terminates a Batch Job with the provided Job Id.\nFor future reference, provide a reason for terminating the Batch Job.\nThe Batch Job's progress is shown before asking for confirmation.\n\n`temporal batch terminate --job-id=MyJobId --reason=JobReason`"
 	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.Flags().StringVar(&s.JobId, "job-id", "", "The Batch Job Id to terminate. Required.")
 	_ = cobra.MarkFlagRequired(s.Command.Flags(), "job-id")
 	s.Command.Flags().StringVar(&s.Reason, "reason", "", "Reason for terminating the Batch Job. Required.")
 	_ = cobra.MarkFlagRequired(s.Command.Flags(), "reason")
+	s.Command.Flags().BoolVarP(&s.Yes, "yes", "y", false, "Confirm prompt to terminate the Batch Job.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalBatchWaitCommand struct {
+	Parent  *TemporalBatchCommand
+	Command cobra.Command
+	JobId   string
+	Timeout Duration
+}
+
+func NewTemporalBatchWaitCommand(cctx *CommandContext, parent *TemporalBatchCommand) *TemporalBatchWaitCommand {
+	var s TemporalBatchWaitCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "wait [flags]"
+	s.Command.Short = "Wait for a Batch Job to finish"
+	if hasHighlighting {
+		s.Command.Long = "The temporal batch wait command waits for a Batch Job to reach a terminal state, showing its progress while it runs.\nThe command fails if the Batch Job fails.\n\n\x1b[1mtemporal batch wait --job-id=MyJobId\x1b[0m"
+	} else {
+		s.Command.Long = "The temporal batch wait command waits for a Batch Job to reach a terminal state, showing its progress while it runs.\nThe command fails if the Batch Job fails.\n\n`temporal batch wait --job-id=MyJobId`"
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().StringVar(&s.JobId, "job-id", "", "The Batch Job Id to wait for. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "job-id")
+	s.Timeout = 0
+	s.Command.Flags().Var(&s.Timeout, "timeout", "Maximum time to wait. Zero means no limit.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalDebugCommand struct {
+	Parent  *TemporalCommand
+	Command cobra.Command
+	ClientOptions
+}
+
+func NewTemporalDebugCommand(cctx *CommandContext, parent *TemporalCommand) *TemporalDebugCommand {
+	var s TemporalDebugCommand
+	s.Parent = parent
+	s.Command.Use = "debug"
+	s.Command.Short = "Diagnose problems using the CLI."
+	s.Command.Long = "Debug commands help find out why the CLI is not behaving as expected."
+	s.Command.Args = cobra.NoArgs
+	s.Command.AddCommand(&NewTemporalDebugConnectionCommand(cctx, &s).Command)
+	s.ClientOptions.buildFlags(cctx, s.Command.PersistentFlags())
+	return &s
+}
+
+type TemporalDebugConnectionCommand struct {
+	Parent  *TemporalDebugCommand
+	Command cobra.Command
+	Pings   int
+}
+
+func NewTemporalDebugConnectionCommand(cctx *CommandContext, parent *TemporalDebugCommand) *TemporalDebugConnectionCommand {
+	var s TemporalDebugConnectionCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "connection [flags]"
+	s.Command.Short = "Diagnose connecting to the server."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal debug connection\x1b[0m command connects to the server the same way every other command does and reports\nwhat it found along the way:\n\n* The resolved client options and where each came from: a flag, an env var, an env config, or the default. Secrets\n  are redacted.\n* The addresses the server host name resolves to.\n* TLS handshake details, including the version, cipher suite, and server certificate.\n* The server version and capabilities.\n* The round-trip latency of several requests.\n\n\x1b[1mtemporal debug connection --env prod\x1b[0m\n\nIf connecting fails, everything found up to that point is still reported along with the error."
+	} else {
+		s.Command.Long = "The `temporal debug connection` command connects to the server the same way every other command does and reports\nwhat it found along the way:\n\n* The resolved client options and where each came from: a flag, an env var, an env config, or the default. Secrets\n  are redacted.\n* The addresses the server host name resolves to.\n* TLS handshake details, including the version, cipher suite, and server certificate.\n* The server version and capabilities.\n* The round-trip latency of several requests.\n\n```\ntemporal debug connection --env prod\n```\n\nIf connecting fails, everything found up to that point is still reported along with the error."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().IntVar(&s.Pings, "pings", 3, "Number of requests to make to measure round-trip latency.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -265,12 +418,16 @@ func NewTemporalEnvCommand(cctx *CommandContext, parent *TemporalCommand) *Tempo
 	s.Parent = parent
 	s.Command.Use = "env"
 	s.Command.Short = "Manage environments."
-	s.Command.Long = "Use the '--env <env name>' option with other commands to point the CLI at a different Temporal Server instance. If --env\nis not passed, the 'default' environment is used."
+	s.Command.Long = "Use the '--env <env name>' option with other commands to point the CLI at a different Temporal Server instance. If --env\nis not passed, the environment selected with 'temporal env use' is used, or the 'default' environment."
 	s.Command.Args = cobra.NoArgs
 	s.Command.AddCommand(&NewTemporalEnvDeleteCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalEnvExportCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalEnvGetCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalEnvImportCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalEnvListCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalEnvSetCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalEnvUseCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalEnvValidateCommand(cctx, &s).Command)
 	return &s
 }
 
@@ -287,11 +444,13 @@ func NewTemporalEnvDeleteCommand(cctx *CommandContext, parent *TemporalEnvComman
 	s.Command.Use = "delete [flags]"
 	s.Command.Short = "Delete an environment or environment property."
 	if hasHighlighting {
-		s.Command.Long = "\x1b[1mtemporal env delete --env environment [-k property]\x1b[0m\n\nDelete an environment or just a single property:\n\n\x1b[1mtemporal env delete --env prod\x1b[0m\n\x1b[1mtemporal env delete --env prod -k tls-cert-path\x1b[0m\n\nIf the environment is not specified, the \x1b[1mdefault\x1b[0m environment is deleted:\n\n\x1b[1mtemporal env delete -k tls-cert-path\x1b[0m"
+		s.Command.Long = "\x1b[1mtemporal env delete --env environment [-k property]\x1b[0m\n\nDelete an environment or just a single property:\n\n\x1b[1mtemporal env delete --env prod\x1b[0m\n\x1b[1mtemporal env delete --env prod -k tls-cert-path\x1b[0m\n\nIf the environment is not specified, the property is deleted from the one selected with \x1b[1mtemporal env use\x1b[0m, or the\n\x1b[1mdefault\x1b[0m environment:\n\n\x1b[1mtemporal env delete -k tls-cert-path\x1b[0m\n\nDeleting a whole environment requires '--env' once one is selected with \x1b[1mtemporal env use\x1b[0m."
 	} else {
-		s.Command.Long = "`temporal env delete --env environment [-k property]`\n\nDelete an environment or just a single property:\n\n`temporal env delete --env prod`\n`temporal env delete --env prod -k tls-cert-path`\n\nIf the environment is not specified, the `default` environment is deleted:\n\n`temporal env delete -k tls-cert-path`"
+		s.Command.Long = "`temporal env delete --env environment [-k property]`\n\nDelete an environment or just a single property:\n\n`temporal env delete --env prod`\n`temporal env delete --env prod -k tls-cert-path`\n\nIf the environment is not specified, the property is deleted from the one selected with `temporal env use`, or the\n`default` environment:\n\n`temporal env delete -k tls-cert-path`\n\nDeleting a whole environment requires '--env' once one is selected with `temporal env use`."
 	}
 	s.Command.Args = cobra.MaximumNArgs(1)
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["offlineAllowed"] = "true"
 	s.Command.Flags().StringVarP(&s.Key, "key", "k", "", "The name of the property.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
@@ -301,6 +460,38 @@ func NewTemporalEnvDeleteCommand(cctx *CommandContext, parent *TemporalEnvComman
 	return &s
 }
 
+type TemporalEnvExportCommand struct {
+	Parent  *TemporalEnvCommand
+	Command cobra.Command
+	ClientOptions
+	Format StringEnum
+}
+
+func NewTemporalEnvExportCommand(cctx *CommandContext, parent *TemporalEnvCommand) *TemporalEnvExportCommand {
+	var s TemporalEnvExportCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "export [flags]"
+	s.Command.Short = "Print client options as environment variables."
+	if hasHighlighting {
+		s.Command.Long = "Print the client options of an environment as environment variables, e.g. for SDK workers and scripts to connect with\nexactly the settings the CLI uses:\n\n\x1b[1meval \"$(temporal env export --env prod)\"\x1b[0m\n\nOptions are resolved the same way as when connecting, so flags and \x1b[1mTEMPORAL_*\x1b[0m environment variables override the\nenvironment's properties. Only options that are set are printed, along with the address and namespace. Note that\nsecrets such as API keys are printed as well.\n\nIf the environment is not specified, the one selected with \x1b[1mtemporal env use\x1b[0m is used, or the \x1b[1mdefault\x1b[0m environment."
+	} else {
+		s.Command.Long = "Print the client options of an environment as environment variables, e.g. for SDK workers and scripts to connect with\nexactly the settings the CLI uses:\n\n`eval \"$(temporal env export --env prod)\"`\n\nOptions are resolved the same way as when connecting, so flags and `TEMPORAL_*` environment variables override the\nenvironment's properties. Only options that are set are printed, along with the address and namespace. Note that\nsecrets such as API keys are printed as well.\n\nIf the environment is not specified, the one selected with `temporal env use` is used, or the `default` environment."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.ClientOptions.buildFlags(cctx, s.Command.Flags())
+	s.Format = NewStringEnum([]string{"shell", "powershell"}, "shell")
+	s.Command.Flags().Var(&s.Format, "format", "Syntax of the printed variables. Accepted values: shell, powershell.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalEnvGetCommand struct {
 	Parent  *TemporalEnvCommand
 	Command cobra.Command
@@ -314,11 +505,13 @@ func NewTemporalEnvGetCommand(cctx *CommandContext, parent *TemporalEnvCommand)
 	s.Command.Use = "get [flags]"
 	s.Command.Short = "Print environment properties."
 	if hasHighlighting {
-		s.Command.Long = "\x1b[1mtemporal env get --env environment\x1b[0m\n\nPrint all properties of the 'prod' environment:\n\n\x1b[1mtemporal env get prod\x1b[0m\n\n\x1b[1mtls-cert-path  /home/my-user/certs/client.cert\ntls-key-path   /home/my-user/certs/client.key\naddress        temporal.example.com:7233\nnamespace      someNamespace\x1b[0m\n\nPrint a single property:\n\n\x1b[1mtemporal env get --env prod -k tls-key-path\x1b[0m\n\n\x1b[1mtls-key-path  /home/my-user/certs/cluster.key\x1b[0m\n\nIf the environment is not specified, the \x1b[1mdefault\x1b[0m environment is used."
+		s.Command.Long = "\x1b[1mtemporal env get --env environment\x1b[0m\n\nPrint all properties of the 'prod' environment:\n\n\x1b[1mtemporal env get prod\x1b[0m\n\n\x1b[1mtls-cert-path  /home/my-user/certs/client.cert\ntls-key-path   /home/my-user/certs/client.key\naddress        temporal.example.com:7233\nnamespace      someNamespace\x1b[0m\n\nPrint a single property:\n\n\x1b[1mtemporal env get --env prod -k tls-key-path\x1b[0m\n\n\x1b[1mtls-key-path  /home/my-user/certs/cluster.key\x1b[0m\n\nIf the environment is not specified, the one selected with \x1b[1mtemporal env use\x1b[0m is used, or the \x1b[1mdefault\x1b[0m environment."
 	} else {
-		s.Command.Long = "`temporal env get --env environment`\n\nPrint all properties of the 'prod' environment:\n\n`temporal env get prod`\n\n```\ntls-cert-path  /home/my-user/certs/client.cert\ntls-key-path   /home/my-user/certs/client.key\naddress        temporal.example.com:7233\nnamespace      someNamespace\n```\n\nPrint a single property:\n\n`temporal env get --env prod -k tls-key-path`\n\n```\ntls-key-path  /home/my-user/certs/cluster.key\n```\n\nIf the environment is not specified, the `default` environment is used."
+		s.Command.Long = "`temporal env get --env environment`\n\nPrint all properties of the 'prod' environment:\n\n`temporal env get prod`\n\n```\ntls-cert-path  /home/my-user/certs/client.cert\ntls-key-path   /home/my-user/certs/client.key\naddress        temporal.example.com:7233\nnamespace      someNamespace\n```\n\nPrint a single property:\n\n`temporal env get --env prod -k tls-key-path`\n\n```\ntls-key-path  /home/my-user/certs/cluster.key\n```\n\nIf the environment is not specified, the one selected with `temporal env use` is used, or the `default` environment."
 	}
 	s.Command.Args = cobra.MaximumNArgs(1)
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["offlineAllowed"] = "true"
 	s.Command.Flags().StringVarP(&s.Key, "key", "k", "", "The name of the property.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
@@ -328,6 +521,40 @@ func NewTemporalEnvGetCommand(cctx *CommandContext, parent *TemporalEnvCommand)
 	return &s
 }
 
+type TemporalEnvImportCommand struct {
+	Parent    *TemporalEnvCommand
+	Command   cobra.Command
+	FromTctl  string
+	FromEnv   bool
+	Overwrite bool
+}
+
+func NewTemporalEnvImportCommand(cctx *CommandContext, parent *TemporalEnvCommand) *TemporalEnvImportCommand {
+	var s TemporalEnvImportCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "import [flags]"
+	s.Command.Short = "Import environments from tctl configuration."
+	if hasHighlighting {
+		s.Command.Long = "Convert the configuration of the legacy tctl CLI into environments. Each context of a tctl config file becomes an\nenvironment of the same name, or only the context given with '--env' is imported:\n\n\x1b[1mtemporal env import --from-tctl ~/.config/temporalio/tctl.yaml\x1b[0m\n\nThe legacy \x1b[1mTEMPORAL_CLI_*\x1b[0m environment variables, e.g. \x1b[1mTEMPORAL_CLI_ADDRESS\x1b[0m and \x1b[1mTEMPORAL_CLI_TLS_CERT\x1b[0m, are\nimported into the environment given with '--env', or the \x1b[1mdefault\x1b[0m environment:\n\n\x1b[1mtemporal env import --from-env --env prod\x1b[0m\n\nProperties already set in an environment are kept unless '--overwrite' is given. Settings without an equivalent CLI\noption, such as tctl plugins, are reported and skipped."
+	} else {
+		s.Command.Long = "Convert the configuration of the legacy tctl CLI into environments. Each context of a tctl config file becomes an\nenvironment of the same name, or only the context given with '--env' is imported:\n\n`temporal env import --from-tctl ~/.config/temporalio/tctl.yaml`\n\nThe legacy `TEMPORAL_CLI_*` environment variables, e.g. `TEMPORAL_CLI_ADDRESS` and `TEMPORAL_CLI_TLS_CERT`, are\nimported into the environment given with '--env', or the `default` environment:\n\n`temporal env import --from-env --env prod`\n\nProperties already set in an environment are kept unless '--overwrite' is given. Settings without an equivalent CLI\noption, such as tctl plugins, are reported and skipped."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.Command.Flags().StringVar(&s.FromTctl, "from-tctl", "", "Path of a tctl config file to import contexts from.")
+	s.Command.Flags().BoolVar(&s.FromEnv, "from-env", false, "Import the legacy TEMPORAL_CLI_* environment variables.")
+	s.Command.Flags().BoolVar(&s.Overwrite, "overwrite", false, "Replace properties that are already set.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalEnvListCommand struct {
 	Parent  *TemporalEnvCommand
 	Command cobra.Command
@@ -343,6 +570,7 @@ func NewTemporalEnvListCommand(cctx *CommandContext, parent *TemporalEnvCommand)
 	s.Command.Args = cobra.NoArgs
 	s.Command.Annotations = make(map[string]string)
 	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -356,6 +584,7 @@ type TemporalEnvSetCommand struct {
 	Command cobra.Command
 	Key     string
 	Value   string
+	Keyring bool
 }
 
 func NewTemporalEnvSetCommand(cctx *CommandContext, parent *TemporalEnvCommand) *TemporalEnvSetCommand {
@@ -365,15 +594,153 @@ func NewTemporalEnvSetCommand(cctx *CommandContext, parent *TemporalEnvCommand)
 	s.Command.Use = "set [flags]"
 	s.Command.Short = "Set environment properties."
 	if hasHighlighting {
-		s.Command.Long = "\x1b[1mtemporal env set --env environment -k property -v value\x1b[0m\n\nProperty names match CLI option names, for example '--address' and '--tls-cert-path':\n\n\x1b[1mtemporal env set --env prod -k address -v 127.0.0.1:7233\x1b[0m\n\x1b[1mtemporal env set --env prod -k tls-cert-path -v /home/my-user/certs/cluster.cert\x1b[0m\n\nIf the environment is not specified, the \x1b[1mdefault\x1b[0m environment is used."
+		s.Command.Long = "\x1b[1mtemporal env set --env environment -k property -v value\x1b[0m\n\nProperty names match CLI option names, for example '--address' and '--tls-cert-path':\n\n\x1b[1mtemporal env set --env prod -k address -v 127.0.0.1:7233\x1b[0m\n\x1b[1mtemporal env set --env prod -k tls-cert-path -v /home/my-user/certs/cluster.cert\x1b[0m\n\nThe special 'advisory-disabled-commands' property is a comma-separated list of commands that may not be run with the\nenvironment. Disabling a command also disables its subcommands:\n\n\x1b[1mtemporal env set --env prod -k advisory-disabled-commands -v \"workflow terminate,operator namespace delete\"\x1b[0m\n\nThis guards against running a command with the wrong environment by mistake, but it is not a security boundary. The\nproperty can be changed like any other, and it does not apply when a different environment or '--env-file' is used.\nRestrict what identities may do on the server instead, for example with authorization rules.\n\nThe special 'required-search-attributes' and 'required-memo-keys' properties are comma-separated lists of search\nattributes and memo keys that workflows started with the environment must have, e.g. for ownership tagging. Commands\nthat start workflows or create or update schedules fail without starting anything if any are missing:\n\n\x1b[1mtemporal env set --env prod -k required-search-attributes -v \"Team,Service\"\x1b[0m\n\nThe special 'workflow-type-registry' property is the path of a YAML or JSON file with defaults for workflow types.\nCommands starting a workflow of a listed type may then omit '--task-queue', and JSON input is checked against the\ntype's JSON Schema for its first argument before the workflow is started. Schemas may be inline or a path relative to\nthe registry file:\n\n\x1b[1mworkflowTypes:\n  MyWorkflow:\n    taskQueue: my-task-queue\n    inputSchema: my-workflow-input.schema.json\x1b[0m\n\n\x1b[1mtemporal env set --env prod -k workflow-type-registry -v /home/my-user/temporal/workflow-types.yaml\x1b[0m\n\nSecrets such as API keys can be kept in the OS keychain (macOS Keychain, Windows Credential Manager, or the Secret\nService via \x1b[1msecret-tool\x1b[0m on Linux) instead of the plaintext file. The value is then read from standard input if not\ngiven:\n\n\x1b[1mtemporal env set --env prod -k api-key --keyring\x1b[0m\n\nThe property is stored as 'keyring:prod/api-key', and properties may also be set to 'keyring:<item>' directly to use\nan existing item of the 'temporal' service. Deleting the property or environment also deletes the item.\n\nWhen the special 'expand-env-vars' property is 'true', the environment's other values may reference environment\nvariables as \x1b[1m${VAR}\x1b[0m, or \x1b[1m${VAR:-default}\x1b[0m to use a default if the variable is unset or empty, so one environment file\ncan work across machines and CI. They are expanded when the environment is used, and \x1b[1m$${\x1b[0m is a literal \x1b[1m${\x1b[0m. Without\nthe property, values are used as-is:\n\n\x1b[1mtemporal env set --env prod -k expand-env-vars -v true\x1b[0m\n\x1b[1mtemporal env set --env prod -k tls-cert-path -v '${HOME}/certs/client.pem'\x1b[0m\n\nIf the environment is not specified, the one selected with \x1b[1mtemporal env use\x1b[0m is used, or the \x1b[1mdefault\x1b[0m environment."
 	} else {
-		s.Command.Long = "`temporal env set --env environment -k property -v value`\n\nProperty names match CLI option names, for example '--address' and '--tls-cert-path':\n\n`temporal env set --env prod -k address -v 127.0.0.1:7233`\n`temporal env set --env prod -k tls-cert-path -v /home/my-user/certs/cluster.cert`\n\nIf the environment is not specified, the `default` environment is used."
+		s.Command.Long = "`temporal env set --env environment -k property -v value`\n\nProperty names match CLI option names, for example '--address' and '--tls-cert-path':\n\n`temporal env set --env prod -k address -v 127.0.0.1:7233`\n`temporal env set --env prod -k tls-cert-path -v /home/my-user/certs/cluster.cert`\n\nThe special 'advisory-disabled-commands' property is a comma-separated list of commands that may not be run with the\nenvironment. Disabling a command also disables its subcommands:\n\n`temporal env set --env prod -k advisory-disabled-commands -v \"workflow terminate,operator namespace delete\"`\n\nThis guards against running a command with the wrong environment by mistake, but it is not a security boundary. The\nproperty can be changed like any other, and it does not apply when a different environment or '--env-file' is used.\nRestrict what identities may do on the server instead, for example with authorization rules.\n\nThe special 'required-search-attributes' and 'required-memo-keys' properties are comma-separated lists of search\nattributes and memo keys that workflows started with the environment must have, e.g. for ownership tagging. Commands\nthat start workflows or create or update schedules fail without starting anything if any are missing:\n\n`temporal env set --env prod -k required-search-attributes -v \"Team,Service\"`\n\nThe special 'workflow-type-registry' property is the path of a YAML or JSON file with defaults for workflow types.\nCommands starting a workflow of a listed type may then omit '--task-queue', and JSON input is checked against the\ntype's JSON Schema for its first argument before the workflow is started. Schemas may be inline or a path relative to\nthe registry file:\n\n```\nworkflowTypes:\n  MyWorkflow:\n    taskQueue: my-task-queue\n    inputSchema: my-workflow-input.schema.json\n```\n\n`temporal env set --env prod -k workflow-type-registry -v /home/my-user/temporal/workflow-types.yaml`\n\nSecrets such as API keys can be kept in the OS keychain (macOS Keychain, Windows Credential Manager, or the Secret\nService via `secret-tool` on Linux) instead of the plaintext file. The value is then read from standard input if not\ngiven:\n\n`temporal env set --env prod -k api-key --keyring`\n\nThe property is stored as 'keyring:prod/api-key', and properties may also be set to 'keyring:<item>' directly to use\nan existing item of the 'temporal' service. Deleting the property or environment also deletes the item.\n\nWhen the special 'expand-env-vars' property is 'true', the environment's other values may reference environment\nvariables as `${VAR}`, or `${VAR:-default}` to use a default if the variable is unset or empty, so one environment file\ncan work across machines and CI. They are expanded when the environment is used, and `$${` is a literal `${`. Without\nthe property, values are used as-is:\n\n`temporal env set --env prod -k expand-env-vars -v true`\n`temporal env set --env prod -k tls-cert-path -v '${HOME}/certs/client.pem'`\n\nIf the environment is not specified, the one selected with `temporal env use` is used, or the `default` environment."
 	}
 	s.Command.Args = cobra.MaximumNArgs(2)
 	s.Command.Annotations = make(map[string]string)
 	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
 	s.Command.Flags().StringVarP(&s.Key, "key", "k", "", "The name of the property.")
 	s.Command.Flags().StringVarP(&s.Value, "value", "v", "", "The value to set the property to.")
+	s.Command.Flags().BoolVar(&s.Keyring, "keyring", false, "Store the value in the OS keychain and only a reference to it in the environment.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalEnvUseCommand struct {
+	Parent  *TemporalEnvCommand
+	Command cobra.Command
+}
+
+func NewTemporalEnvUseCommand(cctx *CommandContext, parent *TemporalEnvCommand) *TemporalEnvUseCommand {
+	var s TemporalEnvUseCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "use [flags]"
+	s.Command.Short = "Select the environment used by default."
+	if hasHighlighting {
+		s.Command.Long = "\x1b[1mtemporal env use environment\x1b[0m\n\nMake an environment the one used by commands run without '--env', so it does not have to be given every time:\n\n\x1b[1mtemporal env use prod\x1b[0m\n\nThe selection is stored in the environment file, under a \x1b[1mcurrent\x1b[0m key that older CLI versions ignore. '--env' and the\n\x1b[1mTEMPORAL_ENV\x1b[0m environment variable still take precedence over it. Select the \x1b[1mdefault\x1b[0m environment to go back to it,\nand run without an argument to print the environment currently used by default:\n\n\x1b[1mtemporal env use default\x1b[0m\n\x1b[1mtemporal env use\x1b[0m"
+	} else {
+		s.Command.Long = "`temporal env use environment`\n\nMake an environment the one used by commands run without '--env', so it does not have to be given every time:\n\n`temporal env use prod`\n\nThe selection is stored in the environment file, under a `current` key that older CLI versions ignore. '--env' and the\n`TEMPORAL_ENV` environment variable still take precedence over it. Select the `default` environment to go back to it,\nand run without an argument to print the environment currently used by default:\n\n`temporal env use default`\n`temporal env use`"
+	}
+	s.Command.Args = cobra.MaximumNArgs(1)
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalEnvValidateCommand struct {
+	Parent  *TemporalEnvCommand
+	Command cobra.Command
+	Dial    bool
+}
+
+func NewTemporalEnvValidateCommand(cctx *CommandContext, parent *TemporalEnvCommand) *TemporalEnvValidateCommand {
+	var s TemporalEnvValidateCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "validate [flags]"
+	s.Command.Short = "Check environments for mistakes."
+	if hasHighlighting {
+		s.Command.Long = "Check every environment, or only the one given with '--env', for problems that would otherwise only show up as\nconfusing errors when running other commands:\n\n* Properties that are not CLI options, e.g. typos, with suggestions.\n* Values that are invalid for their option, or keyring items that cannot be read.\n* Certificate, key, and CA files that cannot be read.\n* Conflicting options, e.g. both '--tls-cert-path' and '--tls-cert-data', or '--api-key' with '--oauth-token-url'.\n\nClient options are checked the same way as when connecting, but without running a credential helper, contacting a\nSPIFFE Workload API, or connecting, so environments can be checked offline. Use '--dial' to also connect to the server\nof each environment, which does all of those:\n\n\x1b[1mtemporal env validate --dial\x1b[0m\n\nThe command fails if any problems are found."
+	} else {
+		s.Command.Long = "Check every environment, or only the one given with '--env', for problems that would otherwise only show up as\nconfusing errors when running other commands:\n\n* Properties that are not CLI options, e.g. typos, with suggestions.\n* Values that are invalid for their option, or keyring items that cannot be read.\n* Certificate, key, and CA files that cannot be read.\n* Conflicting options, e.g. both '--tls-cert-path' and '--tls-cert-data', or '--api-key' with '--oauth-token-url'.\n\nClient options are checked the same way as when connecting, but without running a credential helper, contacting a\nSPIFFE Workload API, or connecting, so environments can be checked offline. Use '--dial' to also connect to the server\nof each environment, which does all of those:\n\n`temporal env validate --dial`\n\nThe command fails if any problems are found."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.Command.Flags().BoolVar(&s.Dial, "dial", false, "Also connect to the server of each environment.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalGenCommand struct {
+	Parent  *TemporalCommand
+	Command cobra.Command
+}
+
+func NewTemporalGenCommand(cctx *CommandContext, parent *TemporalCommand) *TemporalGenCommand {
+	var s TemporalGenCommand
+	s.Parent = parent
+	s.Command.Use = "gen"
+	s.Command.Short = "Generate files for packaging the CLI."
+	s.Command.Long = "Generate man pages and shell completion scripts from the command tree, e.g. for distribution packages to build at\npackage time. The output only depends on the CLI version, so it is reproducible."
+	s.Command.Args = cobra.NoArgs
+	s.Command.AddCommand(&NewTemporalGenCompletionsCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalGenManpagesCommand(cctx, &s).Command)
+	return &s
+}
+
+type TemporalGenCompletionsCommand struct {
+	Parent  *TemporalGenCommand
+	Command cobra.Command
+	Dir     string
+}
+
+func NewTemporalGenCompletionsCommand(cctx *CommandContext, parent *TemporalGenCommand) *TemporalGenCompletionsCommand {
+	var s TemporalGenCompletionsCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "completions [flags]"
+	s.Command.Short = "Generate shell completion scripts."
+	if hasHighlighting {
+		s.Command.Long = "Write completion scripts for bash (\x1b[1mtemporal.bash\x1b[0m), zsh (\x1b[1m_temporal\x1b[0m), fish (\x1b[1mtemporal.fish\x1b[0m), and PowerShell\n(\x1b[1mtemporal.ps1\x1b[0m) to a directory:\n\n\x1b[1mtemporal gen completions --dir out/completions\x1b[0m"
+	} else {
+		s.Command.Long = "Write completion scripts for bash (`temporal.bash`), zsh (`_temporal`), fish (`temporal.fish`), and PowerShell\n(`temporal.ps1`) to a directory:\n\n`temporal gen completions --dir out/completions`"
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.Command.Flags().StringVar(&s.Dir, "dir", "", "Directory to write the scripts to. It is created if needed. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "dir")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalGenManpagesCommand struct {
+	Parent  *TemporalGenCommand
+	Command cobra.Command
+	Dir     string
+}
+
+func NewTemporalGenManpagesCommand(cctx *CommandContext, parent *TemporalGenCommand) *TemporalGenManpagesCommand {
+	var s TemporalGenManpagesCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "manpages [flags]"
+	s.Command.Short = "Generate man pages."
+	if hasHighlighting {
+		s.Command.Long = "Write a section 1 man page for every command to a directory, named after the command path, e.g.\n\x1b[1mtemporal-workflow-start.1\x1b[0m:\n\n\x1b[1mtemporal gen manpages --dir out/man1\x1b[0m"
+	} else {
+		s.Command.Long = "Write a section 1 man page for every command to a directory, named after the command path, e.g.\n`temporal-workflow-start.1`:\n\n`temporal gen manpages --dir out/man1`"
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.Command.Flags().StringVar(&s.Dir, "dir", "", "Directory to write the man pages to. It is created if needed. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "dir")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -402,6 +769,7 @@ func NewTemporalOperatorCommand(cctx *CommandContext, parent *TemporalCommand) *
 	s.Command.AddCommand(&NewTemporalOperatorClusterCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalOperatorNamespaceCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalOperatorSearchAttributeCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalOperatorVisibilityCommand(cctx, &s).Command)
 	s.ClientOptions.buildFlags(cctx, s.Command.PersistentFlags())
 	return &s
 }
@@ -613,6 +981,8 @@ func NewTemporalOperatorNamespaceCommand(cctx *CommandContext, parent *TemporalO
 	s.Command.AddCommand(&NewTemporalOperatorNamespaceDeleteCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalOperatorNamespaceDescribeCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalOperatorNamespaceListCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalOperatorNamespaceStatsCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalOperatorNamespaceTypesCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalOperatorNamespaceUpdateCommand(cctx, &s).Command)
 	return &s
 }
@@ -645,8 +1015,8 @@ func NewTemporalOperatorNamespaceCreateCommand(cctx *CommandContext, parent *Tem
 		s.Command.Long = "The temporal operator namespace create command creates a new Namespace on the Server.\nNamespaces can be created on the active Cluster, or any named Cluster.\n`temporal operator namespace create --cluster=MyCluster -n example-1`\n\nGlobal Namespaces can also be created.\n`temporal operator namespace create --global -n example-2`\n\nOther settings, such as retention and Visibility Archival State, can be configured as needed.\nFor example, the Visibility Archive can be set on a separate URI.\n`temporal operator namespace create --retention=5 --visibility-archival-state=enabled --visibility-uri=some-uri -n example-3`"
 	}
 	s.Command.Args = cobra.MaximumNArgs(1)
-	s.Command.Flags().StringVar(&s.ActiveCluster, "active-cluster", "", "Active cluster name.")
-	s.Command.Flags().StringArrayVar(&s.Cluster, "cluster", nil, "Cluster names.")
+	s.Command.Flags().StringVar(&s.ActiveCluster, "active-cluster", "", "Active cluster name. Exclusive with --cluster and --promote-global.")
+	s.Command.Flags().StringArrayVar(&s.Cluster, "cluster", nil, "Cluster names to replicate to. Replaces the existing list.")
 	s.Command.Flags().StringArrayVar(&s.Data, "data", nil, "Namespace data in key=value format. Use JSON for values.")
 	s.Command.Flags().StringVar(&s.Description, "description", "", "Namespace description.")
 	s.Command.Flags().StringVar(&s.Email, "email", "", "Owner email.")
@@ -668,9 +1038,11 @@ func NewTemporalOperatorNamespaceCreateCommand(cctx *CommandContext, parent *Tem
 }
 
 type TemporalOperatorNamespaceDeleteCommand struct {
-	Parent  *TemporalOperatorNamespaceCommand
-	Command cobra.Command
-	Yes     bool
+	Parent     *TemporalOperatorNamespaceCommand
+	Command    cobra.Command
+	Yes        bool
+	CheckEmpty bool
+	Wait       bool
 }
 
 func NewTemporalOperatorNamespaceDeleteCommand(cctx *CommandContext, parent *TemporalOperatorNamespaceCommand) *TemporalOperatorNamespaceDeleteCommand {
@@ -679,9 +1051,15 @@ func NewTemporalOperatorNamespaceDeleteCommand(cctx *CommandContext, parent *Tem
 	s.Command.DisableFlagsInUseLine = true
 	s.Command.Use = "delete [flags] [namespace]"
 	s.Command.Short = "Deletes an existing Namespace."
-	s.Command.Long = "The temporal operator namespace delete command deletes a given Namespace from the system."
+	if hasHighlighting {
+		s.Command.Long = "The temporal operator namespace delete command deletes a given Namespace from the system. The Namespace name must be\ntyped to confirm unless \x1b[1m--yes\x1b[0m is given.\n\nUse \x1b[1m--check-empty\x1b[0m to refuse deleting a Namespace that still has open Workflows or Schedules, and \x1b[1m--wait\x1b[0m to wait\nfor the server to finish removing the Namespace's data:\n\n\x1b[1mtemporal operator namespace delete MyNamespace --check-empty --wait\x1b[0m"
+	} else {
+		s.Command.Long = "The temporal operator namespace delete command deletes a given Namespace from the system. The Namespace name must be\ntyped to confirm unless `--yes` is given.\n\nUse `--check-empty` to refuse deleting a Namespace that still has open Workflows or Schedules, and `--wait` to wait\nfor the server to finish removing the Namespace's data:\n\n`temporal operator namespace delete MyNamespace --check-empty --wait`"
+	}
 	s.Command.Args = cobra.MaximumNArgs(1)
 	s.Command.Flags().BoolVarP(&s.Yes, "yes", "y", false, "Confirm prompt to perform deletion.")
+	s.Command.Flags().BoolVar(&s.CheckEmpty, "check-empty", false, "Fail before deleting if the Namespace has any open Workflow Executions or Schedules.")
+	s.Command.Flags().BoolVar(&s.Wait, "wait", false, "Wait for the deletion to complete, showing progress, instead of returning once it has started.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -718,8 +1096,13 @@ func NewTemporalOperatorNamespaceDescribeCommand(cctx *CommandContext, parent *T
 }
 
 type TemporalOperatorNamespaceListCommand struct {
-	Parent  *TemporalOperatorNamespaceCommand
-	Command cobra.Command
+	Parent           *TemporalOperatorNamespaceCommand
+	Command          cobra.Command
+	NameRegex        string
+	State            []string
+	Scope            StringEnum
+	Stats            bool
+	StatsConcurrency int
 }
 
 func NewTemporalOperatorNamespaceListCommand(cctx *CommandContext, parent *TemporalOperatorNamespaceCommand) *TemporalOperatorNamespaceListCommand {
@@ -728,8 +1111,82 @@ func NewTemporalOperatorNamespaceListCommand(cctx *CommandContext, parent *Tempo
 	s.Command.DisableFlagsInUseLine = true
 	s.Command.Use = "list [flags]"
 	s.Command.Short = "List all Namespaces."
-	s.Command.Long = "The temporal operator namespace list command lists all Namespaces on the Server."
+	if hasHighlighting {
+		s.Command.Long = "The temporal operator namespace list command lists all Namespaces on the Server.\n\nNamespaces can be filtered, and the number of open workflows and schedules in each can be included:\n\x1b[1mtemporal operator namespace list --name-regex '^prod-' --scope global --stats\x1b[0m"
+	} else {
+		s.Command.Long = "The temporal operator namespace list command lists all Namespaces on the Server.\n\nNamespaces can be filtered, and the number of open workflows and schedules in each can be included:\n`temporal operator namespace list --name-regex '^prod-' --scope global --stats`"
+	}
 	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().StringVar(&s.NameRegex, "name-regex", "", "Only list Namespaces with names matching this regular expression.")
+	s.Command.Flags().StringArrayVar(&s.State, "state", nil, "Only list Namespaces in this state. Options: Registered, Deprecated, Deleted. Can be given multiple times.")
+	s.Scope = NewStringEnum([]string{"all", "global", "local"}, "all")
+	s.Command.Flags().Var(&s.Scope, "scope", "Only list global or local Namespaces. Accepted values: all, global, local.")
+	s.Command.Flags().BoolVar(&s.Stats, "stats", false, "Include the number of open workflows and schedules in each Namespace. These are gathered concurrently, so this may put load on the Server when there are many Namespaces.")
+	s.Command.Flags().IntVar(&s.StatsConcurrency, "stats-concurrency", 10, "Maximum number of Namespaces to gather stats for at once.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalOperatorNamespaceStatsCommand struct {
+	Parent      *TemporalOperatorNamespaceCommand
+	Command     cobra.Command
+	Sample      int
+	Query       string
+	Concurrency int
+}
+
+func NewTemporalOperatorNamespaceStatsCommand(cctx *CommandContext, parent *TemporalOperatorNamespaceCommand) *TemporalOperatorNamespaceStatsCommand {
+	var s TemporalOperatorNamespaceStatsCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "stats [flags] [namespace]"
+	s.Command.Short = "Show history statistics for recent Workflows in a Namespace."
+	if hasHighlighting {
+		s.Command.Long = "The temporal operator namespace stats command samples recently closed Workflow Executions in a Namespace and reports,\nper Workflow Type, the distribution of history length, total payload size, number of Activities scheduled, and\nduration. Each distribution is shown as minimum / p50 / p95 / maximum.\n\n\x1b[1mtemporal operator namespace stats -n MyNamespace --sample 1000\x1b[0m\n\nThe full history of each sampled Workflow is fetched, so large samples may put load on the Server. Use \x1b[1m--query\x1b[0m to\nnarrow the sample:\n\n\x1b[1mtemporal operator namespace stats -n MyNamespace --query \"WorkflowType = 'MyWorkflow'\"\x1b[0m"
+	} else {
+		s.Command.Long = "The temporal operator namespace stats command samples recently closed Workflow Executions in a Namespace and reports,\nper Workflow Type, the distribution of history length, total payload size, number of Activities scheduled, and\nduration. Each distribution is shown as minimum / p50 / p95 / maximum.\n\n`temporal operator namespace stats -n MyNamespace --sample 1000`\n\nThe full history of each sampled Workflow is fetched, so large samples may put load on the Server. Use `--query` to\nnarrow the sample:\n\n`temporal operator namespace stats -n MyNamespace --query \"WorkflowType = 'MyWorkflow'\"`"
+	}
+	s.Command.Args = cobra.MaximumNArgs(1)
+	s.Command.Flags().IntVar(&s.Sample, "sample", 1000, "Maximum number of recently closed Workflows to sample.")
+	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Additional List Filter to narrow which closed Workflows are sampled.")
+	s.Command.Flags().IntVar(&s.Concurrency, "concurrency", 10, "Maximum number of Workflow histories to fetch at once.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalOperatorNamespaceTypesCommand struct {
+	Parent           *TemporalOperatorNamespaceCommand
+	Command          cobra.Command
+	Limit            int
+	Query            string
+	HistoriesPerType int
+	Concurrency      int
+}
+
+func NewTemporalOperatorNamespaceTypesCommand(cctx *CommandContext, parent *TemporalOperatorNamespaceCommand) *TemporalOperatorNamespaceTypesCommand {
+	var s TemporalOperatorNamespaceTypesCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "types [flags] [namespace]"
+	s.Command.Short = "List the Workflow and Activity Types that run in a Namespace."
+	if hasHighlighting {
+		s.Command.Long = "The temporal operator namespace types command scans the most recently started Workflow Executions in a Namespace and\nlists each distinct Workflow Type with how many of the scanned Workflows have it, how many are still open, and when\none was last started.\n\n\x1b[1mtemporal operator namespace types -n MyNamespace\x1b[0m\n\nActivity Types are not in visibility, so they are found by fetching the histories of the most recent Workflows of each\nType. They are listed with the Workflow Types that schedule them, how many times they were scheduled in the sampled\nhistories, and when they were last scheduled. Use \x1b[1m--histories-per-type 0\x1b[0m to only list Workflow Types:\n\n\x1b[1mtemporal operator namespace types -n MyNamespace --histories-per-type 0\x1b[0m"
+	} else {
+		s.Command.Long = "The temporal operator namespace types command scans the most recently started Workflow Executions in a Namespace and\nlists each distinct Workflow Type with how many of the scanned Workflows have it, how many are still open, and when\none was last started.\n\n`temporal operator namespace types -n MyNamespace`\n\nActivity Types are not in visibility, so they are found by fetching the histories of the most recent Workflows of each\nType. They are listed with the Workflow Types that schedule them, how many times they were scheduled in the sampled\nhistories, and when they were last scheduled. Use `--histories-per-type 0` to only list Workflow Types:\n\n`temporal operator namespace types -n MyNamespace --histories-per-type 0`"
+	}
+	s.Command.Args = cobra.MaximumNArgs(1)
+	s.Command.Flags().IntVar(&s.Limit, "limit", 10000, "Maximum number of most recently started Workflows to scan.")
+	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Additional List Filter to narrow which Workflows are scanned.")
+	s.Command.Flags().IntVar(&s.HistoriesPerType, "histories-per-type", 3, "Number of most recent Workflow histories of each Workflow Type to fetch to find Activity Types.")
+	s.Command.Flags().IntVar(&s.Concurrency, "concurrency", 10, "Maximum number of Workflow histories to fetch at once.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -745,6 +1202,7 @@ type TemporalOperatorNamespaceUpdateCommand struct {
 	Cluster                 []string
 	Data                    []string
 	Description             string
+	Edit                    bool
 	Email                   string
 	PromoteGlobal           bool
 	HistoryArchivalState    StringEnum
@@ -761,15 +1219,16 @@ func NewTemporalOperatorNamespaceUpdateCommand(cctx *CommandContext, parent *Tem
 	s.Command.Use = "update [flags]"
 	s.Command.Short = "Updates a Namespace."
 	if hasHighlighting {
-		s.Command.Long = "The temporal operator namespace update command updates a Namespace.\n\nNamespaces can be assigned a different active Cluster.\n\x1b[1mtemporal operator namespace update -n namespace --active-cluster=NewActiveCluster\x1b[0m\n\nNamespaces can also be promoted to global Namespaces.\n\x1b[1mtemporal operator namespace update -n namespace --promote-global\x1b[0m\n\nAny Archives that were previously enabled or disabled can be changed through this command.\nHowever, URI values for archival states cannot be changed after the states are enabled.\n\x1b[1mtemporal operator namespace update -n namespace --history-archival-state=enabled --visibility-archival-state=disabled\x1b[0m"
+		s.Command.Long = "The temporal operator namespace update command updates a Namespace.\n\nNamespaces can be assigned a different active Cluster.\n\x1b[1mtemporal operator namespace update -n namespace --active-cluster=NewActiveCluster\x1b[0m\n\nNamespaces can also be promoted to global Namespaces, optionally setting the Clusters they are replicated to.\n\x1b[1mtemporal operator namespace update -n namespace --promote-global --cluster ClusterA --cluster ClusterB\x1b[0m\n\nBefore changing the active Cluster or Clusters, the resulting replication configuration is checked: a local\nNamespace can only use its own Cluster unless promoted, the active Cluster must be one of the Clusters, and each\nCluster must be known to the server with its connection enabled.\n\nAny Archives that were previously enabled or disabled can be changed through this command.\nHowever, URI values for archival states cannot be changed after the states are enabled.\n\x1b[1mtemporal operator namespace update -n namespace --history-archival-state=enabled --visibility-archival-state=disabled\x1b[0m\n\nValues not given are kept from the current Namespace. If the Namespace is updated by someone else at the same time,\nthe update is made again on top of their changes. Namespace updates have no conflict detection on the server, so the\nNamespace is read again right before writing to keep the window where their changes can be replaced very small.\n\nWith \x1b[1m--edit\x1b[0m, the Namespace's description, owner email, data, configuration, and replication configuration are opened\nas YAML in \x1b[1m$VISUAL\x1b[0m or \x1b[1m$EDITOR\x1b[0m. When the editor is closed, the edited document is checked, the changes are shown as a\ndiff, and then applied. Data keys can be added or changed but not removed. No other options can be given with \x1b[1m--edit\x1b[0m.\n\x1b[1mtemporal operator namespace update -n namespace --edit\x1b[0m"
 	} else {
-		s.Command.Long = "The temporal operator namespace update command updates a Namespace.\n\nNamespaces can be assigned a different active Cluster.\n`temporal operator namespace update -n namespace --active-cluster=NewActiveCluster`\n\nNamespaces can also be promoted to global Namespaces.\n`temporal operator namespace update -n namespace --promote-global`\n\nAny Archives that were previously enabled or disabled can be changed through this command.\nHowever, URI values for archival states cannot be changed after the states are enabled.\n`temporal operator namespace update -n namespace --history-archival-state=enabled --visibility-archival-state=disabled`"
+		s.Command.Long = "The temporal operator namespace update command updates a Namespace.\n\nNamespaces can be assigned a different active Cluster.\n`temporal operator namespace update -n namespace --active-cluster=NewActiveCluster`\n\nNamespaces can also be promoted to global Namespaces, optionally setting the Clusters they are replicated to.\n`temporal operator namespace update -n namespace --promote-global --cluster ClusterA --cluster ClusterB`\n\nBefore changing the active Cluster or Clusters, the resulting replication configuration is checked: a local\nNamespace can only use its own Cluster unless promoted, the active Cluster must be one of the Clusters, and each\nCluster must be known to the server with its connection enabled.\n\nAny Archives that were previously enabled or disabled can be changed through this command.\nHowever, URI values for archival states cannot be changed after the states are enabled.\n`temporal operator namespace update -n namespace --history-archival-state=enabled --visibility-archival-state=disabled`\n\nValues not given are kept from the current Namespace. If the Namespace is updated by someone else at the same time,\nthe update is made again on top of their changes. Namespace updates have no conflict detection on the server, so the\nNamespace is read again right before writing to keep the window where their changes can be replaced very small.\n\nWith `--edit`, the Namespace's description, owner email, data, configuration, and replication configuration are opened\nas YAML in `$VISUAL` or `$EDITOR`. When the editor is closed, the edited document is checked, the changes are shown as a\ndiff, and then applied. Data keys can be added or changed but not removed. No other options can be given with `--edit`.\n`temporal operator namespace update -n namespace --edit`"
 	}
 	s.Command.Args = cobra.MaximumNArgs(1)
 	s.Command.Flags().StringVar(&s.ActiveCluster, "active-cluster", "", "Active cluster name.")
 	s.Command.Flags().StringArrayVar(&s.Cluster, "cluster", nil, "Cluster names.")
 	s.Command.Flags().StringArrayVar(&s.Data, "data", nil, "Namespace data in key=value format. Use JSON for values.")
 	s.Command.Flags().StringVar(&s.Description, "description", "", "Namespace description.")
+	s.Command.Flags().BoolVar(&s.Edit, "edit", false, "Edit the Namespace as YAML in an editor instead of using the other options.")
 	s.Command.Flags().StringVar(&s.Email, "email", "", "Owner email.")
 	s.Command.Flags().BoolVar(&s.PromoteGlobal, "promote-global", false, "Promote local namespace to global namespace.")
 	s.HistoryArchivalState = NewStringEnum([]string{"disabled", "enabled"}, "")
@@ -892,6 +1351,89 @@ func NewTemporalOperatorSearchAttributeRemoveCommand(cctx *CommandContext, paren
 	return &s
 }
 
+type TemporalOperatorVisibilityCommand struct {
+	Parent  *TemporalOperatorCommand
+	Command cobra.Command
+}
+
+func NewTemporalOperatorVisibilityCommand(cctx *CommandContext, parent *TemporalOperatorCommand) *TemporalOperatorVisibilityCommand {
+	var s TemporalOperatorVisibilityCommand
+	s.Parent = parent
+	s.Command.Use = "visibility"
+	s.Command.Short = "Operations on visibility records."
+	s.Command.Long = "Visibility commands help find and repair problems with the visibility store used for listing Workflow Executions."
+	s.Command.Args = cobra.NoArgs
+	s.Command.AddCommand(&NewTemporalOperatorVisibilityReindexCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalOperatorVisibilityVerifyCommand(cctx, &s).Command)
+	return &s
+}
+
+type TemporalOperatorVisibilityReindexCommand struct {
+	Parent      *TemporalOperatorVisibilityCommand
+	Command     cobra.Command
+	WorkflowId  string
+	RunId       string
+	Query       string
+	Yes         bool
+	ResumeToken string
+}
+
+func NewTemporalOperatorVisibilityReindexCommand(cctx *CommandContext, parent *TemporalOperatorVisibilityCommand) *TemporalOperatorVisibilityReindexCommand {
+	var s TemporalOperatorVisibilityReindexCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "reindex [flags]"
+	s.Command.Short = "Regenerates visibility records for Workflow Executions."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal operator visibility reindex\x1b[0m command asks the server to regenerate the tasks of the given\nWorkflow Executions, which rewrites their visibility records. This uses the\nadmin service, which must be exposed by the server. This is useful after visibility store data loss.\n\nExecutions may be reindexed by ID:\n\x1b[1mtemporal operator visibility reindex --workflow-id MyWorkflowId\x1b[0m\n\n...or in bulk via a visibility query list filter:\n\x1b[1mtemporal operator visibility reindex --query 'WorkflowType = \"MyWorkflow\"'\x1b[0m\n\nA bulk reindex can only find executions that still have a visibility record, since the query is answered by the\nvisibility store itself. Executions whose records were lost entirely are not listed and must be reindexed by ID, for\nexample using IDs taken from application logs.\n\nIf a bulk reindex is interrupted, it prints a resume token that can be given with the same query to continue where it\nleft off. This is the only command with a resume token, since it is the only bulk command that works through Workflows\npage by page as it lists them. Others either start a server batch job, which keeps running if the CLI is interrupted,\nor list the Workflows first and report the ones they did not get to."
+	} else {
+		s.Command.Long = "The `temporal operator visibility reindex` command asks the server to regenerate the tasks of the given\nWorkflow Executions, which rewrites their visibility records. This uses the\nadmin service, which must be exposed by the server. This is useful after visibility store data loss.\n\nExecutions may be reindexed by ID:\n```\ntemporal operator visibility reindex --workflow-id MyWorkflowId\n```\n\n...or in bulk via a visibility query list filter:\n```\ntemporal operator visibility reindex --query 'WorkflowType = \"MyWorkflow\"'\n```\n\nA bulk reindex can only find executions that still have a visibility record, since the query is answered by the\nvisibility store itself. Executions whose records were lost entirely are not listed and must be reindexed by ID, for\nexample using IDs taken from application logs.\n\nIf a bulk reindex is interrupted, it prints a resume token that can be given with the same query to continue where it\nleft off. This is the only command with a resume token, since it is the only bulk command that works through Workflows\npage by page as it lists them. Others either start a server batch job, which keeps running if the CLI is interrupted,\nor list the Workflows first and report the ones they did not get to."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().StringVarP(&s.WorkflowId, "workflow-id", "w", "", "Workflow Id. Either this or query must be set.")
+	s.Command.Flags().StringVarP(&s.RunId, "run-id", "r", "", "Run Id. Cannot be set when query is set.")
+	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Reindex Workflow Executions with given List Filter. Either this or Workflow Id must be set.")
+	s.Command.Flags().BoolVarP(&s.Yes, "yes", "y", false, "Confirm prompt to reindex. Only allowed if query is present.")
+	s.Command.Flags().StringVar(&s.ResumeToken, "resume-token", "", "Token printed by an interrupted reindex to continue it. Only allowed if query is present.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalOperatorVisibilityVerifyCommand struct {
+	Parent     *TemporalOperatorVisibilityCommand
+	Command    cobra.Command
+	Query      string
+	WorkflowId []string
+	SampleSize int
+}
+
+func NewTemporalOperatorVisibilityVerifyCommand(cctx *CommandContext, parent *TemporalOperatorVisibilityCommand) *TemporalOperatorVisibilityVerifyCommand {
+	var s TemporalOperatorVisibilityVerifyCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "verify [flags]"
+	s.Command.Short = "Cross-checks visibility records against Workflow Executions."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal operator visibility verify\x1b[0m command compares a sample of visibility records against the actual state of\ntheir Workflow Executions and reports records that are stale or missing. This\nis useful after visibility store incidents. Since visibility is eventually consistent, executions that changed very\nrecently may be reported as stale.\n\n\x1b[1mtemporal operator visibility verify --query 'ExecutionStatus = \"Running\"'\x1b[0m"
+	} else {
+		s.Command.Long = "The `temporal operator visibility verify` command compares a sample of visibility records against the actual state of\ntheir Workflow Executions and reports records that are stale or missing. This\nis useful after visibility store incidents. Since visibility is eventually consistent, executions that changed very\nrecently may be reported as stale.\n\n```\ntemporal operator visibility verify --query 'ExecutionStatus = \"Running\"'\n```"
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Filter visibility records to check using a SQL-like query. Either this or workflow ID must be set.")
+	s.Command.Flags().StringArrayVarP(&s.WorkflowId, "workflow-id", "w", nil, "Workflow Id whose latest run should have a current visibility record. Can be given multiple times.")
+	s.Command.Flags().IntVar(&s.SampleSize, "sample-size", 100, "Maximum number of visibility records from the query to check.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalScheduleCommand struct {
 	Parent  *TemporalCommand
 	Command cobra.Command
@@ -910,6 +1452,7 @@ func NewTemporalScheduleCommand(cctx *CommandContext, parent *TemporalCommand) *
 	s.Command.AddCommand(&NewTemporalScheduleDeleteCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalScheduleDescribeCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalScheduleListCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalScheduleResumeDueCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalScheduleToggleCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalScheduleTriggerCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalScheduleUpdateCommand(cctx, &s).Command)
@@ -985,6 +1528,7 @@ type ScheduleConfigurationOptions struct {
 	TimeZone                string
 	ScheduleSearchAttribute []string
 	ScheduleMemo            []string
+	ScheduleMemoFile        []string
 }
 
 func (v *ScheduleConfigurationOptions) buildFlags(cctx *CommandContext, f *pflag.FlagSet) {
@@ -1004,6 +1548,7 @@ func (v *ScheduleConfigurationOptions) buildFlags(cctx *CommandContext, f *pflag
 	f.StringVar(&v.TimeZone, "time-zone", "", "Time zone to interpret all calendar specs in (IANA name).")
 	f.StringArrayVar(&v.ScheduleSearchAttribute, "schedule-search-attribute", nil, "Search Attribute for the _schedule_ in key=value format. Use valid JSON formats for value.")
 	f.StringArrayVar(&v.ScheduleMemo, "schedule-memo", nil, "Memo for the _schedule_ in key=value format. Use valid JSON formats for value.")
+	f.StringArrayVar(&v.ScheduleMemoFile, "schedule-memo-file", nil, "Memo for the _schedule_ in key=path format, where the file contains the JSON value. Can be given multiple times.")
 }
 
 type TemporalScheduleCreateCommand struct {
@@ -1023,9 +1568,9 @@ func NewTemporalScheduleCreateCommand(cctx *CommandContext, parent *TemporalSche
 	s.Command.Use = "create [flags]"
 	s.Command.Short = "Create a new Schedule."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal schedule create\x1b[0m command creates a new Schedule.\n\nExample:\n\n\x1b[1m  temporal schedule create                                    \\\n    --schedule-id 'your-schedule-id'                          \\\n    --calendar '{\"dayOfWeek\":\"Fri\",\"hour\":\"3\",\"minute\":\"11\"}' \\\n    --workflow-id 'your-base-workflow-id'                     \\\n    --task-queue 'your-task-queue'                            \\\n    --workflow-type 'YourWorkflowType'\x1b[0m\n\nAny combination of \x1b[1m--calendar\x1b[0m, \x1b[1m--interval\x1b[0m, and \x1b[1m--cron\x1b[0m is supported.\nActions will be executed at any time specified in the Schedule."
+		s.Command.Long = "The \x1b[1mtemporal schedule create\x1b[0m command creates a new Schedule.\n\nExample:\n\n\x1b[1m  temporal schedule create                                    \\\n    --schedule-id 'your-schedule-id'                          \\\n    --calendar '{\"dayOfWeek\":\"Fri\",\"hour\":\"3\",\"minute\":\"11\"}' \\\n    --workflow-id 'your-base-workflow-id'                     \\\n    --task-queue 'your-task-queue'                            \\\n    --workflow-type 'YourWorkflowType'\x1b[0m\n\nAny combination of \x1b[1m--calendar\x1b[0m, \x1b[1m--interval\x1b[0m, and \x1b[1m--cron\x1b[0m is supported.\nActions will be executed at any time specified in the Schedule.\n\nThe Schedule is checked before it is sent. Calendar fields out of range fail the command, and settings the server would\nsilently adjust, such as jitter larger than the interval or a catchup window below the server minimum, log a warning."
 	} else {
-		s.Command.Long = "The `temporal schedule create` command creates a new Schedule.\n\nExample:\n\n```\n  temporal schedule create                                    \\\n    --schedule-id 'your-schedule-id'                          \\\n    --calendar '{\"dayOfWeek\":\"Fri\",\"hour\":\"3\",\"minute\":\"11\"}' \\\n    --workflow-id 'your-base-workflow-id'                     \\\n    --task-queue 'your-task-queue'                            \\\n    --workflow-type 'YourWorkflowType'\n```\n\nAny combination of `--calendar`, `--interval`, and `--cron` is supported.\nActions will be executed at any time specified in the Schedule."
+		s.Command.Long = "The `temporal schedule create` command creates a new Schedule.\n\nExample:\n\n```\n  temporal schedule create                                    \\\n    --schedule-id 'your-schedule-id'                          \\\n    --calendar '{\"dayOfWeek\":\"Fri\",\"hour\":\"3\",\"minute\":\"11\"}' \\\n    --workflow-id 'your-base-workflow-id'                     \\\n    --task-queue 'your-task-queue'                            \\\n    --workflow-type 'YourWorkflowType'\n```\n\nAny combination of `--calendar`, `--interval`, and `--cron` is supported.\nActions will be executed at any time specified in the Schedule.\n\nThe Schedule is checked before it is sent. Calendar fields out of range fail the command, and settings the server would\nsilently adjust, such as jitter larger than the interval or a catchup window below the server minimum, log a warning."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.ScheduleConfigurationOptions.buildFlags(cctx, s.Command.Flags())
@@ -1072,6 +1617,10 @@ type TemporalScheduleDescribeCommand struct {
 	Parent  *TemporalScheduleCommand
 	Command cobra.Command
 	ScheduleIdOptions
+	Ical      bool
+	IcalCount int
+	IcalStart Timestamp
+	IcalEnd   Timestamp
 }
 
 func NewTemporalScheduleDescribeCommand(cctx *CommandContext, parent *TemporalScheduleCommand) *TemporalScheduleDescribeCommand {
@@ -1081,12 +1630,16 @@ func NewTemporalScheduleDescribeCommand(cctx *CommandContext, parent *TemporalSc
 	s.Command.Use = "describe [flags]"
 	s.Command.Short = "Get Schedule configuration and current state."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal schedule describe\x1b[0m command shows the current configuration of one Schedule,\nincluding information about past, current, and future Workflow Runs."
+		s.Command.Long = "The \x1b[1mtemporal schedule describe\x1b[0m command shows the current configuration of one Schedule,\nincluding information about past, current, and future Workflow Runs.\n\nUpcoming actions can instead be exported as an iCalendar file, e.g. to subscribe to from a calendar app:\n\n\x1b[1mtemporal schedule describe --schedule-id 'your-schedule-id' --ical --ical-count 50 > schedule.ics\x1b[0m"
 	} else {
-		s.Command.Long = "The `temporal schedule describe` command shows the current configuration of one Schedule,\nincluding information about past, current, and future Workflow Runs."
+		s.Command.Long = "The `temporal schedule describe` command shows the current configuration of one Schedule,\nincluding information about past, current, and future Workflow Runs.\n\nUpcoming actions can instead be exported as an iCalendar file, e.g. to subscribe to from a calendar app:\n\n```\ntemporal schedule describe --schedule-id 'your-schedule-id' --ical --ical-count 50 > schedule.ics\n```"
 	}
 	s.Command.Args = cobra.NoArgs
 	s.ScheduleIdOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().BoolVar(&s.Ical, "ical", false, "Print the upcoming action times of the Schedule as iCalendar events instead. Not allowed with JSON output.")
+	s.Command.Flags().IntVar(&s.IcalCount, "ical-count", 10, "Maximum number of events to export with --ical.")
+	s.Command.Flags().Var(&s.IcalStart, "ical-start", "Start of the time range to export events for with --ical. Default is now.")
+	s.Command.Flags().Var(&s.IcalEnd, "ical-end", "End of the time range to export events for with --ical. Default is one year after start.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1102,20 +1655,47 @@ type TemporalScheduleListCommand struct {
 	ReallyLong bool
 }
 
-func NewTemporalScheduleListCommand(cctx *CommandContext, parent *TemporalScheduleCommand) *TemporalScheduleListCommand {
-	var s TemporalScheduleListCommand
+func NewTemporalScheduleListCommand(cctx *CommandContext, parent *TemporalScheduleCommand) *TemporalScheduleListCommand {
+	var s TemporalScheduleListCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "list [flags]"
+	s.Command.Short = "Lists Schedules."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal schedule list\x1b[0m command lists all Schedules in a namespace."
+	} else {
+		s.Command.Long = "The `temporal schedule list` command lists all Schedules in a namespace."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().BoolVarP(&s.Long, "long", "l", false, "Include detailed information.")
+	s.Command.Flags().BoolVar(&s.ReallyLong, "really-long", false, "Include even more detailed information that's not really usable in table form.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalScheduleResumeDueCommand struct {
+	Parent  *TemporalScheduleCommand
+	Command cobra.Command
+	DryRun  bool
+}
+
+func NewTemporalScheduleResumeDueCommand(cctx *CommandContext, parent *TemporalScheduleCommand) *TemporalScheduleResumeDueCommand {
+	var s TemporalScheduleResumeDueCommand
 	s.Parent = parent
 	s.Command.DisableFlagsInUseLine = true
-	s.Command.Use = "list [flags]"
-	s.Command.Short = "Lists Schedules."
+	s.Command.Use = "resume-due [flags]"
+	s.Command.Short = "Unpauses Schedules whose resume time has passed."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal schedule list\x1b[0m command lists all Schedules in a namespace."
+		s.Command.Long = "Schedules paused with \x1b[1mtemporal schedule toggle --pause --resume-after\x1b[0m record the time they should be resumed in\ntheir notes. The \x1b[1mtemporal schedule resume-due\x1b[0m command unpauses every such Schedule in the namespace whose resume time\nhas passed. Run it periodically, e.g. from cron, so paused Schedules are not forgotten.\n\n\x1b[1mtemporal schedule resume-due\x1b[0m"
 	} else {
-		s.Command.Long = "The `temporal schedule list` command lists all Schedules in a namespace."
+		s.Command.Long = "Schedules paused with `temporal schedule toggle --pause --resume-after` record the time they should be resumed in\ntheir notes. The `temporal schedule resume-due` command unpauses every such Schedule in the namespace whose resume time\nhas passed. Run it periodically, e.g. from cron, so paused Schedules are not forgotten.\n\n`temporal schedule resume-due`"
 	}
 	s.Command.Args = cobra.NoArgs
-	s.Command.Flags().BoolVarP(&s.Long, "long", "l", false, "Include detailed information.")
-	s.Command.Flags().BoolVar(&s.ReallyLong, "really-long", false, "Include even more detailed information that's not really usable in table form.")
+	s.Command.Flags().BoolVar(&s.DryRun, "dry-run", false, "Only show which Schedules are due to be resumed, without resuming them.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1128,9 +1708,10 @@ type TemporalScheduleToggleCommand struct {
 	Parent  *TemporalScheduleCommand
 	Command cobra.Command
 	ScheduleIdOptions
-	Pause   bool
-	Reason  string
-	Unpause bool
+	Pause       bool
+	Reason      string
+	ResumeAfter Duration
+	Unpause     bool
 }
 
 func NewTemporalScheduleToggleCommand(cctx *CommandContext, parent *TemporalScheduleCommand) *TemporalScheduleToggleCommand {
@@ -1140,14 +1721,16 @@ func NewTemporalScheduleToggleCommand(cctx *CommandContext, parent *TemporalSche
 	s.Command.Use = "toggle [flags]"
 	s.Command.Short = "Pauses or unpauses a Schedule."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal schedule toggle\x1b[0m command can pause and unpause a Schedule.\n\nToggling a Schedule takes a reason. The reason will be set as the \x1b[1mnotes\x1b[0m field of the Schedule,\nto help with operations communication.\n\nExamples:\n\n* \x1b[1mtemporal schedule toggle --schedule-id 'your-schedule-id' --pause --reason \"paused because the database is down\"\x1b[0m\n* \x1b[1mtemporal schedule toggle --schedule-id 'your-schedule-id' --unpause --reason \"the database is back up\"\x1b[0m"
+		s.Command.Long = "The \x1b[1mtemporal schedule toggle\x1b[0m command can pause and unpause a Schedule.\n\nToggling a Schedule takes a reason. The reason will be set as the \x1b[1mnotes\x1b[0m field of the Schedule,\nto help with operations communication.\n\nExamples:\n\n* \x1b[1mtemporal schedule toggle --schedule-id 'your-schedule-id' --pause --reason \"paused because the database is down\"\x1b[0m\n* \x1b[1mtemporal schedule toggle --schedule-id 'your-schedule-id' --unpause --reason \"the database is back up\"\x1b[0m\n* \x1b[1mtemporal schedule toggle --schedule-id 'your-schedule-id' --pause --resume-after 4h --reason \"database maintenance\"\x1b[0m\n\nWhen pausing with \x1b[1m--resume-after\x1b[0m, the time to resume is recorded in the notes, and\n\x1b[1mtemporal schedule resume-due\x1b[0m will unpause the Schedule once that time has passed."
 	} else {
-		s.Command.Long = "The `temporal schedule toggle` command can pause and unpause a Schedule.\n\nToggling a Schedule takes a reason. The reason will be set as the `notes` field of the Schedule,\nto help with operations communication.\n\nExamples:\n\n* `temporal schedule toggle --schedule-id 'your-schedule-id' --pause --reason \"paused because the database is down\"`\n* `temporal schedule toggle --schedule-id 'your-schedule-id' --unpause --reason \"the database is back up\"`"
+		s.Command.Long = "The `temporal schedule toggle` command can pause and unpause a Schedule.\n\nToggling a Schedule takes a reason. The reason will be set as the `notes` field of the Schedule,\nto help with operations communication.\n\nExamples:\n\n* `temporal schedule toggle --schedule-id 'your-schedule-id' --pause --reason \"paused because the database is down\"`\n* `temporal schedule toggle --schedule-id 'your-schedule-id' --unpause --reason \"the database is back up\"`\n* `temporal schedule toggle --schedule-id 'your-schedule-id' --pause --resume-after 4h --reason \"database maintenance\"`\n\nWhen pausing with `--resume-after`, the time to resume is recorded in the notes, and\n`temporal schedule resume-due` will unpause the Schedule once that time has passed."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.ScheduleIdOptions.buildFlags(cctx, s.Command.Flags())
 	s.Command.Flags().BoolVar(&s.Pause, "pause", false, "Pauses the schedule.")
 	s.Command.Flags().StringVar(&s.Reason, "reason", "\"(no reason provided)\"", "Reason for pausing/unpausing.")
+	s.ResumeAfter = 0
+	s.Command.Flags().Var(&s.ResumeAfter, "resume-after", "When pausing, record that the schedule should be resumed after this long, to be acted on by temporal schedule resume-due.")
 	s.Command.Flags().BoolVar(&s.Unpause, "unpause", false, "Pauses the schedule.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
@@ -1190,6 +1773,7 @@ type TemporalScheduleUpdateCommand struct {
 	OverlapPolicyOptions
 	SharedWorkflowStartOptions
 	PayloadInputOptions
+	Edit bool
 }
 
 func NewTemporalScheduleUpdateCommand(cctx *CommandContext, parent *TemporalScheduleCommand) *TemporalScheduleUpdateCommand {
@@ -1198,13 +1782,48 @@ func NewTemporalScheduleUpdateCommand(cctx *CommandContext, parent *TemporalSche
 	s.Command.DisableFlagsInUseLine = true
 	s.Command.Use = "update [flags]"
 	s.Command.Short = "Updates a Schedule with a new definition."
-	s.Command.Long = "The temporal schedule update command updates an existing Schedule. It replaces the entire\nconfiguration of the schedule, including spec, action, and policies."
+	if hasHighlighting {
+		s.Command.Long = "The temporal schedule update command updates an existing Schedule. It replaces the entire\nconfiguration of the schedule, including spec, action, and policies. The new configuration is checked the same way as\nfor \x1b[1mtemporal schedule create\x1b[0m.\n\nIf the Schedule is updated by someone else at the same time, the update is sent again after reading the Schedule's\nlatest version, up to 5 attempts in all. The new configuration still replaces theirs.\n\nWith \x1b[1m--edit\x1b[0m, the current Schedule is opened as YAML in \x1b[1m$VISUAL\x1b[0m or \x1b[1m$EDITOR\x1b[0m instead of being replaced from the\noptions. When the editor is closed, the edited document is checked, the changes are shown as a diff, and then applied.\nOnly \x1b[1m--schedule-id\x1b[0m can be given with \x1b[1m--edit\x1b[0m.\n\x1b[1mtemporal schedule update --schedule-id my-schedule --edit\x1b[0m"
+	} else {
+		s.Command.Long = "The temporal schedule update command updates an existing Schedule. It replaces the entire\nconfiguration of the schedule, including spec, action, and policies. The new configuration is checked the same way as\nfor `temporal schedule create`.\n\nIf the Schedule is updated by someone else at the same time, the update is sent again after reading the Schedule's\nlatest version, up to 5 attempts in all. The new configuration still replaces theirs.\n\nWith `--edit`, the current Schedule is opened as YAML in `$VISUAL` or `$EDITOR` instead of being replaced from the\noptions. When the editor is closed, the edited document is checked, the changes are shown as a diff, and then applied.\nOnly `--schedule-id` can be given with `--edit`.\n`temporal schedule update --schedule-id my-schedule --edit`"
+	}
 	s.Command.Args = cobra.NoArgs
 	s.ScheduleConfigurationOptions.buildFlags(cctx, s.Command.Flags())
 	s.ScheduleIdOptions.buildFlags(cctx, s.Command.Flags())
 	s.OverlapPolicyOptions.buildFlags(cctx, s.Command.Flags())
 	s.SharedWorkflowStartOptions.buildFlags(cctx, s.Command.Flags())
 	s.PayloadInputOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().BoolVar(&s.Edit, "edit", false, "Edit the Schedule as YAML in an editor instead of using the other options.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalServeApiCommand struct {
+	Parent    *TemporalCommand
+	Command   cobra.Command
+	Listen    string
+	AuthToken string
+}
+
+func NewTemporalServeApiCommand(cctx *CommandContext, parent *TemporalCommand) *TemporalServeApiCommand {
+	var s TemporalServeApiCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "serve-api [flags]"
+	s.Command.Short = "Serve CLI commands over a local HTTP API."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal serve-api\x1b[0m command runs a small local HTTP server that runs CLI commands on request, so scripts and tools\nin other languages can reuse the CLI's environments, connection, TLS, and codec settings.\n\n\x1b[1mtemporal serve-api --listen 127.0.0.1:7243\x1b[0m\n\nCommands are run by posting their arguments as JSON to \x1b[1m/v1/commands\x1b[0m, and they use the same environment as the\n\x1b[1mtemporal serve-api\x1b[0m command itself:\n\n\x1b[1mcurl -X POST http://127.0.0.1:7243/v1/commands -H \"Authorization: Bearer $TOKEN\" \\\n  -H \"Content-Type: application/json\" -d '{\"args\": [\"workflow\", \"list\", \"--limit\", \"5\"]}'\x1b[0m\n\nEvery request needs the auth token, which is generated and printed at startup unless \x1b[1m--auth-token\x1b[0m is set. Requests\nmust be addressed to a loopback host or the \x1b[1m--listen\x1b[0m host, and requests from web browsers (those with an \x1b[1mOrigin\x1b[0m\nheader) are rejected.\n\nThe response is JSON with the command's \x1b[1moutput\x1b[0m, always produced with \x1b[1m--output json\x1b[0m, its \x1b[1mexitCode\x1b[0m, and an \x1b[1merror\x1b[0m\nmessage if it failed. Commands are run one at a time, and prompts can't be answered, so pass \x1b[1m--yes\x1b[0m where needed.\nCommands that run servers, write files, change environments, or open a browser can't be run, and neither can flags\nthat run processes or write files, like \x1b[1m--data-converter-plugin\x1b[0m, \x1b[1m--credential-helper\x1b[0m, and \x1b[1m--output-file\x1b[0m. These\nflags are rejected even when they are set by the environment or env vars. \x1b[1mGET /v1/health\x1b[0m can be used to check the\nAPI is up."
+	} else {
+		s.Command.Long = "The `temporal serve-api` command runs a small local HTTP server that runs CLI commands on request, so scripts and tools\nin other languages can reuse the CLI's environments, connection, TLS, and codec settings.\n\n```\ntemporal serve-api --listen 127.0.0.1:7243\n```\n\nCommands are run by posting their arguments as JSON to `/v1/commands`, and they use the same environment as the\n`temporal serve-api` command itself:\n\n```\ncurl -X POST http://127.0.0.1:7243/v1/commands -H \"Authorization: Bearer $TOKEN\" \\\n  -H \"Content-Type: application/json\" -d '{\"args\": [\"workflow\", \"list\", \"--limit\", \"5\"]}'\n```\n\nEvery request needs the auth token, which is generated and printed at startup unless `--auth-token` is set. Requests\nmust be addressed to a loopback host or the `--listen` host, and requests from web browsers (those with an `Origin`\nheader) are rejected.\n\nThe response is JSON with the command's `output`, always produced with `--output json`, its `exitCode`, and an `error`\nmessage if it failed. Commands are run one at a time, and prompts can't be answered, so pass `--yes` where needed.\nCommands that run servers, write files, change environments, or open a browser can't be run, and neither can flags\nthat run processes or write files, like `--data-converter-plugin`, `--credential-helper`, and `--output-file`. These\nflags are rejected even when they are set by the environment or env vars. `GET /v1/health` can be used to check the\nAPI is up."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().StringVar(&s.Listen, "listen", "127.0.0.1:7243", "Address to listen on.")
+	s.Command.Flags().StringVar(&s.AuthToken, "auth-token", "", "Token requests must have in an \"Authorization: Bearer <token>\" header. A random one is generated and printed if not set.")
+	cctx.BindFlagEnvVar(s.Command.Flags().Lookup("auth-token"), "TEMPORAL_SERVE_API_AUTH_TOKEN")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1234,22 +1853,30 @@ func NewTemporalServerCommand(cctx *CommandContext, parent *TemporalCommand) *Te
 }
 
 type TemporalServerStartDevCommand struct {
-	Parent             *TemporalServerCommand
-	Command            cobra.Command
-	DbFilename         string
-	Namespace          []string
-	Port               int
-	HttpPort           int
-	MetricsPort        int
-	UiPort             int
-	Headless           bool
-	Ip                 string
-	UiIp               string
-	UiAssetPath        string
-	UiCodecEndpoint    string
-	SqlitePragma       []string
-	DynamicConfigValue []string
-	LogConfig          bool
+	Parent                        *TemporalServerCommand
+	Command                       cobra.Command
+	DbFilename                    string
+	Namespace                     []string
+	Port                          int
+	HttpPort                      int
+	MetricsPort                   int
+	UiPort                        int
+	Headless                      bool
+	Ip                            string
+	Bind                          []string
+	UiIp                          string
+	UiAssetPath                   string
+	UiCodecEndpoint               string
+	SqlitePragma                  []string
+	DynamicConfigValue            []string
+	LogConfig                     bool
+	Retention                     []string
+	MaxHistoryEvents              []string
+	ImportSearchAttributesFromEnv string
+	AnnounceFile                  string
+	LogRpc                        bool
+	ParentPid                     int
+	InjectFailures                []string
 }
 
 func NewTemporalServerStartDevCommand(cctx *CommandContext, parent *TemporalServerCommand) *TemporalServerStartDevCommand {
@@ -1259,25 +1886,36 @@ func NewTemporalServerStartDevCommand(cctx *CommandContext, parent *TemporalServ
 	s.Command.Use = "start-dev [flags]"
 	s.Command.Short = "Start Temporal development server."
 	if hasHighlighting {
-		s.Command.Long = "Start Temporal Server on \x1b[1mlocalhost:7233\x1b[0m with:\n\n\x1b[1mtemporal server start-dev\x1b[0m\n\nView the UI at http://localhost:8233\n\nTo persist Workflows across runs, use:\n\n\x1b[1mtemporal server start-dev --db-filename temporal.db\x1b[0m"
+		s.Command.Long = "Start Temporal Server on \x1b[1mlocalhost:7233\x1b[0m with:\n\n\x1b[1mtemporal server start-dev\x1b[0m\n\nView the UI at http://localhost:8233\n\nTo persist Workflows across runs, use:\n\n\x1b[1mtemporal server start-dev --db-filename temporal.db\x1b[0m\n\nOn Windows, the server can also be run as a Windows service and will stop gracefully when the service is stopped, on\nsystem shutdown, or when the console window is closed.\n\nTo test how clients and Workers handle errors, frontend RPCs can be made to randomly fail with a gRPC status code. For\nexample, to fail 5% of Workflow starts and 10% of all RPCs with \x1b[1mresource_exhausted\x1b[0m:\n\n\x1b[1mtemporal server start-dev \\\n\t--inject-failures 'frontend:StartWorkflowExecution:unavailable:5%' \\\n\t--inject-failures 'frontend:*:resource_exhausted:10%'\x1b[0m"
 	} else {
-		s.Command.Long = "Start Temporal Server on `localhost:7233` with:\n\n`temporal server start-dev`\n\nView the UI at http://localhost:8233\n\nTo persist Workflows across runs, use:\n\n`temporal server start-dev --db-filename temporal.db`"
+		s.Command.Long = "Start Temporal Server on `localhost:7233` with:\n\n`temporal server start-dev`\n\nView the UI at http://localhost:8233\n\nTo persist Workflows across runs, use:\n\n`temporal server start-dev --db-filename temporal.db`\n\nOn Windows, the server can also be run as a Windows service and will stop gracefully when the service is stopped, on\nsystem shutdown, or when the console window is closed.\n\nTo test how clients and Workers handle errors, frontend RPCs can be made to randomly fail with a gRPC status code. For\nexample, to fail 5% of Workflow starts and 10% of all RPCs with `resource_exhausted`:\n\n```\ntemporal server start-dev \\\n\t--inject-failures 'frontend:StartWorkflowExecution:unavailable:5%' \\\n\t--inject-failures 'frontend:*:resource_exhausted:10%'\n```"
 	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.Flags().StringVarP(&s.DbFilename, "db-filename", "f", "", "File in which to persist Temporal state (by default, Workflows are lost when the process dies).")
 	s.Command.Flags().StringArrayVarP(&s.Namespace, "namespace", "n", nil, "Specify namespaces that should be pre-created (namespace \"default\" is always created).")
-	s.Command.Flags().IntVarP(&s.Port, "port", "p", 7233, "Port for the frontend gRPC service.")
+	s.Command.Flags().IntVarP(&s.Port, "port", "p", 7233, "Port for the frontend gRPC service, or 0 to use any free port.")
 	s.Command.Flags().IntVar(&s.HttpPort, "http-port", 0, "Port for the frontend HTTP API service. Default is off.")
 	s.Command.Flags().IntVar(&s.MetricsPort, "metrics-port", 0, "Port for /metrics. Default is off.")
-	s.Command.Flags().IntVar(&s.UiPort, "ui-port", 0, "Port for the Web UI. Default is --port + 1000.")
+	s.Command.Flags().IntVar(&s.UiPort, "ui-port", 0, "Port for the Web UI. Default is --port + 1000, or any free port if --port is 0.")
 	s.Command.Flags().BoolVar(&s.Headless, "headless", false, "Disable the Web UI.")
-	s.Command.Flags().StringVar(&s.Ip, "ip", "localhost", "IP address to bind the frontend service to.")
+	s.Command.Flags().StringVar(&s.Ip, "ip", "localhost", "IP address to bind the frontend service to, e.g. :: for all IPv4 and IPv6 interfaces.")
+	s.Command.Flags().StringArrayVar(&s.Bind, "bind", nil, "Additional IP address to accept frontend gRPC connections on, forwarded to the frontend on --ip. Can be given multiple times.")
 	s.Command.Flags().StringVar(&s.UiIp, "ui-ip", "", "IP address to bind the Web UI to. Default is same as --ip.")
 	s.Command.Flags().StringVar(&s.UiAssetPath, "ui-asset-path", "", "UI custom assets path.")
 	s.Command.Flags().StringVar(&s.UiCodecEndpoint, "ui-codec-endpoint", "", "UI remote codec HTTP endpoint.")
 	s.Command.Flags().StringArrayVar(&s.SqlitePragma, "sqlite-pragma", nil, "Specify SQLite pragma statements in pragma=value format.")
 	s.Command.Flags().StringArrayVar(&s.DynamicConfigValue, "dynamic-config-value", nil, "Dynamic config value, as KEY=JSON_VALUE (string values need quotes).")
 	s.Command.Flags().BoolVar(&s.LogConfig, "log-config", false, "Log the server config being used to stderr.")
+	s.Command.Flags().StringArrayVar(&s.Retention, "retention", nil, "Retention for pre-created namespaces, as DURATION for all of them or NAMESPACE=DURATION for one, e.g. 30d. Can be given multiple times. Only applies when the namespace is first created.")
+	s.Command.Flags().StringArrayVar(&s.MaxHistoryEvents, "max-history-events", nil, "Maximum number of history events per workflow in pre-created namespaces, as COUNT for all of them or NAMESPACE=COUNT for one. Can be given multiple times.")
+	s.Command.Flags().StringVar(&s.ImportSearchAttributesFromEnv, "import-search-attributes-from-env", "", "Name of an env config whose namespace's custom search attributes are registered in every pre-created namespace at startup. Aliased as \"--import-search-attributes-from-profile\".")
+	s.Command.Flags().StringVar(&s.AnnounceFile, "announce-file", "", "File to write the server addresses and ports to as JSON once started, removed on stop. The same JSON is printed to stdout when using JSON output.")
+	s.Command.Flags().BoolVar(&s.LogRpc, "log-rpc", false, "Log every frontend RPC with its method, namespace, caller identity, latency, and status code.")
+	s.Command.Flags().IntVar(&s.ParentPid, "parent-pid", 0, "Stop the server when the process with this ID exits. Useful to avoid leaving orphaned servers behind when the process that started the server is killed.")
+	s.Command.Flags().StringArrayVar(&s.InjectFailures, "inject-failures", nil, "Randomly fail RPCs, as SERVICE:METHOD:CODE:PERCENT. Only the frontend service is supported, the method may be * for all, and the code is a gRPC status code like unavailable. Can be given multiple times.")
+	s.Command.Flags().SetNormalizeFunc(aliasNormalizer(map[string]string{
+		"import-search-attributes-from-profile": "import-search-attributes-from-env",
+	}))
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1304,6 +1942,7 @@ func NewTemporalTaskQueueCommand(cctx *CommandContext, parent *TemporalCommand)
 	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.AddCommand(&NewTemporalTaskQueueDescribeCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalTaskQueueDrainCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalTaskQueueGetBuildIdReachabilityCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalTaskQueueGetBuildIdsCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalTaskQueueListPartitionCommand(cctx, &s).Command)
@@ -1318,6 +1957,7 @@ type TemporalTaskQueueDescribeCommand struct {
 	TaskQueue     string
 	TaskQueueType StringEnum
 	Partitions    int
+	CompareFile   string
 }
 
 func NewTemporalTaskQueueDescribeCommand(cctx *CommandContext, parent *TemporalTaskQueueCommand) *TemporalTaskQueueDescribeCommand {
@@ -1327,9 +1967,9 @@ func NewTemporalTaskQueueDescribeCommand(cctx *CommandContext, parent *TemporalT
 	s.Command.Use = "describe [flags]"
 	s.Command.Short = "Provides information for Workers that have recently polled on this Task Queue."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal task-queue describe\x1b[0m command provides poller\ninformation for a given Task Queue.\n\nThe Server records the last time of each poll request. A \x1b[1mLastAccessTime\x1b[0m value\nin excess of one minute can indicate the Worker is at capacity (all Workflow and Activity slots are full) or that the\nWorker has shut down. Workers are removed if 5 minutes have passed since the last poll\nrequest.\n\nInformation about the Task Queue can be returned to troubleshoot server issues.\n\n\x1b[1mtemporal task-queue describe --task-queue=MyTaskQueue --task-queue-type=\"activity\"\x1b[0m\n\nUse the options listed below to modify what this command returns."
+		s.Command.Long = "The \x1b[1mtemporal task-queue describe\x1b[0m command provides poller\ninformation for a given Task Queue.\n\nThe Server records the last time of each poll request. A \x1b[1mLastAccessTime\x1b[0m value\nin excess of one minute can indicate the Worker is at capacity (all Workflow and Activity slots are full) or that the\nWorker has shut down. Workers are removed if 5 minutes have passed since the last poll\nrequest.\n\nInformation about the Task Queue can be returned to troubleshoot server issues.\n\n\x1b[1mtemporal task-queue describe --task-queue=MyTaskQueue --task-queue-type=\"activity\"\x1b[0m\n\nTo check a Task Queue before and after a Worker deployment, save the JSON output and compare against it later. The\nPollers that appeared or disappeared and the changes in backlog and poll rates are shown:\n\n\x1b[1mtemporal task-queue describe --task-queue MyTaskQueue -o json > previous.json\ntemporal task-queue describe --task-queue MyTaskQueue --compare-file previous.json\x1b[0m\n\nUse the options listed below to modify what this command returns."
 	} else {
-		s.Command.Long = "The `temporal task-queue describe` command provides poller\ninformation for a given Task Queue.\n\nThe Server records the last time of each poll request. A `LastAccessTime` value\nin excess of one minute can indicate the Worker is at capacity (all Workflow and Activity slots are full) or that the\nWorker has shut down. Workers are removed if 5 minutes have passed since the last poll\nrequest.\n\nInformation about the Task Queue can be returned to troubleshoot server issues.\n\n`temporal task-queue describe --task-queue=MyTaskQueue --task-queue-type=\"activity\"`\n\nUse the options listed below to modify what this command returns."
+		s.Command.Long = "The `temporal task-queue describe` command provides poller\ninformation for a given Task Queue.\n\nThe Server records the last time of each poll request. A `LastAccessTime` value\nin excess of one minute can indicate the Worker is at capacity (all Workflow and Activity slots are full) or that the\nWorker has shut down. Workers are removed if 5 minutes have passed since the last poll\nrequest.\n\nInformation about the Task Queue can be returned to troubleshoot server issues.\n\n`temporal task-queue describe --task-queue=MyTaskQueue --task-queue-type=\"activity\"`\n\nTo check a Task Queue before and after a Worker deployment, save the JSON output and compare against it later. The\nPollers that appeared or disappeared and the changes in backlog and poll rates are shown:\n\n```\ntemporal task-queue describe --task-queue MyTaskQueue -o json > previous.json\ntemporal task-queue describe --task-queue MyTaskQueue --compare-file previous.json\n```\n\nUse the options listed below to modify what this command returns."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.Flags().StringVarP(&s.TaskQueue, "task-queue", "t", "", "Task queue name. Required.")
@@ -1337,6 +1977,42 @@ func NewTemporalTaskQueueDescribeCommand(cctx *CommandContext, parent *TemporalT
 	s.TaskQueueType = NewStringEnum([]string{"workflow", "activity"}, "workflow")
 	s.Command.Flags().Var(&s.TaskQueueType, "task-queue-type", "Task Queue type. Accepted values: workflow, activity.")
 	s.Command.Flags().IntVar(&s.Partitions, "partitions", 1, "Query for all partitions up to this number (experimental+temporary feature).")
+	s.Command.Flags().StringVar(&s.CompareFile, "compare-file", "", "JSON output of a previous describe of the Task Queue to show changes against. With JSON output, the changes are under the \"changes\" key.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalTaskQueueDrainCommand struct {
+	Parent            *TemporalTaskQueueCommand
+	Command           cobra.Command
+	TaskQueue         string
+	NewDefaultBuildId string
+	Partitions        int
+	Timeout           Duration
+}
+
+func NewTemporalTaskQueueDrainCommand(cctx *CommandContext, parent *TemporalTaskQueueCommand) *TemporalTaskQueueDrainCommand {
+	var s TemporalTaskQueueDrainCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "drain [flags]"
+	s.Command.Short = "Wait for a Task Queue's backlog to empty before decommissioning its Workers."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal task-queue drain\x1b[0m command watches the workflow and activity backlogs of a\nTask Queue until both are empty, then reports the Workers still polling it so they\ncan be shut down.\n\n\x1b[1mtemporal task-queue drain --task-queue MyTaskQueue --timeout 10m\x1b[0m\n\nNew matching to the current Workers can't be paused for unversioned Task Queues. For Task Queues using Build ID\nversioning, \x1b[1m--new-default-build-id\x1b[0m first adds a new default Build ID so that new Workflows go to Workers with that\nBuild ID instead of the Workers being drained.\n\nUse the options listed below to change the command's behavior."
+	} else {
+		s.Command.Long = "The `temporal task-queue drain` command watches the workflow and activity backlogs of a\nTask Queue until both are empty, then reports the Workers still polling it so they\ncan be shut down.\n\n```\ntemporal task-queue drain --task-queue MyTaskQueue --timeout 10m\n```\n\nNew matching to the current Workers can't be paused for unversioned Task Queues. For Task Queues using Build ID\nversioning, `--new-default-build-id` first adds a new default Build ID so that new Workflows go to Workers with that\nBuild ID instead of the Workers being drained.\n\nUse the options listed below to change the command's behavior."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Flags().StringVarP(&s.TaskQueue, "task-queue", "t", "", "Task queue name. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "task-queue")
+	s.Command.Flags().StringVar(&s.NewDefaultBuildId, "new-default-build-id", "", "Build ID to add as the new default before draining.")
+	s.Command.Flags().IntVar(&s.Partitions, "partitions", 1, "Query for all partitions up to this number (experimental+temporary feature).")
+	s.Timeout = 0
+	s.Command.Flags().Var(&s.Timeout, "timeout", "Maximum time to wait for the backlog to empty. Default is no timeout.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1559,7 +2235,11 @@ type ClientOptions struct {
 	Address                    string
 	Namespace                  string
 	ApiKey                     string
+	OauthTokenUrl              string
+	OauthClientId              string
+	OauthClientSecret          string
 	GrpcMeta                   []string
+	CredentialHelper           string
 	Tls                        bool
 	TlsCertPath                string
 	TlsKeyPath                 string
@@ -1569,21 +2249,48 @@ type ClientOptions struct {
 	TlsCaData                  string
 	TlsDisableHostVerification bool
 	TlsServerName              string
+	TlsMinVersion              StringEnum
+	TlsCipherSuites            []string
+	TlsSpiffeSocket            string
+	TlsSpiffeServerId          string
+	TargetCluster              string
+	RoutingKey                 string
 	CodecEndpoint              string
 	CodecAuth                  string
+	DataConverterPlugin        []string
+	ProtoDescriptors           []string
+	Proxy                      string
+	GrpcKeepaliveTime          Duration
+	GrpcKeepaliveTimeout       Duration
+	GrpcCallTimeout            Duration
+	GrpcMaxMessageSize         int
+	GrpcCompression            StringEnum
+	GrpcRetryMaxAttempts       int
+	GrpcRetryBackoff           Duration
+	GrpcLoadBalancing          StringEnum
+	GrpcServiceConfig          string
+	SkipCapabilityCheck        bool
 }
 
 func (v *ClientOptions) buildFlags(cctx *CommandContext, f *pflag.FlagSet) {
-	f.StringVar(&v.Address, "address", "127.0.0.1:7233", "Temporal server address.")
+	f.StringVar(&v.Address, "address", "127.0.0.1:7233", "Temporal server address. Can be a unix socket, e.g. unix:///var/run/temporal.sock. Can also be a comma-separated list of host:port addresses, or a dns:///host:port target resolving to several, to balance requests across them and skip ones that are down.")
 	cctx.BindFlagEnvVar(f.Lookup("address"), "TEMPORAL_ADDRESS")
 	f.StringVarP(&v.Namespace, "namespace", "n", "default", "Temporal server namespace.")
 	cctx.BindFlagEnvVar(f.Lookup("namespace"), "TEMPORAL_NAMESPACE")
 	f.StringVar(&v.ApiKey, "api-key", "", "Sets the API key on requests.")
 	cctx.BindFlagEnvVar(f.Lookup("api-key"), "TEMPORAL_API_KEY")
+	f.StringVar(&v.OauthTokenUrl, "oauth-token-url", "", "OAuth2 token endpoint to obtain bearer tokens from with the client credentials flow. Tokens are refreshed automatically as they expire. Exclusive with --api-key.")
+	cctx.BindFlagEnvVar(f.Lookup("oauth-token-url"), "TEMPORAL_OAUTH_TOKEN_URL")
+	f.StringVar(&v.OauthClientId, "oauth-client-id", "", "OAuth2 client ID. Required with --oauth-token-url.")
+	cctx.BindFlagEnvVar(f.Lookup("oauth-client-id"), "TEMPORAL_OAUTH_CLIENT_ID")
+	f.StringVar(&v.OauthClientSecret, "oauth-client-secret", "", "OAuth2 client secret. Required with --oauth-token-url.")
+	cctx.BindFlagEnvVar(f.Lookup("oauth-client-secret"), "TEMPORAL_OAUTH_CLIENT_SECRET")
 	f.StringArrayVar(&v.GrpcMeta, "grpc-meta", nil, "HTTP headers to send with requests (formatted as key=value).")
+	f.StringVar(&v.CredentialHelper, "credential-helper", "", "Command to obtain an API key and headers from, e.g. to integrate with a secrets vault or SSO. It is split into arguments like a shell command line, so arguments with spaces can be quoted, and run before connecting, and again if the server rejects the credentials. It must write JSON like {\"apiKey\": \"...\", \"headers\": {\"name\": \"value\"}} to stdout. Exclusive with --api-key and --oauth-token-url.")
+	cctx.BindFlagEnvVar(f.Lookup("credential-helper"), "TEMPORAL_CREDENTIAL_HELPER")
 	f.BoolVar(&v.Tls, "tls", false, "Enable TLS encryption without additional options such as mTLS or client certificates.")
 	cctx.BindFlagEnvVar(f.Lookup("tls"), "TEMPORAL_TLS")
-	f.StringVar(&v.TlsCertPath, "tls-cert-path", "", "Path to x509 certificate.")
+	f.StringVar(&v.TlsCertPath, "tls-cert-path", "", "Path to x509 certificate. The certificate and key files are reloaded on new connections if they change or the certificate expires.")
 	cctx.BindFlagEnvVar(f.Lookup("tls-cert-path"), "TEMPORAL_TLS_CERT")
 	f.StringVar(&v.TlsKeyPath, "tls-key-path", "", "Path to private certificate key.")
 	cctx.BindFlagEnvVar(f.Lookup("tls-key-path"), "TEMPORAL_TLS_KEY")
@@ -1599,10 +2306,52 @@ func (v *ClientOptions) buildFlags(cctx *CommandContext, f *pflag.FlagSet) {
 	cctx.BindFlagEnvVar(f.Lookup("tls-disable-host-verification"), "TEMPORAL_TLS_DISABLE_HOST_VERIFICATION")
 	f.StringVar(&v.TlsServerName, "tls-server-name", "", "Overrides target TLS server name.")
 	cctx.BindFlagEnvVar(f.Lookup("tls-server-name"), "TEMPORAL_TLS_SERVER_NAME")
+	v.TlsMinVersion = NewStringEnum([]string{"1.0", "1.1", "1.2", "1.3"}, "")
+	f.Var(&v.TlsMinVersion, "tls-min-version", "Minimum TLS version to accept. Default is 1.2. Accepted values: 1.0, 1.1, 1.2, 1.3.")
+	cctx.BindFlagEnvVar(f.Lookup("tls-min-version"), "TEMPORAL_TLS_MIN_VERSION")
+	f.StringArrayVar(&v.TlsCipherSuites, "tls-cipher-suites", nil, "TLS cipher suites to allow, by name, e.g. TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384. Can be given multiple times or comma-separated. Only applies to TLS 1.2 and below, TLS 1.3 cipher suites are not configurable.")
+	cctx.BindFlagEnvVar(f.Lookup("tls-cipher-suites"), "TEMPORAL_TLS_CIPHER_SUITES")
+	f.StringVar(&v.TlsSpiffeSocket, "tls-spiffe-socket", "", "SPIFFE Workload API endpoint to get the client certificate from instead of files, e.g. unix:///run/spire/agent.sock. The certificate is fetched again on new connections once it is halfway to expiring. Unless a server CA is given, the server certificate is verified with the trust bundle from the endpoint and must have a SPIFFE ID in the same trust domain, or the one given with --tls-spiffe-server-id, and be valid for --tls-server-name if that is set. Exclusive with the cert and key options.")
+	cctx.BindFlagEnvVar(f.Lookup("tls-spiffe-socket"), "TEMPORAL_TLS_SPIFFE_SOCKET")
+	f.StringVar(&v.TlsSpiffeServerId, "tls-spiffe-server-id", "", "SPIFFE ID the server certificate must have, e.g. spiffe://example.org/temporal/frontend. Requires --tls-spiffe-socket.")
+	cctx.BindFlagEnvVar(f.Lookup("tls-spiffe-server-id"), "TEMPORAL_TLS_SPIFFE_SERVER_ID")
+	f.StringVar(&v.TargetCluster, "target-cluster", "", "Cluster for a gateway in front of multiple clusters to route requests to. Sent as the temporal-target-cluster header.")
+	cctx.BindFlagEnvVar(f.Lookup("target-cluster"), "TEMPORAL_TARGET_CLUSTER")
+	f.StringVar(&v.RoutingKey, "routing-key", "", "Key for a gateway in front of multiple clusters to route requests on. Sent as the temporal-routing-key header.")
+	cctx.BindFlagEnvVar(f.Lookup("routing-key"), "TEMPORAL_ROUTING_KEY")
 	f.StringVar(&v.CodecEndpoint, "codec-endpoint", "", "Endpoint for a remote Codec Server.")
 	cctx.BindFlagEnvVar(f.Lookup("codec-endpoint"), "TEMPORAL_CODEC_ENDPOINT")
 	f.StringVar(&v.CodecAuth, "codec-auth", "", "Sets the authorization header on requests to the Codec Server.")
 	cctx.BindFlagEnvVar(f.Lookup("codec-auth"), "TEMPORAL_CODEC_AUTH")
+	f.StringArrayVar(&v.DataConverterPlugin, "data-converter-plugin", nil, "Data converter plugin for a custom payload encoding, as `encoding=command`, e.g. `binary/avro=avro-to-json --schema my.avsc`, so payloads with the encoding are shown readably. The command is split into arguments like a shell command line, so arguments with spaces can be quoted, and run with an extra \"to-json\" argument, given a payload on stdin as JSON in the same format Codec Servers use. It must write the payload's value as JSON to stdout. Payloads are converted after the Codec Server decodes them. Can be given multiple times.")
+	f.StringArrayVar(&v.ProtoDescriptors, "proto-descriptors", nil, "FileDescriptorSet file, e.g. from \"buf build -o\" or \"protoc --descriptor_set_out\", used to show payloads with binary/protobuf encoding as JSON. Can be given multiple times.")
+	f.StringVar(&v.Proxy, "proxy", "", "Proxy to connect to the server through, as an http, socks5, or socks5h URL that may include a username and password. If unset, HTTPS_PROXY or ALL_PROXY is used for non-local addresses not in NO_PROXY.")
+	v.GrpcKeepaliveTime = Duration(30000 * time.Millisecond)
+	f.Var(&v.GrpcKeepaliveTime, "grpc-keepalive-time", "Ping the server after the connection has been idle this long to check it is still alive. Minimum 10s.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-keepalive-time"), "TEMPORAL_GRPC_KEEPALIVE_TIME")
+	v.GrpcKeepaliveTimeout = Duration(15000 * time.Millisecond)
+	f.Var(&v.GrpcKeepaliveTimeout, "grpc-keepalive-timeout", "Close the connection if there is no response to a keepalive ping within this long.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-keepalive-timeout"), "TEMPORAL_GRPC_KEEPALIVE_TIMEOUT")
+	v.GrpcCallTimeout = 0
+	f.Var(&v.GrpcCallTimeout, "grpc-call-timeout", "Maximum time for each individual request to the server, not including long polls such as following history or waiting on an update. 0 means no limit.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-call-timeout"), "TEMPORAL_GRPC_CALL_TIMEOUT")
+	f.IntVar(&v.GrpcMaxMessageSize, "grpc-max-message-size", 0, "Maximum size in bytes of gRPC messages sent to and received from the server, e.g. to fetch histories with very large payloads. Default is 134217728 (128MB).")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-max-message-size"), "TEMPORAL_GRPC_MAX_MESSAGE_SIZE")
+	v.GrpcCompression = NewStringEnum([]string{"none", "gzip"}, "none")
+	f.Var(&v.GrpcCompression, "grpc-compression", "Compression for requests to and responses from the server, e.g. to cut transfer time when exporting many histories over a slow network. Accepted values: none, gzip.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-compression"), "TEMPORAL_GRPC_COMPRESSION")
+	f.IntVar(&v.GrpcRetryMaxAttempts, "grpc-retry-max-attempts", 0, "Maximum attempts for read-only requests, e.g. describe, list, and get history, that fail with Unavailable or ResourceExhausted errors. These are retried by the CLI before any retries of the underlying client. List and history requests throttled with ResourceExhausted are not counted here, they are always retried with a smaller page size instead. Default is 1, meaning no extra retries.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-retry-max-attempts"), "TEMPORAL_GRPC_RETRY_MAX_ATTEMPTS")
+	v.GrpcRetryBackoff = Duration(200 * time.Millisecond)
+	f.Var(&v.GrpcRetryBackoff, "grpc-retry-backoff", "Backoff before the first retry with --grpc-retry-max-attempts, doubled for each subsequent retry. List and history requests throttled with ResourceExhausted are retried up to 10 times with half the page size after this backoff, doubled up to 30s, and keep the smaller page size for the rest of the command.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-retry-backoff"), "TEMPORAL_GRPC_RETRY_BACKOFF")
+	v.GrpcLoadBalancing = NewStringEnum([]string{"round_robin", "pick_first"}, "")
+	f.Var(&v.GrpcLoadBalancing, "grpc-load-balancing", "gRPC load balancing policy. With round_robin, requests are balanced across every address the server host name resolves to, e.g. frontends behind a headless DNS record, instead of only one. With pick_first, every request goes to the first address that works. Default is round_robin across addresses given in a list or a dns:/// target. Accepted values: round_robin, pick_first.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-load-balancing"), "TEMPORAL_GRPC_LOAD_BALANCING")
+	f.StringVar(&v.GrpcServiceConfig, "grpc-service-config", "", "gRPC service config JSON for the connection, e.g. to set a load balancing config or per-method timeouts and retries. Exclusive with --grpc-load-balancing.")
+	cctx.BindFlagEnvVar(f.Lookup("grpc-service-config"), "TEMPORAL_GRPC_SERVICE_CONFIG")
+	f.BoolVar(&v.SkipCapabilityCheck, "skip-capability-check", false, "Skip checking that the server version and capabilities support the feature being used, and make the call anyway.")
+	cctx.BindFlagEnvVar(f.Lookup("skip-capability-check"), "TEMPORAL_SKIP_CAPABILITY_CHECK")
 }
 
 type TemporalWorkflowCommand struct {
@@ -1622,30 +2371,72 @@ func NewTemporalWorkflowCommand(cctx *CommandContext, parent *TemporalCommand) *
 		s.Command.Long = "Workflow commands perform operations on Workflow Executions.\n\nWorkflow commands use this syntax: `temporal workflow COMMAND [ARGS]`."
 	}
 	s.Command.Args = cobra.NoArgs
+	s.Command.AddCommand(&NewTemporalWorkflowAttachCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowCancelCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalWorkflowCancelTreeCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalWorkflowChildrenCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowCountCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalWorkflowCountEventsCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowDeleteCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowDescribeCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowExecuteCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowFixHistoryJsonCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowListCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowQueryCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalWorkflowRedactCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowResetCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowShowCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowSignalCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowStackCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowStartCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalWorkflowSupersedeCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowTerminateCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowTraceCommand(cctx, &s).Command)
 	s.Command.AddCommand(&NewTemporalWorkflowUpdateCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalWorkflowUpdatesCommand(cctx, &s).Command)
+	s.Command.AddCommand(&NewTemporalWorkflowViewCommand(cctx, &s).Command)
 	s.ClientOptions.buildFlags(cctx, s.Command.PersistentFlags())
 	return &s
 }
 
+type TemporalWorkflowAttachCommand struct {
+	Parent  *TemporalWorkflowCommand
+	Command cobra.Command
+	WorkflowReferenceOptions
+	EventDetails bool
+}
+
+func NewTemporalWorkflowAttachCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowAttachCommand {
+	var s TemporalWorkflowAttachCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "attach [flags]"
+	s.Command.Short = "Follow the progress of a running Workflow Execution until it closes."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow attach\x1b[0m command prints the progress of an already running\nWorkflow Execution the same way \x1b[1mtemporal workflow execute\x1b[0m does, and\ncompletes when the Workflow Execution closes. Like \x1b[1mtemporal workflow execute\x1b[0m, the command fails if the Workflow\nExecution does not complete successfully.\n\n\x1b[1mtemporal workflow attach --workflow-id meaningful-business-id\x1b[0m\n\nThis is useful to resume following a Workflow Execution after \x1b[1mtemporal workflow execute --detach-after\x1b[0m.\n\nLike \x1b[1mtemporal workflow execute\x1b[0m, \x1b[1m--output junit\x1b[0m and \x1b[1m--output tap\x1b[0m report the result as a single test case."
+	} else {
+		s.Command.Long = "The `temporal workflow attach` command prints the progress of an already running\nWorkflow Execution the same way `temporal workflow execute` does, and\ncompletes when the Workflow Execution closes. Like `temporal workflow execute`, the command fails if the Workflow\nExecution does not complete successfully.\n\n```\ntemporal workflow attach --workflow-id meaningful-business-id\n```\n\nThis is useful to resume following a Workflow Execution after `temporal workflow execute --detach-after`.\n\nLike `temporal workflow execute`, `--output junit` and `--output tap` report the result as a single test case."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["outputFormats"] = "junit,tap"
+	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().BoolVar(&s.EventDetails, "event-details", false, "If set when using text output, include event details JSON in printed output. If set when using JSON output, this will include the entire \"history\" JSON key of the attached run (does not follow runs).")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalWorkflowCancelCommand struct {
 	Parent  *TemporalWorkflowCommand
 	Command cobra.Command
 	SingleWorkflowOrBatchOptions
+	FollowChildren bool
+	Wait           bool
+	WaitTimeout    Duration
 }
 
 func NewTemporalWorkflowCancelCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowCancelCommand {
@@ -1661,6 +2452,71 @@ func NewTemporalWorkflowCancelCommand(cctx *CommandContext, parent *TemporalWork
 	}
 	s.Command.Args = cobra.NoArgs
 	s.SingleWorkflowOrBatchOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().BoolVar(&s.FollowChildren, "follow-children", false, "Also cancel all non-abandoned child workflows, recursively. Only allowed with workflow ID.")
+	s.Command.Flags().BoolVar(&s.Wait, "wait", false, "Wait for the workflow to close. Fails if it closes with a status other than canceled. Only allowed with workflow ID.")
+	s.WaitTimeout = 0
+	s.Command.Flags().Var(&s.WaitTimeout, "wait-timeout", "Maximum time to wait for the workflow to close. Zero means no limit. Only allowed with --wait.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalWorkflowCancelTreeCommand struct {
+	Parent  *TemporalWorkflowCommand
+	Command cobra.Command
+	WorkflowReferenceOptions
+	WaitTimeout Duration
+	Reason      string
+}
+
+func NewTemporalWorkflowCancelTreeCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowCancelTreeCommand {
+	var s TemporalWorkflowCancelTreeCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "cancel-tree [flags]"
+	s.Command.Short = "Cancel a Workflow Execution and its children, leaves first."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow cancel-tree\x1b[0m command cancels a Workflow Execution and\nall of its non-abandoned child workflows, recursively, starting from the leaves. Each workflow is only canceled once\nall of its children have closed, which helps with parents that mis-handle the order their children are canceled in.\n\n\x1b[1mtemporal workflow cancel-tree --workflow-id MyWorkflowId\x1b[0m\n\nThe result for each workflow is printed in the order it was canceled. If a workflow does not close within the wait\ntimeout or fails to cancel, its ancestors are skipped, but the rest of the tree is still canceled."
+	} else {
+		s.Command.Long = "The `temporal workflow cancel-tree` command cancels a Workflow Execution and\nall of its non-abandoned child workflows, recursively, starting from the leaves. Each workflow is only canceled once\nall of its children have closed, which helps with parents that mis-handle the order their children are canceled in.\n\n```\ntemporal workflow cancel-tree --workflow-id MyWorkflowId\n```\n\nThe result for each workflow is printed in the order it was canceled. If a workflow does not close within the wait\ntimeout or fails to cancel, its ancestors are skipped, but the rest of the tree is still canceled."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.WaitTimeout = Duration(30000 * time.Millisecond)
+	s.Command.Flags().Var(&s.WaitTimeout, "wait-timeout", "Maximum time to wait for each workflow to close after canceling it before moving on to its parent.")
+	s.Command.Flags().StringVar(&s.Reason, "reason", "", "Reason for the cancellation, recorded on each workflow.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalWorkflowChildrenCommand struct {
+	Parent  *TemporalWorkflowCommand
+	Command cobra.Command
+	WorkflowReferenceOptions
+	Depth int
+}
+
+func NewTemporalWorkflowChildrenCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowChildrenCommand {
+	var s TemporalWorkflowChildrenCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "children [flags]"
+	s.Command.Short = "List the child workflows of a Workflow Execution."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow children\x1b[0m command walks the Event History of a\nWorkflow Execution to list its started child workflows and their statuses,\nrecursively up to the given depth. Workflows that continued as new are shown with the status of their latest run, and\nthe children of every run are listed. Children that can no longer be found, such as ones past retention, are shown with\nstatus NotFound.\n\n\x1b[1mtemporal workflow children --workflow-id MyWorkflowId --depth 2\x1b[0m\n\nUse the options listed below to change the command's behavior."
+	} else {
+		s.Command.Long = "The `temporal workflow children` command walks the Event History of a\nWorkflow Execution to list its started child workflows and their statuses,\nrecursively up to the given depth. Workflows that continued as new are shown with the status of their latest run, and\nthe children of every run are listed. Children that can no longer be found, such as ones past retention, are shown with\nstatus NotFound.\n\n```\ntemporal workflow children --workflow-id MyWorkflowId --depth 2\n```\n\nUse the options listed below to change the command's behavior."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().IntVar(&s.Depth, "depth", -1, "Depth of child workflows to fetch. Use -1 to fetch child workflows at any depth.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1696,6 +2552,36 @@ func NewTemporalWorkflowCountCommand(cctx *CommandContext, parent *TemporalWorkf
 	return &s
 }
 
+type TemporalWorkflowCountEventsCommand struct {
+	Parent  *TemporalWorkflowCommand
+	Command cobra.Command
+	WorkflowReferenceOptions
+	GroupBy StringEnum
+}
+
+func NewTemporalWorkflowCountEventsCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowCountEventsCommand {
+	var s TemporalWorkflowCountEventsCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "count-events [flags]"
+	s.Command.Short = "Count events in a Workflow Execution's history."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow count-events\x1b[0m command reports the number of events and their total size, including the size of\nthe payloads in them, for a single Workflow Execution. This helps identify what is making a history large.\n\n\x1b[1mtemporal workflow count-events --workflow-id MyWorkflowId --group-by activity-type\x1b[0m\n\nUse the options listed below to change the command's behavior."
+	} else {
+		s.Command.Long = "The `temporal workflow count-events` command reports the number of events and their total size, including the size of\nthe payloads in them, for a single Workflow Execution. This helps identify what is making a history large.\n\n`temporal workflow count-events --workflow-id MyWorkflowId --group-by activity-type`\n\nUse the options listed below to change the command's behavior."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.GroupBy = NewStringEnum([]string{"event-type", "activity-type", "signal-name"}, "event-type")
+	s.Command.Flags().Var(&s.GroupBy, "group-by", "What to group events by. Grouping by activity type only counts activity events, and grouping by signal name only counts received signals. Accepted values: event-type, activity-type, signal-name.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalWorkflowDeleteCommand struct {
 	Parent  *TemporalWorkflowCommand
 	Command cobra.Command
@@ -1738,8 +2624,9 @@ type TemporalWorkflowDescribeCommand struct {
 	Parent  *TemporalWorkflowCommand
 	Command cobra.Command
 	WorkflowReferenceOptions
-	ResetPoints bool
-	Raw         bool
+	ResetPoints            bool
+	Raw                    bool
+	IncludeRawHistoryStats bool
 }
 
 func NewTemporalWorkflowDescribeCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowDescribeCommand {
@@ -1749,14 +2636,15 @@ func NewTemporalWorkflowDescribeCommand(cctx *CommandContext, parent *TemporalWo
 	s.Command.Use = "describe [flags]"
 	s.Command.Short = "Show information about a Workflow Execution."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal workflow describe\x1b[0m command shows information about a given\nWorkflow Execution.\n\nThis information can be used to locate Workflow Executions that weren't able to run successfully.\n\n\x1b[1mtemporal workflow describe --workflow-id=meaningful-business-id\x1b[0m\n\nOutput can be shown as printed ('raw') or formatted to only show the Workflow Execution's auto-reset points.\n\n\x1b[1mtemporal workflow describe --workflow-id=meaningful-business-id --raw=true --reset-points=true\x1b[0m\n\nUse the command options below to change the information returned by this command."
+		s.Command.Long = "The \x1b[1mtemporal workflow describe\x1b[0m command shows information about a given\nWorkflow Execution.\n\nThis information can be used to locate Workflow Executions that weren't able to run successfully.\n\n\x1b[1mtemporal workflow describe --workflow-id=meaningful-business-id\x1b[0m\n\nOutput can be shown as printed ('raw') or formatted to only show the Workflow Execution's auto-reset points.\n\n\x1b[1mtemporal workflow describe --workflow-id=meaningful-business-id --raw=true --reset-points=true\x1b[0m\n\nExtended information about the execution, such as its duration, the first Run Id in its chain, and the Run Id it was\nreset from, can be included.\n\n\x1b[1mtemporal workflow describe --workflow-id=meaningful-business-id --include-raw-history-stats\x1b[0m\n\nUse the command options below to change the information returned by this command."
 	} else {
-		s.Command.Long = "The `temporal workflow describe` command shows information about a given\nWorkflow Execution.\n\nThis information can be used to locate Workflow Executions that weren't able to run successfully.\n\n`temporal workflow describe --workflow-id=meaningful-business-id`\n\nOutput can be shown as printed ('raw') or formatted to only show the Workflow Execution's auto-reset points.\n\n`temporal workflow describe --workflow-id=meaningful-business-id --raw=true --reset-points=true`\n\nUse the command options below to change the information returned by this command."
+		s.Command.Long = "The `temporal workflow describe` command shows information about a given\nWorkflow Execution.\n\nThis information can be used to locate Workflow Executions that weren't able to run successfully.\n\n`temporal workflow describe --workflow-id=meaningful-business-id`\n\nOutput can be shown as printed ('raw') or formatted to only show the Workflow Execution's auto-reset points.\n\n`temporal workflow describe --workflow-id=meaningful-business-id --raw=true --reset-points=true`\n\nExtended information about the execution, such as its duration, the first Run Id in its chain, and the Run Id it was\nreset from, can be included.\n\n`temporal workflow describe --workflow-id=meaningful-business-id --include-raw-history-stats`\n\nUse the command options below to change the information returned by this command."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
 	s.Command.Flags().BoolVar(&s.ResetPoints, "reset-points", false, "Only show auto-reset points.")
 	s.Command.Flags().BoolVar(&s.Raw, "raw", false, "Print properties without changing their format.")
+	s.Command.Flags().BoolVar(&s.IncludeRawHistoryStats, "include-raw-history-stats", false, "Include extended execution info: history size and length, execution duration, first Run Id, and the Run Id this run was reset from if any. In JSON output this is the \"extendedInfo\" key.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1772,6 +2660,7 @@ type TemporalWorkflowExecuteCommand struct {
 	WorkflowStartOptions
 	PayloadInputOptions
 	EventDetails bool
+	DetachAfter  Duration
 }
 
 func NewTemporalWorkflowExecuteCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowExecuteCommand {
@@ -1781,15 +2670,19 @@ func NewTemporalWorkflowExecuteCommand(cctx *CommandContext, parent *TemporalWor
 	s.Command.Use = "execute [flags]"
 	s.Command.Short = "Start a new Workflow Execution and prints its progress."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal workflow execute\x1b[0m command starts a new Workflow Execution and\nprints its progress. The command completes when the Workflow Execution completes.\n\nSingle quotes('') are used to wrap input as JSON.\n\n\x1b[1mtemporal workflow execute\n\t\t--workflow-id meaningful-business-id \\\n\t\t--type MyWorkflow \\\n\t\t--task-queue MyTaskQueue \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\x1b[0m"
+		s.Command.Long = "The \x1b[1mtemporal workflow execute\x1b[0m command starts a new Workflow Execution and\nprints its progress. The command completes when the Workflow Execution completes.\n\nSingle quotes('') are used to wrap input as JSON.\n\n\x1b[1mtemporal workflow execute\n\t\t--workflow-id meaningful-business-id \\\n\t\t--type MyWorkflow \\\n\t\t--task-queue MyTaskQueue \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\x1b[0m\n\nWith \x1b[1m--output junit\x1b[0m or \x1b[1m--output tap\x1b[0m, the result is reported as a single test case named after the Workflow Type\nand Workflow Id, with its duration and, if the Workflow Execution did not complete, the failure message. This lets CI\nsystems show Workflow acceptance runs as test results. A Workflow Execution that is detached from with\n\x1b[1m--detach-after\x1b[0m is reported as skipped.\n\n\x1b[1mtemporal workflow execute \\\n\t\t--type MyWorkflow \\\n\t\t--task-queue MyTaskQueue \\\n\t\t--output junit --output-file results.xml\x1b[0m"
 	} else {
-		s.Command.Long = "The `temporal workflow execute` command starts a new Workflow Execution and\nprints its progress. The command completes when the Workflow Execution completes.\n\nSingle quotes('') are used to wrap input as JSON.\n\n```\ntemporal workflow execute\n\t\t--workflow-id meaningful-business-id \\\n\t\t--type MyWorkflow \\\n\t\t--task-queue MyTaskQueue \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\n```"
+		s.Command.Long = "The `temporal workflow execute` command starts a new Workflow Execution and\nprints its progress. The command completes when the Workflow Execution completes.\n\nSingle quotes('') are used to wrap input as JSON.\n\n```\ntemporal workflow execute\n\t\t--workflow-id meaningful-business-id \\\n\t\t--type MyWorkflow \\\n\t\t--task-queue MyTaskQueue \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\n```\n\nWith `--output junit` or `--output tap`, the result is reported as a single test case named after the Workflow Type\nand Workflow Id, with its duration and, if the Workflow Execution did not complete, the failure message. This lets CI\nsystems show Workflow acceptance runs as test results. A Workflow Execution that is detached from with\n`--detach-after` is reported as skipped.\n\n```\ntemporal workflow execute \\\n\t\t--type MyWorkflow \\\n\t\t--task-queue MyTaskQueue \\\n\t\t--output junit --output-file results.xml\n```"
 	}
 	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["outputFormats"] = "junit,tap"
 	s.SharedWorkflowStartOptions.buildFlags(cctx, s.Command.Flags())
 	s.WorkflowStartOptions.buildFlags(cctx, s.Command.Flags())
 	s.PayloadInputOptions.buildFlags(cctx, s.Command.Flags())
 	s.Command.Flags().BoolVar(&s.EventDetails, "event-details", false, "If set when using text output, include event details JSON in printed output. If set when using JSON output, this will include the entire \"history\" JSON key of the started run (does not follow runs).")
+	s.DetachAfter = 0
+	s.Command.Flags().Var(&s.DetachAfter, "detach-after", "Stop following the Workflow Execution after this long if it has not closed, print the workflow attach command to follow it again, and exit successfully.")
 	s.Command.Flags().SetNormalizeFunc(aliasNormalizer(map[string]string{
 		"name": "type",
 	}))
@@ -1820,6 +2713,9 @@ func NewTemporalWorkflowFixHistoryJsonCommand(cctx *CommandContext, parent *Temp
 		s.Command.Long = "```\ntemporal workflow fix-history-json \\\n\t--source original.json \\\n\t--target reserialized.json\n```\n\nUse the options listed below to change the command's behavior."
 	}
 	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
 	s.Command.Flags().StringVarP(&s.Source, "source", "s", "", "Path to the input file. Required.")
 	_ = cobra.MarkFlagRequired(s.Command.Flags(), "source")
 	s.Command.Flags().StringVarP(&s.Target, "target", "t", "", "Path to the output file, or standard output if not set.")
@@ -1832,11 +2728,16 @@ func NewTemporalWorkflowFixHistoryJsonCommand(cctx *CommandContext, parent *Temp
 }
 
 type TemporalWorkflowListCommand struct {
-	Parent   *TemporalWorkflowCommand
-	Command  cobra.Command
-	Query    string
-	Archived bool
-	Limit    int
+	Parent             *TemporalWorkflowCommand
+	Command            cobra.Command
+	Query              string
+	Archived           bool
+	Limit              int
+	GroupBy            StringEnum
+	GroupSamples       int
+	Long               bool
+	MemoKey            []string
+	SearchAttributeKey []string
 }
 
 func NewTemporalWorkflowListCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowListCommand {
@@ -1846,14 +2747,20 @@ func NewTemporalWorkflowListCommand(cctx *CommandContext, parent *TemporalWorkfl
 	s.Command.Use = "list [flags]"
 	s.Command.Short = "List Workflow Executions based on a Query."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal workflow list\x1b[0m command provides a list of Workflow Executions\nthat meet the criteria of a given Query.\nBy default, this command returns up to 10 closed Workflow Executions.\n\n\x1b[1mtemporal workflow list --query=MyQuery\x1b[0m\n\nThe command can also return a list of archived Workflow Executions.\n\n\x1b[1mtemporal workflow list --archived\x1b[0m\n\nUse the command options below to change the information returned by this command."
+		s.Command.Long = "The \x1b[1mtemporal workflow list\x1b[0m command provides a list of Workflow Executions\nthat meet the criteria of a given Query.\nBy default, this command returns up to 10 closed Workflow Executions.\n\n\x1b[1mtemporal workflow list --query=MyQuery\x1b[0m\n\nThe command can also return a list of archived Workflow Executions.\n\n\x1b[1mtemporal workflow list --archived\x1b[0m\n\nTo survey a namespace, group the results and show a count and a few sample Workflow IDs per group.\n\n\x1b[1mtemporal workflow list --group-by WorkflowType\x1b[0m\n\nTo answer questions that would otherwise need a describe per Workflow, '--long' adds the Run Id, Task Queue, and close\ntime, and memo keys and Search Attributes can be shown as columns. Memo values are decoded with the configured codec:\n\n\x1b[1mtemporal workflow list --long --memo-key Customer --search-attribute-key CustomStatus\x1b[0m\n\nUse the command options below to change the information returned by this command."
 	} else {
-		s.Command.Long = "The `temporal workflow list` command provides a list of Workflow Executions\nthat meet the criteria of a given Query.\nBy default, this command returns up to 10 closed Workflow Executions.\n\n`temporal workflow list --query=MyQuery`\n\nThe command can also return a list of archived Workflow Executions.\n\n`temporal workflow list --archived`\n\nUse the command options below to change the information returned by this command."
+		s.Command.Long = "The `temporal workflow list` command provides a list of Workflow Executions\nthat meet the criteria of a given Query.\nBy default, this command returns up to 10 closed Workflow Executions.\n\n`temporal workflow list --query=MyQuery`\n\nThe command can also return a list of archived Workflow Executions.\n\n`temporal workflow list --archived`\n\nTo survey a namespace, group the results and show a count and a few sample Workflow IDs per group.\n\n`temporal workflow list --group-by WorkflowType`\n\nTo answer questions that would otherwise need a describe per Workflow, '--long' adds the Run Id, Task Queue, and close\ntime, and memo keys and Search Attributes can be shown as columns. Memo values are decoded with the configured codec:\n\n`temporal workflow list --long --memo-key Customer --search-attribute-key CustomStatus`\n\nUse the command options below to change the information returned by this command."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Filter results using a SQL-like query.")
 	s.Command.Flags().BoolVar(&s.Archived, "archived", false, "If set, will only query and list archived workflows instead of regular workflows.")
-	s.Command.Flags().IntVar(&s.Limit, "limit", 0, "Limit the number of items to print.")
+	s.Command.Flags().IntVar(&s.Limit, "limit", 0, "Limit the number of items to print. When grouping, limits the number of items grouped.")
+	s.GroupBy = NewStringEnum([]string{"WorkflowType", "ExecutionStatus", "TaskQueue"}, "")
+	s.Command.Flags().Var(&s.GroupBy, "group-by", "Group results by this field, printing a count and samples for each group. Accepted values: WorkflowType, ExecutionStatus, TaskQueue.")
+	s.Command.Flags().IntVar(&s.GroupSamples, "group-samples", 3, "Number of sample executions to show for each group when using --group-by.")
+	s.Command.Flags().BoolVar(&s.Long, "long", false, "Also show the Run Id, Task Queue, and close time of each execution in the table.")
+	s.Command.Flags().StringArrayVar(&s.MemoKey, "memo-key", nil, "Memo key to show as a table column. Can be passed multiple times.")
+	s.Command.Flags().StringArrayVar(&s.SearchAttributeKey, "search-attribute-key", nil, "Search Attribute to show as a table column. Can be passed multiple times.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1866,7 +2773,10 @@ type TemporalWorkflowQueryCommand struct {
 	Parent  *TemporalWorkflowCommand
 	Command cobra.Command
 	PayloadInputOptions
-	WorkflowReferenceOptions
+	WorkflowId      string
+	RunId           string
+	QueryFilter     string
+	Concurrency     int
 	Name            string
 	RejectCondition StringEnum
 }
@@ -1878,13 +2788,16 @@ func NewTemporalWorkflowQueryCommand(cctx *CommandContext, parent *TemporalWorkf
 	s.Command.Use = "query [flags]"
 	s.Command.Short = "Query a Workflow Execution."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal workflow query\x1b[0m command is used to Query a\nWorkflow Execution\nby ID.\n\n\x1b[1mtemporal workflow query \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyQuery \\\n\t\t--input '{\"MyInputKey\": \"MyInputValue\"}'\x1b[0m\n\nUse the options listed below to change the command's behavior."
+		s.Command.Long = "The \x1b[1mtemporal workflow query\x1b[0m command is used to Query a\nWorkflow Execution\nby ID.\n\n\x1b[1mtemporal workflow query \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyQuery \\\n\t\t--input '{\"MyInputKey\": \"MyInputValue\"}'\x1b[0m\n\nIf the Workflow rejects the Query name as unknown, the closest names it has registered are suggested.\n\nThe same Query can be sent to every open Workflow Execution matching a List Filter,\nprinting a result per Workflow. If interrupted, Workflows that were not queried yet are marked as not sent:\n\n\x1b[1mtemporal workflow query \\\n\t\t--query-filter 'WorkflowType = \"MyWorkflow\"' \\\n\t\t--name MyQuery\x1b[0m\n\nUse the options listed below to change the command's behavior."
 	} else {
-		s.Command.Long = "The `temporal workflow query` command is used to Query a\nWorkflow Execution\nby ID.\n\n```\ntemporal workflow query \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyQuery \\\n\t\t--input '{\"MyInputKey\": \"MyInputValue\"}'\n```\n\nUse the options listed below to change the command's behavior."
+		s.Command.Long = "The `temporal workflow query` command is used to Query a\nWorkflow Execution\nby ID.\n\n```\ntemporal workflow query \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyQuery \\\n\t\t--input '{\"MyInputKey\": \"MyInputValue\"}'\n```\n\nIf the Workflow rejects the Query name as unknown, the closest names it has registered are suggested.\n\nThe same Query can be sent to every open Workflow Execution matching a List Filter,\nprinting a result per Workflow. If interrupted, Workflows that were not queried yet are marked as not sent:\n\n```\ntemporal workflow query \\\n\t\t--query-filter 'WorkflowType = \"MyWorkflow\"' \\\n\t\t--name MyQuery\n```\n\nUse the options listed below to change the command's behavior."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.PayloadInputOptions.buildFlags(cctx, s.Command.Flags())
-	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().StringVarP(&s.WorkflowId, "workflow-id", "w", "", "Workflow Id. Either this or query filter must be set.")
+	s.Command.Flags().StringVarP(&s.RunId, "run-id", "r", "", "Run Id. Cannot be set when query filter is set.")
+	s.Command.Flags().StringVar(&s.QueryFilter, "query-filter", "", "Query all open Workflow Executions matching this List Filter. Either this or Workflow Id must be set.")
+	s.Command.Flags().IntVar(&s.Concurrency, "concurrency", 10, "Maximum number of Workflows queried at once when using query filter.")
 	s.Command.Flags().StringVar(&s.Name, "name", "", "Query Type/Name. Required. Aliased as \"--type\".")
 	_ = cobra.MarkFlagRequired(s.Command.Flags(), "name")
 	s.RejectCondition = NewStringEnum([]string{"not_open", "not_completed_cleanly"}, "")
@@ -1900,6 +2813,42 @@ func NewTemporalWorkflowQueryCommand(cctx *CommandContext, parent *TemporalWorkf
 	return &s
 }
 
+type TemporalWorkflowRedactCommand struct {
+	Parent      *TemporalWorkflowCommand
+	Command     cobra.Command
+	HistoryFile string
+	Rules       string
+	Target      string
+}
+
+func NewTemporalWorkflowRedactCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowRedactCommand {
+	var s TemporalWorkflowRedactCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "redact [flags]"
+	s.Command.Short = "Redact payloads and headers in an event history JSON file."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow redact\x1b[0m command masks or removes payloads in an event history JSON file so that the history\ncan be shared, e.g. with support, without leaking sensitive data.\n\n\x1b[1mtemporal workflow redact \\\n\t--history-file original.json \\\n\t--rules rules.yaml \\\n\t--target redacted.json\x1b[0m\n\nThe rules file is YAML with a list of rules. A payload matches a rule when all of the rule's conditions match, and the\nfirst matching rule wins:\n\n\x1b[1mrules:\n  # Any payload on events of these activity types\n  - activityTypes: [ChargeCard]\n    action: remove\n  # Values of these search attributes\n  - searchAttributes: [CustomerEmail]\n  # Values of these header fields\n  - headers: [auth-token]\n  # Payloads whose data matches this regular expression\n  - pattern: '\\d{3}-\\d{2}-\\d{4}'\x1b[0m\n\nThe action is \x1b[1mmask\x1b[0m (the default), which replaces the payload with the JSON string \"REDACTED\", or \x1b[1mremove\x1b[0m, which\nremoves the payload altogether."
+	} else {
+		s.Command.Long = "The `temporal workflow redact` command masks or removes payloads in an event history JSON file so that the history\ncan be shared, e.g. with support, without leaking sensitive data.\n\n```\ntemporal workflow redact \\\n\t--history-file original.json \\\n\t--rules rules.yaml \\\n\t--target redacted.json\n```\n\nThe rules file is YAML with a list of rules. A payload matches a rule when all of the rule's conditions match, and the\nfirst matching rule wins:\n\n```\nrules:\n  # Any payload on events of these activity types\n  - activityTypes: [ChargeCard]\n    action: remove\n  # Values of these search attributes\n  - searchAttributes: [CustomerEmail]\n  # Values of these header fields\n  - headers: [auth-token]\n  # Payloads whose data matches this regular expression\n  - pattern: '\\d{3}-\\d{2}-\\d{4}'\n```\n\nThe action is `mask` (the default), which replaces the payload with the JSON string \"REDACTED\", or `remove`, which\nremoves the payload altogether."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["ignoresMissingEnv"] = "true"
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.Command.Flags().StringVar(&s.HistoryFile, "history-file", "", "Path to the input event history JSON file. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "history-file")
+	s.Command.Flags().StringVar(&s.Rules, "rules", "", "Path to the YAML rules file. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "rules")
+	s.Command.Flags().StringVarP(&s.Target, "target", "t", "", "Path to the output file, or standard output if not set.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalWorkflowResetCommand struct {
 	Parent         *TemporalWorkflowCommand
 	Command        cobra.Command
@@ -1911,8 +2860,12 @@ type TemporalWorkflowResetCommand struct {
 	ReapplyExclude []string
 	Type           StringEnum
 	BuildId        string
+	ToUpdate       string
+	ToBuildId      string
 	Query          string
 	Yes            bool
+	SamplePercent  float64
+	MaxCount       int
 }
 
 func NewTemporalWorkflowResetCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowResetCommand {
@@ -1922,9 +2875,9 @@ func NewTemporalWorkflowResetCommand(cctx *CommandContext, parent *TemporalWorkf
 	s.Command.Use = "reset [flags]"
 	s.Command.Short = "Resets a Workflow Execution by Event ID or reset type."
 	if hasHighlighting {
-		s.Command.Long = "The temporal workflow reset command resets a Workflow Execution.\nA reset allows the Workflow to resume from a certain point without losing its parameters or Event History.\n\nThe Workflow Execution can be set to a given Event Type:\n\x1b[1mtemporal workflow reset --workflow-id=meaningful-business-id --type=LastContinuedAsNew\x1b[0m\n\n...or a specific any Event after \x1b[1mWorkflowTaskStarted\x1b[0m.\n\x1b[1mtemporal workflow reset --workflow-id=meaningful-business-id --event-id=MyLastEvent\x1b[0m\nFor batch reset only FirstWorkflowTask, LastWorkflowTask or BuildId can be used. Workflow Id, run Id and event Id\nshould not be set.\nUse the options listed below to change reset behavior."
+		s.Command.Long = "The temporal workflow reset command resets a Workflow Execution.\nA reset allows the Workflow to resume from a certain point without losing its parameters or Event History.\n\nThe Workflow Execution can be set to a given Event Type:\n\x1b[1mtemporal workflow reset --workflow-id=meaningful-business-id --type=LastContinuedAsNew\x1b[0m\n\n...or a specific any Event after \x1b[1mWorkflowTaskStarted\x1b[0m.\n\x1b[1mtemporal workflow reset --workflow-id=meaningful-business-id --event-id=MyLastEvent\x1b[0m\n...or the Workflow Task before a given Update, or the first Workflow Task processed by a given Build Id.\n\x1b[1mtemporal workflow reset --workflow-id=meaningful-business-id --to-update=MyUpdateId\ntemporal workflow reset --workflow-id=meaningful-business-id --to-build-id=MyBadBuildId\x1b[0m\nFor batch reset only FirstWorkflowTask, LastWorkflowTask or BuildId can be used. Workflow Id, run Id and event Id\nshould not be set.\nUse the options listed below to change reset behavior."
 	} else {
-		s.Command.Long = "The temporal workflow reset command resets a Workflow Execution.\nA reset allows the Workflow to resume from a certain point without losing its parameters or Event History.\n\nThe Workflow Execution can be set to a given Event Type:\n```\ntemporal workflow reset --workflow-id=meaningful-business-id --type=LastContinuedAsNew\n```\n\n...or a specific any Event after `WorkflowTaskStarted`.\n```\ntemporal workflow reset --workflow-id=meaningful-business-id --event-id=MyLastEvent\n```\nFor batch reset only FirstWorkflowTask, LastWorkflowTask or BuildId can be used. Workflow Id, run Id and event Id\nshould not be set.\nUse the options listed below to change reset behavior."
+		s.Command.Long = "The temporal workflow reset command resets a Workflow Execution.\nA reset allows the Workflow to resume from a certain point without losing its parameters or Event History.\n\nThe Workflow Execution can be set to a given Event Type:\n```\ntemporal workflow reset --workflow-id=meaningful-business-id --type=LastContinuedAsNew\n```\n\n...or a specific any Event after `WorkflowTaskStarted`.\n```\ntemporal workflow reset --workflow-id=meaningful-business-id --event-id=MyLastEvent\n```\n...or the Workflow Task before a given Update, or the first Workflow Task processed by a given Build Id.\n```\ntemporal workflow reset --workflow-id=meaningful-business-id --to-update=MyUpdateId\ntemporal workflow reset --workflow-id=meaningful-business-id --to-build-id=MyBadBuildId\n```\nFor batch reset only FirstWorkflowTask, LastWorkflowTask or BuildId can be used. Workflow Id, run Id and event Id\nshould not be set.\nUse the options listed below to change reset behavior."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.Command.Flags().StringVarP(&s.WorkflowId, "workflow-id", "w", "", "Workflow Id. Required for non-batch reset operations.")
@@ -1938,8 +2891,12 @@ func NewTemporalWorkflowResetCommand(cctx *CommandContext, parent *TemporalWorkf
 	s.Type = NewStringEnum([]string{"FirstWorkflowTask", "LastWorkflowTask", "LastContinuedAsNew", "BuildId"}, "")
 	s.Command.Flags().VarP(&s.Type, "type", "t", "Event type to which you want to reset. Accepted values: FirstWorkflowTask, LastWorkflowTask, LastContinuedAsNew, BuildId.")
 	s.Command.Flags().StringVar(&s.BuildId, "build-id", "", "Only used if type is BuildId. Reset the first workflow task processed by this build id. Note that by default, this reset is allowed to be to a prior run in a chain of continue-as-new.")
+	s.Command.Flags().StringVar(&s.ToUpdate, "to-update", "", "Reset to the Workflow Task completed just before the given Update Id was admitted or accepted. Not allowed for batch reset.")
+	s.Command.Flags().StringVar(&s.ToBuildId, "to-build-id", "", "Reset to the first Workflow Task processed by this build id. Shorthand for --type BuildId --build-id. For non-batch reset, only the given run is searched.")
 	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Start a batch reset to operate on Workflow Executions with given List Filter.")
 	s.Command.Flags().BoolVarP(&s.Yes, "yes", "y", false, "Confirm prompt to perform batch. Only allowed if query is present.")
+	s.Command.Flags().Float64Var(&s.SamplePercent, "sample-percent", 0, "Only operate on this percentage of the Workflow Executions matching the query, chosen at random. At most 1000 can be selected. Only allowed if query is present.")
+	s.Command.Flags().IntVar(&s.MaxCount, "max-count", 0, "Only operate on at most this many of the Workflow Executions matching the query, up to 1000. Only allowed if query is present.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1952,8 +2909,9 @@ type TemporalWorkflowShowCommand struct {
 	Parent  *TemporalWorkflowCommand
 	Command cobra.Command
 	WorkflowReferenceOptions
-	Follow       bool
-	EventDetails bool
+	Follow              bool
+	EventDetails        bool
+	DecodedEventDetails bool
 }
 
 func NewTemporalWorkflowShowCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowShowCommand {
@@ -1963,14 +2921,17 @@ func NewTemporalWorkflowShowCommand(cctx *CommandContext, parent *TemporalWorkfl
 	s.Command.Use = "show [flags]"
 	s.Command.Short = "Show Event History for a Workflow Execution."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal workflow show\x1b[0m command provides the Event History for a\nWorkflow Execution. With JSON output specified, this output can be given to\nan SDK to perform a replay.\n\nUse the options listed below to change the command's behavior."
+		s.Command.Long = "The \x1b[1mtemporal workflow show\x1b[0m command provides the Event History for a\nWorkflow Execution. With JSON output specified, this output can be given to\nan SDK to perform a replay.\n\nWith \x1b[1m--output jsonl\x1b[0m, each event is printed on its own line as soon as it is read instead of after the whole history,\nso large histories can be piped into other tools as they stream in. With \x1b[1m--follow\x1b[0m, new events keep being printed until\nthe Workflow Execution closes. Commands that read history files accept either format.\n\nWith \x1b[1m--output timeline\x1b[0m, a gantt-style timeline of the Workflow's Activities, Timers, and Child Workflows is shown\ninstead, scaled to the terminal width, to make it clear where the time in a slow run went.\n\nUse the options listed below to change the command's behavior."
 	} else {
-		s.Command.Long = "The `temporal workflow show` command provides the Event History for a\nWorkflow Execution. With JSON output specified, this output can be given to\nan SDK to perform a replay.\n\nUse the options listed below to change the command's behavior."
+		s.Command.Long = "The `temporal workflow show` command provides the Event History for a\nWorkflow Execution. With JSON output specified, this output can be given to\nan SDK to perform a replay.\n\nWith `--output jsonl`, each event is printed on its own line as soon as it is read instead of after the whole history,\nso large histories can be piped into other tools as they stream in. With `--follow`, new events keep being printed until\nthe Workflow Execution closes. Commands that read history files accept either format.\n\nWith `--output timeline`, a gantt-style timeline of the Workflow's Activities, Timers, and Child Workflows is shown\ninstead, scaled to the terminal width, to make it clear where the time in a slow run went.\n\nUse the options listed below to change the command's behavior."
 	}
 	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["outputFormats"] = "timeline"
 	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
-	s.Command.Flags().BoolVarP(&s.Follow, "follow", "f", false, "Follow the progress of a Workflow Execution in real time (does not apply to JSON output).")
+	s.Command.Flags().BoolVarP(&s.Follow, "follow", "f", false, "Follow the progress of a Workflow Execution in real time (does not apply to json output, but does to jsonl). Reconnects with backoff if the connection is lost.")
 	s.Command.Flags().BoolVar(&s.EventDetails, "event-details", false, "If set when using text output, include event details JSON in printed output.")
+	s.Command.Flags().BoolVar(&s.DecodedEventDetails, "decoded-event-details", false, "Like --event-details, but with payloads in the details shown decoded the same way as other output, e.g. using --proto-descriptors, instead of as raw protobuf JSON.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -1980,11 +2941,13 @@ func NewTemporalWorkflowShowCommand(cctx *CommandContext, parent *TemporalWorkfl
 }
 
 type SingleWorkflowOrBatchOptions struct {
-	WorkflowId string
-	RunId      string
-	Query      string
-	Reason     string
-	Yes        bool
+	WorkflowId    string
+	RunId         string
+	Query         string
+	Reason        string
+	Yes           bool
+	SamplePercent float64
+	MaxCount      int
 }
 
 func (v *SingleWorkflowOrBatchOptions) buildFlags(cctx *CommandContext, f *pflag.FlagSet) {
@@ -1993,13 +2956,16 @@ func (v *SingleWorkflowOrBatchOptions) buildFlags(cctx *CommandContext, f *pflag
 	f.StringVarP(&v.Query, "query", "q", "", "Start a batch to operate on Workflow Executions with given List Filter. Either this or Workflow Id must be set.")
 	f.StringVar(&v.Reason, "reason", "", "Reason to perform batch. Only allowed if query is present unless the command specifies otherwise. Defaults to message with the current user's name.")
 	f.BoolVarP(&v.Yes, "yes", "y", false, "Confirm prompt to perform batch. Only allowed if query is present.")
+	f.Float64Var(&v.SamplePercent, "sample-percent", 0, "Only operate on this percentage of the Workflow Executions matching the query, chosen at random. At most 1000 can be selected. Only allowed if query is present.")
+	f.IntVar(&v.MaxCount, "max-count", 0, "Only operate on at most this many of the Workflow Executions matching the query, up to 1000. Only allowed if query is present.")
 }
 
 type TemporalWorkflowSignalCommand struct {
 	Parent  *TemporalWorkflowCommand
 	Command cobra.Command
 	PayloadInputOptions
-	Name string
+	Name      string
+	DedupeKey string
 	SingleWorkflowOrBatchOptions
 }
 
@@ -2018,6 +2984,7 @@ func NewTemporalWorkflowSignalCommand(cctx *CommandContext, parent *TemporalWork
 	s.PayloadInputOptions.buildFlags(cctx, s.Command.Flags())
 	s.Command.Flags().StringVar(&s.Name, "name", "", "Signal Name. Required. Aliased as \"--type\".")
 	_ = cobra.MarkFlagRequired(s.Command.Flags(), "name")
+	s.Command.Flags().StringVar(&s.DedupeKey, "dedupe-key", "", "Key to derive a deterministic request ID from, so the server ignores repeated signals with the same key, workflow, and signal name. Only allowed with workflow ID.")
 	s.SingleWorkflowOrBatchOptions.buildFlags(cctx, s.Command.Flags())
 	s.Command.Flags().SetNormalizeFunc(aliasNormalizer(map[string]string{
 		"type": "name",
@@ -2069,13 +3036,14 @@ type SharedWorkflowStartOptions struct {
 	TaskTimeout      Duration
 	SearchAttribute  []string
 	Memo             []string
+	MemoFile         []string
 }
 
 func (v *SharedWorkflowStartOptions) buildFlags(cctx *CommandContext, f *pflag.FlagSet) {
 	f.StringVarP(&v.WorkflowId, "workflow-id", "w", "", "Workflow Id.")
 	f.StringVar(&v.Type, "type", "", "Workflow Type name. Required. Aliased as \"--name\".")
 	_ = cobra.MarkFlagRequired(f, "type")
-	f.StringVarP(&v.TaskQueue, "task-queue", "t", "", "Workflow Task queue. Required.")
+	f.StringVarP(&v.TaskQueue, "task-queue", "t", "", "Workflow Task queue, which may come from the env's workflow type registry. Required.")
 	_ = cobra.MarkFlagRequired(f, "task-queue")
 	v.RunTimeout = 0
 	f.Var(&v.RunTimeout, "run-timeout", "Timeout of a Workflow Run.")
@@ -2085,13 +3053,19 @@ func (v *SharedWorkflowStartOptions) buildFlags(cctx *CommandContext, f *pflag.F
 	f.Var(&v.TaskTimeout, "task-timeout", "Start-to-close timeout for a Workflow Task.")
 	f.StringArrayVar(&v.SearchAttribute, "search-attribute", nil, "Passes Search Attribute in key=value format. Use valid JSON formats for value.")
 	f.StringArrayVar(&v.Memo, "memo", nil, "Passes Memo in key=value format. Use valid JSON formats for value.")
+	f.StringArrayVar(&v.MemoFile, "memo-file", nil, "Passes Memo in key=path format, where the file contains the JSON value. Can be given multiple times.")
 }
 
 type WorkflowStartOptions struct {
-	Cron          string
-	FailExisting  bool
-	StartDelay    Duration
-	IdReusePolicy string
+	Cron                       string
+	FailExisting               bool
+	StartDelay                 Duration
+	IdReusePolicy              string
+	RetryInitialInterval       Duration
+	RetryMaximumInterval       Duration
+	RetryBackoffCoefficient    float64
+	RetryMaximumAttempts       int
+	RetryNonRetryableErrorType []string
 }
 
 func (v *WorkflowStartOptions) buildFlags(cctx *CommandContext, f *pflag.FlagSet) {
@@ -2100,6 +3074,13 @@ func (v *WorkflowStartOptions) buildFlags(cctx *CommandContext, f *pflag.FlagSet
 	v.StartDelay = 0
 	f.Var(&v.StartDelay, "start-delay", "Specify a delay before the workflow starts. Cannot be used with a cron schedule. If the workflow receives a signal or update before the delay has elapsed, it will begin immediately.")
 	f.StringVar(&v.IdReusePolicy, "id-reuse-policy", "", "Allows the same Workflow Id to be used in a new Workflow Execution. Accepted values: AllowDuplicate, AllowDuplicateFailedOnly, RejectDuplicate, TerminateIfRunning.")
+	v.RetryInitialInterval = 0
+	f.Var(&v.RetryInitialInterval, "retry-initial-interval", "Interval of the first workflow retry. Defaults to the server default if any other retry option is set.")
+	v.RetryMaximumInterval = 0
+	f.Var(&v.RetryMaximumInterval, "retry-maximum-interval", "Maximum interval between workflow retries. Must be at least the initial interval.")
+	f.Float64Var(&v.RetryBackoffCoefficient, "retry-backoff-coefficient", 0, "Coefficient used to calculate the next retry interval. Must be at least 1.")
+	f.IntVar(&v.RetryMaximumAttempts, "retry-maximum-attempts", 0, "Maximum number of workflow attempts. Zero means unlimited.")
+	f.StringArrayVar(&v.RetryNonRetryableErrorType, "retry-non-retryable-error-type", nil, "Error type that will not be retried. Can be given multiple times.")
 }
 
 type PayloadInputOptions struct {
@@ -2150,14 +3131,55 @@ func NewTemporalWorkflowStartCommand(cctx *CommandContext, parent *TemporalWorkf
 	return &s
 }
 
+type TemporalWorkflowSupersedeCommand struct {
+	Parent  *TemporalWorkflowCommand
+	Command cobra.Command
+	WorkflowReferenceOptions
+	PayloadInputOptions
+	Cancel      bool
+	WaitTimeout Duration
+	Reason      string
+}
+
+func NewTemporalWorkflowSupersedeCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowSupersedeCommand {
+	var s TemporalWorkflowSupersedeCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "supersede [flags]"
+	s.Command.Short = "Replace a running Workflow Execution with a fresh run of the same Workflow Id."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow supersede\x1b[0m command ends the running\nWorkflow Execution and starts a new run with the same Workflow Id. The\nWorkflow Type, Task Queue, timeouts, retry policy, cron schedule, memo, search attributes, headers, and input are\ncopied from the start event of the superseded run. Input can be overridden with the payload input options.\n\n\x1b[1mtemporal workflow supersede --workflow-id MyWorkflowId\x1b[0m\n\nBy default the running execution is terminated and the new run is started in a single request, so there is never a\nmoment without a running execution. With \x1b[1m--cancel\x1b[0m, the running execution is canceled instead and the new run is\nstarted once it has closed:\n\x1b[1mtemporal workflow supersede --workflow-id MyWorkflowId --cancel --reason \"restart with fresh state\"\x1b[0m"
+	} else {
+		s.Command.Long = "The `temporal workflow supersede` command ends the running\nWorkflow Execution and starts a new run with the same Workflow Id. The\nWorkflow Type, Task Queue, timeouts, retry policy, cron schedule, memo, search attributes, headers, and input are\ncopied from the start event of the superseded run. Input can be overridden with the payload input options.\n\n```\ntemporal workflow supersede --workflow-id MyWorkflowId\n```\n\nBy default the running execution is terminated and the new run is started in a single request, so there is never a\nmoment without a running execution. With `--cancel`, the running execution is canceled instead and the new run is\nstarted once it has closed:\n```\ntemporal workflow supersede --workflow-id MyWorkflowId --cancel --reason \"restart with fresh state\"\n```"
+	}
+	s.Command.Args = cobra.NoArgs
+	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.PayloadInputOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Flags().BoolVar(&s.Cancel, "cancel", false, "Cancel the running execution and wait for it to close instead of terminating it.")
+	s.WaitTimeout = Duration(30000 * time.Millisecond)
+	s.Command.Flags().Var(&s.WaitTimeout, "wait-timeout", "Maximum time to wait for the canceled execution to close before giving up without starting a new run. Only used with --cancel.")
+	s.Command.Flags().StringVar(&s.Reason, "reason", "", "Reason for the cancellation. Only allowed with --cancel. Defaults to message with the current user's name.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
 type TemporalWorkflowTerminateCommand struct {
-	Parent     *TemporalWorkflowCommand
-	Command    cobra.Command
-	WorkflowId string
-	RunId      string
-	Query      string
-	Reason     string
-	Yes        bool
+	Parent         *TemporalWorkflowCommand
+	Command        cobra.Command
+	WorkflowId     string
+	RunId          string
+	Query          string
+	Reason         string
+	Yes            bool
+	SamplePercent  float64
+	MaxCount       int
+	FollowChildren bool
+	Wait           bool
+	WaitTimeout    Duration
 }
 
 func NewTemporalWorkflowTerminateCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowTerminateCommand {
@@ -2177,6 +3199,12 @@ func NewTemporalWorkflowTerminateCommand(cctx *CommandContext, parent *TemporalW
 	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Start a batch to terminate Workflow Executions with given List Filter. Either this or Workflow Id must be set.")
 	s.Command.Flags().StringVar(&s.Reason, "reason", "", "Reason for termination. Defaults to message with the current user's name.")
 	s.Command.Flags().BoolVarP(&s.Yes, "yes", "y", false, "Confirm prompt to perform batch. Only allowed if query is present.")
+	s.Command.Flags().Float64Var(&s.SamplePercent, "sample-percent", 0, "Only operate on this percentage of the Workflow Executions matching the query, chosen at random. At most 1000 can be selected. Only allowed if query is present.")
+	s.Command.Flags().IntVar(&s.MaxCount, "max-count", 0, "Only operate on at most this many of the Workflow Executions matching the query, up to 1000. Only allowed if query is present.")
+	s.Command.Flags().BoolVar(&s.FollowChildren, "follow-children", false, "Also terminate all non-abandoned child workflows, recursively. Only allowed with workflow ID.")
+	s.Command.Flags().BoolVar(&s.Wait, "wait", false, "Wait for the workflow to close. Fails if it closes with a status other than terminated. Only allowed with workflow ID.")
+	s.WaitTimeout = 0
+	s.Command.Flags().Var(&s.WaitTimeout, "wait-timeout", "Maximum time to wait for the workflow to close. Zero means no limit. Only allowed with --wait.")
 	s.Command.Run = func(c *cobra.Command, args []string) {
 		if err := s.run(cctx, args); err != nil {
 			cctx.Options.Fail(err)
@@ -2229,6 +3257,12 @@ type TemporalWorkflowUpdateCommand struct {
 	UpdateId            string
 	RunId               string
 	FirstExecutionRunId string
+	Query               string
+	Concurrency         int
+	Rps                 float64
+	Yes                 bool
+	SamplePercent       float64
+	MaxCount            int
 }
 
 func NewTemporalWorkflowUpdateCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowUpdateCommand {
@@ -2238,19 +3272,24 @@ func NewTemporalWorkflowUpdateCommand(cctx *CommandContext, parent *TemporalWork
 	s.Command.Use = "update [flags]"
 	s.Command.Short = "Updates a running workflow synchronously."
 	if hasHighlighting {
-		s.Command.Long = "The \x1b[1mtemporal workflow update\x1b[0m command is used to synchronously Update a\nWorkflowExecution by ID.\n\n\x1b[1mtemporal workflow update \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyUpdate \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\x1b[0m\n\nUse the options listed below to change the command's behavior."
+		s.Command.Long = "The \x1b[1mtemporal workflow update\x1b[0m command is used to synchronously Update a\nWorkflowExecution by ID.\n\n\x1b[1mtemporal workflow update \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyUpdate \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\x1b[0m\n\nIf the Workflow rejects the Update name as unknown, the closest names it has registered are suggested.\n\nThe same Update can be sent to every running Workflow Execution matching a\nList Filter. Updates are sent by the CLI, not a server batch job, and the outcome for\neach Workflow is printed. If interrupted, Workflows that did not get the Update yet are marked as not sent:\n\n\x1b[1mtemporal workflow update \\\n\t\t--query 'WorkflowType = \"MyWorkflow\"' \\\n\t\t--name MyUpdate \\\n\t\t--concurrency 5 \\\n\t\t--rps 20\x1b[0m\n\nUse the options listed below to change the command's behavior."
 	} else {
-		s.Command.Long = "The `temporal workflow update` command is used to synchronously Update a\nWorkflowExecution by ID.\n\n```\ntemporal workflow update \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyUpdate \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\n```\n\nUse the options listed below to change the command's behavior."
+		s.Command.Long = "The `temporal workflow update` command is used to synchronously Update a\nWorkflowExecution by ID.\n\n```\ntemporal workflow update \\\n\t\t--workflow-id MyWorkflowId \\\n\t\t--name MyUpdate \\\n\t\t--input '{\"Input\": \"As-JSON\"}'\n```\n\nIf the Workflow rejects the Update name as unknown, the closest names it has registered are suggested.\n\nThe same Update can be sent to every running Workflow Execution matching a\nList Filter. Updates are sent by the CLI, not a server batch job, and the outcome for\neach Workflow is printed. If interrupted, Workflows that did not get the Update yet are marked as not sent:\n\n```\ntemporal workflow update \\\n\t\t--query 'WorkflowType = \"MyWorkflow\"' \\\n\t\t--name MyUpdate \\\n\t\t--concurrency 5 \\\n\t\t--rps 20\n```\n\nUse the options listed below to change the command's behavior."
 	}
 	s.Command.Args = cobra.NoArgs
 	s.PayloadInputOptions.buildFlags(cctx, s.Command.Flags())
 	s.Command.Flags().StringVar(&s.Name, "name", "", "Update Name. Required. Aliased as \"--type\".")
 	_ = cobra.MarkFlagRequired(s.Command.Flags(), "name")
-	s.Command.Flags().StringVarP(&s.WorkflowId, "workflow-id", "w", "", "Workflow Id. Required.")
-	_ = cobra.MarkFlagRequired(s.Command.Flags(), "workflow-id")
-	s.Command.Flags().StringVar(&s.UpdateId, "update-id", "", "Update ID. If unset, default to a UUID.")
-	s.Command.Flags().StringVarP(&s.RunId, "run-id", "r", "", "Run Id. If unset, the currently running Workflow Execution receives the Update.")
-	s.Command.Flags().StringVar(&s.FirstExecutionRunId, "first-execution-run-id", "", "Send the Update to the last Workflow Execution in the chain that started with this Run Id.")
+	s.Command.Flags().StringVarP(&s.WorkflowId, "workflow-id", "w", "", "Workflow Id. Either this or query must be set.")
+	s.Command.Flags().StringVar(&s.UpdateId, "update-id", "", "Update ID. If unset, default to a UUID. When query is set, the same ID is used for every Workflow so that re-running is idempotent.")
+	s.Command.Flags().StringVarP(&s.RunId, "run-id", "r", "", "Run Id. If unset, the currently running Workflow Execution receives the Update. Cannot be set when query is set.")
+	s.Command.Flags().StringVar(&s.FirstExecutionRunId, "first-execution-run-id", "", "Send the Update to the last Workflow Execution in the chain that started with this Run Id. Cannot be set when query is set.")
+	s.Command.Flags().StringVarP(&s.Query, "query", "q", "", "Send the Update to all running Workflow Executions matching this List Filter. Either this or Workflow Id must be set.")
+	s.Command.Flags().IntVar(&s.Concurrency, "concurrency", 10, "Maximum number of Updates in flight at once when query is set.")
+	s.Command.Flags().Float64Var(&s.Rps, "rps", 0, "Maximum number of Updates sent per second when query is set. Default is no limit.")
+	s.Command.Flags().BoolVarP(&s.Yes, "yes", "y", false, "Confirm prompt to send Updates. Only allowed if query is present.")
+	s.Command.Flags().Float64Var(&s.SamplePercent, "sample-percent", 0, "Only send the Update to this percentage of the Workflow Executions matching the query, chosen at random. Only allowed if query is present.")
+	s.Command.Flags().IntVar(&s.MaxCount, "max-count", 0, "Only send the Update to at most this many of the Workflow Executions matching the query. Only allowed if query is present.")
 	s.Command.Flags().SetNormalizeFunc(aliasNormalizer(map[string]string{
 		"type": "name",
 	}))
@@ -2261,3 +3300,84 @@ func NewTemporalWorkflowUpdateCommand(cctx *CommandContext, parent *TemporalWork
 	}
 	return &s
 }
+
+type TemporalWorkflowUpdatesCommand struct {
+	Parent  *TemporalWorkflowCommand
+	Command cobra.Command
+}
+
+func NewTemporalWorkflowUpdatesCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowUpdatesCommand {
+	var s TemporalWorkflowUpdatesCommand
+	s.Parent = parent
+	s.Command.Use = "updates"
+	s.Command.Short = "Inspect Updates of a Workflow Execution."
+	s.Command.Long = "Updates commands inspect the Updates of a\nWorkflow Execution."
+	s.Command.Args = cobra.NoArgs
+	s.Command.AddCommand(&NewTemporalWorkflowUpdatesListCommand(cctx, &s).Command)
+	return &s
+}
+
+type TemporalWorkflowUpdatesListCommand struct {
+	Parent  *TemporalWorkflowUpdatesCommand
+	Command cobra.Command
+	WorkflowReferenceOptions
+}
+
+func NewTemporalWorkflowUpdatesListCommand(cctx *CommandContext, parent *TemporalWorkflowUpdatesCommand) *TemporalWorkflowUpdatesListCommand {
+	var s TemporalWorkflowUpdatesListCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "list [flags]"
+	s.Command.Short = "List the Updates of a Workflow Execution."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow updates list\x1b[0m command walks the Event History of a\nWorkflow Execution to list its Updates with their ID, name, stage, and outcome.\n\n\x1b[1mtemporal workflow updates list --workflow-id MyWorkflowId\x1b[0m\n\nOnly Updates the server has recorded in history are listed. Updates that are admitted but not yet accepted by the\nWorkflow are listed when the server persists admitted Updates, and rejected Updates are listed when the server records\nrejections. Updates still in flight are shown with stage Admitted or Accepted.\n\nUse the options listed below to change the command's behavior."
+	} else {
+		s.Command.Long = "The `temporal workflow updates list` command walks the Event History of a\nWorkflow Execution to list its Updates with their ID, name, stage, and outcome.\n\n```\ntemporal workflow updates list --workflow-id MyWorkflowId\n```\n\nOnly Updates the server has recorded in history are listed. Updates that are admitted but not yet accepted by the\nWorkflow are listed when the server persists admitted Updates, and rejected Updates are listed when the server records\nrejections. Updates still in flight are shown with stage Admitted or Accepted.\n\nUse the options listed below to change the command's behavior."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.WorkflowReferenceOptions.buildFlags(cctx, s.Command.Flags())
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
+
+type TemporalWorkflowViewCommand struct {
+	Parent      *TemporalWorkflowCommand
+	Command     cobra.Command
+	HistoryFile string
+	WorkflowId  string
+	Port        int
+	UiPort      int
+	NoBrowser   bool
+}
+
+func NewTemporalWorkflowViewCommand(cctx *CommandContext, parent *TemporalWorkflowCommand) *TemporalWorkflowViewCommand {
+	var s TemporalWorkflowViewCommand
+	s.Parent = parent
+	s.Command.DisableFlagsInUseLine = true
+	s.Command.Use = "view [flags]"
+	s.Command.Short = "View an Event History file in the Web UI."
+	if hasHighlighting {
+		s.Command.Long = "The \x1b[1mtemporal workflow view\x1b[0m command starts a temporary development server and Web UI, imports an\nEvent History JSON file into it, and opens the browser on the Workflow Execution.\nThe server and everything imported are removed when the command is stopped with Ctrl+C.\n\n\x1b[1mtemporal workflow view --history-file run.json\x1b[0m\n\nThe history is imported as it was recorded, so no Workflow code runs, though timers and tasks of a Workflow that was\nstill running when the history was exported may still be scheduled."
+	} else {
+		s.Command.Long = "The `temporal workflow view` command starts a temporary development server and Web UI, imports an\nEvent History JSON file into it, and opens the browser on the Workflow Execution.\nThe server and everything imported are removed when the command is stopped with Ctrl+C.\n\n```\ntemporal workflow view --history-file run.json\n```\n\nThe history is imported as it was recorded, so no Workflow code runs, though timers and tasks of a Workflow that was\nstill running when the history was exported may still be scheduled."
+	}
+	s.Command.Args = cobra.NoArgs
+	s.Command.Annotations = make(map[string]string)
+	s.Command.Annotations["offlineAllowed"] = "true"
+	s.Command.Flags().StringVar(&s.HistoryFile, "history-file", "", "Path to the Event History JSON file. Required.")
+	_ = cobra.MarkFlagRequired(s.Command.Flags(), "history-file")
+	s.Command.Flags().StringVarP(&s.WorkflowId, "workflow-id", "w", "", "Workflow Id to import the history as. Defaults to the file name without extension.")
+	s.Command.Flags().IntVar(&s.Port, "port", 0, "Port for the server's frontend gRPC service. Default is any free port.")
+	s.Command.Flags().IntVar(&s.UiPort, "ui-port", 0, "Port for the Web UI. Default is any free port.")
+	s.Command.Flags().BoolVar(&s.NoBrowser, "no-browser", false, "Only print the Web UI address instead of opening the browser.")
+	s.Command.Run = func(c *cobra.Command, args []string) {
+		if err := s.run(cctx, args); err != nil {
+			cctx.Options.Fail(err)
+		}
+	}
+	return &s
+}
@@ -0,0 +1,188 @@
+package temporalcli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func (c *TemporalGenCompletionsCommand) run(cctx *CommandContext, args []string) error {
+	root := c.Command.Root()
+	gens := []struct {
+		file string
+		gen  func(io.Writer) error
+	}{
+		{"temporal.bash", func(w io.Writer) error { return root.GenBashCompletionV2(w, true) }},
+		{"_temporal", root.GenZshCompletion},
+		{"temporal.fish", func(w io.Writer) error { return root.GenFishCompletion(w, true) }},
+		{"temporal.ps1", root.GenPowerShellCompletionWithDesc},
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed creating directory: %w", err)
+	}
+	for _, gen := range gens {
+		var buf bytes.Buffer
+		if err := gen.gen(&buf); err != nil {
+			return fmt.Errorf("failed generating %v: %w", gen.file, err)
+		}
+		if err := writeGeneratedFile(cctx, filepath.Join(c.Dir, gen.file), buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *TemporalGenManpagesCommand) run(cctx *CommandContext, args []string) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed creating directory: %w", err)
+	}
+	var genErr error
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		// The root is not an "available" command since it does not run
+		// anything itself
+		if genErr != nil || (cmd.HasParent() && (!cmd.IsAvailableCommand() || cmd.IsAdditionalHelpTopicCommand())) {
+			return
+		}
+		var buf bytes.Buffer
+		writeManPage(&buf, cmd)
+		file := filepath.Join(c.Dir, manPageName(cmd)+".1")
+		if genErr = writeGeneratedFile(cctx, file, buf.Bytes()); genErr != nil {
+			return
+		}
+		for _, child := range cmd.Commands() {
+			visit(child)
+		}
+	}
+	// Long descriptions are highlighted when stdout is a terminal, so the pages
+	// are written from a command tree built without highlighting
+	origHighlighting := hasHighlighting
+	hasHighlighting = false
+	root := NewTemporalCommand(&CommandContext{})
+	hasHighlighting = origHighlighting
+	visit(&root.Command)
+	return genErr
+}
+
+func writeGeneratedFile(cctx *CommandContext, file string, b []byte) error {
+	if err := os.WriteFile(file, b, 0644); err != nil {
+		return fmt.Errorf("failed writing %v: %w", file, err)
+	}
+	cctx.Printer.Println(file)
+	return nil
+}
+
+// E.g. "temporal-workflow-start" for "temporal workflow start".
+func manPageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+// Writes a roff man page for the command. There is intentionally no date so
+// the output is reproducible.
+func writeManPage(w *bytes.Buffer, cmd *cobra.Command) {
+	name := manPageName(cmd)
+	fmt.Fprintf(w, ".TH %q \"1\" \"\" \"temporal %v\" \"Temporal CLI Manual\"\n", strings.ToUpper(name), Version)
+	fmt.Fprintf(w, ".SH NAME\n%v \\- %v\n", name, roffEscape(cmd.Short))
+	fmt.Fprintf(w, ".SH SYNOPSIS\n.B %v\n", roffEscape(cmd.UseLine()))
+	if cmd.HasAvailableSubCommands() {
+		fmt.Fprintf(w, ".B %v [command]\n", roffEscape(cmd.CommandPath()))
+	}
+
+	desc := cmd.Long
+	if desc == "" {
+		desc = cmd.Short
+	}
+	w.WriteString(".SH DESCRIPTION\n")
+	writeRoffText(w, desc)
+
+	writeManPageFlags(w, "OPTIONS", cmd.NonInheritedFlags())
+	writeManPageFlags(w, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags())
+
+	var seeAlso []string
+	if cmd.HasParent() {
+		seeAlso = append(seeAlso, manPageName(cmd.Parent()))
+	}
+	for _, child := range cmd.Commands() {
+		if child.IsAvailableCommand() && !child.IsAdditionalHelpTopicCommand() {
+			seeAlso = append(seeAlso, manPageName(child))
+		}
+	}
+	if len(seeAlso) > 0 {
+		w.WriteString(".SH SEE ALSO\n")
+		for i, name := range seeAlso {
+			if i > 0 {
+				w.WriteString(",\n")
+			}
+			fmt.Fprintf(w, ".BR %v (1)", name)
+		}
+		w.WriteString("\n")
+	}
+}
+
+func writeManPageFlags(w *bytes.Buffer, title string, flags *pflag.FlagSet) {
+	if !flags.HasAvailableFlags() {
+		return
+	}
+	fmt.Fprintf(w, ".SH %v\n", title)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Deprecated != "" {
+			return
+		}
+		w.WriteString(".TP\n")
+		if flag.Shorthand != "" && flag.ShorthandDeprecated == "" {
+			fmt.Fprintf(w, "\\fB\\-%v\\fR, ", flag.Shorthand)
+		}
+		fmt.Fprintf(w, "\\fB\\-\\-%v\\fR", flag.Name)
+		if typ := flag.Value.Type(); typ != "bool" {
+			fmt.Fprintf(w, " \\fI%v\\fR", roffEscape(typ))
+		}
+		w.WriteString("\n")
+		usage := flag.Usage
+		if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" && flag.DefValue != "0" &&
+			flag.DefValue != "0s" {
+			usage += fmt.Sprintf(" (default %q)", flag.DefValue)
+		}
+		writeRoffText(w, usage)
+	})
+}
+
+// Writes Markdown-ish text with paragraphs and fenced code blocks kept.
+func writeRoffText(w *bytes.Buffer, text string) {
+	inCode := false
+	for i, para := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if i > 0 && !inCode {
+			w.WriteString(".PP\n")
+		}
+		for _, line := range strings.Split(para, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				inCode = !inCode
+				if inCode {
+					w.WriteString(".nf\n.RS 4\n")
+				} else {
+					w.WriteString(".RE\n.fi\n")
+				}
+				continue
+			}
+			w.WriteString(roffEscape(line))
+			w.WriteString("\n")
+		}
+		if inCode {
+			w.WriteString("\n")
+		}
+	}
+}
+
+// Escapes backslashes and lines that would otherwise be read as requests.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
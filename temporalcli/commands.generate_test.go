@@ -0,0 +1,49 @@
+package temporalcli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGen_Manpages(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	dir := filepath.Join(t.TempDir(), "man1")
+
+	res := h.Execute("gen", "manpages", "--dir", dir)
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), filepath.Join(dir, "temporal-workflow-start.1"))
+	b, err := os.ReadFile(filepath.Join(dir, "temporal-workflow-start.1"))
+	h.NoError(err)
+	h.Contains(string(b), `.TH "TEMPORAL-WORKFLOW-START" "1"`)
+	h.Contains(string(b), `temporal-workflow-start \- Starts a new Workflow Execution.`)
+	h.Contains(string(b), `\fB\-w\fR, \fB\-\-workflow-id\fR \fIstring\fR`)
+	h.Contains(string(b), ".BR temporal-workflow (1)")
+	// Description is never highlighted for a terminal
+	h.Contains(string(b), "The `temporal workflow start` command starts")
+	h.NotContains(string(b), "\x1b")
+	h.FileExists(filepath.Join(dir, "temporal.1"))
+	h.NoFileExists(filepath.Join(dir, "temporal-help.1"))
+
+	// Output is the same every time
+	dir2 := filepath.Join(t.TempDir(), "man1")
+	h.NoError(h.Execute("gen", "manpages", "--dir", dir2).Err)
+	b2, err := os.ReadFile(filepath.Join(dir2, "temporal-workflow-start.1"))
+	h.NoError(err)
+	h.Equal(string(b), string(b2))
+}
+
+func TestGen_Completions(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	dir := t.TempDir()
+
+	res := h.Execute("gen", "completions", "--dir", dir)
+	h.NoError(res.Err)
+	for _, file := range []string{"temporal.bash", "_temporal", "temporal.fish", "temporal.ps1"} {
+		b, err := os.ReadFile(filepath.Join(dir, file))
+		h.NoError(err)
+		h.Contains(string(b), "temporal")
+	}
+}
@@ -2,24 +2,34 @@ package temporalcli
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"github.com/itchyny/gojq"
+	"github.com/klauspost/compress/zstd"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"github.com/temporalio/cli/temporalcli/internal/progress"
 	"github.com/temporalio/ui-server/v2/server/version"
 	"go.temporal.io/api/common/v1"
 	"go.temporal.io/api/failure/v1"
@@ -51,6 +61,40 @@ type CommandContext struct {
 	// Is set to true if any command actually started running. This is a hack to workaround the fact
 	// that cobra does not properly exit nonzero if an unknown command/subcommand is given.
 	ActuallyRanCommand bool
+
+	// Environment selected with "env use", empty if none
+	currentEnv string
+	// Set if the running command is annotated offline-allowed, in which case
+	// dialing a client fails
+	offline bool
+	// Set if --read-only is used, in which case mutating RPCs fail
+	readOnly bool
+	// Set if --no-progress is used
+	noProgress bool
+	// Set if --grpc-debug is used
+	grpcDebug bool
+	// Set to "junit" or "tap" if the workflow result is to be reported as a
+	// test result, in which case JSONOutput is also set to silence text output
+	testReportFormat string
+	// Redacts credentials from logs, errors, and --verbose output. Set during
+	// pre-run of the main command.
+	redactor *redactor
+
+	// Set if --output-file is used, finished at the end of Execute
+	outputFile *atomicOutputFile
+	// Set if --log-file is used, closed at the end of Execute
+	logFile *rotatingLogFile
+	// Set if --verbose is used, summary printed at the end of Execute
+	rpcRecorder *rpcRecorder
+	// Set when the first client is dialed, summary printed at the end of Execute
+	// if any requests were throttled
+	pageThrottler *pageThrottler
+	// Where each flag not given on the command line got its value from, keyed
+	// by flag name
+	flagSources map[string]string
+	// Loaded on first use from the env config's workflow-type-registry
+	typeRegistry       *workflowTypeRegistry
+	typeRegistryLoaded bool
 }
 
 type CommandOptions struct {
@@ -60,10 +104,17 @@ type CommandOptions struct {
 	EnvConfigFile string
 	// If unset, attempts to extract --env from Args (which defaults to "default")
 	EnvConfigName string
+	// If set, env config is loaded from and saved to this store instead of
+	// EnvConfigFile
+	EnvConfigStore ConfigStore
+	// Where env config values of the form "keyring:<item>" are read from and
+	// "env set --keyring" saves to. If unset, the OS keychain is used.
+	Keyring Keyring
 	// If true, does not do any env config reading
 	DisableEnvConfig bool
 	// If nil, os.LookupEnv is used. This is for environment variables and not
-	// related to env config stuff above.
+	// related to env config stuff above. Embedders can set this to provide
+	// values from somewhere other than the process environment.
 	LookupEnv func(string) (string, bool)
 
 	// These three fields below default to OS values
@@ -77,6 +128,59 @@ type CommandOptions struct {
 	AdditionalClientGRPCDialOptions []grpc.DialOption
 }
 
+// ConfigStore loads and saves env config values, keyed by env name then
+// property name. Embedders can implement this to provide env config from
+// somewhere other than a file, e.g. a secret store. Load should return nil
+// values with no error if there is no config yet.
+type ConfigStore interface {
+	LoadEnvConfig() (map[string]map[string]string, error)
+	SaveEnvConfig(map[string]map[string]string) error
+}
+
+// CurrentEnvStore can be implemented by a ConfigStore to persist the
+// environment "env use" selects for commands run without --env. Load should
+// return an empty name with no error if none is selected.
+type CurrentEnvStore interface {
+	LoadCurrentEnv() (string, error)
+	SaveCurrentEnv(string) error
+}
+
+// EnvConfigFileStore is a ConfigStore and CurrentEnvStore for a YAML env config
+// file. This is what is used by default.
+type EnvConfigFileStore struct {
+	File string
+}
+
+func (e *EnvConfigFileStore) LoadEnvConfig() (map[string]map[string]string, error) {
+	config, err := readEnvConfigFile(e.File)
+	return config.Env, err
+}
+
+func (e *EnvConfigFileStore) SaveEnvConfig(env map[string]map[string]string) error {
+	return e.update(func(config *envConfigFile) { config.Env = env })
+}
+
+func (e *EnvConfigFileStore) LoadCurrentEnv() (string, error) {
+	config, err := readEnvConfigFile(e.File)
+	return config.currentEnv(), err
+}
+
+func (e *EnvConfigFileStore) SaveCurrentEnv(name string) error {
+	return e.update(func(config *envConfigFile) { config.setCurrentEnv(name) })
+}
+
+func (e *EnvConfigFileStore) update(f func(*envConfigFile)) error {
+	if e.File == "" {
+		return fmt.Errorf("unable to find place for env file (unknown HOME dir)")
+	}
+	config, err := readEnvConfigFile(e.File)
+	if err != nil {
+		return err
+	}
+	f(&config)
+	return writeEnvConfigFile(e.File, config)
+}
+
 func NewCommandContext(ctx context.Context, options CommandOptions) (*CommandContext, context.CancelFunc, error) {
 	cctx := &CommandContext{Context: ctx, Options: options}
 	if err := cctx.preprocessOptions(); err != nil {
@@ -84,11 +188,42 @@ func NewCommandContext(ctx context.Context, options CommandOptions) (*CommandCon
 	}
 
 	// Setup interrupt handler
-	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	ctx, stop := notifyInterruptContext(ctx, cctx.Options.Stderr)
 	cctx.Context = ctx
 	return cctx, stop, nil
 }
 
+// The first interrupt cancels the context so in-flight calls stop and commands
+// can report what they have done so far. The second exits immediately.
+func notifyInterruptContext(ctx context.Context, stderr io.Writer) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	doneCh := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+		case <-doneCh:
+			return
+		}
+		fmt.Fprintln(stderr, "Interrupted, stopping (interrupt again to force quit)")
+		cancel()
+		select {
+		case <-sigCh:
+			os.Exit(130)
+		case <-doneCh:
+		}
+	}()
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(sigCh)
+			close(doneCh)
+		})
+		cancel()
+	}
+}
+
 const temporalEnv = "TEMPORAL_ENV"
 
 func (c *CommandContext) preprocessOptions() error {
@@ -109,8 +244,12 @@ func (c *CommandContext) preprocessOptions() error {
 		c.Options.Stderr = os.Stderr
 	}
 
+	if c.Options.Keyring == nil {
+		c.Options.Keyring = OSKeyring{}
+	}
+
 	if !c.Options.DisableEnvConfig {
-		if c.Options.EnvConfigFile == "" {
+		if c.Options.EnvConfigStore == nil && c.Options.EnvConfigFile == "" {
 			// Default to --env-file, prefetched from CLI args
 			for i, arg := range c.Options.Args {
 				if arg == "--env-file" && i+1 < len(c.Options.Args) {
@@ -123,8 +262,24 @@ func (c *CommandContext) preprocessOptions() error {
 			}
 		}
 
+		// Load env flags
+		if c.Options.EnvConfigStore == nil && c.Options.EnvConfigFile != "" {
+			c.Options.EnvConfigStore = &EnvConfigFileStore{File: c.Options.EnvConfigFile}
+		}
+		if c.Options.EnvConfigStore != nil {
+			var err error
+			if c.EnvConfigValues, err = c.Options.EnvConfigStore.LoadEnvConfig(); err != nil {
+				return err
+			}
+			if store, ok := c.Options.EnvConfigStore.(CurrentEnvStore); ok {
+				if c.currentEnv, err = store.LoadCurrentEnv(); err != nil {
+					return err
+				}
+			}
+		}
+
 		if c.Options.EnvConfigName == "" {
-			c.Options.EnvConfigName = "default"
+			c.Options.EnvConfigName = c.defaultEnvName()
 			if envVal, ok := c.Options.LookupEnv(temporalEnv); ok {
 				c.Options.EnvConfigName = envVal
 			}
@@ -135,14 +290,6 @@ func (c *CommandContext) preprocessOptions() error {
 				}
 			}
 		}
-
-		// Load env flags
-		if c.Options.EnvConfigFile != "" {
-			var err error
-			if c.EnvConfigValues, err = readEnvConfigFile(c.Options.EnvConfigFile); err != nil {
-				return err
-			}
-		}
 	}
 
 	// Setup default fail callback
@@ -151,7 +298,12 @@ func (c *CommandContext) preprocessOptions() error {
 			// If context is closed, say that the program was interrupted and ignore
 			// the actual error
 			if c.Err() != nil {
-				err = fmt.Errorf("program interrupted")
+				var partialErr *partialOutputError
+				if errors.As(err, &partialErr) {
+					err = fmt.Errorf("program interrupted, partial output kept in %v", partialErr.path)
+				} else {
+					err = fmt.Errorf("program interrupted")
+				}
 			}
 			if c.Logger != nil {
 				c.Logger.Error(err.Error())
@@ -161,9 +313,28 @@ func (c *CommandContext) preprocessOptions() error {
 			os.Exit(1)
 		}
 	}
+	// Errors may include credentials, e.g. a header echoed back by a server. The
+	// output file is finished first since failing may exit.
+	fail := c.Options.Fail
+	c.Options.Fail = func(err error) {
+		if c.outputFile != nil {
+			err = c.outputFile.finish(err)
+			c.outputFile = nil
+		}
+		fail(c.redactor.redactError(err))
+	}
 	return nil
 }
 
+// Returns the environment used when neither --env nor TEMPORAL_ENV is given:
+// the one selected with "env use", or "default".
+func (c *CommandContext) defaultEnvName() string {
+	if c.currentEnv != "" {
+		return c.currentEnv
+	}
+	return "default"
+}
+
 const flagEnvVarAnnotation = "__temporal_env_var"
 
 func (c *CommandContext) BindFlagEnvVar(flag *pflag.Flag, envVar string) {
@@ -175,11 +346,87 @@ func (c *CommandContext) BindFlagEnvVar(flag *pflag.Flag, envVar string) {
 }
 
 func (c *CommandContext) WriteEnvConfigToFile() error {
-	if c.Options.EnvConfigFile == "" {
-		return fmt.Errorf("unable to find place for env file (unknown HOME dir)")
+	store := c.Options.EnvConfigStore
+	if store == nil {
+		store = &EnvConfigFileStore{File: c.Options.EnvConfigFile}
+	}
+	if fileStore, ok := store.(*EnvConfigFileStore); ok {
+		c.Logger.Info("Writing env file", "file", fileStore.File)
+	}
+	return store.SaveEnvConfig(c.EnvConfigValues)
+}
+
+func (c *CommandContext) saveCurrentEnv(name string) error {
+	var store any = c.Options.EnvConfigStore
+	if store == nil {
+		store = &EnvConfigFileStore{File: c.Options.EnvConfigFile}
+	}
+	currentEnvStore, ok := store.(CurrentEnvStore)
+	if !ok {
+		return fmt.Errorf("env config store cannot persist the current environment")
+	}
+	if name == "" {
+		c.Logger.Info("Clearing current env")
+	} else {
+		c.Logger.Info("Setting current env", "env", name)
+	}
+	if err := currentEnvStore.SaveCurrentEnv(name); err != nil {
+		return err
+	}
+	c.currentEnv = name
+	return nil
+}
+
+// Env config property that, when "true", makes the environment's other values
+// have environment variables expanded.
+const expandEnvVarsProperty = "expand-env-vars"
+
+// Returns an env config value of the environment as it is used: with
+// environment variables expanded if the environment opts in, then read from
+// the keyring if it references an item there.
+func (c *CommandContext) resolveEnvConfigValue(envName, value string) (string, error) {
+	if c.EnvConfigValues[envName][expandEnvVarsProperty] == "true" {
+		var err error
+		if value, err = expandEnvVars(value, c.Options.LookupEnv); err != nil {
+			return "", err
+		}
+	}
+	return c.resolveKeyringValue(value)
+}
+
+// Expands ${VAR} and ${VAR:-default} in env config values. Only the braced
+// form is expanded so values like passwords may otherwise contain "$", and
+// "$${" is a literal "${".
+func expandEnvVars(value string, lookupEnv func(string) (string, bool)) (string, error) {
+	var sb strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			sb.WriteString(value)
+			return sb.String(), nil
+		} else if start > 0 && value[start-1] == '$' {
+			sb.WriteString(value[:start-1] + "${")
+			value = value[start+2:]
+			continue
+		}
+		end := strings.Index(value[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable in %q", value)
+		}
+		name, def, hasDef := strings.Cut(value[start+2:start+end], ":-")
+		if name == "" {
+			return "", fmt.Errorf("empty variable name in %q", value)
+		}
+		envVal, ok := lookupEnv(name)
+		if !ok || (hasDef && envVal == "") {
+			if !hasDef {
+				return "", fmt.Errorf("environment variable %v is not set", name)
+			}
+			envVal = def
+		}
+		sb.WriteString(value[:start] + envVal)
+		value = value[start+end+1:]
 	}
-	c.Logger.Info("Writing env file", "file", c.Options.EnvConfigFile)
-	return writeEnvConfigFile(c.Options.EnvConfigFile, c.EnvConfigValues)
 }
 
 func (c *CommandContext) MarshalFriendlyJSONPayloads(m *common.Payloads) (json.RawMessage, error) {
@@ -235,9 +482,75 @@ func UnmarshalProtoJSONWithOptions(b []byte, m proto.Message, jsonShorthandPaylo
 	return opts.Unmarshal(b, m)
 }
 
+// Env config property with comma-separated commands that may not be run. This
+// only guards against mistakes, it is not a security boundary.
+const disabledCommandsProperty = "advisory-disabled-commands"
+
+// Returns the disabled command entry that matches the command (which may be one
+// of its parents), or empty if the command is allowed.
+func (c *CommandContext) disabledCommand(cmd *cobra.Command) string {
+	disabled := c.EnvConfigValues[c.Options.EnvConfigName][disabledCommandsProperty]
+	if disabled == "" {
+		return ""
+	}
+	path := strings.Fields(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	for _, entry := range strings.Split(disabled, ",") {
+		entryPath := strings.Fields(entry)
+		if len(entryPath) > 0 && entryPath[0] == cmd.Root().Name() {
+			entryPath = entryPath[1:]
+		}
+		if len(entryPath) > 0 && len(entryPath) <= len(path) && slices.Equal(entryPath, path[:len(entryPath)]) {
+			return strings.Join(entryPath, " ")
+		}
+	}
+	return ""
+}
+
+// Env config properties with comma-separated search attributes and memo keys
+// that started workflows must have
+const (
+	requiredSearchAttributesProperty = "required-search-attributes"
+	requiredMemoKeysProperty         = "required-memo-keys"
+)
+
+// Fails if the search attributes or memo of a workflow being started are
+// missing any keys the env config requires.
+func (c *CommandContext) checkRequiredLabels(searchAttributes, memo map[string]any) error {
+	env := c.EnvConfigValues[c.Options.EnvConfigName]
+	var problems []string
+	for _, required := range []struct {
+		property string
+		name     string
+		option   string
+		values   map[string]any
+	}{
+		{requiredSearchAttributesProperty, "search attributes", "--search-attribute", searchAttributes},
+		{requiredMemoKeysProperty, "memo keys", "--memo", memo},
+	} {
+		var missing []string
+		for _, key := range strings.Split(env[required.property], ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				if _, ok := required.values[key]; !ok {
+					missing = append(missing, key)
+				}
+			}
+		}
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%v %v (set with %v KEY=VALUE)",
+				required.name, strings.Join(missing, ", "), required.option))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("environment %q requires workflows to have %v",
+			c.Options.EnvConfigName, strings.Join(problems, " and "))
+	}
+	return nil
+}
+
 // Set flag values from environment file & variables. Returns a callback to log anything interesting
 // since logging will not yet be initialized when this runs.
-func (c *CommandContext) populateFlagsFromEnv(flags *pflag.FlagSet) (func(*slog.Logger), error) {
+// Flags for which skip returns true are left alone.
+func (c *CommandContext) populateFlagsFromEnv(flags *pflag.FlagSet, skip func(*pflag.Flag) bool) (func(*slog.Logger), error) {
 	if flags == nil {
 		return func(logger *slog.Logger) {}, nil
 	}
@@ -245,16 +558,23 @@ func (c *CommandContext) populateFlagsFromEnv(flags *pflag.FlagSet) (func(*slog.
 	var flagErr error
 	flags.VisitAll(func(flag *pflag.Flag) {
 		// If the flag was already changed by the user, we don't overwrite
-		if flagErr != nil || flag.Changed {
+		if flagErr != nil || flag.Changed || skip(flag) {
 			return
 		}
 		// Env config first, then environ
 		if v, ok := c.EnvConfigValues[c.Options.EnvConfigName][flag.Name]; ok {
-			if err := flag.Value.Set(v); err != nil {
+			resolved, err := c.resolveEnvConfigValue(c.Options.EnvConfigName, v)
+			if err != nil {
+				flagErr = fmt.Errorf("failed getting flag %v from config: %w", flag.Name, err)
+				return
+			}
+			// The unresolved value is reported so keyring secrets are not shown
+			if err := flag.Value.Set(resolved); err != nil {
 				flagErr = fmt.Errorf("failed setting flag %v from config with value %v: %w", flag.Name, v, err)
 				return
 			}
 			flag.Changed = true
+			c.setFlagSource(flag.Name, fmt.Sprintf("env config %q", c.Options.EnvConfigName))
 		}
 		if anns := flag.Annotations[flagEnvVarAnnotation]; len(anns) == 1 {
 			if envVal, ok := c.Options.LookupEnv(anns[0]); ok {
@@ -269,6 +589,7 @@ func (c *CommandContext) populateFlagsFromEnv(flags *pflag.FlagSet) (func(*slog.
 					})
 				}
 				flag.Changed = true
+				c.setFlagSource(flag.Name, "env var "+anns[0])
 			}
 		}
 	})
@@ -280,6 +601,107 @@ func (c *CommandContext) populateFlagsFromEnv(flags *pflag.FlagSet) (func(*slog.
 	return logFn, flagErr
 }
 
+const commandDefaultsEnvVar = "TEMPORAL_COMMAND_DEFAULTS"
+
+// Set flag values not otherwise set from the command-specific defaults env var
+// (e.g. TEMPORAL_COMMAND_DEFAULTS_WORKFLOW_LIST) and then the defaults env var
+// for all commands. Unknown flags are only an error in the command-specific one.
+func (c *CommandContext) populateFlagsFromCommandDefaults(cmd *cobra.Command) error {
+	envVars := []string{commandDefaultsEnvVar}
+	if _, path, ok := strings.Cut(cmd.CommandPath(), " "); ok {
+		envVars = slices.Insert(envVars, 0, commandDefaultsEnvVar+"_"+
+			strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_").Replace(path)))
+	}
+	for i, envVar := range envVars {
+		if v, ok := c.Options.LookupEnv(envVar); ok {
+			var unchanged []*pflag.Flag
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if !flag.Changed {
+					unchanged = append(unchanged, flag)
+				}
+			})
+			ignoreUnknown := i == len(envVars)-1
+			if err := setDefaultFlags(cmd.Flags(), strings.Fields(v), ignoreUnknown); err != nil {
+				return fmt.Errorf("invalid %v: %w", envVar, err)
+			}
+			for _, flag := range unchanged {
+				if flag.Changed {
+					c.setFlagSource(flag.Name, "env var "+envVar)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *CommandContext) setFlagSource(name, source string) {
+	if c.flagSources == nil {
+		c.flagSources = map[string]string{}
+	}
+	c.flagSources[name] = source
+}
+
+// Returns where the flag's value came from: the command line, an env config
+// or env var, or the default.
+func (c *CommandContext) flagSource(flag *pflag.Flag) string {
+	if source, ok := c.flagSources[flag.Name]; ok {
+		return source
+	} else if flag.Changed {
+		return "flag"
+	}
+	return "default"
+}
+
+// Sets each flag in args that has not been changed. Flags can be given as
+// --name=value, --name value, or -n value, and bool flags may omit the value.
+func setDefaultFlags(flags *pflag.FlagSet, args []string, ignoreUnknown bool) error {
+	// Flags set here may be repeated, e.g. for string slices
+	setHere := map[string]bool{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		var flag *pflag.Flag
+		var value string
+		var hasValue bool
+		if name, ok := strings.CutPrefix(arg, "--"); ok {
+			name, value, hasValue = strings.Cut(name, "=")
+			flag = flags.Lookup(name)
+		} else if len(arg) == 2 && arg[0] == '-' {
+			flag = flags.ShorthandLookup(arg[1:])
+		} else {
+			return fmt.Errorf("expected flag, got %q", arg)
+		}
+		if flag == nil {
+			if !ignoreUnknown {
+				return fmt.Errorf("unknown flag %q", arg)
+			}
+			// Skip what looks like its value
+			if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+			}
+			continue
+		}
+		if !hasValue {
+			if flag.NoOptDefVal != "" {
+				value = flag.NoOptDefVal
+			} else if i+1 < len(args) {
+				i++
+				value = args[i]
+			} else {
+				return fmt.Errorf("missing value for flag %q", arg)
+			}
+		}
+		if flag.Changed && !setHere[flag.Name] {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("failed setting flag %v with value %v: %w", flag.Name, value, err)
+		}
+		flag.Changed = true
+		setHere[flag.Name] = true
+	}
+	return nil
+}
+
 // Returns error if JSON output enabled
 func (c *CommandContext) promptYes(message string, autoConfirm bool) (bool, error) {
 	if c.JSONOutput && !autoConfirm {
@@ -321,11 +743,34 @@ func Execute(ctx context.Context, options CommandOptions) {
 		cmd := NewTemporalCommand(cctx)
 		cmd.Command.SetArgs(cctx.Options.Args)
 		err = cmd.Command.ExecuteContext(cctx)
+		if err == nil && cctx.Printer != nil {
+			err = cctx.Printer.FieldsErr()
+		}
+		// Closed after any failure is logged
+		if cctx.logFile != nil {
+			defer cctx.logFile.Close()
+		}
+		if cctx.outputFile != nil {
+			err = cctx.outputFile.finish(err)
+			cctx.outputFile = nil
+		}
+		if cctx.pageThrottler != nil {
+			if summaryErr := cctx.pageThrottler.printSummary(cctx.Options.Stderr); summaryErr != nil && err == nil {
+				err = fmt.Errorf("failed printing throttling summary: %w", summaryErr)
+			}
+		}
+		if cctx.rpcRecorder != nil {
+			summaryOut := redactWriter{w: cctx.Options.Stderr, r: cctx.redactor}
+			if summaryErr := cctx.rpcRecorder.printSummary(summaryOut); summaryErr != nil && err == nil {
+				err = fmt.Errorf("failed printing RPC summary: %w", summaryErr)
+			}
+		}
 	}
 
 	// Use failure handler, but can still return
 	if err != nil {
 		cctx.Options.Fail(err)
+		return
 	}
 
 	// If no command ever actually got run, exit nonzero with an error.  This is
@@ -348,18 +793,50 @@ func Execute(ctx context.Context, options CommandOptions) {
 	}
 }
 
+// Value of --output, which also accepts "go-template=" followed by any
+// template in place of the documented "go-template=TEMPLATE".
+type outputFlagValue struct{ *StringEnum }
+
+func (o outputFlagValue) Set(p string) error {
+	if strings.HasPrefix(p, "go-template=") {
+		o.Value = p
+		o.ChangedFromDefault = true
+		return nil
+	}
+	return o.StringEnum.Set(p)
+}
+
 func (c *TemporalCommand) initCommand(cctx *CommandContext) {
 	c.Command.Version = fmt.Sprintf("%s (server %s) (ui %s)", Version, headers.ServerVersion, version.UIVersion)
+	c.Command.PersistentFlags().Lookup("output").Value = outputFlagValue{&c.Output}
 	// Unfortunately color is a global option, so we can set in pre-run but we
 	// must unset in post-run
 	origNoColor := color.NoColor
 	c.Command.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// Offline commands never take client connection settings (i.e. flags
+		// inherited from a non-root parent) from the environment, since those
+		// are irrelevant and may not even be valid where the command is run
+		_, cctx.offline = cmd.Annotations["offlineAllowed"]
+		skipFlag := func(flag *pflag.Flag) bool {
+			return cctx.offline && cmd.LocalFlags().Lookup(flag.Name) == nil &&
+				c.Command.PersistentFlags().Lookup(flag.Name) == nil
+		}
+
 		// Populate environ. We will make the error return here which will cause
 		// usage to be printed.
-		logCalls, err := cctx.populateFlagsFromEnv(cmd.Flags())
+		logCalls, err := cctx.populateFlagsFromEnv(cmd.Flags(), skipFlag)
 		if err != nil {
 			return err
 		}
+		// The environment selected with "env use" is the default for --env
+		if !c.Command.PersistentFlags().Changed("env") {
+			c.Env = cctx.defaultEnvName()
+		}
+		if err := cctx.populateFlagsFromCommandDefaults(cmd); err != nil {
+			return err
+		} else if err := cctx.populateTaskQueueFromRegistry(cmd.Flags()); err != nil {
+			return err
+		}
 
 		// Default color.NoColor global is equivalent to "auto" so only override if
 		// never or always
@@ -368,6 +845,20 @@ func (c *TemporalCommand) initCommand(cctx *CommandContext) {
 		}
 
 		res := c.preRun(cctx)
+		if res == nil {
+			cctx.redactor.addFlagSecrets(cmd.Flags())
+		}
+		if res == nil && commandOnlyOutputFormats[c.Output.Value] && !supportsOutputFormat(cmd, c.Output.Value) {
+			res = fmt.Errorf("%v output is only supported by %v", c.Output.Value,
+				strings.Join(commandsSupportingOutputFormat(cmd.Root(), c.Output.Value), " and "))
+		}
+		// Options come from the edited document with --edit, so the command
+		// checks what it still needs itself
+		if edit := cmd.Flags().Lookup("edit"); edit != nil && edit.Changed {
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				delete(flag.Annotations, cobra.BashCompOneRequiredFlag)
+			})
+		}
 
 		logCalls(cctx.Logger)
 
@@ -387,6 +878,10 @@ func (c *TemporalCommand) initCommand(cctx *CommandContext) {
 				}
 			}
 		}
+		if disabled := cctx.disabledCommand(cmd); disabled != "" {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("command %q is disabled for environment %q", disabled, cctx.Options.EnvConfigName)
+		}
 		return res
 	}
 	c.Command.PersistentPostRun = func(*cobra.Command, []string) {
@@ -395,41 +890,84 @@ func (c *TemporalCommand) initCommand(cctx *CommandContext) {
 }
 
 func (c *TemporalCommand) preRun(cctx *CommandContext) error {
+	cctx.redactor = &redactor{disabled: c.NoRedact}
 	// Configure logger if not already on context
 	if cctx.Logger == nil {
-		// If level is never, make noop logger
-		if c.LogLevel.Value == "never" {
-			cctx.Logger = newNopLogger()
-		} else {
+		var handlers multiLogHandler
+		// If level is never, console logging is off
+		if c.LogLevel.Value != "never" {
 			var level slog.Level
 			if err := level.UnmarshalText([]byte(c.LogLevel.Value)); err != nil {
 				return fmt.Errorf("invalid log level %q: %w", c.LogLevel.Value, err)
 			}
-			var handler slog.Handler
-			switch c.LogFormat {
-			// We have a "pretty" alias for compatibility
-			case "", "text", "pretty":
-				handler = slog.NewTextHandler(cctx.Options.Stderr, &slog.HandlerOptions{
-					Level: level,
-					// Remove the TZ
-					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-						if a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
-							a.Value = slog.StringValue(a.Value.Time().Format("2006-01-02T15:04:05.000"))
-						}
-						return a
-					},
-				})
-			case "json":
-				handler = slog.NewJSONHandler(cctx.Options.Stderr, &slog.HandlerOptions{Level: level})
-			default:
-				return fmt.Errorf("invalid log format %q", c.LogFormat)
+			handler, err := newLogHandler(c.LogFormat, cctx.Options.Stderr, level)
+			if err != nil {
+				return err
+			}
+			handlers = append(handlers, handler)
+		}
+		// The log file has its own level, independent of the console
+		if c.LogFile != "" {
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(c.LogFileLevel.Value)); err != nil {
+				return fmt.Errorf("invalid log file level %q: %w", c.LogFileLevel.Value, err)
+			}
+			maxSize, err := humanize.ParseBytes(c.LogFileMaxSize)
+			if err != nil {
+				return fmt.Errorf("invalid log file max size %q: %w", c.LogFileMaxSize, err)
+			}
+			if cctx.logFile, err = openRotatingLogFile(c.LogFile, int64(maxSize)); err != nil {
+				return err
+			}
+			handler, err := newLogHandler(c.LogFormat, cctx.logFile, level)
+			if err != nil {
+				return err
 			}
-			cctx.Logger = slog.New(handler)
+			handlers = append(handlers, handler)
+		}
+		switch len(handlers) {
+		case 0:
+			cctx.Logger = newNopLogger()
+		case 1:
+			cctx.Logger = slog.New(redactLogHandler{Handler: handlers[0], r: cctx.redactor})
+		default:
+			cctx.Logger = slog.New(redactLogHandler{Handler: handlers, r: cctx.redactor})
 		}
 	}
 
 	// Configure printer if not already on context
-	cctx.JSONOutput = c.Output.Value == "json" || c.Output.Value == "jsonl"
+	// The go-template format is JSON output rendered through a template
+	var outputTemplate *template.Template
+	if text, ok := strings.CutPrefix(c.Output.Value, "go-template="); ok {
+		var err error
+		if outputTemplate, err = template.New("output").Parse(text); err != nil {
+			return fmt.Errorf("invalid go-template: %w", err)
+		}
+	}
+	// A jq expression filters JSON output, which it implies if no output is given
+	var outputJQ *gojq.Code
+	if c.Jq != "" {
+		if c.Output.ChangedFromDefault && c.Output.Value != "json" && c.Output.Value != "jsonl" {
+			return fmt.Errorf("--jq can only be used with json or jsonl output")
+		} else if !c.Output.ChangedFromDefault {
+			c.Output.Value = "json"
+		}
+		query, err := gojq.Parse(c.Jq)
+		if err != nil {
+			return fmt.Errorf("invalid jq expression: %w", err)
+		}
+		if outputJQ, err = gojq.Compile(query); err != nil {
+			return fmt.Errorf("invalid jq expression: %w", err)
+		}
+	}
+	if c.Output.Value == "junit" || c.Output.Value == "tap" {
+		cctx.testReportFormat = c.Output.Value
+	}
+	cctx.JSONOutput = c.Output.Value == "json" || c.Output.Value == "jsonl" || c.Output.Value == "yaml" ||
+		outputTemplate != nil || cctx.testReportFormat != ""
+	if len(c.Fields) > 0 && cctx.JSONOutput {
+		return fmt.Errorf("--fields can only be used with text, csv, or markdown output")
+	}
 	// Only indent JSON if not jsonl
 	var jsonIndent string
 	if c.Output.Value == "json" {
@@ -440,12 +978,43 @@ func (c *TemporalCommand) preRun(cctx *CommandContext) error {
 		// Disable printer by making writer noop if "none" chosen
 		if c.Output.Value == "none" {
 			printerOutput = nopWriter{}
+		} else if c.OutputFile != "" {
+			var err error
+			cctx.outputFile, err = newAtomicOutputFile(c.OutputFile, c.Append, c.Compress.Value)
+			if err != nil {
+				return fmt.Errorf("failed opening output file: %w", err)
+			}
+			printerOutput = cctx.outputFile
+		} else if c.Append {
+			return fmt.Errorf("cannot use --append without --output-file")
+		} else if c.Compress.Value != "none" {
+			return fmt.Errorf("cannot use --compress without --output-file")
 		}
 		cctx.Printer = &printer.Printer{
 			Output:               printerOutput,
 			JSON:                 cctx.JSONOutput,
 			JSONIndent:           jsonIndent,
 			JSONPayloadShorthand: !c.NoJsonShorthandPayloads,
+			RawEnums:             c.RawEnums,
+			Template:             outputTemplate,
+			JQ:                   outputJQ,
+			YAML:                 c.Output.Value == "yaml",
+			CSV:                  c.Output.Value == "csv",
+			Markdown:             c.Output.Value == "markdown",
+		}
+		for _, fields := range c.Fields {
+			// Env values are a single comma-separated string
+			for _, field := range strings.Split(fields, ",") {
+				cctx.Printer.Fields = append(cctx.Printer.Fields, strings.TrimSpace(field))
+			}
+		}
+		// CSV is read by other tools and Markdown is pasted into documents, so
+		// times are absolute unless asked otherwise and colors are never used
+		if cctx.Printer.CSV || cctx.Printer.Markdown {
+			if !c.TimeFormat.ChangedFromDefault {
+				c.TimeFormat.Value = "iso"
+			}
+			color.NoColor = true
 		}
 		switch c.TimeFormat.Value {
 		case "iso":
@@ -459,9 +1028,28 @@ func (c *TemporalCommand) preRun(cctx *CommandContext) error {
 		}
 	}
 	cctx.JSONShorthandPayloads = !c.NoJsonShorthandPayloads
+	if c.Verbose && cctx.rpcRecorder == nil {
+		cctx.rpcRecorder = newRPCRecorder()
+	}
+	cctx.readOnly = c.ReadOnly
+	cctx.noProgress = c.NoProgress
+	cctx.grpcDebug = c.GrpcDebug
 	return nil
 }
 
+// Starts a progress bar on stderr for a long-running operation. Returns nil,
+// which is safe to use, if progress is disabled or stderr is not a terminal.
+// Callers should not print to stdout until the bar is finished, since it is
+// likely the same terminal.
+func (c *CommandContext) startProgress(label string, total int64) *progress.Bar {
+	if c.noProgress {
+		return nil
+	} else if f, ok := c.Options.Stderr.(*os.File); !ok || !isatty.IsTerminal(f.Fd()) {
+		return nil
+	}
+	return progress.New(c.Options.Stderr, label, total)
+}
+
 // May be empty result if can't get user home dir
 func defaultEnvConfigFile(appName, configName string) string {
 	// No env file if no $HOME
@@ -471,22 +1059,41 @@ func defaultEnvConfigFile(appName, configName string) string {
 	return ""
 }
 
-func readEnvConfigFile(file string) (env map[string]map[string]string, err error) {
+// Every top-level value is a map of maps, which is how older CLIs read the
+// file, so that they can still read files that have a current env
+type envConfigFile struct {
+	Env map[string]map[string]string `yaml:"env"`
+	// Current env name is under "env" -> "name"
+	Current map[string]map[string]string `yaml:"current,omitempty"`
+}
+
+func (e *envConfigFile) currentEnv() string {
+	return e.Current["env"]["name"]
+}
+
+func (e *envConfigFile) setCurrentEnv(name string) {
+	if name == "" {
+		e.Current = nil
+	} else {
+		e.Current = map[string]map[string]string{"env": {"name": name}}
+	}
+}
+
+func readEnvConfigFile(file string) (config envConfigFile, err error) {
 	b, err := os.ReadFile(file)
 	if os.IsNotExist(err) {
-		return nil, nil
+		return envConfigFile{}, nil
 	} else if err != nil {
-		return nil, fmt.Errorf("failed reading env file: %w", err)
+		return envConfigFile{}, fmt.Errorf("failed reading env file: %w", err)
 	}
-	var m map[string]map[string]map[string]string
-	if err := yaml.Unmarshal(b, &m); err != nil {
-		return nil, fmt.Errorf("failed unmarshalling env YAML: %w", err)
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return envConfigFile{}, fmt.Errorf("failed unmarshalling env YAML: %w", err)
 	}
-	return m["env"], nil
+	return config, nil
 }
 
-func writeEnvConfigFile(file string, env map[string]map[string]string) error {
-	b, err := yaml.Marshal(map[string]any{"env": env})
+func writeEnvConfigFile(file string, config envConfigFile) error {
+	b, err := yaml.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("failed marshaling YAML: %w", err)
 	}
@@ -508,6 +1115,24 @@ func aliasNormalizer(aliases map[string]string) func(f *pflag.FlagSet, name stri
 	}
 }
 
+// Output formats only some commands support. Those commands list them in their
+// outputFormats annotation.
+var commandOnlyOutputFormats = map[string]bool{"timeline": true, "junit": true, "tap": true}
+
+func supportsOutputFormat(cmd *cobra.Command, format string) bool {
+	return slices.Contains(strings.Split(cmd.Annotations["outputFormats"], ","), format)
+}
+
+func commandsSupportingOutputFormat(cmd *cobra.Command, format string) (names []string) {
+	if supportsOutputFormat(cmd, format) {
+		names = append(names, strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+	}
+	for _, sub := range cmd.Commands() {
+		names = append(names, commandsSupportingOutputFormat(sub, format)...)
+	}
+	return names
+}
+
 func newNopLogger() *slog.Logger { return slog.New(discardLogHandler{}) }
 
 type discardLogHandler struct{}
@@ -527,3 +1152,158 @@ func timestampToTime(t *timestamppb.Timestamp) time.Time {
 type nopWriter struct{}
 
 func (nopWriter) Write(b []byte) (int, error) { return len(b), nil }
+
+// Writer for --output-file that writes to a temporary file alongside the
+// target and only renames it into place on success, so interrupted or failed
+// commands never leave a partially written output file. Whatever they did
+// write is kept next to it with a .partial suffix instead. When compressed,
+// appended output is a new gzip member or zstd frame, which readers of either
+// format read as one stream.
+type atomicOutputFile struct {
+	path       string
+	tmp        *os.File
+	compressor io.WriteCloser
+	written    bool
+}
+
+func newAtomicOutputFile(path string, appendExisting bool, compress string) (*atomicOutputFile, error) {
+	// Keep existing mode if the file is there, otherwise use a typical default
+	mode := os.FileMode(0o644)
+	existing, err := os.Open(path)
+	if err == nil {
+		defer existing.Close()
+		if stat, err := existing.Stat(); err != nil {
+			return nil, err
+		} else if stat.IsDir() {
+			return nil, fmt.Errorf("%v is a directory", path)
+		} else {
+			mode = stat.Mode().Perm()
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	f := &atomicOutputFile{path: path, tmp: tmp}
+	if err := tmp.Chmod(mode); err != nil {
+		f.discard()
+		return nil, err
+	}
+	if appendExisting && existing != nil {
+		if _, err := io.Copy(tmp, existing); err != nil {
+			f.discard()
+			return nil, err
+		}
+	}
+	switch compress {
+	case "gzip":
+		f.compressor = gzip.NewWriter(tmp)
+	case "zstd":
+		if f.compressor, err = zstd.NewWriter(tmp); err != nil {
+			f.discard()
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (f *atomicOutputFile) Write(b []byte) (int, error) {
+	f.written = f.written || len(b) > 0
+	if f.compressor != nil {
+		return f.compressor.Write(b)
+	}
+	return f.tmp.Write(b)
+}
+
+// Moves the file into place if cmdErr is nil. Otherwise, output written before
+// the failure is moved to the .partial file, and the returned error says where
+// it is. Returns cmdErr or any error finishing the file.
+func (f *atomicOutputFile) finish(cmdErr error) error {
+	if cmdErr != nil {
+		if !f.written {
+			f.discard()
+			return cmdErr
+		}
+		partialPath := f.path + ".partial"
+		if err := f.moveTo(partialPath); err != nil {
+			return fmt.Errorf("%w (failed keeping partial output: %v)", cmdErr, err)
+		}
+		return &partialOutputError{err: cmdErr, path: partialPath}
+	}
+	if err := f.moveTo(f.path); err != nil {
+		return fmt.Errorf("failed writing output file: %w", err)
+	}
+	return nil
+}
+
+type partialOutputError struct {
+	err  error
+	path string
+}
+
+func (e *partialOutputError) Error() string {
+	return fmt.Sprintf("%v (partial output kept in %v)", e.err, e.path)
+}
+
+func (e *partialOutputError) Unwrap() error { return e.err }
+
+func (f *atomicOutputFile) moveTo(path string) error {
+	if f.compressor != nil {
+		if err := f.compressor.Close(); err != nil {
+			f.discard()
+			return err
+		}
+	}
+	if err := f.tmp.Close(); err != nil {
+		_ = os.Remove(f.tmp.Name())
+		return err
+	}
+	if err := os.Rename(f.tmp.Name(), path); err != nil {
+		_ = os.Remove(f.tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func (f *atomicOutputFile) discard() {
+	_ = f.tmp.Close()
+	_ = os.Remove(f.tmp.Name())
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Reads the file, decompressing it if it is gzip or zstd compressed.
+func readFileDecompressed(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader
+	switch {
+	case bytes.HasPrefix(b, gzipMagic):
+		gz, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("failed decompressing %v: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	case bytes.HasPrefix(b, zstdMagic):
+		zr, err := zstd.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("failed decompressing %v: %w", path, err)
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return b, nil
+	}
+	if b, err = io.ReadAll(r); err != nil {
+		return nil, fmt.Errorf("failed decompressing %v: %w", path, err)
+	}
+	return b, nil
+}
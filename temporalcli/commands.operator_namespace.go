@@ -1,15 +1,28 @@
 package temporalcli
 
 import (
+	"cmp"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"github.com/temporalio/cli/temporalcli/internal/progress"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/namespace/v1"
 	"go.temporal.io/api/operatorservice/v1"
 	"go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -79,6 +92,18 @@ func (c *TemporalOperatorNamespaceDeleteCommand) run(cctx *CommandContext, args
 		return err
 	}
 
+	cl, err := c.Parent.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	if c.CheckEmpty {
+		if err := checkNamespaceEmpty(cctx, cl, nsName); err != nil {
+			return err
+		}
+	}
+
 	yes, err := cctx.promptString(
 		color.RedString("Are you sure you want to delete namespace %s? Type namespace name to confirm:", nsName),
 		nsName,
@@ -91,12 +116,6 @@ func (c *TemporalOperatorNamespaceDeleteCommand) run(cctx *CommandContext, args
 		return fmt.Errorf("user denied confirmation or mistyped the namespace name")
 	}
 
-	cl, err := c.Parent.Parent.ClientOptions.dialClient(cctx)
-	if err != nil {
-		return err
-	}
-	defer cl.Close()
-
 	resp, err := cl.OperatorService().DeleteNamespace(cctx, &operatorservice.DeleteNamespaceRequest{
 		Namespace: nsName,
 	})
@@ -104,6 +123,12 @@ func (c *TemporalOperatorNamespaceDeleteCommand) run(cctx *CommandContext, args
 		return fmt.Errorf("unable to delete namespace %s: %w", nsName, err)
 	}
 
+	if c.Wait {
+		if err := waitNamespaceDeleted(cctx, cl, resp.DeletedNamespace); err != nil {
+			return err
+		}
+	}
+
 	if cctx.JSONOutput {
 		return cctx.Printer.PrintStructured(resp, printer.StructuredOptions{})
 	}
@@ -111,6 +136,65 @@ func (c *TemporalOperatorNamespaceDeleteCommand) run(cctx *CommandContext, args
 	return nil
 }
 
+func checkNamespaceEmpty(cctx *CommandContext, cl client.Client, nsName string) error {
+	countResp, err := cl.WorkflowService().CountWorkflowExecutions(cctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: nsName,
+		Query:     "ExecutionStatus = 'Running'",
+	})
+	if err != nil {
+		return fmt.Errorf("failed counting open workflows: %w", err)
+	}
+	schedules, err := countSchedules(cctx, cl, nsName)
+	if err != nil {
+		return fmt.Errorf("failed listing schedules: %w", err)
+	}
+	if countResp.Count > 0 || schedules > 0 {
+		return fmt.Errorf("namespace %s is not empty: %v open workflow(s) and %v schedule(s)",
+			nsName, countResp.Count, schedules)
+	}
+	return nil
+}
+
+// The server renames a deleted namespace and removes its data in the
+// background, dropping the renamed namespace once done.
+func waitNamespaceDeleted(cctx *CommandContext, cl client.Client, deletedName string) error {
+	// Progress is shown as a bar if possible, otherwise as a line per check. The
+	// first count is the total.
+	bar := cctx.startProgress("Removing namespace data", 0)
+	defer bar.Finish()
+	var total int64 = -1
+	for {
+		_, err := cl.WorkflowService().DescribeNamespace(cctx, &workflowservice.DescribeNamespaceRequest{
+			Namespace: deletedName,
+		})
+		var notFound *serviceerror.NamespaceNotFound
+		if errors.As(err, &notFound) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed checking deletion progress: %w", err)
+		}
+		// Remaining count is best effort since visibility may be gone already
+		countResp, err := cl.WorkflowService().CountWorkflowExecutions(cctx, &workflowservice.CountWorkflowExecutionsRequest{
+			Namespace: deletedName,
+		})
+		if err == nil && bar != nil {
+			if total < 0 {
+				total = countResp.Count
+			}
+			bar.Set(total-min(countResp.Count, total), total)
+		} else if err == nil {
+			cctx.Printer.Printlnf("Removing namespace data, %v workflow(s) remaining...", countResp.Count)
+		} else if bar == nil {
+			cctx.Printer.Println("Removing namespace data...")
+		}
+		select {
+		case <-cctx.Done():
+			return fmt.Errorf("interrupted waiting for deletion of %v to complete", deletedName)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
 func (c *TemporalOperatorNamespaceDescribeCommand) run(cctx *CommandContext, args []string) error {
 	nsID := c.NamespaceId
 
@@ -160,6 +244,27 @@ func (c *TemporalOperatorNamespaceListCommand) run(cctx *CommandContext, args []
 	if err != nil {
 		return err
 	}
+	defer cl.Close()
+
+	// Parse filters up front so bad values fail before listing
+	var nameRegex *regexp.Regexp
+	if c.NameRegex != "" {
+		if nameRegex, err = regexp.Compile(c.NameRegex); err != nil {
+			return fmt.Errorf("invalid name regex: %w", err)
+		}
+	}
+	var states []enums.NamespaceState
+	for _, v := range c.State {
+		state, err := stringToProtoEnum[enums.NamespaceState](
+			v, enums.NamespaceState_shorthandValue, enums.NamespaceState_value)
+		if err != nil {
+			return fmt.Errorf("invalid state: %w", err)
+		}
+		states = append(states, state)
+	}
+	if c.Stats && c.StatsConcurrency < 1 {
+		return fmt.Errorf("stats concurrency must be at least 1")
+	}
 
 	// This is a listing command subject to json vs jsonl rules
 	cctx.Printer.StartList()
@@ -175,14 +280,46 @@ func (c *TemporalOperatorNamespaceListCommand) run(cctx *CommandContext, args []
 			return fmt.Errorf("failed listing namespaces: %w", err)
 		}
 
-		for _, ns := range resp.GetNamespaces() {
-			if cctx.JSONOutput {
-				_ = cctx.Printer.PrintStructured(ns, printer.StructuredOptions{})
+		namespaces := make([]*workflowservice.DescribeNamespaceResponse, 0, len(resp.Namespaces))
+		for _, ns := range resp.Namespaces {
+			if nameRegex != nil && !nameRegex.MatchString(ns.NamespaceInfo.GetName()) {
+				continue
+			} else if len(states) > 0 && !slices.Contains(states, ns.NamespaceInfo.GetState()) {
+				continue
+			} else if c.Scope.Value == "global" && !ns.IsGlobalNamespace {
+				continue
+			} else if c.Scope.Value == "local" && ns.IsGlobalNamespace {
+				continue
+			}
+			namespaces = append(namespaces, ns)
+		}
+
+		var stats []*namespaceStats
+		if c.Stats {
+			if stats, err = gatherNamespaceStats(cctx, cl, namespaces, c.StatsConcurrency); err != nil {
+				return err
 			}
 		}
 
-		if !cctx.JSONOutput {
-			_ = printNamespaceDescriptions(cctx, resp.Namespaces...)
+		if cctx.JSONOutput {
+			for i, ns := range namespaces {
+				if stats == nil {
+					_ = cctx.Printer.PrintStructured(ns, printer.StructuredOptions{})
+					continue
+				}
+				// Inject the stats into the namespace object
+				var nsObj map[string]any
+				if b, err := cctx.MarshalProtoJSON(ns); err != nil {
+					return fmt.Errorf("failed marshaling namespace: %w", err)
+				} else if err := json.Unmarshal(b, &nsObj); err != nil {
+					return fmt.Errorf("failed unmarshaling: %w", err)
+				}
+				nsObj["openWorkflowCount"] = stats[i].OpenWorkflows
+				nsObj["scheduleCount"] = stats[i].Schedules
+				_ = cctx.Printer.PrintStructured(nsObj, printer.StructuredOptions{})
+			}
+		} else if len(namespaces) > 0 {
+			_ = printNamespaceDescriptionsWithStats(cctx, namespaces, stats)
 		}
 
 		nextPageToken = resp.GetNextPageToken()
@@ -192,31 +329,498 @@ func (c *TemporalOperatorNamespaceListCommand) run(cctx *CommandContext, args []
 	}
 }
 
+type namespaceStats struct {
+	OpenWorkflows int64
+	Schedules     int
+}
+
+// Gathers stats for each namespace with at most the given number at once.
+// Result is in the same order as the namespaces.
+func gatherNamespaceStats(
+	cctx *CommandContext,
+	cl client.Client,
+	namespaces []*workflowservice.DescribeNamespaceResponse,
+	concurrency int,
+) ([]*namespaceStats, error) {
+	stats := make([]*namespaceStats, len(namespaces))
+	errs := make([]error, len(namespaces))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ns := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, nsName string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			countResp, err := cl.WorkflowService().CountWorkflowExecutions(cctx, &workflowservice.CountWorkflowExecutionsRequest{
+				Namespace: nsName,
+				Query:     "ExecutionStatus = 'Running'",
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("failed counting open workflows in %v: %w", nsName, err)
+				return
+			}
+			schedules, err := countSchedules(cctx, cl, nsName)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed counting schedules in %v: %w", nsName, err)
+				return
+			}
+			stats[i] = &namespaceStats{OpenWorkflows: countResp.Count, Schedules: schedules}
+		}(i, ns.NamespaceInfo.GetName())
+	}
+	wg.Wait()
+	return stats, errors.Join(errs...)
+}
+
+func countSchedules(cctx *CommandContext, cl client.Client, nsName string) (int, error) {
+	var schedules int
+	var token []byte
+	for {
+		listResp, err := cl.WorkflowService().ListSchedules(cctx, &workflowservice.ListSchedulesRequest{
+			Namespace:     nsName,
+			NextPageToken: token,
+		})
+		if err != nil {
+			return 0, err
+		}
+		schedules += len(listResp.Schedules)
+		if token = listResp.NextPageToken; len(token) == 0 {
+			return schedules, nil
+		}
+	}
+}
+
+func (c *TemporalOperatorNamespaceStatsCommand) run(cctx *CommandContext, args []string) error {
+	nsName, err := c.Parent.Parent.getNSFromFlagOrArg0(cctx, args)
+	if err != nil {
+		return err
+	} else if c.Sample < 1 {
+		return fmt.Errorf("sample must be at least 1")
+	} else if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	cl, err := c.Parent.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	// Collect the sample, most recent first per visibility ordering
+	query := "ExecutionStatus != 'Running'"
+	if c.Query != "" {
+		query += " AND (" + c.Query + ")"
+	}
+	var execs []*workflow.WorkflowExecutionInfo
+	var nextPageToken []byte
+	for len(execs) < c.Sample {
+		resp, err := cl.WorkflowService().ListWorkflowExecutions(cctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     nsName,
+			PageSize:      int32(min(c.Sample-len(execs), 1000)),
+			NextPageToken: nextPageToken,
+			Query:         query,
+		})
+		if err != nil {
+			return fmt.Errorf("failed listing workflows: %w", err)
+		}
+		execs = append(execs, resp.Executions...)
+		if nextPageToken = resp.NextPageToken; len(nextPageToken) == 0 {
+			break
+		}
+	}
+	if len(execs) > c.Sample {
+		execs = execs[:c.Sample]
+	}
+
+	bar := cctx.startProgress("Fetching histories", int64(len(execs)))
+	samples, err := gatherWorkflowHistorySamples(cctx, cl, nsName, execs, c.Concurrency, bar)
+	bar.Finish()
+	if err != nil {
+		return err
+	}
+	stats := summarizeWorkflowHistorySamples(samples)
+
+	if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(stats, printer.StructuredOptions{})
+	}
+	cctx.Printer.Printlnf("Sampled %v closed workflow(s) in namespace %v", len(samples), nsName)
+	if len(stats) == 0 {
+		return nil
+	}
+	cctx.Printer.Println()
+	rows := make([]map[string]any, len(stats))
+	for i, s := range stats {
+		rows[i] = map[string]any{
+			"WorkflowType":  s.WorkflowType,
+			"Sampled":       s.Sampled,
+			"HistoryLength": s.HistoryLength.String(),
+			"PayloadBytes":  s.PayloadBytes.String(),
+			"Activities":    s.Activities.String(),
+			"Duration":      s.Duration.String(),
+		}
+	}
+	return cctx.Printer.PrintStructured(rows, printer.StructuredOptions{
+		Fields: []string{"WorkflowType", "Sampled", "HistoryLength", "PayloadBytes", "Activities", "Duration"},
+		Table:  &printer.TableOptions{},
+	})
+}
+
+type workflowHistorySample struct {
+	workflowType  string
+	historyLength int64
+	payloadBytes  int64
+	activities    int64
+	duration      time.Duration
+	activityTypes map[string]*activityTypeUse
+}
+
+type activityTypeUse struct {
+	scheduled     int64
+	lastScheduled time.Time
+}
+
+// Fetches the full history of each execution with at most the given number at
+// once. Result is in the same order as the executions.
+func gatherWorkflowHistorySamples(
+	cctx *CommandContext,
+	cl client.Client,
+	nsName string,
+	execs []*workflow.WorkflowExecutionInfo,
+	concurrency int,
+	bar *progress.Bar,
+) ([]*workflowHistorySample, error) {
+	samples := make([]*workflowHistorySample, len(execs))
+	errs := make([]error, len(execs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, exec := range execs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, exec *workflow.WorkflowExecutionInfo) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			sample := &workflowHistorySample{
+				workflowType:  exec.GetType().GetName(),
+				activityTypes: map[string]*activityTypeUse{},
+			}
+			if exec.StartTime != nil && exec.CloseTime != nil {
+				sample.duration = exec.CloseTime.AsTime().Sub(exec.StartTime.AsTime())
+			}
+			req := &workflowservice.GetWorkflowExecutionHistoryRequest{Namespace: nsName, Execution: exec.Execution}
+			for {
+				resp, err := cl.WorkflowService().GetWorkflowExecutionHistory(cctx, req)
+				if err != nil {
+					errs[i] = fmt.Errorf("failed getting history of %v: %w", exec.GetExecution().GetWorkflowId(), err)
+					return
+				}
+				for _, event := range resp.GetHistory().GetEvents() {
+					sample.historyLength++
+					sample.payloadBytes += int64(payloadsSize(event.ProtoReflect()))
+					if attrs := event.GetActivityTaskScheduledEventAttributes(); attrs != nil {
+						sample.activities++
+						use := sample.activityTypes[attrs.GetActivityType().GetName()]
+						if use == nil {
+							use = &activityTypeUse{}
+							sample.activityTypes[attrs.GetActivityType().GetName()] = use
+						}
+						use.scheduled++
+						use.lastScheduled = event.EventTime.AsTime()
+					}
+				}
+				if req.NextPageToken = resp.NextPageToken; len(req.NextPageToken) == 0 {
+					break
+				}
+			}
+			samples[i] = sample
+			bar.Add(1)
+		}(i, exec)
+	}
+	wg.Wait()
+	return samples, errors.Join(errs...)
+}
+
+type workflowTypeHistoryStats struct {
+	WorkflowType  string                    `json:"workflowType"`
+	Sampled       int                       `json:"sampled"`
+	HistoryLength statsDistribution[int64]  `json:"historyLength"`
+	PayloadBytes  statsDistribution[int64]  `json:"payloadBytes"`
+	Activities    statsDistribution[int64]  `json:"activities"`
+	Duration      statsDistribution[string] `json:"duration"`
+}
+
+type statsDistribution[T any] struct {
+	Min T `json:"min"`
+	P50 T `json:"p50"`
+	P95 T `json:"p95"`
+	Max T `json:"max"`
+}
+
+func (s statsDistribution[T]) String() string {
+	return fmt.Sprintf("%v / %v / %v / %v", s.Min, s.P50, s.P95, s.Max)
+}
+
+// Uses nearest-rank percentiles. Values are sorted in place.
+func newStatsDistribution[T cmp.Ordered](vals []T) statsDistribution[T] {
+	slices.Sort(vals)
+	percentile := func(p int) T { return vals[max((len(vals)*p+99)/100, 1)-1] }
+	return statsDistribution[T]{Min: vals[0], P50: percentile(50), P95: percentile(95), Max: vals[len(vals)-1]}
+}
+
+// Groups samples by workflow type, most sampled first.
+func summarizeWorkflowHistorySamples(samples []*workflowHistorySample) []*workflowTypeHistoryStats {
+	byType := map[string][]*workflowHistorySample{}
+	for _, sample := range samples {
+		byType[sample.workflowType] = append(byType[sample.workflowType], sample)
+	}
+	stats := make([]*workflowTypeHistoryStats, 0, len(byType))
+	for workflowType, samples := range byType {
+		var lengths, payloads, activities []int64
+		var durations []time.Duration
+		for _, sample := range samples {
+			lengths = append(lengths, sample.historyLength)
+			payloads = append(payloads, sample.payloadBytes)
+			activities = append(activities, sample.activities)
+			durations = append(durations, sample.duration)
+		}
+		d := newStatsDistribution(durations)
+		stats = append(stats, &workflowTypeHistoryStats{
+			WorkflowType:  workflowType,
+			Sampled:       len(samples),
+			HistoryLength: newStatsDistribution(lengths),
+			PayloadBytes:  newStatsDistribution(payloads),
+			Activities:    newStatsDistribution(activities),
+			Duration: statsDistribution[string]{
+				Min: d.Min.String(), P50: d.P50.String(), P95: d.P95.String(), Max: d.Max.String(),
+			},
+		})
+	}
+	slices.SortFunc(stats, func(a, b *workflowTypeHistoryStats) int {
+		if c := cmp.Compare(b.Sampled, a.Sampled); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.WorkflowType, b.WorkflowType)
+	})
+	return stats
+}
+
+type namespaceWorkflowType struct {
+	Type     string    `json:"type"`
+	Count    int       `json:"count"`
+	Open     int       `json:"open"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+type namespaceActivityType struct {
+	Type          string    `json:"type"`
+	WorkflowTypes []string  `json:"workflowTypes"`
+	Scheduled     int64     `json:"scheduled"`
+	LastSeen      time.Time `json:"lastSeen"`
+}
+
+func (c *TemporalOperatorNamespaceTypesCommand) run(cctx *CommandContext, args []string) error {
+	nsName, err := c.Parent.Parent.getNSFromFlagOrArg0(cctx, args)
+	if err != nil {
+		return err
+	} else if c.Limit < 1 {
+		return fmt.Errorf("limit must be at least 1")
+	} else if c.HistoriesPerType < 0 {
+		return fmt.Errorf("histories per type cannot be negative")
+	} else if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	cl, err := c.Parent.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	// Visibility returns the most recently started first, so the executions
+	// whose histories are sampled are the most recent of each type
+	workflowTypes := map[string]*namespaceWorkflowType{}
+	var toSample []*workflow.WorkflowExecutionInfo
+	var scanned int
+	var nextPageToken []byte
+	for scanned < c.Limit {
+		resp, err := cl.WorkflowService().ListWorkflowExecutions(cctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     nsName,
+			PageSize:      int32(min(c.Limit-scanned, 1000)),
+			NextPageToken: nextPageToken,
+			Query:         c.Query,
+		})
+		if err != nil {
+			return fmt.Errorf("failed listing workflows: %w", err)
+		}
+		for _, exec := range resp.Executions {
+			if scanned >= c.Limit {
+				break
+			}
+			scanned++
+			name := exec.GetType().GetName()
+			wt := workflowTypes[name]
+			if wt == nil {
+				wt = &namespaceWorkflowType{Type: name}
+				workflowTypes[name] = wt
+			}
+			if wt.Count < c.HistoriesPerType {
+				toSample = append(toSample, exec)
+			}
+			wt.Count++
+			if exec.Status == enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+				wt.Open++
+			}
+			if startTime := exec.StartTime.AsTime(); startTime.After(wt.LastSeen) {
+				wt.LastSeen = startTime
+			}
+		}
+		if nextPageToken = resp.NextPageToken; len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	bar := cctx.startProgress("Fetching histories", int64(len(toSample)))
+	samples, err := gatherWorkflowHistorySamples(cctx, cl, nsName, toSample, c.Concurrency, bar)
+	bar.Finish()
+	if err != nil {
+		return err
+	}
+	activityTypes := map[string]*namespaceActivityType{}
+	for _, sample := range samples {
+		for name, use := range sample.activityTypes {
+			at := activityTypes[name]
+			if at == nil {
+				at = &namespaceActivityType{Type: name}
+				activityTypes[name] = at
+			}
+			if !slices.Contains(at.WorkflowTypes, sample.workflowType) {
+				at.WorkflowTypes = append(at.WorkflowTypes, sample.workflowType)
+			}
+			at.Scheduled += use.scheduled
+			if use.lastScheduled.After(at.LastSeen) {
+				at.LastSeen = use.lastScheduled
+			}
+		}
+	}
+
+	// Most common first
+	sortedWorkflowTypes := make([]*namespaceWorkflowType, 0, len(workflowTypes))
+	for _, wt := range workflowTypes {
+		sortedWorkflowTypes = append(sortedWorkflowTypes, wt)
+	}
+	slices.SortFunc(sortedWorkflowTypes, func(a, b *namespaceWorkflowType) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Type, b.Type)
+	})
+	sortedActivityTypes := make([]*namespaceActivityType, 0, len(activityTypes))
+	for _, at := range activityTypes {
+		slices.Sort(at.WorkflowTypes)
+		sortedActivityTypes = append(sortedActivityTypes, at)
+	}
+	slices.SortFunc(sortedActivityTypes, func(a, b *namespaceActivityType) int {
+		if c := cmp.Compare(b.Scheduled, a.Scheduled); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Type, b.Type)
+	})
+
+	if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(struct {
+			Scanned       int                      `json:"scanned"`
+			Sampled       int                      `json:"sampled"`
+			WorkflowTypes []*namespaceWorkflowType `json:"workflowTypes"`
+			ActivityTypes []*namespaceActivityType `json:"activityTypes"`
+		}{scanned, len(samples), sortedWorkflowTypes, sortedActivityTypes}, printer.StructuredOptions{})
+	}
+	cctx.Printer.Printlnf("Scanned %v workflow(s) in namespace %v", scanned, nsName)
+	if len(sortedWorkflowTypes) > 0 {
+		cctx.Printer.Println()
+		err := cctx.Printer.PrintStructured(sortedWorkflowTypes, printer.StructuredOptions{
+			Fields: []string{"Type", "Count", "Open", "LastSeen"},
+			Table:  &printer.TableOptions{},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	cctx.Printer.Println()
+	cctx.Printer.Printlnf("Activity types in %v sampled history(s):", len(samples))
+	if len(sortedActivityTypes) == 0 {
+		cctx.Printer.Println("  None")
+		return nil
+	}
+	cctx.Printer.Println()
+	rows := make([]map[string]any, len(sortedActivityTypes))
+	for i, at := range sortedActivityTypes {
+		rows[i] = map[string]any{
+			"Type":          at.Type,
+			"WorkflowTypes": strings.Join(at.WorkflowTypes, ", "),
+			"Scheduled":     at.Scheduled,
+			"LastSeen":      at.LastSeen,
+		}
+	}
+	return cctx.Printer.PrintStructured(rows, printer.StructuredOptions{
+		Fields: []string{"Type", "WorkflowTypes", "Scheduled", "LastSeen"},
+		Table:  &printer.TableOptions{},
+	})
+}
+
 func (c *TemporalOperatorNamespaceUpdateCommand) run(cctx *CommandContext, args []string) error {
 	nsName, err := c.Parent.Parent.getNSFromFlagOrArg0(cctx, args)
 	if err != nil {
 		return err
 	}
 
+	if c.Edit {
+		if err := checkOnlyEditFlags(cctx, c.Command.LocalNonPersistentFlags()); err != nil {
+			return err
+		}
+	}
+
 	cl, err := c.Parent.Parent.ClientOptions.dialClient(cctx)
 	if err != nil {
 		return err
 	}
 	defer cl.Close()
+	if c.Edit {
+		return c.runEdit(cctx, cl, nsName)
+	}
 
 	var updateRequest *workflowservice.UpdateNamespaceRequest
 
 	if c.PromoteGlobal && len(c.ActiveCluster) > 0 {
 		return fmt.Errorf("both --promote-global and --active-cluster flags cannot be set together")
+	} else if len(c.ActiveCluster) > 0 && len(c.Cluster) > 0 {
+		return fmt.Errorf("both --active-cluster and --cluster flags cannot be set together")
+	}
+	if c.PromoteGlobal || len(c.ActiveCluster) > 0 || len(c.Cluster) > 0 {
+		if err := c.validateReplication(cctx, cl, nsName); err != nil {
+			return err
+		}
 	}
 
 	if c.PromoteGlobal {
-		cctx.Printer.Printlnf("Will promote local namespace to global namespace for:%s, other flag will be omitted. "+
-			"If it is already global namespace, this will be no-op.\n", nsName)
+		cctx.Printer.Printlnf("Will promote local namespace to global namespace for:%s, other flags except --cluster "+
+			"will be omitted. If it is already global namespace, this will be no-op.\n", nsName)
 		updateRequest = &workflowservice.UpdateNamespaceRequest{
 			Namespace:        nsName,
 			PromoteNamespace: true,
 		}
+		for _, clusterName := range c.Cluster {
+			if updateRequest.ReplicationConfig == nil {
+				updateRequest.ReplicationConfig = &replication.NamespaceReplicationConfig{}
+			}
+			updateRequest.ReplicationConfig.Clusters = append(updateRequest.ReplicationConfig.Clusters,
+				&replication.ClusterReplicationConfig{ClusterName: clusterName})
+		}
 	} else if len(c.ActiveCluster) > 0 {
 		cctx.Printer.Printlnf("Will set active cluster name to: %s, other flag will be omitted.\n", c.ActiveCluster)
 		replicationConfig := &replication.NamespaceReplicationConfig{
@@ -227,74 +831,325 @@ func (c *TemporalOperatorNamespaceUpdateCommand) run(cctx *CommandContext, args
 			ReplicationConfig: replicationConfig,
 		}
 	} else {
-		resp, err := cl.WorkflowService().DescribeNamespace(cctx, &workflowservice.DescribeNamespaceRequest{
-			Namespace: nsName,
+		return c.runReadModifyWrite(cctx, cl, nsName)
+	}
+
+	resp, err := cl.WorkflowService().UpdateNamespace(cctx, updateRequest)
+	if err != nil {
+		return fmt.Errorf("namespace update failed: %w", err)
+	}
+	return c.printUpdated(cctx, nsName, resp)
+}
+
+// Checks that the replication config the namespace would have after the update
+// is one the server accepts, so mistakes are reported before anything changes.
+// Only a local namespace's own cluster can be used unless it is promoted, and
+// every cluster must be known and connected with the active one among them.
+func (c *TemporalOperatorNamespaceUpdateCommand) validateReplication(
+	cctx *CommandContext,
+	cl client.Client,
+	nsName string,
+) error {
+	resp, err := cl.WorkflowService().DescribeNamespace(cctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: nsName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed describing namespace: %w", err)
+	}
+	activeCluster := resp.ReplicationConfig.GetActiveClusterName()
+	var clusters []string
+	for _, cluster := range resp.ReplicationConfig.GetClusters() {
+		clusters = append(clusters, cluster.ClusterName)
+	}
+	if len(c.ActiveCluster) > 0 {
+		activeCluster = c.ActiveCluster
+	}
+	if len(c.Cluster) > 0 {
+		clusters = c.Cluster
+	}
+
+	if c.PromoteGlobal && resp.IsGlobalNamespace {
+		cctx.Logger.Warn("Namespace is already global, promotion will be a no-op", "namespace", nsName)
+	} else if !resp.IsGlobalNamespace && !c.PromoteGlobal {
+		if activeCluster != resp.ReplicationConfig.GetActiveClusterName() ||
+			len(clusters) != 1 || clusters[0] != activeCluster {
+			return fmt.Errorf("namespace %v is local and can only use its own cluster %v, "+
+				"use --promote-global to make it global", nsName, resp.ReplicationConfig.GetActiveClusterName())
+		}
+	}
+	if !slices.Contains(clusters, activeCluster) {
+		return fmt.Errorf("active cluster %v must be in the cluster list %v", activeCluster, clusters)
+	}
+
+	// Confirm the clusters are known and connected. Not every user can list
+	// clusters, in which case the server does the validation.
+	known := map[string]*operatorservice.ClusterMetadata{}
+	var nextPageToken []byte
+	for {
+		page, err := cl.OperatorService().ListClusters(cctx, &operatorservice.ListClustersRequest{
+			NextPageToken: nextPageToken,
 		})
 		if err != nil {
-			return fmt.Errorf("namespace update failed: %w", err)
+			cctx.Logger.Warn("Unable to list clusters to validate the cluster list", "error", err)
+			return nil
+		}
+		for _, cluster := range page.Clusters {
+			known[cluster.ClusterName] = cluster
+		}
+		if nextPageToken = page.NextPageToken; len(nextPageToken) == 0 {
+			break
 		}
+	}
+	for _, name := range clusters {
+		if cluster, ok := known[name]; !ok {
+			return fmt.Errorf("cluster %v is not known to the server", name)
+		} else if !cluster.IsConnectionEnabled {
+			return fmt.Errorf("cluster %v does not have its connection enabled", name)
+		}
+	}
+	return nil
+}
 
-		description := resp.NamespaceInfo.GetDescription()
-		ownerEmail := resp.NamespaceInfo.GetOwnerEmail()
-		retention := resp.Config.GetWorkflowExecutionRetentionTtl()
+// Most attempts of a schedule or namespace update that keeps conflicting with
+// concurrent updates.
+const updateConflictAttempts = 5
 
-		if len(c.Description) > 0 {
-			description = c.Description
+// Updates the namespace based on its current values. Namespace updates have no
+// conflict detection, so the namespace is read again right before writing and
+// the update is rebuilt if it changed since, and retried if another update
+// replaced our values right after. This leaves only the window between the
+// second read and the write.
+func (c *TemporalOperatorNamespaceUpdateCommand) runReadModifyWrite(
+	cctx *CommandContext,
+	cl client.Client,
+	nsName string,
+) error {
+	describe := func() (*workflowservice.DescribeNamespaceResponse, error) {
+		return cl.WorkflowService().DescribeNamespace(cctx, &workflowservice.DescribeNamespaceRequest{
+			Namespace: nsName,
+		})
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := describe()
+		if err != nil {
+			return fmt.Errorf("namespace update failed: %w", err)
 		}
-		if len(c.Email) > 0 {
-			ownerEmail = c.Email
+		updateRequest, err := c.buildUpdateRequest(nsName, resp)
+		if err != nil {
+			return err
 		}
-
-		data := map[string]string{}
-		if len(c.Data) > 0 {
-			data, err = stringKeysValues(c.Data)
+		latest, err := describe()
+		if err != nil {
+			return fmt.Errorf("namespace update failed: %w", err)
+		}
+		if proto.Equal(resp.NamespaceInfo, latest.NamespaceInfo) && proto.Equal(resp.Config, latest.Config) {
+			updateResp, err := cl.WorkflowService().UpdateNamespace(cctx, updateRequest)
 			if err != nil {
-				return err
+				return fmt.Errorf("namespace update failed: %w", err)
+			}
+			after, err := describe()
+			if err != nil {
+				return fmt.Errorf("failed checking namespace update: %w", err)
+			} else if namespaceUpdateApplied(after, updateRequest) {
+				return c.printUpdated(cctx, nsName, updateResp)
 			}
 		}
-
-		if c.Retention > 0 {
-			retention = durationpb.New(c.Retention.Duration())
+		if attempt == updateConflictAttempts {
+			return fmt.Errorf("namespace kept being updated by someone else, gave up after %v attempts", attempt)
 		}
+		cctx.Logger.Info("Namespace was updated by someone else at the same time, retrying", "attempt", attempt)
+	}
+}
+
+// Updates the namespace with the user's edit of the parts of it that can be
+// updated. Only what changed is sent, since the server does not allow changing
+// the active cluster along with anything else.
+func (c *TemporalOperatorNamespaceUpdateCommand) runEdit(
+	cctx *CommandContext,
+	cl client.Client,
+	nsName string,
+) error {
+	resp, err := cl.WorkflowService().DescribeNamespace(cctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: nsName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed describing namespace: %w", err)
+	}
+	current := &workflowservice.UpdateNamespaceRequest{
+		UpdateInfo: &namespace.UpdateNamespaceInfo{
+			Description: resp.NamespaceInfo.GetDescription(),
+			OwnerEmail:  resp.NamespaceInfo.GetOwnerEmail(),
+			Data:        resp.NamespaceInfo.GetData(),
+		},
+		Config: &namespace.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: resp.Config.GetWorkflowExecutionRetentionTtl(),
+			HistoryArchivalState:          resp.Config.GetHistoryArchivalState(),
+			HistoryArchivalUri:            resp.Config.GetHistoryArchivalUri(),
+			VisibilityArchivalState:       resp.Config.GetVisibilityArchivalState(),
+			VisibilityArchivalUri:         resp.Config.GetVisibilityArchivalUri(),
+		},
+		ReplicationConfig: &replication.NamespaceReplicationConfig{
+			ActiveClusterName: resp.ReplicationConfig.GetActiveClusterName(),
+			Clusters:          resp.ReplicationConfig.GetClusters(),
+		},
+	}
+	edited, err := cctx.editAsYAML("namespace", current)
+	if err != nil {
+		return err
+	} else if edited == nil {
+		cctx.Printer.Println("No changes")
+		return nil
+	}
+	updateRequest := edited.(*workflowservice.UpdateNamespaceRequest)
+	if updateRequest.Namespace != "" && updateRequest.Namespace != nsName {
+		return fmt.Errorf("namespace name cannot be changed")
+	}
+	updateRequest.Namespace = nsName
 
-		var clusters []*replication.ClusterReplicationConfig
-		if len(c.Cluster) > 0 {
-			for _, clusterName := range c.Cluster {
-				clusters = append(clusters, &replication.ClusterReplicationConfig{
-					ClusterName: clusterName,
-				})
+	clusterNames := func(r *workflowservice.UpdateNamespaceRequest) (names []string) {
+		for _, cluster := range r.ReplicationConfig.GetClusters() {
+			names = append(names, cluster.ClusterName)
+		}
+		return
+	}
+	activeChanged := updateRequest.ReplicationConfig.GetActiveClusterName() !=
+		current.ReplicationConfig.ActiveClusterName
+	clustersChanged := !slices.Equal(clusterNames(updateRequest), clusterNames(current))
+	if activeChanged || clustersChanged || updateRequest.PromoteNamespace {
+		if activeChanged {
+			c.ActiveCluster = updateRequest.ReplicationConfig.GetActiveClusterName()
+		}
+		c.Cluster = clusterNames(updateRequest)
+		c.PromoteGlobal = updateRequest.PromoteNamespace
+		if err := c.validateReplication(cctx, cl, nsName); err != nil {
+			return err
+		}
+	}
+	if activeChanged {
+		if !proto.Equal(updateRequest.UpdateInfo, current.UpdateInfo) ||
+			!proto.Equal(updateRequest.Config, current.Config) || clustersChanged {
+			return fmt.Errorf("the active cluster cannot be changed along with anything else")
+		}
+		updateRequest.UpdateInfo, updateRequest.Config = nil, nil
+		updateRequest.ReplicationConfig.Clusters = nil
+	} else {
+		if !clustersChanged {
+			updateRequest.ReplicationConfig = nil
+		} else {
+			updateRequest.ReplicationConfig.ActiveClusterName = ""
+		}
+		// Unchanged archival settings are left alone so clusters without
+		// archival enabled accept the update
+		if config, currentConfig := updateRequest.GetConfig(), current.Config; config != nil {
+			if config.HistoryArchivalState == currentConfig.HistoryArchivalState &&
+				config.HistoryArchivalUri == currentConfig.HistoryArchivalUri {
+				config.HistoryArchivalState, config.HistoryArchivalUri = enums.ARCHIVAL_STATE_UNSPECIFIED, ""
+			}
+			if config.VisibilityArchivalState == currentConfig.VisibilityArchivalState &&
+				config.VisibilityArchivalUri == currentConfig.VisibilityArchivalUri {
+				config.VisibilityArchivalState, config.VisibilityArchivalUri = enums.ARCHIVAL_STATE_UNSPECIFIED, ""
 			}
 		}
+	}
 
-		updateInfo := &namespace.UpdateNamespaceInfo{
-			Description: description,
-			OwnerEmail:  ownerEmail,
-			Data:        data,
+	updateResp, err := cl.WorkflowService().UpdateNamespace(cctx, updateRequest)
+	if err != nil {
+		return fmt.Errorf("namespace update failed: %w", err)
+	}
+	if updateRequest.UpdateInfo != nil {
+		after, err := cl.WorkflowService().DescribeNamespace(cctx, &workflowservice.DescribeNamespaceRequest{
+			Namespace: nsName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed checking namespace update: %w", err)
+		} else if !namespaceUpdateApplied(after, updateRequest) {
+			return fmt.Errorf("namespace was updated by someone else while being edited, run again to edit the latest version")
 		}
+	}
+	return c.printUpdated(cctx, nsName, updateResp)
+}
 
-		updateConfig := &namespace.NamespaceConfig{
-			WorkflowExecutionRetentionTtl: retention,
-			HistoryArchivalState:          archivalState(c.HistoryArchivalState.String()),
-			HistoryArchivalUri:            c.HistoryUri,
-			VisibilityArchivalState:       archivalState(c.VisibilityArchivalState.String()),
-			VisibilityArchivalUri:         c.VisibilityUri,
+func namespaceUpdateApplied(resp *workflowservice.DescribeNamespaceResponse, req *workflowservice.UpdateNamespaceRequest) bool {
+	if resp.NamespaceInfo.GetDescription() != req.UpdateInfo.GetDescription() ||
+		resp.NamespaceInfo.GetOwnerEmail() != req.UpdateInfo.GetOwnerEmail() ||
+		resp.Config.GetWorkflowExecutionRetentionTtl().AsDuration() !=
+			req.Config.GetWorkflowExecutionRetentionTtl().AsDuration() {
+		return false
+	}
+	for k, v := range req.UpdateInfo.GetData() {
+		if resp.NamespaceInfo.GetData()[k] != v {
+			return false
 		}
-		replicationConfig := &replication.NamespaceReplicationConfig{
-			Clusters: clusters,
+	}
+	return true
+}
+
+func (c *TemporalOperatorNamespaceUpdateCommand) buildUpdateRequest(
+	nsName string,
+	resp *workflowservice.DescribeNamespaceResponse,
+) (*workflowservice.UpdateNamespaceRequest, error) {
+	description := resp.NamespaceInfo.GetDescription()
+	ownerEmail := resp.NamespaceInfo.GetOwnerEmail()
+	retention := resp.Config.GetWorkflowExecutionRetentionTtl()
+
+	if len(c.Description) > 0 {
+		description = c.Description
+	}
+	if len(c.Email) > 0 {
+		ownerEmail = c.Email
+	}
+
+	data := map[string]string{}
+	if len(c.Data) > 0 {
+		var err error
+		data, err = stringKeysValues(c.Data)
+		if err != nil {
+			return nil, err
 		}
-		updateRequest = &workflowservice.UpdateNamespaceRequest{
-			Namespace:         nsName,
-			UpdateInfo:        updateInfo,
-			Config:            updateConfig,
-			ReplicationConfig: replicationConfig,
+	}
+
+	if c.Retention > 0 {
+		retention = durationpb.New(c.Retention.Duration())
+	}
+
+	var clusters []*replication.ClusterReplicationConfig
+	if len(c.Cluster) > 0 {
+		for _, clusterName := range c.Cluster {
+			clusters = append(clusters, &replication.ClusterReplicationConfig{
+				ClusterName: clusterName,
+			})
 		}
 	}
 
-	resp, err := cl.WorkflowService().UpdateNamespace(cctx, updateRequest)
-	if err != nil {
-		return fmt.Errorf("namespace update failed: %w", err)
+	updateInfo := &namespace.UpdateNamespaceInfo{
+		Description: description,
+		OwnerEmail:  ownerEmail,
+		Data:        data,
 	}
 
+	updateConfig := &namespace.NamespaceConfig{
+		WorkflowExecutionRetentionTtl: retention,
+		HistoryArchivalState:          archivalState(c.HistoryArchivalState.String()),
+		HistoryArchivalUri:            c.HistoryUri,
+		VisibilityArchivalState:       archivalState(c.VisibilityArchivalState.String()),
+		VisibilityArchivalUri:         c.VisibilityUri,
+	}
+	replicationConfig := &replication.NamespaceReplicationConfig{
+		Clusters: clusters,
+	}
+	return &workflowservice.UpdateNamespaceRequest{
+		Namespace:         nsName,
+		UpdateInfo:        updateInfo,
+		Config:            updateConfig,
+		ReplicationConfig: replicationConfig,
+	}, nil
+}
+
+func (c *TemporalOperatorNamespaceUpdateCommand) printUpdated(
+	cctx *CommandContext,
+	nsName string,
+	resp *workflowservice.UpdateNamespaceResponse,
+) error {
 	if cctx.JSONOutput {
 		_ = cctx.Printer.PrintStructured(resp, printer.StructuredOptions{})
 	}
@@ -304,6 +1159,15 @@ func (c *TemporalOperatorNamespaceUpdateCommand) run(cctx *CommandContext, args
 }
 
 func printNamespaceDescriptions(cctx *CommandContext, responses ...*workflowservice.DescribeNamespaceResponse) error {
+	return printNamespaceDescriptionsWithStats(cctx, responses, nil)
+}
+
+// Stats are optional, but if present must be the same length as responses
+func printNamespaceDescriptionsWithStats(
+	cctx *CommandContext,
+	responses []*workflowservice.DescribeNamespaceResponse,
+	stats []*namespaceStats,
+) error {
 	namespaces := make([]map[string]any, len(responses))
 	for i, resp := range responses {
 		namespaces[i] = map[string]any{
@@ -324,21 +1188,24 @@ func printNamespaceDescriptions(cctx *CommandContext, responses ...*workflowserv
 			"Config.HistoryArchivalUri":            resp.Config.HistoryArchivalUri,
 			"Config.VisibilityArchivalUri":         resp.Config.VisibilityArchivalUri,
 		}
+		if stats != nil {
+			namespaces[i]["OpenWorkflows"] = stats[i].OpenWorkflows
+			namespaces[i]["Schedules"] = stats[i].Schedules
+		}
 	}
 
-	return cctx.Printer.PrintStructured(
-		namespaces,
-		printer.StructuredOptions{
-			Fields: []string{
-				"NamespaceInfo.Name", "NamespaceInfo.Id", "NamespaceInfo.Description",
-				"NamespaceInfo.OwnerEmail", "NamespaceInfo.State", "NamespaceInfo.Data",
-				"Config.WorkflowExecutionRetentionTtl", "ReplicationConfig.ActiveClusterName",
-				"ReplicationConfig.Clusters", "Config.HistoryArchivalState", "Config.VisibilityArchivalState",
-				"IsGlobalNamespace", "FailoverVersion", "FailoverHistory", "Config.HistoryArchivalUri",
-				"Config.VisibilityArchivalUri",
-			},
-		},
-	)
+	fields := []string{
+		"NamespaceInfo.Name", "NamespaceInfo.Id", "NamespaceInfo.Description",
+		"NamespaceInfo.OwnerEmail", "NamespaceInfo.State", "NamespaceInfo.Data",
+		"Config.WorkflowExecutionRetentionTtl", "ReplicationConfig.ActiveClusterName",
+		"ReplicationConfig.Clusters", "Config.HistoryArchivalState", "Config.VisibilityArchivalState",
+		"IsGlobalNamespace", "FailoverVersion", "FailoverHistory", "Config.HistoryArchivalUri",
+		"Config.VisibilityArchivalUri",
+	}
+	if stats != nil {
+		fields = append(fields, "OpenWorkflows", "Schedules")
+	}
+	return cctx.Printer.PrintStructured(namespaces, printer.StructuredOptions{Fields: fields})
 }
 
 func archivalState(input string) enums.ArchivalState {
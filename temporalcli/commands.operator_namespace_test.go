@@ -1,12 +1,21 @@
 package temporalcli_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/temporalio/cli/temporalcli"
 	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/namespace/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/grpc"
 )
 
 func (s *SharedServerSuite) TestOperator_NamespaceCreateListAndDescribe() {
@@ -106,6 +115,155 @@ func (s *SharedServerSuite) TestNamespaceUpdate() {
 	s.Equal("v3", describeResp.NamespaceInfo.Data["k3"])
 }
 
+func (s *SharedServerSuite) TestNamespaceUpdate_ReplicationPreflight() {
+	nsName := "test-namespace-update-preflight"
+	res := s.Execute(
+		"operator", "namespace", "create",
+		"--address", s.Address(),
+		"-n", nsName,
+	)
+	s.NoError(res.Err)
+	resp, err := s.Client.WorkflowService().DescribeNamespace(s.Context, &workflowservice.DescribeNamespaceRequest{
+		Namespace: nsName,
+	})
+	s.NoError(err)
+	s.False(resp.IsGlobalNamespace)
+	activeCluster := resp.ReplicationConfig.ActiveClusterName
+
+	// Local namespaces cannot change clusters without promotion
+	res = s.Execute(
+		"operator", "namespace", "update",
+		"--address", s.Address(),
+		"--cluster", activeCluster,
+		"--cluster", "other-cluster",
+		"-n", nsName,
+	)
+	s.ErrorContains(res.Err, "is local and can only use its own cluster "+activeCluster)
+	res = s.Execute(
+		"operator", "namespace", "update",
+		"--address", s.Address(),
+		"--active-cluster", "other-cluster",
+		"-n", nsName,
+	)
+	s.ErrorContains(res.Err, "is local and can only use its own cluster")
+
+	// Promotion requires the active cluster in the list and known clusters
+	res = s.Execute(
+		"operator", "namespace", "update",
+		"--address", s.Address(),
+		"--promote-global",
+		"--cluster", "other-cluster",
+		"-n", nsName,
+	)
+	s.ErrorContains(res.Err, "active cluster "+activeCluster+" must be in the cluster list")
+	res = s.Execute(
+		"operator", "namespace", "update",
+		"--address", s.Address(),
+		"--promote-global",
+		"--cluster", activeCluster,
+		"--cluster", "other-cluster",
+		"-n", nsName,
+	)
+	s.ErrorContains(res.Err, "cluster other-cluster is not known to the server")
+
+	// Nothing changed
+	resp, err = s.Client.WorkflowService().DescribeNamespace(s.Context, &workflowservice.DescribeNamespaceRequest{
+		Namespace: nsName,
+	})
+	s.NoError(err)
+	s.False(resp.IsGlobalNamespace)
+	s.Len(resp.ReplicationConfig.Clusters, 1)
+}
+
+func (s *SharedServerSuite) TestNamespaceUpdate_RetriesOnConflict() {
+	for _, afterMethod := range []string{"/DescribeNamespace", "/UpdateNamespace"} {
+		func() {
+			nsName := "test-namespace-update-conflict-" + strings.ToLower(strings.TrimPrefix(afterMethod, "/"))
+			res := s.Execute(
+				"operator", "namespace", "create",
+				"--address", s.Address(),
+				"--description", "description before",
+				"-n", nsName,
+			)
+			s.NoError(res.Err)
+
+			// Apply a concurrent update once, either between our read and write
+			// or right after our write
+			var concurrent atomic.Int32
+			s.CommandHarness.Options.AdditionalClientGRPCDialOptions = []grpc.DialOption{
+				grpc.WithChainUnaryInterceptor(func(
+					ctx context.Context,
+					method string, req, reply any,
+					cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+				) error {
+					err := invoker(ctx, method, req, reply, cc, opts...)
+					if strings.HasSuffix(method, afterMethod) && concurrent.Add(1) == 1 {
+						_, concurrentErr := s.Client.WorkflowService().UpdateNamespace(s.Context, &workflowservice.UpdateNamespaceRequest{
+							Namespace: nsName,
+							UpdateInfo: &namespace.UpdateNamespaceInfo{
+								Description: "concurrent",
+								OwnerEmail:  "concurrent@example.com",
+							},
+						})
+						s.NoError(concurrentErr)
+					}
+					return err
+				}),
+			}
+			defer func() { s.CommandHarness.Options.AdditionalClientGRPCDialOptions = nil }()
+
+			res = s.Execute(
+				"operator", "namespace", "update",
+				"--address", s.Address(),
+				"--description", "description after",
+				"-n", nsName,
+			)
+			s.NoError(res.Err)
+			resp, err := s.Client.WorkflowService().DescribeNamespace(s.Context, &workflowservice.DescribeNamespaceRequest{
+				Namespace: nsName,
+			})
+			s.NoError(err)
+			// Ours is applied and their other changes are kept
+			s.Equal("description after", resp.NamespaceInfo.Description)
+			s.Equal("concurrent@example.com", resp.NamespaceInfo.OwnerEmail)
+		}()
+	}
+}
+
+func (s *SharedServerSuite) TestNamespaceUpdate_Edit() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("test uses sed as the editor")
+	}
+	nsName := "test-namespace-update-edit"
+	res := s.Execute(
+		"operator", "namespace", "create",
+		"--address", s.Address(),
+		"--description", "before-edit",
+		"--retention", "24h",
+		"-n", nsName,
+	)
+	s.NoError(res.Err)
+	s.CommandHarness.Options.LookupEnv = func(key string) (string, bool) {
+		if key == "EDITOR" {
+			return "sed -i s/before-edit/after-edit/", true
+		}
+		return "", false
+	}
+
+	res = s.Execute("operator", "namespace", "update", "--address", s.Address(), "-n", nsName, "--edit", "--email", "x")
+	s.ErrorContains(res.Err, "--email cannot be used with --edit")
+
+	res = s.Execute("operator", "namespace", "update", "--address", s.Address(), "-n", nsName, "--edit")
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "+", "description: after-edit")
+	resp, err := s.Client.WorkflowService().DescribeNamespace(s.Context, &workflowservice.DescribeNamespaceRequest{
+		Namespace: nsName,
+	})
+	s.NoError(err)
+	s.Equal("after-edit", resp.NamespaceInfo.Description)
+	s.Equal(24*time.Hour, resp.Config.WorkflowExecutionRetentionTtl.AsDuration())
+}
+
 func (s *SharedServerSuite) TestNamespaceUpdate_NamespaceDontExist() {
 	nsName := "missing-namespace"
 	res := s.Execute(
@@ -156,6 +314,69 @@ func (s *SharedServerSuite) TestDeleteNamespace() {
 	s.Contains(res.Err.Error(), "Namespace test-namespace is not found")
 }
 
+func (s *SharedServerSuite) TestDeleteNamespace_CheckEmptyAndWait() {
+	nsName := "test-namespace-check-empty"
+	res := s.Execute(
+		"operator", "namespace", "create",
+		"--address", s.Address(),
+		nsName,
+	)
+	s.NoError(res.Err)
+
+	// Start a workflow nobody will run, waiting for the namespace to be usable
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "start",
+			"--address", s.Address(),
+			"-n", nsName,
+			"--task-queue", "no-worker",
+			"--type", "DevWorkflow",
+			"--workflow-id", "open-workflow",
+		)
+		return res.Err == nil
+	}, 20*time.Second, 500*time.Millisecond)
+
+	// Not empty once visible
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"operator", "namespace", "delete",
+			"--address", s.Address(),
+			"--yes",
+			"--check-empty",
+			"-n", nsName,
+		)
+		return res.Err != nil && strings.Contains(res.Err.Error(), "1 open workflow(s) and 0 schedule(s)")
+	}, 10*time.Second, 200*time.Millisecond)
+
+	// Delete it and confirm namespace deletion goes through and waits
+	res = s.Execute(
+		"workflow", "delete",
+		"--address", s.Address(),
+		"-n", nsName,
+		"-w", "open-workflow",
+	)
+	s.NoError(res.Err)
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"operator", "namespace", "delete",
+			"--address", s.Address(),
+			"--yes",
+			"--check-empty",
+			"--wait",
+			"-n", nsName,
+		)
+		return res.Err == nil
+	}, 30*time.Second, 500*time.Millisecond)
+	s.Contains(res.Stdout.String(), "Namespace test-namespace-check-empty has been deleted.")
+
+	res = s.Execute(
+		"operator", "namespace", "describe",
+		"--address", s.Address(),
+		"-n", nsName,
+	)
+	s.ErrorContains(res.Err, "is not found")
+}
+
 func (s *SharedServerSuite) TestDescribeWithID() {
 	res := s.Execute(
 		"operator", "namespace", "describe",
@@ -206,3 +427,203 @@ func (s *SharedServerSuite) TestUpdateOldAndNewNSArgs() {
 	s.Error(res.Err)
 	s.ContainsOnSameLine(res.Err.Error(), "namespace was provided as both an argument", "and a flag")
 }
+
+func (s *SharedServerSuite) TestOperator_NamespaceList_FiltersAndStats() {
+	nsName := "test-namespace-list-stats"
+	res := s.Execute(
+		"operator", "namespace", "create",
+		"--address", s.Address(),
+		nsName,
+	)
+	s.NoError(res.Err)
+
+	// Start a workflow nobody will run, waiting for the namespace to be usable
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "start",
+			"--address", s.Address(),
+			"-n", nsName,
+			"--task-queue", "no-worker",
+			"--type", "DevWorkflow",
+			"--workflow-id", "open-workflow",
+		)
+		return res.Err == nil
+	}, 20*time.Second, 500*time.Millisecond)
+
+	// Only the matching namespace, with stats once visible
+	var jsonOut []map[string]any
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"operator", "namespace", "list",
+			"--address", s.Address(),
+			"--name-regex", "^test-namespace-list-",
+			"--state", "Registered",
+			"--stats",
+			"-o", "json",
+		)
+		s.NoError(res.Err)
+		jsonOut = nil
+		s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+		return len(jsonOut) == 1 && jsonOut[0]["openWorkflowCount"] == float64(1)
+	}, 10*time.Second, 200*time.Millisecond)
+	s.Equal(float64(0), jsonOut[0]["scheduleCount"])
+
+	// Text has stats too
+	res = s.Execute(
+		"operator", "namespace", "list",
+		"--address", s.Address(),
+		"--name-regex", "^test-namespace-list-",
+		"--stats",
+	)
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "NamespaceInfo.Name", nsName)
+	s.ContainsOnSameLine(res.Stdout.String(), "OpenWorkflows", "1")
+
+	// Dev server namespaces are local
+	res = s.Execute(
+		"operator", "namespace", "list",
+		"--address", s.Address(),
+		"--name-regex", "^test-namespace-list-",
+		"--scope", "global",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	jsonOut = nil
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Empty(jsonOut)
+
+	res = s.Execute(
+		"operator", "namespace", "list",
+		"--address", s.Address(),
+		"--name-regex", "(",
+	)
+	s.ErrorContains(res.Err, "invalid name regex")
+}
+
+func (s *SharedServerSuite) TestOperator_NamespaceStats() {
+	s.Worker().OnDevActivity(func(ctx context.Context, a any) (any, error) {
+		return a, nil
+	})
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, input any) (any, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: 10 * time.Second})
+		for i := 0; i < 2; i++ {
+			if err := workflow.ExecuteActivity(ctx, DevActivity, input).Get(ctx, nil); err != nil {
+				return nil, err
+			}
+		}
+		return input, nil
+	})
+
+	// Run a few workflows and only sample those
+	var ids []string
+	for i := 0; i < 3; i++ {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			"some-input",
+		)
+		s.NoError(err)
+		s.NoError(run.Get(s.Context, nil))
+		ids = append(ids, fmt.Sprintf("WorkflowId = '%v'", run.GetID()))
+	}
+	query := strings.Join(ids, " OR ")
+
+	// JSON, waiting for visibility
+	var stats []map[string]any
+	s.Eventually(func() bool {
+		res := s.Execute(
+			"operator", "namespace", "stats",
+			"--address", s.Address(),
+			"-o", "json",
+			"--query", query,
+		)
+		s.NoError(res.Err)
+		stats = nil
+		s.NoError(json.Unmarshal(res.Stdout.Bytes(), &stats))
+		return len(stats) == 1 && stats[0]["sampled"] == float64(3)
+	}, 5*time.Second, 100*time.Millisecond)
+	s.Equal("DevWorkflow", stats[0]["workflowType"])
+	s.Equal(map[string]any{"min": float64(2), "p50": float64(2), "p95": float64(2), "max": float64(2)}, stats[0]["activities"])
+	s.Greater(stats[0]["historyLength"].(map[string]any)["min"], float64(10))
+	s.Greater(stats[0]["payloadBytes"].(map[string]any)["min"], float64(0))
+	s.NotEmpty(stats[0]["duration"].(map[string]any)["max"])
+
+	// Text with a smaller sample
+	res := s.Execute(
+		"operator", "namespace", "stats",
+		"--address", s.Address(),
+		"--query", query,
+		"--sample", "2",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Sampled 2 closed workflow(s) in namespace default")
+	s.ContainsOnSameLine(res.Stdout.String(), "DevWorkflow", "2 / 2 / 2 / 2")
+}
+
+func (s *SharedServerSuite) TestOperator_NamespaceTypes() {
+	s.Worker().OnDevActivity(func(ctx context.Context, a any) (any, error) {
+		return a, nil
+	})
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, input any) (any, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: 10 * time.Second})
+		return input, workflow.ExecuteActivity(ctx, DevActivity, input).Get(ctx, nil)
+	})
+
+	// Run a few workflows and only scan those
+	var ids []string
+	for i := 0; i < 3; i++ {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			"some-input",
+		)
+		s.NoError(err)
+		s.NoError(run.Get(s.Context, nil))
+		ids = append(ids, fmt.Sprintf("WorkflowId = '%v'", run.GetID()))
+	}
+	query := strings.Join(ids, " OR ")
+
+	// JSON, waiting for visibility
+	var types struct {
+		Scanned       int              `json:"scanned"`
+		Sampled       int              `json:"sampled"`
+		WorkflowTypes []map[string]any `json:"workflowTypes"`
+		ActivityTypes []map[string]any `json:"activityTypes"`
+	}
+	s.Eventually(func() bool {
+		res := s.Execute(
+			"operator", "namespace", "types",
+			"--address", s.Address(),
+			"-o", "json",
+			"--query", query,
+			"--histories-per-type", "2",
+		)
+		s.NoError(res.Err)
+		s.NoError(json.Unmarshal(res.Stdout.Bytes(), &types))
+		return types.Scanned == 3
+	}, 5*time.Second, 100*time.Millisecond)
+	s.Equal(2, types.Sampled)
+	s.Len(types.WorkflowTypes, 1)
+	s.Equal("DevWorkflow", types.WorkflowTypes[0]["type"])
+	s.Equal(float64(3), types.WorkflowTypes[0]["count"])
+	s.Equal(float64(0), types.WorkflowTypes[0]["open"])
+	s.NotEmpty(types.WorkflowTypes[0]["lastSeen"])
+	s.Len(types.ActivityTypes, 1)
+	s.Equal("DevActivity", types.ActivityTypes[0]["type"])
+	s.Equal([]any{"DevWorkflow"}, types.ActivityTypes[0]["workflowTypes"])
+	s.Equal(float64(2), types.ActivityTypes[0]["scheduled"])
+
+	// Text without activity types
+	res := s.Execute(
+		"operator", "namespace", "types",
+		"--address", s.Address(),
+		"--query", query,
+		"--histories-per-type", "0",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Scanned 3 workflow(s) in namespace default")
+	s.ContainsOnSameLine(res.Stdout.String(), "DevWorkflow", "3", "0")
+	s.NotContains(res.Stdout.String(), "DevActivity")
+}
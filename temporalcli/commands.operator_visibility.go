@@ -0,0 +1,262 @@
+package temporalcli
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/server/api/adminservice/v1"
+)
+
+type visibilityProblem struct {
+	WorkflowId       string `json:"workflowId"`
+	RunId            string `json:"runId"`
+	Problem          string `json:"problem"`
+	VisibilityStatus string `json:"visibilityStatus,omitempty"`
+	ActualStatus     string `json:"actualStatus,omitempty"`
+}
+
+func (c *TemporalOperatorVisibilityReindexCommand) run(cctx *CommandContext, args []string) error {
+	cl, adminClient, err := c.Parent.Parent.ClientOptions.dialAdminClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	// We create a faux SingleWorkflowOrBatchOptions to use the shared logic
+	opts := SingleWorkflowOrBatchOptions{
+		WorkflowId: c.WorkflowId,
+		RunId:      c.RunId,
+		Query:      c.Query,
+		Yes:        c.Yes,
+	}
+	if c.ResumeToken != "" && c.Query == "" {
+		return fmt.Errorf("cannot set resume token without query")
+	}
+	exec, err := opts.singleExecution(singleOrBatchOverrides{})
+	if err != nil {
+		return err
+	} else if exec == nil {
+		if _, err := opts.confirmQueryExecutions(cctx, cl, "Reindex"); err != nil {
+			return err
+		}
+	}
+
+	// Admin calls need the namespace ID
+	nsResp, err := cl.WorkflowService().DescribeNamespace(cctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: c.Parent.Parent.Namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed describing namespace: %w", err)
+	}
+	reindex := func(exec *common.WorkflowExecution) error {
+		_, err := adminClient.RefreshWorkflowTasks(cctx, &adminservice.RefreshWorkflowTasksRequest{
+			NamespaceId: nsResp.NamespaceInfo.GetId(),
+			Execution:   exec,
+		})
+		var unimplemented *serviceerror.Unimplemented
+		if errors.As(err, &unimplemented) {
+			return fmt.Errorf("server does not support reindexing: %w", err)
+		} else if err != nil {
+			return fmt.Errorf("failed reindexing workflow %v: %w", exec.WorkflowId, err)
+		}
+		return nil
+	}
+
+	var reindexed int
+	if exec != nil {
+		if err := reindex(exec); err != nil {
+			return err
+		}
+		reindexed++
+	} else {
+		// The resume token is the token of the page being worked on, so resuming
+		// may reindex a few workflows again which is harmless
+		pageToken, err := base64.RawURLEncoding.DecodeString(c.ResumeToken)
+		if err != nil {
+			return fmt.Errorf("invalid resume token: %w", err)
+		}
+		for {
+			resp, err := cl.ListWorkflow(cctx, &workflowservice.ListWorkflowExecutionsRequest{
+				Query:         c.Query,
+				NextPageToken: pageToken,
+			})
+			if err == nil {
+				for _, info := range resp.Executions {
+					if err = reindex(info.Execution); err != nil {
+						break
+					}
+					reindexed++
+				}
+			} else {
+				err = fmt.Errorf("failed listing workflows: %w", err)
+			}
+			if err != nil && cctx.Err() != nil {
+				return c.printInterrupted(cctx, reindexed, pageToken)
+			} else if err != nil {
+				return err
+			}
+			if pageToken = resp.NextPageToken; len(pageToken) == 0 {
+				break
+			}
+		}
+	}
+
+	if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(struct {
+			Reindexed int `json:"reindexed"`
+		}{reindexed}, printer.StructuredOptions{})
+	}
+	cctx.Printer.Printlnf("Requested reindex of %v workflow(s)", reindexed)
+	return nil
+}
+
+func (c *TemporalOperatorVisibilityReindexCommand) printInterrupted(
+	cctx *CommandContext,
+	reindexed int,
+	pageToken []byte,
+) error {
+	resumeToken := base64.RawURLEncoding.EncodeToString(pageToken)
+	if cctx.JSONOutput {
+		_ = cctx.Printer.PrintStructured(struct {
+			Reindexed   int    `json:"reindexed"`
+			ResumeToken string `json:"resumeToken"`
+		}{reindexed, resumeToken}, printer.StructuredOptions{})
+	}
+	cctx.Printer.Printlnf("Interrupted after requesting reindex of %v workflow(s)", reindexed)
+	cctx.Printer.Printlnf("To resume, run again with the same query and: --resume-token %v", resumeToken)
+	return fmt.Errorf("reindex interrupted: %w", cctx.Err())
+}
+
+func (c *TemporalOperatorVisibilityVerifyCommand) run(cctx *CommandContext, args []string) error {
+	if c.Query == "" && len(c.WorkflowId) == 0 {
+		return fmt.Errorf("must set either query or workflow ID")
+	}
+	cl, err := c.Parent.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	var checked int
+	var problems []*visibilityProblem
+
+	// Check that a sample of the records from the query match their executions
+	if c.Query != "" {
+		var nextPageToken []byte
+		for sampled := 0; sampled < c.SampleSize; {
+			resp, err := cl.ListWorkflow(cctx, &workflowservice.ListWorkflowExecutionsRequest{
+				Query:         c.Query,
+				NextPageToken: nextPageToken,
+			})
+			if err != nil {
+				return fmt.Errorf("failed listing workflows: %w", err)
+			}
+			for _, record := range resp.Executions {
+				if sampled >= c.SampleSize {
+					break
+				}
+				sampled++
+				checked++
+				problem, err := verifyVisibilityRecord(cctx, cl, record)
+				if err != nil {
+					return err
+				} else if problem != nil {
+					problems = append(problems, problem)
+				}
+			}
+			if nextPageToken = resp.NextPageToken; len(nextPageToken) == 0 {
+				break
+			}
+		}
+	}
+
+	// Check that each given workflow has a matching record
+	for _, workflowID := range c.WorkflowId {
+		checked++
+		desc, err := cl.DescribeWorkflowExecution(cctx, workflowID, "")
+		if err != nil {
+			return fmt.Errorf("failed describing workflow %v: %w", workflowID, err)
+		}
+		actual := desc.WorkflowExecutionInfo
+		resp, err := cl.ListWorkflow(cctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query: fmt.Sprintf("WorkflowId = %q AND RunId = %q", workflowID, actual.Execution.GetRunId()),
+		})
+		if err != nil {
+			return fmt.Errorf("failed listing workflow %v: %w", workflowID, err)
+		} else if len(resp.Executions) == 0 {
+			problems = append(problems, &visibilityProblem{
+				WorkflowId:   workflowID,
+				RunId:        actual.Execution.GetRunId(),
+				Problem:      "missing",
+				ActualStatus: actual.Status.String(),
+			})
+		} else if problem := compareVisibilityRecord(resp.Executions[0], actual); problem != nil {
+			problems = append(problems, problem)
+		}
+	}
+
+	if cctx.JSONOutput {
+		if problems == nil {
+			problems = []*visibilityProblem{}
+		}
+		return cctx.Printer.PrintStructured(struct {
+			Checked  int                  `json:"checked"`
+			Problems []*visibilityProblem `json:"problems"`
+		}{checked, problems}, printer.StructuredOptions{})
+	}
+	if len(problems) > 0 {
+		if err := cctx.Printer.PrintStructured(problems, printer.StructuredOptions{
+			Table: &printer.TableOptions{},
+		}); err != nil {
+			return err
+		}
+		cctx.Printer.Println()
+	}
+	cctx.Printer.Printlnf("Checked %v execution(s), found %v problem(s)", checked, len(problems))
+	return nil
+}
+
+// Returns a problem if the record's execution is gone or does not match the
+// record.
+func verifyVisibilityRecord(
+	cctx *CommandContext,
+	cl client.Client,
+	record *workflow.WorkflowExecutionInfo,
+) (*visibilityProblem, error) {
+	desc, err := cl.DescribeWorkflowExecution(cctx, record.Execution.GetWorkflowId(), record.Execution.GetRunId())
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return &visibilityProblem{
+			WorkflowId:       record.Execution.GetWorkflowId(),
+			RunId:            record.Execution.GetRunId(),
+			Problem:          "orphaned",
+			VisibilityStatus: record.Status.String(),
+		}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed describing workflow %v: %w", record.Execution.GetWorkflowId(), err)
+	}
+	return compareVisibilityRecord(record, desc.WorkflowExecutionInfo), nil
+}
+
+func compareVisibilityRecord(record, actual *workflow.WorkflowExecutionInfo) *visibilityProblem {
+	// Visibility stores may not keep full timestamp precision
+	closeTimeDiff := record.CloseTime.AsTime().Sub(actual.CloseTime.AsTime())
+	if record.Status == actual.Status && closeTimeDiff > -time.Second && closeTimeDiff < time.Second {
+		return nil
+	}
+	return &visibilityProblem{
+		WorkflowId:       record.Execution.GetWorkflowId(),
+		RunId:            record.Execution.GetRunId(),
+		Problem:          "stale",
+		VisibilityStatus: record.Status.String(),
+		ActualStatus:     actual.Status.String(),
+	}
+}
@@ -0,0 +1,181 @@
+package temporalcli_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/grpc"
+)
+
+func (s *SharedServerSuite) TestOperator_Visibility_Verify() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+
+	// Run a couple of workflows and wait for them to be visible as completed
+	var workflowIDs []string
+	for i := 0; i < 2; i++ {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			"ignored",
+		)
+		s.NoError(err)
+		s.NoError(run.Get(s.Context, nil))
+		workflowIDs = append(workflowIDs, run.GetID())
+	}
+	query := "TaskQueue = '" + s.Worker().Options.TaskQueue + "' AND ExecutionStatus = 'Completed'"
+	s.Eventually(func() bool {
+		resp, err := s.Client.ListWorkflow(s.Context, &workflowservice.ListWorkflowExecutionsRequest{Query: query})
+		s.NoError(err)
+		return len(resp.Executions) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Text
+	res := s.Execute(
+		"operator", "visibility", "verify",
+		"--address", s.Address(),
+		"--query", query,
+		"-w", workflowIDs[0],
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Checked 3 execution(s), found 0 problem(s)")
+
+	// JSON with limited sample
+	res = s.Execute(
+		"operator", "visibility", "verify",
+		"--address", s.Address(),
+		"--query", query,
+		"--sample-size", "1",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var jsonOut map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal(float64(1), jsonOut["checked"])
+	s.Empty(jsonOut["problems"])
+
+	// Must set query or workflow ID
+	res = s.Execute(
+		"operator", "visibility", "verify",
+		"--address", s.Address(),
+	)
+	s.ErrorContains(res.Err, "must set either query or workflow ID")
+}
+
+func (s *SharedServerSuite) TestOperator_Visibility_Reindex() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+	query := "TaskQueue = '" + s.Worker().Options.TaskQueue + "'"
+	s.Eventually(func() bool {
+		resp, err := s.Client.ListWorkflow(s.Context, &workflowservice.ListWorkflowExecutionsRequest{Query: query})
+		s.NoError(err)
+		return len(resp.Executions) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Single
+	res := s.Execute(
+		"operator", "visibility", "reindex",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Requested reindex of 1 workflow(s)")
+
+	// Query
+	res = s.Execute(
+		"operator", "visibility", "reindex",
+		"--address", s.Address(),
+		"--query", query,
+		"--yes",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Reindex approximately 1 workflow(s)? y/N yes")
+	s.NotContains(res.Stdout.String(), "batch")
+	res = s.Execute(
+		"operator", "visibility", "reindex",
+		"--address", s.Address(),
+		"--query", query,
+		"--yes",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var jsonOut map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal(float64(1), jsonOut["reindexed"])
+
+	// Record still verifies after reindex
+	res = s.Execute(
+		"operator", "visibility", "verify",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "found 0 problem(s)")
+}
+
+func (s *SharedServerSuite) TestOperator_Visibility_Reindex_Interrupted() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	for i := 0; i < 2; i++ {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			"ignored",
+		)
+		s.NoError(err)
+		s.NoError(run.Get(s.Context, nil))
+	}
+	query := "TaskQueue = '" + s.Worker().Options.TaskQueue + "'"
+	s.Eventually(func() bool {
+		resp, err := s.Client.ListWorkflow(s.Context, &workflowservice.ListWorkflowExecutionsRequest{Query: query})
+		s.NoError(err)
+		return len(resp.Executions) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Simulate an interrupt during the second reindex call
+	var refreshCalls atomic.Int32
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption,
+		) error {
+			if strings.HasSuffix(method, "/RefreshWorkflowTasks") && refreshCalls.Add(1) == 2 {
+				s.CommandHarness.CancelContext()
+				return context.Canceled
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+	res := s.Execute(
+		"operator", "visibility", "reindex",
+		"--address", s.Address(),
+		"--query", query,
+		"--yes",
+	)
+	s.ErrorContains(res.Err, "reindex interrupted")
+	s.Contains(res.Stdout.String(), "Interrupted after requesting reindex of 1 workflow(s)")
+	s.Contains(res.Stdout.String(), "--resume-token")
+}
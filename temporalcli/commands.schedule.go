@@ -1,13 +1,17 @@
 package temporalcli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/temporalio/cli/temporalcli/internal/printer"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
@@ -16,7 +20,10 @@ import (
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/converter"
 	"go.temporal.io/server/common/primitives/timestamp"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type printableSchedule struct {
@@ -73,6 +80,21 @@ func describeResultToPrintable(id string, desc *client.ScheduleDescription) *pri
 	}
 	// Schedule.Action
 	out.Action = desc.Schedule.Action
+	if action, ok := desc.Schedule.Action.(*client.ScheduleWorkflowAction); ok && len(action.Memo) > 0 {
+		// Show memo values decoded instead of as raw payloads
+		decoded := *action
+		decoded.Memo = make(map[string]any, len(action.Memo))
+		for k, v := range action.Memo {
+			decoded.Memo[k] = v
+			if p, ok := v.(*commonpb.Payload); ok {
+				var val any
+				if err := converter.GetDefaultDataConverter().FromPayload(p, &val); err == nil {
+					decoded.Memo[k] = val
+				}
+			}
+		}
+		out.Action = &decoded
+	}
 	// Schedule.Spec
 	specToPrintable(out, desc.Schedule.Spec)
 	// Schedule.Policy
@@ -251,10 +273,173 @@ func (c *ScheduleConfigurationOptions) toScheduleSpec(spec *client.ScheduleSpec)
 	return nil
 }
 
-func toScheduleAction(sw *SharedWorkflowStartOptions, i *PayloadInputOptions) (client.ScheduleAction, error) {
+// Server limits that schedules are checked against before they are submitted.
+const (
+	// Shorter catchup windows are raised to this by the server
+	scheduleMinCatchupWindow = 10 * time.Second
+	// Default server blob size limits for the action input
+	scheduleInputWarnSize = 512 * 1024
+	scheduleInputMaxSize  = 2 * 1024 * 1024
+)
+
+// Calendar field ranges by field name. Year is not checked.
+var scheduleCalendarFieldRanges = map[string][2]int{
+	"second":     {0, 59},
+	"minute":     {0, 59},
+	"hour":       {0, 23},
+	"dayOfMonth": {1, 31},
+	"month":      {1, 12},
+	"dayOfWeek":  {0, 7},
+}
+
+// Fails for schedules the server will reject and logs warnings for schedules
+// the server will silently adjust.
+func checkSchedule(
+	cctx *CommandContext,
+	spec *client.ScheduleSpec,
+	catchupWindow time.Duration,
+	action client.ScheduleAction,
+) error {
+	warnings, err := lintSchedule(spec, catchupWindow, action)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		cctx.Logger.Warn(warning)
+	}
+	return nil
+}
+
+func lintSchedule(
+	spec *client.ScheduleSpec,
+	catchupWindow time.Duration,
+	action client.ScheduleAction,
+) (warnings []string, err error) {
+	for _, cron := range spec.CronExpressions {
+		if err := lintCronString(cron); err != nil {
+			return nil, err
+		}
+	}
+
+	var minInterval time.Duration
+	for _, interval := range spec.Intervals {
+		if interval.Every <= 0 {
+			return nil, fmt.Errorf("interval must be positive, got %v", interval.Every)
+		} else if interval.Offset < 0 {
+			return nil, fmt.Errorf("interval offset must not be negative, got %v", interval.Offset)
+		}
+		if interval.Every%time.Second != 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"Interval %v has sub-second precision, which the server ignores", interval.Every))
+		}
+		if interval.Offset >= interval.Every {
+			warnings = append(warnings, fmt.Sprintf(
+				"Interval offset %v is not less than interval %v, only the remainder has effect",
+				interval.Offset, interval.Every))
+		}
+		if minInterval == 0 || interval.Every < minInterval {
+			minInterval = interval.Every
+		}
+	}
+	if minInterval > 0 && spec.Jitter > minInterval {
+		warnings = append(warnings, fmt.Sprintf(
+			"Jitter %v is larger than interval %v, the server limits jitter to the interval", spec.Jitter, minInterval))
+	}
+
+	if catchupWindow > 0 && catchupWindow < scheduleMinCatchupWindow {
+		warnings = append(warnings, fmt.Sprintf(
+			"Catchup window %v is below the server minimum, it will be raised to %v",
+			catchupWindow, scheduleMinCatchupWindow))
+	}
+
+	if wfAction, ok := action.(*client.ScheduleWorkflowAction); ok {
+		var size int
+		for _, arg := range wfAction.Args {
+			if raw, ok := arg.(RawValue); ok {
+				size += proto.Size(raw.Payload)
+			}
+		}
+		if size > scheduleInputMaxSize {
+			warnings = append(warnings, fmt.Sprintf(
+				"Action input is %v bytes, over the default server limit of %v bytes", size, scheduleInputMaxSize))
+		} else if size > scheduleInputWarnSize {
+			warnings = append(warnings, fmt.Sprintf(
+				"Action input is %v bytes, over the default server warning size of %v bytes",
+				size, scheduleInputWarnSize))
+		}
+	}
+	return warnings, nil
+}
+
+// Checks field ranges of a cron string in the forms the server accepts.
+// Unrecognized forms are left for the server to validate.
+func lintCronString(cron string) error {
+	fields := strings.Fields(cron)
+	if i := slices.Index(fields, "#"); i >= 0 {
+		fields = fields[:i]
+	}
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		fields = fields[1:]
+	}
+	var names []string
+	switch len(fields) {
+	case 5:
+		names = []string{"minute", "hour", "dayOfMonth", "month", "dayOfWeek"}
+	case 6:
+		names = []string{"minute", "hour", "dayOfMonth", "month", "dayOfWeek", "year"}
+	case 7:
+		names = []string{"second", "minute", "hour", "dayOfMonth", "month", "dayOfWeek", "year"}
+	default:
+		return nil
+	}
+	for i, field := range fields {
+		if err := lintCalendarField(names[i], field); err != nil {
+			return fmt.Errorf("invalid schedule spec %q: %w", cron, err)
+		}
+	}
+	return nil
+}
+
+func lintCalendarField(name, value string) error {
+	bounds, ok := scheduleCalendarFieldRanges[name]
+	if !ok {
+		return nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("%v has invalid step %q", name, step)
+			}
+		}
+		if rng == "*" || rng == "?" {
+			continue
+		}
+		lo, hi, _ := strings.Cut(rng, "-")
+		for _, v := range []string{lo, hi} {
+			// Names like Jan or Fri are left for the server
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				continue
+			}
+			if n < bounds[0] || n > bounds[1] {
+				return fmt.Errorf("%v value %v is out of range %v-%v", name, n, bounds[0], bounds[1])
+			}
+		}
+	}
+	return nil
+}
+
+func toScheduleAction(
+	cctx *CommandContext,
+	sw *SharedWorkflowStartOptions,
+	i *PayloadInputOptions,
+) (client.ScheduleAction, error) {
 	opts, err := buildStartOptions(sw, &WorkflowStartOptions{})
 	if err != nil {
 		return nil, err
+	} else if err := cctx.checkRequiredLabels(opts.SearchAttributes, opts.Memo); err != nil {
+		return nil, err
 	}
 	untypedSearchAttributes, err := encodeSearchAttributesToPayloads(opts.SearchAttributes)
 	if err != nil {
@@ -283,6 +468,9 @@ func (c *TemporalScheduleCreateCommand) run(cctx *CommandContext, args []string)
 		return err
 	}
 	defer cl.Close()
+	if err := c.Parent.ClientOptions.checkServerFeature(cctx, cl, serverFeatureSchedules); err != nil {
+		return err
+	}
 
 	opts := client.ScheduleOptions{
 		ID:               c.ScheduleId,
@@ -297,14 +485,16 @@ func (c *TemporalScheduleCreateCommand) run(cctx *CommandContext, args []string)
 
 	if err = c.toScheduleSpec(&opts.Spec); err != nil {
 		return err
-	} else if opts.Action, err = toScheduleAction(&c.SharedWorkflowStartOptions, &c.PayloadInputOptions); err != nil {
+	} else if opts.Action, err = toScheduleAction(cctx, &c.SharedWorkflowStartOptions, &c.PayloadInputOptions); err != nil {
 		return err
 	} else if opts.Overlap, err = enumspb.ScheduleOverlapPolicyFromString(c.OverlapPolicy.Value); err != nil {
 		return err
-	} else if opts.Memo, err = stringKeysJSONValues(c.ScheduleMemo, false); err != nil {
+	} else if opts.Memo, err = stringKeysJSONValuesWithFiles(c.ScheduleMemo, c.ScheduleMemoFile, false); err != nil {
 		return fmt.Errorf("invalid memo values: %w", err)
 	} else if opts.SearchAttributes, err = stringKeysJSONValues(c.ScheduleSearchAttribute, false); err != nil {
 		return fmt.Errorf("invalid search attribute values: %w", err)
+	} else if err = checkSchedule(cctx, &opts.Spec, opts.CatchupWindow, opts.Action); err != nil {
+		return err
 	}
 
 	_, err = cl.ScheduleClient().Create(cctx, opts)
@@ -333,6 +523,13 @@ func (c *TemporalScheduleDescribeCommand) run(cctx *CommandContext, args []strin
 	}
 	defer cl.Close()
 
+	if c.Ical {
+		if cctx.JSONOutput {
+			return fmt.Errorf("cannot use --ical with JSON output")
+		}
+		return c.printICal(cctx, cl)
+	}
+
 	if cctx.JSONOutput {
 		// Use raw gRPC for stability
 		res, err := cl.WorkflowService().DescribeSchedule(cctx, &workflowservice.DescribeScheduleRequest{
@@ -360,6 +557,84 @@ func (c *TemporalScheduleDescribeCommand) run(cctx *CommandContext, args []strin
 	return cctx.Printer.PrintStructured(printable, printer.StructuredOptions{})
 }
 
+func (c *TemporalScheduleDescribeCommand) printICal(cctx *CommandContext, cl client.Client) error {
+	start := c.IcalStart.Time()
+	if start.IsZero() {
+		start = time.Now()
+	}
+	end := c.IcalEnd.Time()
+	if end.IsZero() {
+		end = start.AddDate(1, 0, 0)
+	} else if !end.After(start) {
+		return fmt.Errorf("--ical-end must be after --ical-start")
+	}
+	desc, err := cl.WorkflowService().DescribeSchedule(cctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  c.Parent.Namespace,
+		ScheduleId: c.ScheduleId,
+	})
+	if err != nil {
+		return err
+	}
+	times, err := cl.WorkflowService().ListScheduleMatchingTimes(cctx, &workflowservice.ListScheduleMatchingTimesRequest{
+		Namespace:  c.Parent.Namespace,
+		ScheduleId: c.ScheduleId,
+		StartTime:  timestamppb.New(start),
+		EndTime:    timestamppb.New(end),
+	})
+	if err != nil {
+		return fmt.Errorf("failed listing schedule times: %w", err)
+	}
+
+	summary := "Temporal Schedule " + c.ScheduleId
+	if workflowType := desc.Schedule.GetAction().GetStartWorkflow().GetWorkflowType().GetName(); workflowType != "" {
+		summary += ": " + workflowType
+	}
+	stamp := time.Now().UTC().Format(icalTimeFormat)
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//Temporal//Temporal CLI//EN"}
+	for i, t := range times.StartTime {
+		if c.IcalCount > 0 && i >= c.IcalCount {
+			break
+		}
+		at := t.AsTime().UTC().Format(icalTimeFormat)
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+icalEscape(c.ScheduleId+"-"+at)+"@temporal",
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+at,
+			"SUMMARY:"+icalEscape(summary),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		cctx.Printer.Print(icalFold(line), "\r\n")
+	}
+	return nil
+}
+
+const icalTimeFormat = "20060102T150405Z"
+
+// Escapes iCalendar text values per RFC 5545.
+func icalEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// Folds iCalendar content lines longer than 75 octets per RFC 5545, without
+// splitting multi-byte characters.
+func icalFold(line string) string {
+	var b strings.Builder
+	lineLen := 0
+	for _, r := range line {
+		if n := utf8.RuneLen(r); lineLen+n > 75 {
+			b.WriteString("\r\n ")
+			lineLen = 1
+		}
+		b.WriteRune(r)
+		lineLen += utf8.RuneLen(r)
+	}
+	return b.String()
+}
+
 func (c *TemporalScheduleListCommand) run(cctx *CommandContext, args []string) error {
 	cl, err := c.Parent.ClientOptions.dialClient(cctx)
 	if err != nil {
@@ -474,9 +749,20 @@ func (c *TemporalScheduleToggleCommand) run(cctx *CommandContext, args []string)
 	sch := cl.ScheduleClient().GetHandle(cctx, c.ScheduleId)
 
 	if c.Pause {
-		return sch.Pause(cctx, client.SchedulePauseOptions{
-			Note: c.Reason,
-		})
+		note := c.Reason
+		var resumeAt time.Time
+		if c.ResumeAfter > 0 {
+			resumeAt = time.Now().Add(c.ResumeAfter.Duration()).UTC().Truncate(time.Second)
+			note = scheduleNoteWithResumeTime(note, resumeAt)
+		}
+		if err := sch.Pause(cctx, client.SchedulePauseOptions{Note: note}); err != nil {
+			return err
+		} else if !resumeAt.IsZero() {
+			cctx.Printer.Printlnf("Schedule paused, due to resume at %v", resumeAt.Format(time.RFC3339))
+		}
+		return nil
+	} else if c.ResumeAfter > 0 {
+		return errors.New("--resume-after can only be used with --pause")
 	} else {
 		return sch.Unpause(cctx, client.ScheduleUnpauseOptions{
 			Note: c.Reason,
@@ -484,6 +770,83 @@ func (c *TemporalScheduleToggleCommand) run(cctx *CommandContext, args []string)
 	}
 }
 
+// Schedules paused with a resume time have this suffix on their notes
+var scheduleResumeTimeRegex = regexp.MustCompile(` \(resume after (\S+)\)$`)
+
+func scheduleNoteWithResumeTime(note string, resumeAt time.Time) string {
+	return fmt.Sprintf("%v (resume after %v)", note, resumeAt.Format(time.RFC3339))
+}
+
+// Returns the note without the resume time suffix and the resume time, or zero
+// time if there is no resume time in the note.
+func scheduleNoteResumeTime(note string) (string, time.Time) {
+	match := scheduleResumeTimeRegex.FindStringSubmatchIndex(note)
+	if match == nil {
+		return note, time.Time{}
+	}
+	resumeAt, err := time.Parse(time.RFC3339, note[match[2]:match[3]])
+	if err != nil {
+		return note, time.Time{}
+	}
+	return note[:match[0]], resumeAt
+}
+
+type scheduleResumeDueResult struct {
+	ScheduleId string    `json:"scheduleId"`
+	ResumeAt   time.Time `json:"resumeAt"`
+	Resumed    bool      `json:"resumed"`
+}
+
+func (c *TemporalScheduleResumeDueCommand) run(cctx *CommandContext, args []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	// List results may be stale, so candidates are confirmed with a describe
+	// before unpausing
+	iter, err := cl.ScheduleClient().List(cctx, client.ScheduleListOptions{})
+	if err != nil {
+		return err
+	}
+	results := []scheduleResumeDueResult{}
+	for iter.HasNext() {
+		entry, err := iter.Next()
+		if err != nil {
+			return err
+		}
+		if _, resumeAt := scheduleNoteResumeTime(entry.Note); !entry.Paused || resumeAt.IsZero() {
+			continue
+		}
+		sch := cl.ScheduleClient().GetHandle(cctx, entry.ID)
+		desc, err := sch.Describe(cctx)
+		if err != nil {
+			return fmt.Errorf("failed describing schedule %v: %w", entry.ID, err)
+		}
+		note, resumeAt := scheduleNoteResumeTime(desc.Schedule.State.Note)
+		if !desc.Schedule.State.Paused || resumeAt.IsZero() {
+			continue
+		}
+		result := scheduleResumeDueResult{ScheduleId: entry.ID, ResumeAt: resumeAt}
+		if !c.DryRun && !resumeAt.After(time.Now()) {
+			err := sch.Unpause(cctx, client.ScheduleUnpauseOptions{
+				Note: fmt.Sprintf("resumed after pause (%v)", note),
+			})
+			if err != nil {
+				return fmt.Errorf("failed unpausing schedule %v: %w", entry.ID, err)
+			}
+			result.Resumed = true
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 && !cctx.JSONOutput {
+		cctx.Printer.Println("No paused schedules with a resume time")
+		return nil
+	}
+	return cctx.Printer.PrintStructured(results, printer.StructuredOptions{Table: &printer.TableOptions{}})
+}
+
 func (c *TemporalScheduleTriggerCommand) run(cctx *CommandContext, args []string) error {
 	cl, err := c.Parent.ClientOptions.dialClient(cctx)
 	if err != nil {
@@ -508,11 +871,21 @@ func (c *TemporalScheduleTriggerCommand) run(cctx *CommandContext, args []string
 }
 
 func (c *TemporalScheduleUpdateCommand) run(cctx *CommandContext, args []string) error {
-	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if c.Edit {
+		if c.ScheduleId == "" {
+			return fmt.Errorf("schedule ID is required")
+		} else if err := checkOnlyEditFlags(cctx, c.Command.LocalNonPersistentFlags(), "schedule-id"); err != nil {
+			return err
+		}
+	}
+	cl, err := c.Parent.ClientOptions.dialClient(cctx, grpc.WithChainUnaryInterceptor(scheduleConflictTokenInterceptor()))
 	if err != nil {
 		return err
 	}
 	defer cl.Close()
+	if c.Edit {
+		return c.runEdit(cctx, cl)
+	}
 
 	newSchedule := client.Schedule{
 		Spec: &client.ScheduleSpec{},
@@ -537,19 +910,156 @@ func (c *TemporalScheduleUpdateCommand) run(cctx *CommandContext, args []string)
 
 	if err = c.toScheduleSpec(newSchedule.Spec); err != nil {
 		return err
-	} else if newSchedule.Action, err = toScheduleAction(&c.SharedWorkflowStartOptions, &c.PayloadInputOptions); err != nil {
+	} else if newSchedule.Action, err = toScheduleAction(cctx, &c.SharedWorkflowStartOptions, &c.PayloadInputOptions); err != nil {
+		return err
+	} else if err = checkSchedule(cctx, newSchedule.Spec, c.CatchupWindow.Duration(), newSchedule.Action); err != nil {
 		return err
 	}
 
+	// The server applies updates asynchronously and silently drops them if
+	// another update happened since the schedule was read (per the conflict
+	// token), so wait for each to be applied and, if another won, send it again
+	// with the conflict token of a fresh describe
 	sch := cl.ScheduleClient().GetHandle(cctx, c.ScheduleId)
-	return sch.Update(cctx, client.ScheduleUpdateOptions{
-		DoUpdate: func(u client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
-			// replace whole schedule
-			return &client.ScheduleUpdate{
-				Schedule: &newSchedule,
-			}, nil
-		},
+	for attempt := 1; ; attempt++ {
+		var lastUpdateAt time.Time
+		err := sch.Update(cctx, client.ScheduleUpdateOptions{
+			DoUpdate: func(u client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+				lastUpdateAt = u.Description.Info.LastUpdateAt
+				// replace whole schedule
+				return &client.ScheduleUpdate{
+					Schedule: &newSchedule,
+				}, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		applied, err := waitScheduleUpdateApplied(cctx, sch, lastUpdateAt, func(actual client.Schedule) bool {
+			return scheduleUpdateMatches(actual, &newSchedule)
+		})
+		if err != nil || applied {
+			return err
+		} else if attempt == updateConflictAttempts {
+			return fmt.Errorf("schedule kept being updated by someone else, gave up after %v attempts", attempt)
+		}
+		cctx.Logger.Info("Schedule was updated by someone else at the same time, retrying", "attempt", attempt)
+	}
+}
+
+// Replaces the schedule with the user's edit of it. The edit is not retried on
+// conflict since it was made from the values that were replaced.
+func (c *TemporalScheduleUpdateCommand) runEdit(cctx *CommandContext, cl client.Client) error {
+	desc, err := cl.WorkflowService().DescribeSchedule(cctx, &workflowservice.DescribeScheduleRequest{
+		Namespace:  c.Parent.Namespace,
+		ScheduleId: c.ScheduleId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed describing schedule: %w", err)
+	}
+	edited, err := cctx.editAsYAML("schedule", desc.Schedule)
+	if err != nil {
+		return err
+	} else if edited == nil {
+		cctx.Printer.Println("No changes")
+		return nil
+	}
+	newSchedule := edited.(*schedpb.Schedule)
+	if newSchedule.Spec == nil {
+		return fmt.Errorf("edited schedule must have a spec")
+	} else if newSchedule.Action.GetStartWorkflow() == nil {
+		return fmt.Errorf("edited schedule must have a start workflow action")
+	}
+
+	_, err = cl.WorkflowService().UpdateSchedule(cctx, &workflowservice.UpdateScheduleRequest{
+		Namespace:     c.Parent.Namespace,
+		ScheduleId:    c.ScheduleId,
+		Schedule:      newSchedule,
+		ConflictToken: desc.ConflictToken,
+		Identity:      clientIdentity(),
+		RequestId:     uuid.NewString(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed updating schedule: %w", err)
+	}
+	sch := cl.ScheduleClient().GetHandle(cctx, c.ScheduleId)
+	sent := newSchedule.Action.GetStartWorkflow()
+	applied, err := waitScheduleUpdateApplied(cctx, sch, desc.Info.GetUpdateTime().AsTime(), func(actual client.Schedule) bool {
+		action, _ := actual.Action.(*client.ScheduleWorkflowAction)
+		return action != nil &&
+			action.ID == sent.WorkflowId &&
+			fmt.Sprint(action.Workflow) == sent.WorkflowType.GetName() &&
+			action.TaskQueue == sent.TaskQueue.GetName() &&
+			actual.State.Note == newSchedule.State.GetNotes() &&
+			actual.State.Paused == newSchedule.State.GetPaused()
 	})
+	if err != nil {
+		return err
+	} else if !applied {
+		return fmt.Errorf("schedule was updated by someone else while being edited, run again to edit the latest version")
+	}
+	cctx.Printer.Println("Schedule updated")
+	return nil
+}
+
+// The SDK does not send the conflict token from the describe it does before
+// an update, so this adds it. Otherwise updates replace concurrent changes
+// instead of conflicting with them.
+func scheduleConflictTokenInterceptor() grpc.UnaryClientInterceptor {
+	var conflictToken []byte
+	return func(
+		ctx context.Context,
+		method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		if updateReq, ok := req.(*workflowservice.UpdateScheduleRequest); ok && updateReq.ConflictToken == nil {
+			updateReq.ConflictToken = conflictToken
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if describeResp, ok := reply.(*workflowservice.DescribeScheduleResponse); ok && err == nil {
+			conflictToken = describeResp.ConflictToken
+		}
+		return err
+	}
+}
+
+// Waits until the schedule has been updated since the given time and returns
+// whether it was this update that was applied.
+func waitScheduleUpdateApplied(
+	cctx *CommandContext,
+	sch client.ScheduleHandle,
+	lastUpdateAt time.Time,
+	matches func(client.Schedule) bool,
+) (bool, error) {
+	ctx, cancel := context.WithTimeout(cctx, 10*time.Second)
+	defer cancel()
+	for {
+		desc, err := sch.Describe(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed checking schedule update: %w", err)
+		} else if desc.Info.LastUpdateAt.After(lastUpdateAt) {
+			return matches(desc.Schedule), nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("timed out waiting for schedule update to be applied")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Compares the parts of the schedule the server does not normalize.
+func scheduleUpdateMatches(actual client.Schedule, sent *client.Schedule) bool {
+	actualAction, _ := actual.Action.(*client.ScheduleWorkflowAction)
+	sentAction, _ := sent.Action.(*client.ScheduleWorkflowAction)
+	if actualAction == nil || sentAction == nil {
+		return actualAction == sentAction
+	}
+	return actualAction.ID == sentAction.ID &&
+		fmt.Sprint(actualAction.Workflow) == fmt.Sprint(sentAction.Workflow) &&
+		actualAction.TaskQueue == sentAction.TaskQueue &&
+		actual.State.Note == sent.State.Note &&
+		actual.State.Paused == sent.State.Paused
 }
 
 func formatCalendarSpec(spec client.ScheduleCalendarSpec) *schedpb.CalendarSpec {
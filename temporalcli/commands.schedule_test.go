@@ -8,11 +8,18 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"regexp"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/temporalio/cli/temporalcli"
+	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/workflow"
+	"google.golang.org/grpc"
 )
 
 func (s *SharedServerSuite) createSchedule(args ...string) (schedId, schedWfId string, res *CommandResult) {
@@ -130,6 +137,49 @@ func (s *SharedServerSuite) TestSchedule_Describe() {
 	s.Equal(schedWfId, j.Schedule.Action.StartWorkflow.Id)
 }
 
+func (s *SharedServerSuite) TestSchedule_Describe_ICal() {
+	schedId, _, res := s.createSchedule("--interval", "1h")
+	s.NoError(res.Err)
+
+	// Next 3
+	res = s.Execute(
+		"schedule", "describe",
+		"--address", s.Address(),
+		"-s", schedId,
+		"--ical", "--ical-count", "3",
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.True(strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	s.True(strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	s.Equal(3, strings.Count(out, "BEGIN:VEVENT"))
+	s.Contains(out, "SUMMARY:Temporal Schedule "+schedId+": DevWorkflow\r\n")
+
+	// Date range
+	start := time.Now().Truncate(time.Hour).Add(time.Hour)
+	res = s.Execute(
+		"schedule", "describe",
+		"--address", s.Address(),
+		"-s", schedId,
+		"--ical", "--ical-count", "0",
+		"--ical-start", start.Format(time.RFC3339),
+		"--ical-end", start.Add(5*time.Hour).Format(time.RFC3339),
+	)
+	s.NoError(res.Err)
+	out = res.Stdout.String()
+	s.Equal(5, strings.Count(out, "BEGIN:VEVENT"))
+	s.Contains(out, "DTSTART:"+start.Add(time.Hour).UTC().Format("20060102T150405Z")+"\r\n")
+
+	// Not with JSON
+	res = s.Execute(
+		"schedule", "describe",
+		"--address", s.Address(),
+		"-s", schedId,
+		"--ical", "-o", "json",
+	)
+	s.ErrorContains(res.Err, "cannot use --ical with JSON output")
+}
+
 func (s *SharedServerSuite) TestSchedule_CreateDescribeCalendar() {
 	schedId, _, res := s.createSchedule("--calendar", `{"hour":"2,4","dayOfWeek":"thu,fri"}`)
 	s.NoError(res.Err)
@@ -172,6 +222,27 @@ func (s *SharedServerSuite) TestSchedule_CreateDescribe_SearchAttributes_Memo()
 	s.ContainsOnSameLine(out, "Action", "wfMemo", b64(`"other data"`))
 }
 
+func (s *SharedServerSuite) TestSchedule_CreateDescribe_MemoFile() {
+	memoFile := filepath.Join(s.T().TempDir(), "memo.json")
+	s.NoError(os.WriteFile(memoFile, []byte(`"file data"`), 0644))
+
+	schedId, _, res := s.createSchedule("--interval", "10d",
+		"--schedule-memo-file", "schedMemo="+memoFile,
+		"--memo-file", "wfMemo="+memoFile,
+	)
+	s.NoError(res.Err)
+
+	res = s.Execute(
+		"schedule", "describe",
+		"--address", s.Address(),
+		"-s", schedId,
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "Memo", "schedMemo", `"file data"`)
+	s.ContainsOnSameLine(out, "Action", "wfMemo", `"file data"`)
+}
+
 func (s *SharedServerSuite) TestSchedule_List() {
 	schedId, _, res := s.createSchedule("--interval", "10d")
 	s.NoError(res.Err)
@@ -298,6 +369,70 @@ func (s *SharedServerSuite) TestSchedule_Toggle() {
 	s.ContainsOnSameLine(out, "Notes", "done testing")
 }
 
+func (s *SharedServerSuite) TestSchedule_Toggle_ResumeAfter() {
+	schedId, _, res := s.createSchedule("--interval", "10d")
+	s.NoError(res.Err)
+
+	res = s.Execute(
+		"schedule", "toggle",
+		"--address", s.Address(),
+		"-s", schedId,
+		"--pause",
+		"--reason", "testing",
+		"--resume-after", "2s",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "due to resume at")
+
+	// Nothing is reported paused if pausing fails
+	res = s.Execute(
+		"schedule", "toggle",
+		"--address", s.Address(),
+		"-s", "does-not-exist",
+		"--pause",
+		"--resume-after", "2s",
+	)
+	s.Error(res.Err)
+	s.NotContains(res.Stdout.String(), "due to resume at")
+
+	res = s.Execute(
+		"schedule", "describe",
+		"--address", s.Address(),
+		"-s", schedId,
+	)
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "Notes", "testing (resume after")
+
+	// Dry run shows it but does not resume, and list can be stale so wait for it
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"schedule", "resume-due",
+			"--address", s.Address(),
+			"--dry-run",
+		)
+		s.NoError(res.Err)
+		return AssertContainsOnSameLine(res.Stdout.String(), schedId, "false") == nil
+	}, 10*time.Second, 200*time.Millisecond)
+
+	// Once due, it is resumed
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"schedule", "resume-due",
+			"--address", s.Address(),
+		)
+		s.NoError(res.Err)
+		return AssertContainsOnSameLine(res.Stdout.String(), schedId, "true") == nil
+	}, 10*time.Second, 500*time.Millisecond)
+	res = s.Execute(
+		"schedule", "describe",
+		"--address", s.Address(),
+		"-s", schedId,
+	)
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "Paused", "false")
+	s.ContainsOnSameLine(res.Stdout.String(), "Notes", "resumed after pause (testing)")
+}
+
 func (s *SharedServerSuite) TestSchedule_Trigger() {
 	schedId, schedWfId, res := s.createSchedule("--interval", "10d")
 	s.NoError(res.Err)
@@ -397,6 +532,100 @@ func (s *SharedServerSuite) TestSchedule_Update() {
 	}, 10*time.Second, 100*time.Millisecond)
 }
 
+func (s *SharedServerSuite) TestSchedule_Update_RetriesOnConflict() {
+	schedId, schedWfId, res := s.createSchedule("--interval", "10d")
+	s.NoError(res.Err)
+
+	// Apply a concurrent update right before the first update is sent, so the
+	// first update is dropped as a conflict
+	var updates atomic.Int32
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+		) error {
+			if strings.HasSuffix(method, "/UpdateSchedule") && updates.Add(1) == 1 {
+				handle := s.Client.ScheduleClient().GetHandle(s.Context, schedId)
+				before, err := handle.Describe(s.Context)
+				s.NoError(err)
+				s.NoError(handle.Update(s.Context, client.ScheduleUpdateOptions{
+					DoUpdate: func(u client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+						u.Description.Schedule.State.Note = "concurrent"
+						return &client.ScheduleUpdate{Schedule: &u.Description.Schedule}, nil
+					},
+				}))
+				s.Eventually(func() bool {
+					after, err := handle.Describe(s.Context)
+					return err == nil && after.Info.LastUpdateAt.After(before.Info.LastUpdateAt)
+				}, 5*time.Second, 50*time.Millisecond)
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+
+	res = s.Execute(
+		"schedule", "update",
+		"--address", s.Address(),
+		"-s", schedId,
+		"--task-queue", "SomeOtherTq",
+		"--type", "SomeOtherWf",
+		"--workflow-id", schedWfId,
+		"--interval", "1h",
+		"--notes", "mine",
+	)
+	s.NoError(res.Err)
+	s.Equal(int32(2), updates.Load())
+	desc, err := s.Client.ScheduleClient().GetHandle(s.Context, schedId).Describe(s.Context)
+	s.NoError(err)
+	s.Equal("mine", desc.Schedule.State.Note)
+	s.Equal("SomeOtherTq", desc.Schedule.Action.(*client.ScheduleWorkflowAction).TaskQueue)
+}
+
+func (s *SharedServerSuite) TestSchedule_Update_Edit() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("test uses sed as the editor")
+	}
+	schedId, _, res := s.createSchedule("--interval", "10d", "--notes", "before-edit")
+	s.NoError(res.Err)
+	editor := ""
+	s.CommandHarness.Options.LookupEnv = func(key string) (string, bool) {
+		if key == "EDITOR" {
+			return editor, true
+		}
+		return "", false
+	}
+
+	// Other options can't be given
+	res = s.Execute("schedule", "update", "--address", s.Address(), "-s", schedId, "--edit", "--notes", "x")
+	s.ErrorContains(res.Err, "--notes cannot be used with --edit")
+
+	// Unchanged does nothing
+	editor = "sed -i s/not-present/x/"
+	res = s.Execute("schedule", "update", "--address", s.Address(), "-s", schedId, "--edit")
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "No changes")
+
+	// Invalid edits are rejected
+	editor = "sed -i s/notes:/bogusField:/"
+	res = s.Execute("schedule", "update", "--address", s.Address(), "-s", schedId, "--edit")
+	s.ErrorContains(res.Err, "invalid schedule")
+	s.ErrorContains(res.Err, "bogusField")
+
+	// Changes are shown and applied
+	editor = "sed -i s/before-edit/after-edit/"
+	res = s.Execute("schedule", "update", "--address", s.Address(), "-s", schedId, "--edit")
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "-", "notes: before-edit")
+	s.ContainsOnSameLine(res.Stdout.String(), "+", "notes: after-edit")
+	s.Contains(res.Stdout.String(), "Schedule updated")
+	desc, err := s.Client.ScheduleClient().GetHandle(s.Context, schedId).Describe(s.Context)
+	s.NoError(err)
+	s.Equal("after-edit", desc.Schedule.State.Note)
+	s.Equal(10*24*time.Hour, desc.Schedule.Spec.Intervals[0].Every)
+}
+
 func (s *SharedServerSuite) TestSchedule_Memo_Update() {
 	schedId, schedWfId, res := s.createSchedule("--memo", "bar=1")
 	s.NoError(res.Err)
@@ -440,3 +669,17 @@ func (s *SharedServerSuite) TestSchedule_Memo_Update() {
 		return j.Schedule.Action.StartWorkflow.Memo.Fields.Bar.Data == "Mg=="
 	}, 10*time.Second, 100*time.Millisecond)
 }
+
+func (s *SharedServerSuite) TestSchedule_Create_Lint() {
+	// Out of range calendar fields fail
+	_, _, res := s.createSchedule("--calendar", `{"hour":"25"}`)
+	s.ErrorContains(res.Err, "hour value 25 is out of range 0-23")
+	_, _, res = s.createSchedule("--cron", "0 12 * 13 *")
+	s.ErrorContains(res.Err, "month value 13 is out of range 1-12")
+
+	// Adjusted settings warn but still create
+	_, _, res = s.createSchedule("--interval", "10m", "--jitter", "1h", "--catchup-window", "5s")
+	s.NoError(res.Err)
+	s.Contains(res.Stderr.String(), "Jitter 1h0m0s is larger than interval 10m0s")
+	s.Contains(res.Stderr.String(), "Catchup window 5s is below the server minimum")
+}
@@ -0,0 +1,235 @@
+package temporalcli
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Commands that can't be run over the API since they run servers, write files,
+// or open a browser. Env config changes are denied since env config can set the
+// denied flags for later commands.
+var cliAPIDeniedCommands = []string{
+	"temporal env delete",
+	"temporal env import",
+	"temporal env set",
+	"temporal env use",
+	"temporal gen",
+	"temporal serve-api",
+	"temporal server",
+	"temporal workflow view",
+}
+
+// Flags that can't be given over the API since they run processes or write
+// files. Only the request args are checked, so the ones set by this command's
+// own env config and env vars still apply.
+var cliAPIDeniedFlags = []string{
+	"credential-helper",
+	"data-converter-plugin",
+	"edit",
+	"env-file",
+	"log-file",
+	"output-file",
+	"target",
+}
+
+func (c *TemporalServeApiCommand) run(cctx *CommandContext, args []string) error {
+	ln, err := net.Listen("tcp", c.Listen)
+	if err != nil {
+		return fmt.Errorf("failed listening: %w", err)
+	}
+	listenHost, _, _ := net.SplitHostPort(c.Listen)
+	if !isLoopbackHost(listenHost) {
+		cctx.Logger.Warn("Serving CLI API on a non-loopback address", "address", ln.Addr())
+	}
+	api := &cliAPI{options: cctx.Options, authToken: c.AuthToken, listenHost: listenHost}
+	if api.authToken == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return fmt.Errorf("failed generating auth token: %w", err)
+		}
+		api.authToken = hex.EncodeToString(b)
+		cctx.Printer.Printlnf("Auth token: %v", api.authToken)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/health", api.handleHealth)
+	mux.HandleFunc("/v1/commands", api.handleCommand)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+	cctx.Printer.Printlnf("Serving CLI API at http://%v", ln.Addr())
+	select {
+	case err := <-errCh:
+		return err
+	case <-cctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type cliAPI struct {
+	options   CommandOptions
+	authToken string
+	// Host requests may be addressed to besides loopback ones
+	listenHost string
+	// Commands share process-wide state like color settings, so only one runs
+	// at a time
+	lock sync.Mutex
+}
+
+type cliAPICommandRequest struct {
+	Args []string `json:"args"`
+}
+
+type cliAPICommandResponse struct {
+	ExitCode int             `json:"exitCode"`
+	Output   json.RawMessage `json:"output,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+func isLoopbackHost(host string) bool {
+	return host == "localhost" || net.ParseIP(host).IsLoopback()
+}
+
+func (a *cliAPI) authorized(w http.ResponseWriter, r *http.Request) bool {
+	// Browsers always send an Origin on cross-origin requests, and a Host that
+	// isn't ours means DNS rebinding, so neither can come from a web page
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	if !isLoopbackHost(host) && host != a.listenHost {
+		writeCLIAPIError(w, http.StatusForbidden, fmt.Sprintf("invalid host %q", r.Host))
+		return false
+	} else if r.Header.Get("Origin") != "" {
+		writeCLIAPIError(w, http.StatusForbidden, "requests from browsers are not allowed")
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if ok && subtle.ConstantTimeCompare([]byte(token), []byte(a.authToken)) == 1 {
+		return true
+	}
+	writeCLIAPIError(w, http.StatusUnauthorized, "missing or invalid auth token")
+	return false
+}
+
+func (a *cliAPI) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if a.authorized(w, r) {
+		writeCLIAPIJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	}
+}
+
+func (a *cliAPI) handleCommand(w http.ResponseWriter, r *http.Request) {
+	if !a.authorized(w, r) {
+		return
+	} else if r.Method != http.MethodPost {
+		writeCLIAPIError(w, http.StatusMethodNotAllowed, "commands must be POSTed")
+		return
+	} else if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		writeCLIAPIError(w, http.StatusUnsupportedMediaType, "content type must be application/json")
+		return
+	}
+	var req cliAPICommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeCLIAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	} else if len(req.Args) == 0 {
+		writeCLIAPIError(w, http.StatusBadRequest, "args required")
+		return
+	}
+	cmd, err := a.parseCommand(req.Args)
+	if err != nil {
+		writeCLIAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Run the command with the same environment as this one, always with JSON
+	// output and never prompting
+	var stdout, stderr bytes.Buffer
+	var cmdErr error
+	options := a.options
+	options.Args = append(append([]string{}, req.Args...), "--output", "json", "--color", "never")
+	if cmd.Flags().Changed("env") {
+		// Let the command pick its own environment
+		options.EnvConfigName = ""
+	}
+	options.Stdin = bytes.NewReader(nil)
+	options.Stdout = &stdout
+	options.Stderr = &stderr
+	options.Fail = func(err error) {
+		if cmdErr == nil {
+			cmdErr = err
+		}
+	}
+	a.lock.Lock()
+	Execute(r.Context(), options)
+	a.lock.Unlock()
+
+	resp := cliAPICommandResponse{}
+	if out := bytes.TrimSpace(stdout.Bytes()); len(out) > 0 {
+		if json.Valid(out) {
+			resp.Output = out
+		} else {
+			// Commands that print plain text even in JSON mode
+			resp.Output, _ = json.Marshal(string(out))
+		}
+	}
+	if cmdErr != nil {
+		resp.ExitCode = 1
+		resp.Error = cmdErr.Error()
+	}
+	writeCLIAPIJSON(w, http.StatusOK, resp)
+}
+
+// Parses the args the same way running them would, to check the command and
+// flags are allowed regardless of where they appear
+func (a *cliAPI) parseCommand(args []string) (*cobra.Command, error) {
+	cmd, rest, err := NewTemporalCommand(&CommandContext{}).Command.Find(args)
+	if err != nil {
+		return nil, err
+	} else if err := cmd.ParseFlags(rest); err != nil {
+		return nil, err
+	}
+	for _, denied := range cliAPIDeniedCommands {
+		if path := cmd.CommandPath(); path == denied || strings.HasPrefix(path, denied+" ") {
+			return nil, fmt.Errorf("%v commands can't be run over the API", strings.TrimPrefix(denied, "temporal "))
+		}
+	}
+	for _, denied := range cliAPIDeniedFlags {
+		if flag := cmd.Flags().Lookup(denied); flag != nil && flag.Changed {
+			return nil, fmt.Errorf("--%v can't be used over the API", denied)
+		}
+	}
+	return cmd, nil
+}
+
+func writeCLIAPIError(w http.ResponseWriter, status int, msg string) {
+	writeCLIAPIJSON(w, status, cliAPICommandResponse{ExitCode: 1, Error: msg})
+}
+
+func writeCLIAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -1,11 +1,29 @@
 package temporalcli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"math/rand"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/temporalio/cli/temporalcli/devserver"
+	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func (t *TemporalServerStartDevCommand) run(cctx *CommandContext, args []string) error {
@@ -13,6 +31,11 @@ func (t *TemporalServerStartDevCommand) run(cctx *CommandContext, args []string)
 	if t.Ip == "localhost" {
 		t.Ip = "127.0.0.1"
 	}
+	// Port 0 means any free port, which also makes the default UI port free
+	anyPort := t.Port == 0
+	if anyPort {
+		t.Port = devserver.MustGetFreePort(t.Ip)
+	}
 	// Prepare options
 	opts := devserver.StartOptions{
 		FrontendIP:             t.Ip,
@@ -46,13 +69,29 @@ func (t *TemporalServerStartDevCommand) run(cctx *CommandContext, args []string)
 	if err := devserver.CheckPortFree(opts.FrontendIP, opts.FrontendHTTPPort); err != nil {
 		return fmt.Errorf("can't set frontend HTTP port %d: %w", opts.FrontendHTTPPort, err)
 	}
+	// Additional binds can't overlap an IP that is already all interfaces
+	if len(t.Bind) > 0 {
+		if ip := net.ParseIP(t.Ip); ip != nil && ip.IsUnspecified() {
+			return fmt.Errorf("cannot use --bind when --ip is %v", t.Ip)
+		}
+		for _, bind := range t.Bind {
+			if net.ParseIP(bind) == nil {
+				return fmt.Errorf("invalid bind IP %q", bind)
+			} else if err := devserver.CheckPortFree(bind, opts.FrontendPort); err != nil {
+				return fmt.Errorf("can't bind frontend port %d on %v: %w", opts.FrontendPort, bind, err)
+			}
+		}
+		opts.AdditionalFrontendIPs = t.Bind
+	}
 	// Setup UI
 	if !t.Headless {
 		opts.UIIP, opts.UIPort = t.Ip, t.UiPort
 		if opts.UIIP == "" {
 			opts.UIIP = t.Ip
 		}
-		if opts.UIPort == 0 {
+		if opts.UIPort == 0 && anyPort {
+			opts.UIPort = devserver.MustGetFreePort(opts.UIIP)
+		} else if opts.UIPort == 0 {
 			opts.UIPort = t.Port + 1000
 			if err := devserver.CheckPortFree(opts.UIIP, opts.UIPort); err != nil {
 				return fmt.Errorf("can't use default UI port %d (%d + 1000): %w", opts.UIPort, t.Port, err)
@@ -87,6 +126,47 @@ func (t *TemporalServerStartDevCommand) run(cctx *CommandContext, args []string)
 		}
 	}
 
+	// Namespace overrides
+	if opts.NamespaceRetention, err = namespaceOverrides(opts.Namespaces, t.Retention, func(s string) (time.Duration, error) {
+		var d Duration
+		if err := d.Set(s); err != nil {
+			return 0, err
+		} else if d <= 0 {
+			return 0, fmt.Errorf("must be positive")
+		}
+		return d.Duration(), nil
+	}); err != nil {
+		return fmt.Errorf("invalid retention: %w", err)
+	}
+	if opts.NamespaceMaxHistoryEvents, err = namespaceOverrides(opts.Namespaces, t.MaxHistoryEvents, func(s string) (int, error) {
+		count, err := strconv.Atoi(s)
+		if err == nil && count <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+		return count, err
+	}); err != nil {
+		return fmt.Errorf("invalid max history events: %w", err)
+	}
+
+	if t.LogRpc {
+		opts.GRPCInterceptors = append(opts.GRPCInterceptors, rpcLoggingInterceptor(cctx.Logger))
+	}
+	if len(t.InjectFailures) > 0 {
+		failures, err := parseInjectedFailures(t.InjectFailures)
+		if err != nil {
+			return err
+		}
+		opts.GRPCInterceptors = append(opts.GRPCInterceptors, failureInjectionInterceptor(cctx.Logger, failures))
+	}
+
+	// Fetch search attributes to import before starting so a bad env fails fast
+	var importSearchAttributes map[string]enums.IndexedValueType
+	if t.ImportSearchAttributesFromEnv != "" {
+		if importSearchAttributes, err = t.envSearchAttributes(cctx); err != nil {
+			return err
+		}
+	}
+
 	// If not using DB file, set persistent cluster ID
 	if t.DbFilename == "" {
 		opts.ClusterID = persistentClusterID()
@@ -107,27 +187,294 @@ func (t *TemporalServerStartDevCommand) run(cctx *CommandContext, args []string)
 		}
 	}
 
+	// Server runs until interrupted, the Windows service is stopped, or the
+	// parent process exits
+	ctx, cancel := context.WithCancel(cctx)
+	defer cancel()
+	serviceStopped, err := handleServiceStop(cancel)
+	if err != nil {
+		return fmt.Errorf("failed handling service: %w", err)
+	}
+	defer serviceStopped()
+	if t.ParentPid > 0 {
+		parentExited, err := watchProcessExit(ctx, t.ParentPid)
+		if err != nil {
+			return fmt.Errorf("failed watching parent process %v: %w", t.ParentPid, err)
+		}
+		go func() {
+			select {
+			case <-parentExited:
+				cctx.Logger.Info("Parent process exited, stopping server", "pid", t.ParentPid)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
 	// Start, wait for context complete, then stop
 	s, err := devserver.Start(opts)
 	if err != nil {
 		return fmt.Errorf("failed starting server: %w", err)
 	}
 	defer s.Stop()
+	if len(importSearchAttributes) > 0 {
+		if err := t.registerSearchAttributes(cctx, opts.Namespaces, importSearchAttributes); err != nil {
+			return err
+		}
+	}
 
 	friendlyIP := t.Ip
 	if friendlyIP == "127.0.0.1" {
 		friendlyIP = "localhost"
 	}
-	cctx.Printer.Printlnf("%-16s %v:%v", "Temporal server:", friendlyIP, t.Port)
+	announce := devServerAnnouncement{
+		Address:     net.JoinHostPort(friendlyIP, strconv.Itoa(t.Port)),
+		GRPCPort:    t.Port,
+		HTTPPort:    opts.FrontendHTTPPort,
+		MetricsPort: opts.MetricsPort,
+	}
+	for _, bind := range t.Bind {
+		announce.AdditionalAddresses = append(announce.AdditionalAddresses,
+			net.JoinHostPort(bind, strconv.Itoa(t.Port)))
+	}
+	if !t.Headless {
+		announce.UIAddress = fmt.Sprintf("http://%v", net.JoinHostPort(friendlyIP, strconv.Itoa(opts.UIPort)))
+		announce.UIPort = opts.UIPort
+	}
+	if t.AnnounceFile != "" {
+		b, err := json.MarshalIndent(announce, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed marshaling announcement: %w", err)
+		} else if err := os.WriteFile(t.AnnounceFile, b, 0644); err != nil {
+			return fmt.Errorf("failed writing announce file: %w", err)
+		}
+		defer os.Remove(t.AnnounceFile)
+	}
+	if cctx.JSONOutput {
+		if err := cctx.Printer.PrintStructured(announce, printer.StructuredOptions{}); err != nil {
+			return err
+		}
+	}
+	cctx.Printer.Printlnf("%-16s %v", "Temporal server:", announce.Address)
+	for _, addr := range announce.AdditionalAddresses {
+		cctx.Printer.Printlnf("%-16s %v", "", addr)
+	}
 	if !t.Headless {
-		cctx.Printer.Printlnf("%-16s http://%v:%v", "Web UI:", friendlyIP, opts.UIPort)
+		cctx.Printer.Printlnf("%-16s %v", "Web UI:", announce.UIAddress)
 	}
-	cctx.Printer.Printlnf("%-16s http://%v:%v/metrics", "Metrics:", friendlyIP, opts.MetricsPort)
-	<-cctx.Done()
+	cctx.Printer.Printlnf("%-16s http://%v/metrics", "Metrics:",
+		net.JoinHostPort(friendlyIP, strconv.Itoa(opts.MetricsPort)))
+	<-ctx.Done()
 	cctx.Printer.Println("Stopping server...")
 	return nil
 }
 
+type devServerAnnouncement struct {
+	Address             string   `json:"address"`
+	AdditionalAddresses []string `json:"additionalAddresses,omitempty"`
+	GRPCPort            int      `json:"grpcPort"`
+	UIAddress           string   `json:"uiAddress,omitempty"`
+	UIPort              int      `json:"uiPort,omitempty"`
+	HTTPPort            int      `json:"httpPort,omitempty"`
+	MetricsPort         int      `json:"metricsPort"`
+}
+
+func (t *TemporalServerStartDevCommand) envSearchAttributes(
+	cctx *CommandContext,
+) (map[string]enums.IndexedValueType, error) {
+	clientOpts, err := clientOptionsFromEnvConfig(cctx, t.ImportSearchAttributesFromEnv)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := clientOpts.dialClient(cctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed connecting to environment %q: %w", t.ImportSearchAttributesFromEnv, err)
+	}
+	defer cl.Close()
+	resp, err := cl.OperatorService().ListSearchAttributes(cctx, &operatorservice.ListSearchAttributesRequest{
+		Namespace: clientOpts.Namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing search attributes of environment %q: %w",
+			t.ImportSearchAttributesFromEnv, err)
+	}
+	return resp.CustomAttributes, nil
+}
+
+// Registers the search attributes that do not already exist on each namespace
+func (t *TemporalServerStartDevCommand) registerSearchAttributes(
+	cctx *CommandContext,
+	namespaces []string,
+	searchAttributes map[string]enums.IndexedValueType,
+) error {
+	cl, err := client.Dial(client.Options{
+		HostPort: net.JoinHostPort(t.Ip, strconv.Itoa(t.Port)),
+		Logger:   log.NewStructuredLogger(cctx.Logger),
+	})
+	if err != nil {
+		return fmt.Errorf("failed connecting to started server: %w", err)
+	}
+	defer cl.Close()
+	for _, ns := range namespaces {
+		existing, err := cl.OperatorService().ListSearchAttributes(cctx, &operatorservice.ListSearchAttributesRequest{
+			Namespace: ns,
+		})
+		if err != nil {
+			return fmt.Errorf("failed listing search attributes of namespace %q: %w", ns, err)
+		}
+		toAdd := map[string]enums.IndexedValueType{}
+		for name, typ := range searchAttributes {
+			if _, ok := existing.CustomAttributes[name]; !ok {
+				toAdd[name] = typ
+			}
+		}
+		if len(toAdd) == 0 {
+			continue
+		}
+		_, err = cl.OperatorService().AddSearchAttributes(cctx, &operatorservice.AddSearchAttributesRequest{
+			Namespace:        ns,
+			SearchAttributes: toAdd,
+		})
+		if err != nil {
+			return fmt.Errorf("failed adding search attributes to namespace %q: %w", ns, err)
+		}
+		cctx.Logger.Info("Imported search attributes", "namespace", ns, "count", len(toAdd))
+	}
+	return nil
+}
+
+func rpcLoggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// Calls from the server's own internal workers are just noise to users
+		var clientName string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if name := md.Get("client-name"); len(name) > 0 {
+				clientName = name[0]
+			}
+		}
+		if clientName == "temporal-server" {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		attrs := []any{"method", info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]}
+		if r, ok := req.(interface{ GetNamespace() string }); ok && r.GetNamespace() != "" {
+			attrs = append(attrs, "namespace", r.GetNamespace())
+		}
+		if r, ok := req.(interface{ GetIdentity() string }); ok && r.GetIdentity() != "" {
+			attrs = append(attrs, "identity", r.GetIdentity())
+		}
+		if clientName != "" {
+			attrs = append(attrs, "client", clientName)
+		}
+		attrs = append(attrs, "latency", time.Since(start), "status", status.Code(err).String())
+		logger.Info("RPC", attrs...)
+		return resp, err
+	}
+}
+
+type injectedFailure struct {
+	// Empty for all methods
+	method  string
+	code    codes.Code
+	percent float64
+}
+
+// Parses SERVICE:METHOD:CODE:PERCENT values.
+func parseInjectedFailures(values []string) ([]injectedFailure, error) {
+	failures := make([]injectedFailure, 0, len(values))
+	for _, value := range values {
+		pieces := strings.Split(value, ":")
+		if len(pieces) != 4 {
+			return nil, fmt.Errorf("invalid injected failure %q, expected SERVICE:METHOD:CODE:PERCENT", value)
+		} else if pieces[0] != "frontend" {
+			return nil, fmt.Errorf("invalid injected failure %q, only the frontend service is supported", value)
+		}
+		var failure injectedFailure
+		if pieces[1] != "*" {
+			failure.method = pieces[1]
+		}
+		// Codes unmarshal from their quoted upper-case names
+		codeJSON := strconv.Quote(strings.ToUpper(pieces[2]))
+		if err := failure.code.UnmarshalJSON([]byte(codeJSON)); err != nil || failure.code == codes.OK {
+			return nil, fmt.Errorf("invalid injected failure %q, unknown gRPC status code %q", value, pieces[2])
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(pieces[3], "%"), 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			return nil, fmt.Errorf("invalid injected failure %q, percent must be above 0 and at most 100", value)
+		}
+		failure.percent = percent
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+func failureInjectionInterceptor(logger *slog.Logger, failures []injectedFailure) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// The server's own internal workers are not what is being tested
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if name := md.Get("client-name"); len(name) > 0 && name[0] == "temporal-server" {
+				return handler(ctx, req)
+			}
+		}
+		method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+		for _, failure := range failures {
+			if (failure.method == "" || failure.method == method) && rand.Float64()*100 < failure.percent {
+				logger.Info("Injecting RPC failure", "method", method, "code", failure.code.String())
+				return nil, status.Errorf(failure.code, "injected failure for %v", method)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Values are either VALUE for all namespaces or NAMESPACE=VALUE for one, with
+// the latter taking precedence regardless of order.
+func namespaceOverrides[T any](
+	namespaces []string,
+	values []string,
+	parse func(string) (T, error),
+) (map[string]T, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	ret := make(map[string]T, len(namespaces))
+	specific := map[string]bool{}
+	for _, value := range values {
+		ns, valueStr, hasNS := strings.Cut(value, "=")
+		if !hasNS {
+			valueStr = value
+		} else if !slices.Contains(namespaces, ns) {
+			return nil, fmt.Errorf("namespace %q is not pre-created", ns)
+		}
+		v, err := parse(valueStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", valueStr, err)
+		}
+		if hasNS {
+			ret[ns] = v
+			specific[ns] = true
+			continue
+		}
+		for _, ns := range namespaces {
+			if !specific[ns] {
+				ret[ns] = v
+			}
+		}
+	}
+	return ret, nil
+}
+
 func persistentClusterID() string {
 	// If there is not a database file in use, we want a cluster ID to be the same
 	// for every re-run, so we set it as an environment config in a special env
@@ -138,12 +485,12 @@ func persistentClusterID() string {
 		return uuid.NewString()
 	}
 	// Try to get existing first
-	env, _ := readEnvConfigFile(file)
-	if id := env["default"]["cluster-id"]; id != "" {
+	config, _ := readEnvConfigFile(file)
+	if id := config.Env["default"]["cluster-id"]; id != "" {
 		return id
 	}
 	// Create and try to write
 	id := uuid.NewString()
-	_ = writeEnvConfigFile(file, map[string]map[string]string{"default": {"cluster-id": id}})
+	_ = writeEnvConfigFile(file, envConfigFile{Env: map[string]map[string]string{"default": {"cluster-id": id}}})
 	return id
 }
@@ -2,7 +2,13 @@ package temporalcli_test
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,6 +17,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/temporalio/cli/temporalcli/devserver"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 )
 
@@ -63,6 +72,387 @@ func TestServer_StartDev_Simple(t *testing.T) {
 	}
 }
 
+func TestServer_StartDev_ParentPid(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses sleep process")
+	}
+	h := NewCommandHarness(t)
+	defer h.Close()
+
+	// Start a process to act as the parent
+	parent := exec.Command("sleep", "60")
+	h.NoError(parent.Start())
+	defer parent.Process.Kill()
+
+	resCh := make(chan *CommandResult, 1)
+	port := strconv.Itoa(devserver.MustGetFreePort("127.0.0.1"))
+	go func() {
+		resCh <- h.Execute("server", "start-dev", "-p", port, "--headless",
+			"--parent-pid", strconv.Itoa(parent.Process.Pid))
+	}()
+	h.EventuallyWithT(func(t *assert.CollectT) {
+		select {
+		case res := <-resCh:
+			require.NoError(t, res.Err)
+			require.Fail(t, "got early server result")
+		default:
+		}
+		cl, err := client.Dial(client.Options{HostPort: "127.0.0.1:" + port})
+		if assert.NoError(t, err) {
+			cl.Close()
+		}
+	}, 5*time.Second, 200*time.Millisecond)
+
+	// Kill the parent and confirm server stops on its own
+	h.NoError(parent.Process.Kill())
+	_ = parent.Wait()
+	select {
+	case <-time.After(20 * time.Second):
+		h.FailNow("didn't stop after 20 seconds")
+	case res := <-resCh:
+		h.NoError(res.Err)
+	}
+
+	// Unknown parent fails
+	res := h.Execute("server", "start-dev", "-p", port, "--headless", "--parent-pid", strconv.Itoa(parent.Process.Pid))
+	h.ErrorContains(res.Err, "failed watching parent process")
+}
+
+func TestServer_StartDev_AnyPortAnnounce(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	announceFile := filepath.Join(t.TempDir(), "announce.json")
+
+	// Start in background, then wait for announce file
+	resCh := make(chan *CommandResult, 1)
+	go func() {
+		resCh <- h.Execute("server", "start-dev", "-p", "0", "--announce-file", announceFile, "-o", "json")
+	}()
+	var announce struct {
+		Address     string `json:"address"`
+		GRPCPort    int    `json:"grpcPort"`
+		UIAddress   string `json:"uiAddress"`
+		UIPort      int    `json:"uiPort"`
+		MetricsPort int    `json:"metricsPort"`
+	}
+	var announceBytes []byte
+	h.EventuallyWithT(func(t *assert.CollectT) {
+		select {
+		case res := <-resCh:
+			require.NoError(t, res.Err)
+			require.Fail(t, "got early server result")
+		default:
+		}
+		var err error
+		announceBytes, err = os.ReadFile(announceFile)
+		assert.NoError(t, err)
+	}, 5*time.Second, 200*time.Millisecond)
+	h.NoError(json.Unmarshal(announceBytes, &announce))
+	h.NotZero(announce.GRPCPort)
+	h.NotEqual(7233, announce.GRPCPort)
+	h.Equal("localhost:"+strconv.Itoa(announce.GRPCPort), announce.Address)
+	h.NotZero(announce.UIPort)
+	h.NotEqual(announce.GRPCPort+1000, announce.UIPort)
+	h.Equal("http://localhost:"+strconv.Itoa(announce.UIPort), announce.UIAddress)
+	h.NotZero(announce.MetricsPort)
+
+	// Confirm connectable
+	cl, err := client.Dial(client.Options{HostPort: announce.Address})
+	h.NoError(err)
+	_, err = cl.CheckHealth(context.Background(), nil)
+	h.NoError(err)
+	cl.Close()
+
+	h.CancelContext()
+	select {
+	case <-time.After(20 * time.Second):
+		h.FailNow("didn't cleanup after 20 seconds")
+	case res := <-resCh:
+		h.NoError(res.Err)
+		h.JSONEq(string(announceBytes), res.Stdout.String())
+	}
+	h.NoFileExists(announceFile)
+}
+
+func TestServer_StartDev_IPv6AndBind(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+
+	// Start on IPv6 loopback with IPv4 loopback forwarded
+	port := strconv.Itoa(devserver.MustGetFreePort("::1"))
+	resCh := make(chan *CommandResult, 1)
+	go func() {
+		resCh <- h.Execute("server", "start-dev", "-p", port, "--headless", "--ip", "::1", "--bind", "127.0.0.1")
+	}()
+
+	// Both addresses must work
+	for _, hostPort := range []string{"[::1]:" + port, "127.0.0.1:" + port} {
+		h.EventuallyWithT(func(t *assert.CollectT) {
+			select {
+			case res := <-resCh:
+				require.NoError(t, res.Err)
+				require.Fail(t, "got early server result")
+			default:
+			}
+			cl, err := client.Dial(client.Options{HostPort: hostPort})
+			if assert.NoError(t, err) {
+				defer cl.Close()
+				_, err = cl.CheckHealth(context.Background(), nil)
+				assert.NoError(t, err)
+			}
+		}, 5*time.Second, 200*time.Millisecond)
+	}
+
+	h.CancelContext()
+	select {
+	case <-time.After(20 * time.Second):
+		h.FailNow("didn't cleanup after 20 seconds")
+	case res := <-resCh:
+		h.NoError(res.Err)
+		h.Contains(res.Stdout.String(), "[::1]:"+port)
+		h.Contains(res.Stdout.String(), "127.0.0.1:"+port)
+	}
+}
+
+func TestServer_StartDev_BindWithUnspecifiedIP(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	res := h.Execute("server", "start-dev", "--headless", "--ip", "0.0.0.0", "--bind", "127.0.0.1")
+	h.ErrorContains(res.Err, "cannot use --bind when --ip is 0.0.0.0")
+}
+
+func TestServer_StartDev_LogRPC(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+
+	// Start in background, then wait for client to be able to connect
+	port := strconv.Itoa(devserver.MustGetFreePort("127.0.0.1"))
+	resCh := make(chan *CommandResult, 1)
+	go func() {
+		resCh <- h.Execute("server", "start-dev", "-p", port, "--headless", "--log-rpc", "--log-format", "json")
+	}()
+	var cl client.Client
+	h.EventuallyWithT(func(t *assert.CollectT) {
+		select {
+		case res := <-resCh:
+			require.NoError(t, res.Err)
+			require.Fail(t, "got early server result")
+		default:
+		}
+		var err error
+		cl, err = client.Dial(client.Options{HostPort: "127.0.0.1:" + port, Identity: "rpc-logging-test"})
+		assert.NoError(t, err)
+	}, 3*time.Second, 200*time.Millisecond)
+	defer cl.Close()
+
+	_, err := cl.ExecuteWorkflow(
+		context.Background(),
+		client.StartWorkflowOptions{TaskQueue: "my-task-queue"},
+		"MyWorkflow",
+	)
+	h.NoError(err)
+
+	h.CancelContext()
+	var res *CommandResult
+	select {
+	case <-time.After(20 * time.Second):
+		h.FailNow("didn't cleanup after 20 seconds")
+	case res = <-resCh:
+		h.NoError(res.Err)
+	}
+
+	// Find the start call in the logs
+	var found bool
+	for _, line := range strings.Split(res.Stderr.String(), "\n") {
+		var entry map[string]any
+		if json.Unmarshal([]byte(line), &entry) != nil || entry["msg"] != "RPC" ||
+			entry["method"] != "StartWorkflowExecution" {
+			continue
+		}
+		found = true
+		h.Equal("default", entry["namespace"])
+		h.Equal("rpc-logging-test", entry["identity"])
+		h.Equal("temporal-go", entry["client"])
+		h.Equal("OK", entry["status"])
+		h.Contains(entry, "latency")
+	}
+	h.True(found)
+}
+
+func TestServer_StartDev_InjectFailures(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+
+	// Invalid values fail before starting
+	res := h.Execute("server", "start-dev", "--headless", "--inject-failures", "history:*:unavailable:5%")
+	h.ErrorContains(res.Err, "only the frontend service is supported")
+	res = h.Execute("server", "start-dev", "--headless", "--inject-failures", "frontend:*:not_a_code:5%")
+	h.ErrorContains(res.Err, `unknown gRPC status code "not_a_code"`)
+	res = h.Execute("server", "start-dev", "--headless", "--inject-failures", "frontend:*:unavailable:150%")
+	h.ErrorContains(res.Err, "percent must be above 0 and at most 100")
+
+	// Start in background, then wait for client to be able to connect
+	port := strconv.Itoa(devserver.MustGetFreePort("127.0.0.1"))
+	resCh := make(chan *CommandResult, 1)
+	go func() {
+		resCh <- h.Execute(
+			"server", "start-dev",
+			"-p", port,
+			"--headless",
+			"--inject-failures", "frontend:StartWorkflowExecution:permission_denied:100%",
+		)
+	}()
+	var cl client.Client
+	h.EventuallyWithT(func(t *assert.CollectT) {
+		select {
+		case res := <-resCh:
+			require.NoError(t, res.Err)
+			require.Fail(t, "got early server result")
+		default:
+		}
+		var err error
+		cl, err = client.Dial(client.Options{HostPort: "127.0.0.1:" + port})
+		assert.NoError(t, err)
+	}, 3*time.Second, 200*time.Millisecond)
+	defer cl.Close()
+
+	// Only the given method fails
+	_, err := cl.ExecuteWorkflow(
+		context.Background(),
+		client.StartWorkflowOptions{TaskQueue: "my-task-queue"},
+		"MyWorkflow",
+	)
+	h.ErrorContains(err, "injected failure for StartWorkflowExecution")
+	_, err = cl.WorkflowService().DescribeNamespace(context.Background(),
+		&workflowservice.DescribeNamespaceRequest{Namespace: "default"})
+	h.NoError(err)
+
+	h.CancelContext()
+	select {
+	case <-time.After(20 * time.Second):
+		h.FailNow("didn't cleanup after 20 seconds")
+	case res = <-resCh:
+		h.NoError(res.Err)
+	}
+	h.ContainsOnSameLine(res.Stderr.String(), "Injecting RPC failure", "StartWorkflowExecution", "PermissionDenied")
+}
+
+func TestServer_StartDev_NamespaceOverrides(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+
+	// Start in background, then wait for client to be able to connect
+	port := strconv.Itoa(devserver.MustGetFreePort("127.0.0.1"))
+	resCh := make(chan *CommandResult, 1)
+	go func() {
+		resCh <- h.Execute(
+			"server", "start-dev",
+			"-p", port,
+			"--headless",
+			"--namespace", "ns1",
+			"--namespace", "ns2",
+			"--retention", "ns2=2d",
+			"--retention", "30d",
+			"--max-history-events", "ns1=1000",
+		)
+	}()
+	var cl client.Client
+	h.EventuallyWithT(func(t *assert.CollectT) {
+		select {
+		case res := <-resCh:
+			require.NoError(t, res.Err)
+			require.Fail(t, "got early server result")
+		default:
+		}
+		var err error
+		cl, err = client.Dial(client.Options{HostPort: "127.0.0.1:" + port})
+		assert.NoError(t, err)
+	}, 3*time.Second, 200*time.Millisecond)
+	defer cl.Close()
+
+	// Check retentions
+	nsClient, err := client.NewNamespaceClient(client.Options{HostPort: "127.0.0.1:" + port})
+	h.NoError(err)
+	defer nsClient.Close()
+	for ns, retention := range map[string]time.Duration{
+		"default": 30 * 24 * time.Hour,
+		"ns1":     30 * 24 * time.Hour,
+		"ns2":     2 * 24 * time.Hour,
+	} {
+		resp, err := nsClient.Describe(context.Background(), ns)
+		h.NoError(err)
+		h.Equal(retention, resp.Config.WorkflowExecutionRetentionTtl.AsDuration(), "namespace %v", ns)
+	}
+
+	h.CancelContext()
+	select {
+	case <-time.After(20 * time.Second):
+		h.Fail("didn't cleanup after 20 seconds")
+	case res := <-resCh:
+		h.NoError(res.Err)
+	}
+
+	// Unknown namespace fails
+	res := NewCommandHarness(t).Execute("server", "start-dev", "-p", port, "--headless", "--retention", "ns3=1d")
+	h.ErrorContains(res.Err, `namespace "ns3" is not pre-created`)
+}
+
+func (s *SharedServerSuite) TestServer_StartDev_ImportSearchAttributes() {
+	// Env pointing at the shared server which has CustomKeywordField
+	envFile, err := os.CreateTemp("", "")
+	s.NoError(err)
+	envFile.Close()
+	defer os.Remove(envFile.Name())
+	res := s.Execute("env", "set", "--env-file", envFile.Name(), "remote.address", s.Address())
+	s.NoError(res.Err)
+
+	h := NewCommandHarness(s.T())
+	defer h.Close()
+	port := strconv.Itoa(devserver.MustGetFreePort("127.0.0.1"))
+	resCh := make(chan *CommandResult, 1)
+	go func() {
+		resCh <- h.Execute(
+			"server", "start-dev",
+			"-p", port,
+			"--headless",
+			"--namespace", "ns1",
+			"--env-file", envFile.Name(),
+			"--import-search-attributes-from-env", "remote",
+		)
+	}()
+	var cl client.Client
+	h.EventuallyWithT(func(t *assert.CollectT) {
+		select {
+		case res := <-resCh:
+			require.NoError(t, res.Err)
+			require.Fail(t, "got early server result")
+		default:
+		}
+		var err error
+		cl, err = client.Dial(client.Options{HostPort: "127.0.0.1:" + port})
+		if !assert.NoError(t, err) {
+			return
+		}
+		// Search attributes are registered right after start
+		for _, ns := range []string{"default", "ns1"} {
+			resp, err := cl.OperatorService().ListSearchAttributes(context.Background(),
+				&operatorservice.ListSearchAttributesRequest{Namespace: ns})
+			if assert.NoError(t, err) {
+				assert.Equal(t, enums.INDEXED_VALUE_TYPE_KEYWORD, resp.CustomAttributes["CustomKeywordField"])
+			}
+		}
+	}, 5*time.Second, 200*time.Millisecond)
+	defer cl.Close()
+
+	h.CancelContext()
+	select {
+	case <-time.After(20 * time.Second):
+		h.Fail("didn't cleanup after 20 seconds")
+	case res := <-resCh:
+		h.NoError(res.Err)
+	}
+}
+
 func TestServer_StartDev_ConcurrentStarts(t *testing.T) {
 	startOne := func() {
 		h := NewCommandHarness(t)
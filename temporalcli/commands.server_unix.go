@@ -0,0 +1,48 @@
+//go:build !windows
+
+package temporalcli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Services are only a Windows concept, so this does nothing here.
+func handleServiceStop(stop context.CancelFunc) (func(), error) {
+	return func() {}, nil
+}
+
+// Returns a channel closed when the process with the given PID exits. Nothing
+// is sent if the context is closed first.
+func watchProcessExit(ctx context.Context, pid int) (<-chan struct{}, error) {
+	if !processExists(pid) {
+		return nil, os.ErrProcessDone
+	}
+	exitedCh := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !processExists(pid) {
+					close(exitedCh)
+					return
+				}
+			}
+		}
+	}()
+	return exitedCh, nil
+}
+
+func processExists(pid int) bool {
+	// Signal 0 only checks existence, and a permission error means it exists
+	// but is owned by someone else
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,83 @@
+//go:build windows
+
+package temporalcli
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+)
+
+// If running as a Windows service, this registers a service handler that calls
+// stop on service stop or system shutdown. The returned function must be called
+// once the server has stopped so the service can report it has stopped.
+// Console close, logoff, and shutdown events when not a service are delivered
+// as SIGTERM and are handled like an interrupt.
+func handleServiceStop(stop context.CancelFunc) (func(), error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return nil, err
+	} else if !isService {
+		return func() {}, nil
+	}
+	h := &devServerService{stop: stop, stopped: make(chan struct{})}
+	runErrCh := make(chan error, 1)
+	go func() { runErrCh <- svc.Run("temporal", h) }()
+	return func() {
+		close(h.stopped)
+		<-runErrCh
+	}, nil
+}
+
+type devServerService struct {
+	stop    context.CancelFunc
+	stopped chan struct{}
+}
+
+func (d *devServerService) Execute(
+	args []string,
+	requests <-chan svc.ChangeRequest,
+	status chan<- svc.Status,
+) (svcSpecificEC bool, exitCode uint32) {
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for {
+		select {
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				status <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				d.stop()
+				<-d.stopped
+				return false, 0
+			}
+		case <-d.stopped:
+			return false, 0
+		}
+	}
+}
+
+// Returns a channel closed when the process with the given PID exits. Nothing
+// is sent if the context is closed first.
+func watchProcessExit(ctx context.Context, pid int) (<-chan struct{}, error) {
+	handle, err := windows.OpenProcess(windows.SYNCHRONIZE, false, uint32(pid))
+	if err != nil {
+		return nil, err
+	}
+	exitedCh := make(chan struct{})
+	go func() {
+		defer windows.CloseHandle(handle)
+		for ctx.Err() == nil {
+			event, err := windows.WaitForSingleObject(handle, uint32(time.Second/time.Millisecond))
+			if err == nil && event == uint32(windows.WAIT_TIMEOUT) {
+				continue
+			}
+			close(exitedCh)
+			return
+		}
+	}()
+	return exitedCh, nil
+}
@@ -1,7 +1,11 @@
 package temporalcli
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/fatih/color"
@@ -10,9 +14,92 @@ import (
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/taskqueue/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
 	"go.temporal.io/server/common/tqid"
 )
 
+type statusWithPartition struct {
+	Partition int `json:"partition"`
+	taskqueue.TaskQueueStatus
+}
+
+type pollerWithPartition struct {
+	Partition int `json:"partition"`
+	taskqueue.PollerInfo
+	// copy this out to display nicer in table or card, but not json
+	Versioning *commonpb.WorkerVersionCapabilities `json:"-"`
+}
+
+// JSON output of task queue describe, also read back for --compare-file
+type taskQueueDescription struct {
+	TaskQueues []*statusWithPartition `json:"taskQueues"`
+	Pollers    []*pollerWithPartition `json:"pollers"`
+	Changes    []*taskQueueChange     `json:"changes,omitempty"`
+}
+
+type taskQueueChange struct {
+	Change   string   `json:"change"`
+	Item     string   `json:"item"`
+	Previous *float64 `json:"previous,omitempty"`
+	Current  *float64 `json:"current,omitempty"`
+}
+
+// Changes from the previous description to the current one. Pollers are
+// matched by partition and identity.
+func compareTaskQueueDescriptions(previous, current *taskQueueDescription) []*taskQueueChange {
+	var changes []*taskQueueChange
+	item := func(name string, partition int) string {
+		if partition > 0 {
+			return fmt.Sprintf("%v (partition %v)", name, partition)
+		}
+		return name
+	}
+	valueChange := func(change, item string, prev, curr float64) {
+		if prev != curr {
+			changes = append(changes, &taskQueueChange{Change: change, Item: item, Previous: &prev, Current: &curr})
+		}
+	}
+
+	prevStatuses := map[int]*statusWithPartition{}
+	for _, status := range previous.TaskQueues {
+		prevStatuses[status.Partition] = status
+	}
+	for _, status := range current.TaskQueues {
+		prev := prevStatuses[status.Partition]
+		if prev == nil {
+			continue
+		}
+		partition := fmt.Sprintf("partition %v", status.Partition)
+		valueChange("backlog", partition, float64(prev.BacklogCountHint), float64(status.BacklogCountHint))
+		valueChange("rate", partition, prev.RatePerSecond, status.RatePerSecond)
+	}
+
+	type pollerKey struct {
+		partition int
+		identity  string
+	}
+	prevPollers := map[pollerKey]*pollerWithPartition{}
+	for _, poller := range previous.Pollers {
+		prevPollers[pollerKey{poller.Partition, poller.Identity}] = poller
+	}
+	for _, poller := range current.Pollers {
+		key := pollerKey{poller.Partition, poller.Identity}
+		if prev := prevPollers[key]; prev == nil {
+			changes = append(changes, &taskQueueChange{Change: "poller added", Item: item(poller.Identity, poller.Partition)})
+		} else {
+			valueChange("poller rate", item(poller.Identity, poller.Partition), prev.RatePerSecond, poller.RatePerSecond)
+			delete(prevPollers, key)
+		}
+	}
+	// Remaining previous pollers are gone, kept in their original order
+	for _, poller := range previous.Pollers {
+		if prevPollers[pollerKey{poller.Partition, poller.Identity}] != nil {
+			changes = append(changes, &taskQueueChange{Change: "poller removed", Item: item(poller.Identity, poller.Partition)})
+		}
+	}
+	return changes
+}
+
 func (c *TemporalTaskQueueDescribeCommand) run(cctx *CommandContext, args []string) error {
 	// Call describe
 	cl, err := c.Parent.ClientOptions.dialClient(cctx)
@@ -36,15 +123,15 @@ func (c *TemporalTaskQueueDescribeCommand) run(cctx *CommandContext, args []stri
 	}
 	partitions := c.Partitions
 
-	type statusWithPartition struct {
-		Partition int `json:"partition"`
-		taskqueue.TaskQueueStatus
-	}
-	type pollerWithPartition struct {
-		Partition int `json:"partition"`
-		taskqueue.PollerInfo
-		// copy this out to display nicer in table or card, but not json
-		Versioning *commonpb.WorkerVersionCapabilities `json:"-"`
+	// Load the snapshot up front so a bad file fails before describing
+	var previous *taskQueueDescription
+	if c.CompareFile != "" {
+		b, err := os.ReadFile(c.CompareFile)
+		if err != nil {
+			return fmt.Errorf("failed reading compare file: %w", err)
+		} else if err := json.Unmarshal(b, &previous); err != nil {
+			return fmt.Errorf("failed parsing compare file, expected JSON output of this command: %w", err)
+		}
 	}
 
 	var statuses []*statusWithPartition
@@ -77,12 +164,14 @@ func (c *TemporalTaskQueueDescribeCommand) run(cctx *CommandContext, args []stri
 		}
 	}
 
+	desc := &taskQueueDescription{TaskQueues: statuses, Pollers: pollers}
+	if previous != nil {
+		desc.Changes = compareTaskQueueDescriptions(previous, desc)
+	}
+
 	// For JSON, we'll just dump the proto
 	if cctx.JSONOutput {
-		return cctx.Printer.PrintStructured(map[string]any{
-			"taskQueues": statuses,
-			"pollers":    pollers,
-		}, printer.StructuredOptions{})
+		return cctx.Printer.PrintStructured(desc, printer.StructuredOptions{})
 	}
 
 	// For text, we will use a table for pollers
@@ -97,7 +186,148 @@ func (c *TemporalTaskQueueDescribeCommand) run(cctx *CommandContext, args []stri
 		items[i].LastAccessTime = poller.LastAccessTime.AsTime()
 		items[i].RatePerSecond = poller.RatePerSecond
 	}
-	return cctx.Printer.PrintStructured(items, printer.StructuredOptions{Table: &printer.TableOptions{}})
+	if err := cctx.Printer.PrintStructured(items, printer.StructuredOptions{Table: &printer.TableOptions{}}); err != nil {
+		return err
+	} else if previous == nil {
+		return nil
+	}
+
+	cctx.Printer.Println()
+	cctx.Printer.Println(color.MagentaString("Changes since %v:", c.CompareFile))
+	if len(desc.Changes) == 0 {
+		cctx.Printer.Println("No changes")
+		return nil
+	}
+	changeRows := make([]struct {
+		Change   string
+		Item     string
+		Previous string
+		Current  string
+		Delta    string
+	}, len(desc.Changes))
+	for i, change := range desc.Changes {
+		changeRows[i].Change = change.Change
+		changeRows[i].Item = change.Item
+		if change.Previous != nil && change.Current != nil {
+			changeRows[i].Previous = strconv.FormatFloat(*change.Previous, 'f', -1, 64)
+			changeRows[i].Current = strconv.FormatFloat(*change.Current, 'f', -1, 64)
+			changeRows[i].Delta = strconv.FormatFloat(*change.Current-*change.Previous, 'f', -1, 64)
+			if *change.Current > *change.Previous {
+				changeRows[i].Delta = "+" + changeRows[i].Delta
+			}
+		}
+	}
+	return cctx.Printer.PrintStructured(changeRows, printer.StructuredOptions{Table: &printer.TableOptions{}})
+}
+
+func (c *TemporalTaskQueueDrainCommand) run(cctx *CommandContext, args []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+	taskQueue, err := tqid.NewTaskQueueFamily(c.Parent.Namespace, c.TaskQueue)
+	if err != nil {
+		return fmt.Errorf("failed to parse task queue name: %w", err)
+	}
+
+	if c.NewDefaultBuildId != "" {
+		if err := c.Parent.ClientOptions.checkServerFeature(cctx, cl, serverFeatureBuildIDVersioning); err != nil {
+			return err
+		}
+		err := cl.UpdateWorkerBuildIdCompatibility(cctx, &client.UpdateWorkerBuildIdCompatibilityOptions{
+			TaskQueue: c.TaskQueue,
+			Operation: &client.BuildIDOpAddNewIDInNewDefaultSet{BuildID: c.NewDefaultBuildId},
+		})
+		if err != nil {
+			return fmt.Errorf("error updating task queue build IDs: %w", err)
+		}
+		if !cctx.JSONOutput {
+			cctx.Printer.Printlnf("Added new default Build ID %v", c.NewDefaultBuildId)
+		}
+	}
+
+	ctx := context.Context(cctx)
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout.Duration())
+		defer cancel()
+	}
+	type drainPoller struct {
+		Identity       string    `json:"identity"`
+		TaskQueueType  string    `json:"taskQueueType"`
+		LastAccessTime time.Time `json:"lastAccessTime"`
+	}
+	var workflowBacklog, activityBacklog int64
+	var pollers []*drainPoller
+	for {
+		pollers = pollers[:0]
+		for _, taskQueueType := range []enums.TaskQueueType{enums.TASK_QUEUE_TYPE_WORKFLOW, enums.TASK_QUEUE_TYPE_ACTIVITY} {
+			var backlog int64
+			pollerIdentities := map[string]bool{}
+			for p := 0; p < c.Partitions; p++ {
+				resp, err := cl.WorkflowService().DescribeTaskQueue(cctx, &workflowservice.DescribeTaskQueueRequest{
+					Namespace: c.Parent.Namespace,
+					TaskQueue: &taskqueue.TaskQueue{
+						Name: taskQueue.TaskQueue(taskQueueType).NormalPartition(p).RpcName(),
+						Kind: enums.TASK_QUEUE_KIND_NORMAL,
+					},
+					TaskQueueType:          taskQueueType,
+					IncludeTaskQueueStatus: true,
+				})
+				if err != nil {
+					return fmt.Errorf("unable to describe task queue: %w", err)
+				}
+				backlog += resp.TaskQueueStatus.GetBacklogCountHint()
+				for _, pi := range resp.Pollers {
+					if !pollerIdentities[pi.Identity] {
+						pollerIdentities[pi.Identity] = true
+						pollers = append(pollers, &drainPoller{
+							Identity:       pi.Identity,
+							TaskQueueType:  taskQueueType.String(),
+							LastAccessTime: pi.LastAccessTime.AsTime(),
+						})
+					}
+				}
+			}
+			if taskQueueType == enums.TASK_QUEUE_TYPE_WORKFLOW {
+				workflowBacklog = backlog
+			} else {
+				activityBacklog = backlog
+			}
+		}
+		if workflowBacklog == 0 && activityBacklog == 0 {
+			break
+		}
+		if !cctx.JSONOutput {
+			cctx.Printer.Printlnf("Draining, backlog of %d workflow and %d activity task(s)...",
+				workflowBacklog, activityBacklog)
+		}
+		select {
+		case <-ctx.Done():
+			if cctx.Err() != nil {
+				return fmt.Errorf("interrupted draining task queue %v", c.TaskQueue)
+			}
+			return fmt.Errorf("timed out after %v draining task queue %v with backlog of %d workflow and %d activity task(s)",
+				c.Timeout.Duration(), c.TaskQueue, workflowBacklog, activityBacklog)
+		case <-time.After(time.Second):
+		}
+	}
+
+	if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(map[string]any{
+			"workflowBacklog": workflowBacklog,
+			"activityBacklog": activityBacklog,
+			"pollers":         pollers,
+		}, printer.StructuredOptions{})
+	}
+	cctx.Printer.Printlnf("Task queue %v drained", c.TaskQueue)
+	if len(pollers) == 0 {
+		cctx.Printer.Println("No remaining pollers")
+		return nil
+	}
+	cctx.Printer.Println(color.MagentaString("Remaining pollers:"))
+	return cctx.Printer.PrintStructured(pollers, printer.StructuredOptions{Table: &printer.TableOptions{}})
 }
 
 func (c *TemporalTaskQueueListPartitionCommand) run(cctx *CommandContext, args []string) error {
@@ -124,6 +354,23 @@ func (c *TemporalTaskQueueListPartitionCommand) run(cctx *CommandContext, args [
 		return cctx.Printer.PrintStructured(resp, printer.StructuredOptions{})
 	}
 
+	// For CSV, both partition types are in one table since there are no headings
+	if cctx.Printer.CSV {
+		type partition struct {
+			Type          string
+			Key           string
+			OwnerHostName string
+		}
+		var rows []partition
+		for _, e := range resp.WorkflowTaskQueuePartitions {
+			rows = append(rows, partition{Type: "workflow", Key: e.Key, OwnerHostName: e.OwnerHostName})
+		}
+		for _, e := range resp.ActivityTaskQueuePartitions {
+			rows = append(rows, partition{Type: "activity", Key: e.Key, OwnerHostName: e.OwnerHostName})
+		}
+		return cctx.Printer.PrintStructured(rows, printer.StructuredOptions{Table: &printer.TableOptions{}})
+	}
+
 	var items []*taskqueue.TaskQueuePartitionMetadata
 	cctx.Printer.Println(color.MagentaString("Workflow Task Queue Partitions\n"))
 	for _, e := range resp.WorkflowTaskQueuePartitions {
@@ -15,6 +15,9 @@ func (c *TemporalTaskQueueGetBuildIdsCommand) run(cctx *CommandContext, args []s
 		return err
 	}
 	defer cl.Close()
+	if err := c.Parent.ClientOptions.checkServerFeature(cctx, cl, serverFeatureBuildIDVersioning); err != nil {
+		return err
+	}
 
 	sets, err := cl.GetWorkerBuildIdCompatibility(cctx, &client.GetWorkerBuildIdCompatibilityOptions{
 		TaskQueue: c.TaskQueue,
@@ -67,6 +70,9 @@ func (c *TemporalTaskQueueGetBuildIdReachabilityCommand) run(cctx *CommandContex
 		return err
 	}
 	defer cl.Close()
+	if err := c.Parent.ClientOptions.checkServerFeature(cctx, cl, serverFeatureBuildIDVersioning); err != nil {
+		return err
+	}
 
 	reachability := client.TaskReachabilityUnspecified
 	if c.ReachabilityType.Value != "" {
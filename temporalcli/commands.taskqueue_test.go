@@ -2,12 +2,17 @@ package temporalcli_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/temporalio/cli/temporalcli"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
 )
 
 func (s *SharedServerSuite) TestTaskQueue_Describe_Simple() {
@@ -63,6 +68,90 @@ func (s *SharedServerSuite) TestTaskQueue_Describe_Simple() {
 	s.GreaterOrEqual(10, len(jsonOut.TaskQueues))
 }
 
+func (s *SharedServerSuite) TestTaskQueue_Describe_CompareFile() {
+	s.Eventually(func() bool {
+		desc, err := s.Client.DescribeTaskQueue(s.Context, s.Worker().Options.TaskQueue, enums.TASK_QUEUE_TYPE_WORKFLOW)
+		s.NoError(err)
+		return len(desc.Pollers) > 0
+	}, 5*time.Second, 100*time.Millisecond, "Worker never appeared")
+	identity := s.DevServer.Options.ClientOptions.Identity
+
+	// Unchanged against a snapshot of itself
+	res := s.Execute(
+		"task-queue", "describe",
+		"-o", "json",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+	)
+	s.NoError(res.Err)
+	snapshotFile := filepath.Join(s.T().TempDir(), "previous.json")
+	s.NoError(os.WriteFile(snapshotFile, res.Stdout.Bytes(), 0o644))
+	res = s.Execute(
+		"task-queue", "describe",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--compare-file", snapshotFile,
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Changes since "+snapshotFile)
+	s.Contains(res.Stdout.String(), "No changes")
+
+	// Snapshot with a backlog and another poller instead of the current one, but
+	// the same rate
+	var current struct {
+		TaskQueues []struct {
+			RatePerSecond float64 `json:"rate_per_second"`
+		} `json:"taskQueues"`
+	}
+	b, err := os.ReadFile(snapshotFile)
+	s.NoError(err)
+	s.NoError(json.Unmarshal(b, &current))
+	s.NoError(os.WriteFile(snapshotFile, []byte(fmt.Sprintf(`{
+  "taskQueues": [{"partition": 0, "backlog_count_hint": 5, "rate_per_second": %v}],
+  "pollers": [{"partition": 0, "identity": "old-worker", "rate_per_second": 100000}]
+}`, current.TaskQueues[0].RatePerSecond)), 0o644))
+	res = s.Execute(
+		"task-queue", "describe",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--compare-file", snapshotFile,
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "backlog", "partition 0", "5", "0", "-5")
+	s.ContainsOnSameLine(out, "poller added", identity)
+	s.ContainsOnSameLine(out, "poller removed", "old-worker")
+
+	// JSON
+	res = s.Execute(
+		"task-queue", "describe",
+		"-o", "json",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--compare-file", snapshotFile,
+	)
+	s.NoError(res.Err)
+	var jsonOut struct {
+		Changes []map[string]any `json:"changes"`
+	}
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal([]map[string]any{
+		{"change": "backlog", "item": "partition 0", "previous": 5.0, "current": 0.0},
+		{"change": "poller added", "item": identity},
+		{"change": "poller removed", "item": "old-worker"},
+	}, jsonOut.Changes)
+
+	// Bad file
+	s.NoError(os.WriteFile(snapshotFile, []byte("not json"), 0o644))
+	res = s.Execute(
+		"task-queue", "describe",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--compare-file", snapshotFile,
+	)
+	s.ErrorContains(res.Err, "failed parsing compare file")
+}
+
 func (s *SharedServerSuite) TestTaskQueue_ListPartition() {
 	testTaskQueue := uuid.NewString()
 	res := s.Execute(
@@ -76,6 +165,21 @@ func (s *SharedServerSuite) TestTaskQueue_ListPartition() {
 	s.NoError(res.Err)
 }
 
+func (s *SharedServerSuite) TestTaskQueue_ListPartitionCSVOutput() {
+	testTaskQueue := uuid.NewString()
+	res := s.Execute(
+		"task-queue", "list-partition",
+		"--address", s.Address(),
+		"--task-queue", testTaskQueue,
+		"--output", "csv",
+	)
+	s.NoError(res.Err)
+	lines := strings.Split(strings.TrimSpace(res.Stdout.String()), "\n")
+	s.Equal("Type,Key,OwnerHostName", lines[0])
+	s.True(strings.HasPrefix(lines[1], "workflow,"+testTaskQueue+","))
+	s.True(strings.HasPrefix(lines[len(lines)-1], "activity,"))
+}
+
 func (s *SharedServerSuite) TestTaskQueue_ListPartitionInvalidNamespace() {
 	testTaskQueue := uuid.NewString()
 	res := s.Execute(
@@ -102,3 +206,56 @@ func (s *SharedServerSuite) TestTaskQueue_ListPartitionJsonOutput() {
 	s.NotEmpty(listResp.ActivityTaskQueuePartitions)
 	s.NotEmpty(listResp.WorkflowTaskQueuePartitions)
 }
+
+func (s *SharedServerSuite) TestTaskQueue_Drain() {
+	// Wait until the poller appears
+	s.Eventually(func() bool {
+		desc, err := s.Client.DescribeTaskQueue(s.Context, s.Worker().Options.TaskQueue, enums.TASK_QUEUE_TYPE_WORKFLOW)
+		s.NoError(err)
+		return len(desc.Pollers) > 0
+	}, 5*time.Second, 100*time.Millisecond, "Worker never appeared")
+
+	// Empty backlog drains immediately and reports the worker still polling
+	res := s.Execute(
+		"task-queue", "drain",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "drained")
+	s.ContainsOnSameLine(res.Stdout.String(), s.DevServer.Options.ClientOptions.Identity, "Workflow")
+
+	// JSON
+	res = s.Execute(
+		"task-queue", "drain",
+		"-o", "json",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+	)
+	s.NoError(res.Err)
+	var jsonOut struct {
+		WorkflowBacklog int              `json:"workflowBacklog"`
+		Pollers         []map[string]any `json:"pollers"`
+	}
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal(0, jsonOut.WorkflowBacklog)
+	s.NotEmpty(jsonOut.Pollers)
+
+	// A backlog with no worker times out
+	taskQueue := "drain-tq-" + uuid.NewString()
+	_, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: taskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	res = s.Execute(
+		"task-queue", "drain",
+		"--address", s.Address(),
+		"--task-queue", taskQueue,
+		"--timeout", "3s",
+		"--partitions", "4",
+	)
+	s.ErrorContains(res.Err, "timed out after 3s draining task queue")
+}
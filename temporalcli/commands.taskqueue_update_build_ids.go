@@ -12,6 +12,9 @@ func (c *TemporalTaskQueueUpdateBuildIdsCommand) updateBuildIds(cctx *CommandCon
 		return err
 	}
 	defer cl.Close()
+	if err := c.Parent.ClientOptions.checkServerFeature(cctx, cl, serverFeatureBuildIDVersioning); err != nil {
+		return err
+	}
 
 	if err := cl.UpdateWorkerBuildIdCompatibility(cctx, options); err != nil {
 		return fmt.Errorf("error updating task queue build IDs: %w", err)
@@ -1,9 +1,19 @@
 package temporalcli
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"os/user"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/google/uuid"
@@ -12,8 +22,11 @@ import (
 	"go.temporal.io/api/common/v1"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/query/v1"
+	"go.temporal.io/api/sdk/v1"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
 )
 
 func (c *TemporalWorkflowCancelCommand) run(cctx *CommandContext, args []string) error {
@@ -23,17 +36,35 @@ func (c *TemporalWorkflowCancelCommand) run(cctx *CommandContext, args []string)
 	}
 	defer cl.Close()
 
+	if c.FollowChildren && c.Query != "" {
+		return fmt.Errorf("cannot follow children when query is set")
+	} else if c.Wait && c.Query != "" {
+		return fmt.Errorf("cannot wait when query is set")
+	} else if c.WaitTimeout > 0 && !c.Wait {
+		return fmt.Errorf("cannot use --wait-timeout without --wait")
+	}
+
 	exec, batchReq, err := c.workflowExecOrBatch(cctx, c.Parent.Namespace, cl, singleOrBatchOverrides{})
 
 	// Run single or batch
 	if err != nil {
 		return err
 	} else if exec != nil {
-		err = cl.CancelWorkflow(cctx, exec.WorkflowId, exec.RunId)
-		if err != nil {
+		if c.FollowChildren {
+			err = applyToWorkflowTree(cctx, cl, exec, "cancel", func(exec *common.WorkflowExecution) error {
+				return cl.CancelWorkflow(cctx, exec.WorkflowId, exec.RunId)
+			})
+			if err != nil {
+				return err
+			}
+		} else if err = cl.CancelWorkflow(cctx, exec.WorkflowId, exec.RunId); err != nil {
 			return fmt.Errorf("failed to cancel workflow: %w", err)
+		} else {
+			cctx.Printer.Println("Canceled workflow")
+		}
+		if c.Wait {
+			return waitWorkflowClosed(cctx, cl, exec, c.WaitTimeout.Duration(), enums.WORKFLOW_EXECUTION_STATUS_CANCELED)
 		}
-		cctx.Printer.Println("Canceled workflow")
 	} else { // batchReq != nil
 		batchReq.Operation = &workflowservice.StartBatchOperationRequest_CancellationOperation{
 			CancellationOperation: &batch.BatchOperationCancellation{
@@ -48,6 +79,123 @@ func (c *TemporalWorkflowCancelCommand) run(cctx *CommandContext, args []string)
 	return nil
 }
 
+type workflowCancelTreeResult struct {
+	WorkflowId string `json:"workflowId"`
+	RunId      string `json:"runId"`
+	Type       string `json:"type"`
+	Depth      int    `json:"depth"`
+	// Status the workflow closed with, or empty if it did not close
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (c *TemporalWorkflowCancelTreeCommand) run(cctx *CommandContext, args []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	reason := c.Reason
+	if reason == "" {
+		reason = defaultReason()
+	}
+	root := &workflowTreeNode{WorkflowId: c.WorkflowId, RunId: c.RunId}
+	if err := collectPendingChildren(cctx, cl, root); err != nil {
+		return err
+	}
+
+	// Cancel children before parents, only moving on to a parent once all of
+	// its children have closed
+	var results []*workflowCancelTreeResult
+	var cancelNode func(node *workflowTreeNode, depth int) bool
+	cancelNode = func(node *workflowTreeNode, depth int) bool {
+		childrenClosed := true
+		for _, child := range node.Children {
+			if !cancelNode(child, depth+1) {
+				childrenClosed = false
+			}
+		}
+		res := &workflowCancelTreeResult{WorkflowId: node.WorkflowId, RunId: node.RunId, Type: node.Type, Depth: depth}
+		results = append(results, res)
+		if !childrenClosed {
+			res.Error = "skipped, not all children closed"
+			return false
+		} else if cctx.Err() != nil {
+			res.Error = "skipped, interrupted"
+			return false
+		}
+		status, err := cancelWorkflowAndWait(cctx, cl, c.Parent.Namespace,
+			&common.WorkflowExecution{WorkflowId: node.WorkflowId, RunId: node.RunId}, reason, c.WaitTimeout.Duration())
+		if err != nil {
+			res.Error = err.Error()
+			return false
+		}
+		res.Status = status.String()
+		return true
+	}
+	allClosed := cancelNode(root, 0)
+
+	if cctx.JSONOutput {
+		cctx.Printer.StartList()
+		for _, res := range results {
+			_ = cctx.Printer.PrintStructured(res, printer.StructuredOptions{})
+		}
+		cctx.Printer.EndList()
+	} else {
+		type row struct {
+			WorkflowId string
+			Type       string
+			RunId      string
+			Result     string
+		}
+		rows := make([]row, len(results))
+		for i, res := range results {
+			rows[i] = row{
+				WorkflowId: strings.Repeat("  ", res.Depth) + res.WorkflowId,
+				Type:       res.Type,
+				RunId:      res.RunId,
+				Result:     res.Status,
+			}
+			if res.Error != "" {
+				rows[i].Result = res.Error
+			}
+		}
+		if err := cctx.Printer.PrintStructured(rows, printer.StructuredOptions{Table: &printer.TableOptions{}}); err != nil {
+			return err
+		}
+	}
+	if !allClosed {
+		return fmt.Errorf("not all workflows in the tree were canceled")
+	}
+	return nil
+}
+
+// Requests cancellation of the execution and waits for it to close, returning
+// the status it closed with. An execution that is already closed is not an
+// error.
+func cancelWorkflowAndWait(
+	cctx *CommandContext,
+	cl client.Client,
+	namespace string,
+	exec *common.WorkflowExecution,
+	reason string,
+	timeout time.Duration,
+) (enums.WorkflowExecutionStatus, error) {
+	_, err := cl.WorkflowService().RequestCancelWorkflowExecution(cctx, &workflowservice.RequestCancelWorkflowExecutionRequest{
+		Namespace:         namespace,
+		WorkflowExecution: exec,
+		Identity:          clientIdentity(),
+		RequestId:         uuid.NewString(),
+		Reason:            reason,
+	})
+	var notFound *serviceerror.NotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return 0, fmt.Errorf("failed to cancel: %w", err)
+	}
+	return waitWorkflowCloseStatus(cctx, cl, exec, timeout)
+}
+
 func (c *TemporalWorkflowDeleteCommand) run(cctx *CommandContext, args []string) error {
 	cl, err := c.Parent.ClientOptions.dialClient(cctx)
 	if err != nil {
@@ -83,8 +231,91 @@ func (c *TemporalWorkflowDeleteCommand) run(cctx *CommandContext, args []string)
 }
 
 func (c *TemporalWorkflowQueryCommand) run(cctx *CommandContext, args []string) error {
+	if c.QueryFilter != "" {
+		return c.queryMany(cctx)
+	} else if c.WorkflowId == "" {
+		return fmt.Errorf("must set either workflow ID or query filter")
+	}
 	return queryHelper(cctx, c.Parent, c.PayloadInputOptions,
-		c.Name, c.RejectCondition, c.WorkflowReferenceOptions)
+		c.Name, c.RejectCondition, WorkflowReferenceOptions{WorkflowId: c.WorkflowId, RunId: c.RunId})
+}
+
+type workflowQueryResult struct {
+	WorkflowId string          `json:"workflowId"`
+	RunId      string          `json:"runId"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+func (c *TemporalWorkflowQueryCommand) queryMany(cctx *CommandContext) error {
+	if c.WorkflowId != "" || c.RunId != "" {
+		return fmt.Errorf("cannot set workflow ID or run ID when query filter is set")
+	} else if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+	input, err := c.buildRawInputPayloads()
+	if err != nil {
+		return err
+	}
+	rejectCond, err := queryRejectCondition(c.RejectCondition)
+	if err != nil {
+		return err
+	}
+
+	execs, err := listOpenExecutions(cctx, cl, c.QueryFilter)
+	if err != nil {
+		return err
+	}
+	results := make([]*workflowQueryResult, len(execs))
+	for i, exec := range execs {
+		results[i] = &workflowQueryResult{WorkflowId: exec.WorkflowId, RunId: exec.RunId, Error: "not sent"}
+	}
+	var failed atomic.Int32
+	forEachExecution(cctx, execs, c.Concurrency, 0, func(i int, exec *common.WorkflowExecution) {
+		results[i].Error = ""
+		resp, err := cl.WorkflowService().QueryWorkflow(cctx, &workflowservice.QueryWorkflowRequest{
+			Namespace: c.Parent.Namespace,
+			Execution: exec,
+			Query: &query.WorkflowQuery{
+				QueryType: c.Name,
+				QueryArgs: input,
+			},
+			QueryRejectCondition: rejectCond,
+		})
+		if err == nil && resp.QueryRejected != nil {
+			err = fmt.Errorf("query was rejected, workflow has status: %v", resp.QueryRejected.GetStatus())
+		} else if err == nil {
+			results[i].Result, err = cctx.MarshalFriendlyJSONPayloads(resp.QueryResult)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			failed.Add(1)
+		}
+	})
+
+	if cctx.JSONOutput {
+		if err := cctx.Printer.PrintStructured(results, printer.StructuredOptions{}); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		cctx.Printer.Println("No open workflows match the query filter")
+	} else {
+		err := cctx.Printer.PrintStructured(results, printer.StructuredOptions{Table: &printer.TableOptions{}})
+		if err != nil {
+			return err
+		}
+	}
+	if cctx.Err() != nil {
+		return fmt.Errorf("interrupted, workflows marked as not sent were not queried: %w", cctx.Err())
+	} else if failed.Load() > 0 {
+		return fmt.Errorf("%v of %v queries failed", failed.Load(), len(results))
+	}
+	return nil
 }
 
 func (c *TemporalWorkflowSignalCommand) run(cctx *CommandContext, args []string) error {
@@ -94,6 +325,10 @@ func (c *TemporalWorkflowSignalCommand) run(cctx *CommandContext, args []string)
 	}
 	defer cl.Close()
 
+	if c.DedupeKey != "" && c.Query != "" {
+		return fmt.Errorf("cannot use dedupe key when query is set")
+	}
+
 	// Get input payloads
 	input, err := c.buildRawInputPayloads()
 	if err != nil {
@@ -114,6 +349,7 @@ func (c *TemporalWorkflowSignalCommand) run(cctx *CommandContext, args []string)
 			SignalName:        c.Name,
 			Input:             input,
 			Identity:          clientIdentity(),
+			RequestId:         signalRequestID(c.Parent.Namespace, exec, c.Name, c.DedupeKey),
 		})
 		if err != nil {
 			return fmt.Errorf("failed signalling workflow: %w", err)
@@ -134,6 +370,16 @@ func (c *TemporalWorkflowSignalCommand) run(cctx *CommandContext, args []string)
 	return nil
 }
 
+// Returns a request ID that is the same for every signal with the same dedupe
+// key to the same execution, or a random one if there is no dedupe key.
+func signalRequestID(namespace string, exec *common.WorkflowExecution, signalName, dedupeKey string) string {
+	if dedupeKey == "" {
+		return uuid.NewString()
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(strings.Join(
+		[]string{namespace, exec.WorkflowId, exec.RunId, signalName, dedupeKey}, "\x00"))).String()
+}
+
 func (c *TemporalWorkflowStackCommand) run(cctx *CommandContext, args []string) error {
 	return queryHelper(cctx, c.Parent, PayloadInputOptions{},
 		"__stack_trace", c.RejectCondition, c.WorkflowReferenceOptions)
@@ -148,11 +394,21 @@ func (c *TemporalWorkflowTerminateCommand) run(cctx *CommandContext, _ []string)
 
 	// We create a faux SingleWorkflowOrBatchOptions to use the shared logic
 	opts := SingleWorkflowOrBatchOptions{
-		WorkflowId: c.WorkflowId,
-		RunId:      c.RunId,
-		Query:      c.Query,
-		Reason:     c.Reason,
-		Yes:        c.Yes,
+		WorkflowId:    c.WorkflowId,
+		RunId:         c.RunId,
+		Query:         c.Query,
+		Reason:        c.Reason,
+		Yes:           c.Yes,
+		SamplePercent: c.SamplePercent,
+		MaxCount:      c.MaxCount,
+	}
+
+	if c.FollowChildren && c.Query != "" {
+		return fmt.Errorf("cannot follow children when query is set")
+	} else if c.Wait && c.Query != "" {
+		return fmt.Errorf("cannot wait when query is set")
+	} else if c.WaitTimeout > 0 && !c.Wait {
+		return fmt.Errorf("cannot use --wait-timeout without --wait")
 	}
 
 	exec, batchReq, err := opts.workflowExecOrBatch(cctx, c.Parent.Namespace, cl, singleOrBatchOverrides{
@@ -168,11 +424,21 @@ func (c *TemporalWorkflowTerminateCommand) run(cctx *CommandContext, _ []string)
 		if reason == "" {
 			reason = defaultReason()
 		}
-		err = cl.TerminateWorkflow(cctx, exec.WorkflowId, exec.RunId, reason)
-		if err != nil {
+		if c.FollowChildren {
+			err = applyToWorkflowTree(cctx, cl, exec, "terminate", func(exec *common.WorkflowExecution) error {
+				return cl.TerminateWorkflow(cctx, exec.WorkflowId, exec.RunId, reason)
+			})
+			if err != nil {
+				return err
+			}
+		} else if err = cl.TerminateWorkflow(cctx, exec.WorkflowId, exec.RunId, reason); err != nil {
 			return fmt.Errorf("failed to terminate workflow: %w", err)
+		} else {
+			cctx.Printer.Println("Workflow terminated")
+		}
+		if c.Wait {
+			return waitWorkflowClosed(cctx, cl, exec, c.WaitTimeout.Duration(), enums.WORKFLOW_EXECUTION_STATUS_TERMINATED)
 		}
-		cctx.Printer.Println("Workflow terminated")
 	} else { // batchReq != nil
 		batchReq.Operation = &workflowservice.StartBatchOperationRequest_TerminationOperation{
 			TerminationOperation: &batch.BatchOperationTermination{
@@ -193,6 +459,9 @@ func (c *TemporalWorkflowUpdateCommand) run(cctx *CommandContext, args []string)
 		return err
 	}
 	defer cl.Close()
+	if err := c.Parent.ClientOptions.checkServerFeature(cctx, cl, serverFeatureWorkflowUpdate); err != nil {
+		return err
+	}
 
 	// Get raw input
 	input, err := c.buildRawInput()
@@ -200,6 +469,18 @@ func (c *TemporalWorkflowUpdateCommand) run(cctx *CommandContext, args []string)
 		return err
 	}
 
+	if c.Query != "" {
+		return c.updateMany(cctx, cl, input)
+	} else if c.WorkflowId == "" {
+		return fmt.Errorf("must set either workflow ID or query")
+	} else if c.Yes {
+		return fmt.Errorf("cannot set 'yes' when workflow ID is set")
+	} else if c.SamplePercent != 0 || c.MaxCount != 0 {
+		return fmt.Errorf("cannot set sample percent or max count when workflow ID is set")
+	} else if c.Command.Flags().Changed("concurrency") || c.Rps != 0 {
+		return fmt.Errorf("cannot set concurrency or rps when workflow ID is set")
+	}
+
 	request := &client.UpdateWorkflowWithOptionsRequest{
 		WorkflowID:          c.WorkflowId,
 		RunID:               c.RunId,
@@ -210,23 +491,100 @@ func (c *TemporalWorkflowUpdateCommand) run(cctx *CommandContext, args []string)
 	}
 
 	updateHandle, err := cl.UpdateWorkflowWithOptions(cctx, request)
-	if err != nil {
-		return fmt.Errorf("unable to update workflow: %w", err)
+	if err == nil {
+		var valuePtr interface{}
+		if err = updateHandle.Get(cctx, &valuePtr); err == nil {
+			return cctx.Printer.PrintStructured(
+				struct {
+					Name     string      `json:"name"`
+					UpdateID string      `json:"updateId"`
+					Result   interface{} `json:"result"`
+				}{Name: c.Name, UpdateID: updateHandle.UpdateID(), Result: valuePtr},
+				printer.StructuredOptions{})
+		}
 	}
+	err = withHandlerNameSuggestions(cctx, cl, c.Parent.Namespace,
+		&common.WorkflowExecution{WorkflowId: c.WorkflowId, RunId: c.RunId}, "update", c.Name, err)
+	return fmt.Errorf("unable to update workflow: %w", err)
+}
+
+type workflowUpdateResult struct {
+	WorkflowId string          `json:"workflowId"`
+	RunId      string          `json:"runId"`
+	UpdateId   string          `json:"updateId,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
 
-	var valuePtr interface{}
-	err = updateHandle.Get(cctx, &valuePtr)
+func (c *TemporalWorkflowUpdateCommand) updateMany(cctx *CommandContext, cl client.Client, input []any) error {
+	if c.WorkflowId != "" || c.RunId != "" || c.FirstExecutionRunId != "" {
+		return fmt.Errorf("cannot set workflow ID, run ID, or first execution run ID when query is set")
+	} else if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	} else if c.Rps < 0 {
+		return fmt.Errorf("rps cannot be negative")
+	}
+	// We create a faux SingleWorkflowOrBatchOptions to use the shared prompt
+	// and sampling
+	opts := SingleWorkflowOrBatchOptions{
+		Query:         openExecutionsQuery(c.Query),
+		Yes:           c.Yes,
+		SamplePercent: c.SamplePercent,
+		MaxCount:      c.MaxCount,
+	}
+	execs, err := opts.confirmQueryExecutions(cctx, cl, fmt.Sprintf("Send Update %q to", c.Name))
 	if err != nil {
-		return fmt.Errorf("unable to update workflow: %w", err)
+		return err
+	} else if execs == nil {
+		if execs, err = listOpenExecutions(cctx, cl, c.Query); err != nil {
+			return err
+		}
+	}
+	results := make([]*workflowUpdateResult, len(execs))
+	for i, exec := range execs {
+		results[i] = &workflowUpdateResult{WorkflowId: exec.WorkflowId, RunId: exec.RunId, Error: "not sent"}
 	}
+	var failed atomic.Int32
+	forEachExecution(cctx, execs, c.Concurrency, c.Rps, func(i int, exec *common.WorkflowExecution) {
+		results[i].Error = ""
+		handle, err := cl.UpdateWorkflowWithOptions(cctx, &client.UpdateWorkflowWithOptionsRequest{
+			WorkflowID: exec.WorkflowId,
+			RunID:      exec.RunId,
+			UpdateName: c.Name,
+			UpdateID:   c.UpdateId,
+			Args:       input,
+		})
+		if err == nil {
+			results[i].UpdateId = handle.UpdateID()
+			var value any
+			if err = handle.Get(cctx, &value); err == nil {
+				results[i].Result, err = json.Marshal(value)
+			}
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			failed.Add(1)
+		}
+	})
 
-	return cctx.Printer.PrintStructured(
-		struct {
-			Name     string      `json:"name"`
-			UpdateID string      `json:"updateId"`
-			Result   interface{} `json:"result"`
-		}{Name: c.Name, UpdateID: updateHandle.UpdateID(), Result: valuePtr},
-		printer.StructuredOptions{})
+	if cctx.JSONOutput {
+		if err := cctx.Printer.PrintStructured(results, printer.StructuredOptions{}); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		cctx.Printer.Println("No running workflows match the query")
+	} else {
+		err := cctx.Printer.PrintStructured(results, printer.StructuredOptions{Table: &printer.TableOptions{}})
+		if err != nil {
+			return err
+		}
+	}
+	if cctx.Err() != nil {
+		return fmt.Errorf("interrupted, workflows marked as not sent did not get the update: %w", cctx.Err())
+	} else if failed.Load() > 0 {
+		return fmt.Errorf("%v of %v updates failed", failed.Load(), len(results))
+	}
+	return nil
 }
 
 func username() string {
@@ -241,6 +599,10 @@ func defaultReason() string {
 	return "Requested from CLI by " + username()
 }
 
+// Most executions a sampled batch can have. They are sent in the batch request
+// itself, and servers limit how many it can have (1000 by default).
+const maxSampledExecutions = 1000
+
 type singleOrBatchOverrides struct {
 	AllowReasonWithWorkflowID bool
 }
@@ -251,53 +613,262 @@ func (s *SingleWorkflowOrBatchOptions) workflowExecOrBatch(
 	cl client.Client,
 	overrides singleOrBatchOverrides,
 ) (*common.WorkflowExecution, *workflowservice.StartBatchOperationRequest, error) {
-	// If workflow is set, we return single execution
+	exec, err := s.singleExecution(overrides)
+	if err != nil || exec != nil {
+		return exec, nil, err
+	}
+	// When sampling, the batch is on the selected executions instead of the
+	// query
+	sampled, err := s.confirmQueryExecutions(cctx, cl, "Start batch against")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Default the reason if not set
+	reason := s.Reason
+	if reason == "" {
+		reason = defaultReason()
+	}
+
+	req := &workflowservice.StartBatchOperationRequest{
+		Namespace: namespace,
+		JobId:     uuid.NewString(),
+		Reason:    reason,
+	}
+	if sampled != nil {
+		req.Executions = sampled
+	} else {
+		req.VisibilityQuery = s.Query
+	}
+	return nil, req, nil
+}
+
+// Returns the execution if workflow ID is set, or nil if query is set instead.
+func (s *SingleWorkflowOrBatchOptions) singleExecution(
+	overrides singleOrBatchOverrides,
+) (*common.WorkflowExecution, error) {
 	if s.WorkflowId != "" {
 		if s.Query != "" {
-			return nil, nil, fmt.Errorf("cannot set query when workflow ID is set")
+			return nil, fmt.Errorf("cannot set query when workflow ID is set")
 		} else if s.Reason != "" && !overrides.AllowReasonWithWorkflowID {
-			return nil, nil, fmt.Errorf("cannot set reason when workflow ID is set")
+			return nil, fmt.Errorf("cannot set reason when workflow ID is set")
 		} else if s.Yes {
-			return nil, nil, fmt.Errorf("cannot set 'yes' when workflow ID is set")
+			return nil, fmt.Errorf("cannot set 'yes' when workflow ID is set")
+		} else if s.SamplePercent != 0 || s.MaxCount != 0 {
+			return nil, fmt.Errorf("cannot set sample percent or max count when workflow ID is set")
 		}
-		return &common.WorkflowExecution{WorkflowId: s.WorkflowId, RunId: s.RunId}, nil, nil
+		return &common.WorkflowExecution{WorkflowId: s.WorkflowId, RunId: s.RunId}, nil
 	}
 
 	// Check query is set properly
 	if s.Query == "" {
-		return nil, nil, fmt.Errorf("must set either workflow ID or query")
-	} else if s.WorkflowId != "" {
-		return nil, nil, fmt.Errorf("cannot set workflow ID when query is set")
+		return nil, fmt.Errorf("must set either workflow ID or query")
 	} else if s.RunId != "" {
-		return nil, nil, fmt.Errorf("cannot set run ID when query is set")
+		return nil, fmt.Errorf("cannot set run ID when query is set")
 	}
+	return nil, nil
+}
 
-	// Count the workflows that will be affected
-	count, err := cl.CountWorkflow(cctx, &workflowservice.CountWorkflowExecutionsRequest{Query: s.Query})
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed counting workflows from query: %w", err)
+// Confirms acting on the workflows matching the query, or on a sample of them
+// if sample percent or max count is set, in which case the sampled executions
+// are returned. The action starts the prompt, e.g. "Reindex" prompts "Reindex
+// approximately 10 workflow(s)? y/N".
+func (s *SingleWorkflowOrBatchOptions) confirmQueryExecutions(
+	cctx *CommandContext,
+	cl client.Client,
+	action string,
+) ([]*common.WorkflowExecution, error) {
+	var sampled []*common.WorkflowExecution
+	var prompt string
+	if s.SamplePercent != 0 || s.MaxCount != 0 {
+		if s.MaxCount > maxSampledExecutions {
+			return nil, fmt.Errorf("max count cannot be more than %v", maxSampledExecutions)
+		}
+		// Select one more than allowed to know if there are too many
+		maxCount := s.MaxCount
+		if maxCount == 0 {
+			maxCount = maxSampledExecutions + 1
+		}
+		var err error
+		if sampled, err = sampleExecutions(cctx, cl, s.Query, s.SamplePercent, maxCount); err != nil {
+			return nil, err
+		} else if len(sampled) == 0 {
+			return nil, fmt.Errorf("no workflows selected from query")
+		} else if len(sampled) > maxSampledExecutions {
+			return nil, fmt.Errorf("more than %v workflows selected, use --max-count or a narrower query",
+				maxSampledExecutions)
+		}
+		prompt = fmt.Sprintf("%v %v selected workflow(s)? y/N", action, len(sampled))
+	} else {
+		// Count the workflows that will be affected
+		count, err := cl.CountWorkflow(cctx, &workflowservice.CountWorkflowExecutionsRequest{Query: s.Query})
+		if err != nil {
+			return nil, fmt.Errorf("failed counting workflows from query: %w", err)
+		}
+		prompt = fmt.Sprintf("%v approximately %v workflow(s)? y/N", action, count.Count)
 	}
-	yes, err := cctx.promptYes(
-		fmt.Sprintf("Start batch against approximately %v workflow(s)? y/N", count.Count), s.Yes)
+	yes, err := cctx.promptYes(prompt, s.Yes)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	} else if !yes {
 		// We consider this a command failure
-		return nil, nil, fmt.Errorf("user denied confirmation")
+		return nil, fmt.Errorf("user denied confirmation")
 	}
+	return sampled, nil
+}
 
-	// Default the reason if not set
-	reason := s.Reason
-	if reason == "" {
-		reason = defaultReason()
+type workflowTreeNode struct {
+	WorkflowId string              `json:"workflowId"`
+	RunId      string              `json:"runId"`
+	Type       string              `json:"type"`
+	Children   []*workflowTreeNode `json:"children,omitempty"`
+}
+
+// Describes the execution and recursively adds all pending children that do
+// not have an abandon parent close policy.
+func collectPendingChildren(cctx *CommandContext, cl client.Client, node *workflowTreeNode) error {
+	resp, err := cl.DescribeWorkflowExecution(cctx, node.WorkflowId, node.RunId)
+	if err != nil {
+		return fmt.Errorf("failed describing workflow %v: %w", node.WorkflowId, err)
+	}
+	node.RunId = resp.WorkflowExecutionInfo.GetExecution().GetRunId()
+	node.Type = resp.WorkflowExecutionInfo.GetType().GetName()
+	for _, child := range resp.PendingChildren {
+		if child.ParentClosePolicy == enums.PARENT_CLOSE_POLICY_ABANDON {
+			continue
+		}
+		childNode := &workflowTreeNode{WorkflowId: child.WorkflowId, RunId: child.RunId, Type: child.WorkflowTypeName}
+		// Children that have not started yet have no run to describe
+		if child.RunId != "" {
+			if err := collectPendingChildren(cctx, cl, childNode); err != nil {
+				return err
+			}
+		}
+		node.Children = append(node.Children, childNode)
+	}
+	return nil
+}
+
+// Collects the tree of non-abandoned children for the execution and invokes
+// the given operation on each, children before parents. The affected tree is
+// then printed.
+func applyToWorkflowTree(
+	cctx *CommandContext,
+	cl client.Client,
+	exec *common.WorkflowExecution,
+	opName string,
+	op func(*common.WorkflowExecution) error,
+) error {
+	root := &workflowTreeNode{WorkflowId: exec.WorkflowId, RunId: exec.RunId}
+	if err := collectPendingChildren(cctx, cl, root); err != nil {
+		return err
+	}
+	var done []*workflowTreeNode
+	var apply func(node *workflowTreeNode, isRoot bool) error
+	apply = func(node *workflowTreeNode, isRoot bool) error {
+		for _, child := range node.Children {
+			if err := apply(child, false); err != nil {
+				return err
+			}
+		}
+		err := op(&common.WorkflowExecution{WorkflowId: node.WorkflowId, RunId: node.RunId})
+		// A child may have completed on its own in the meantime
+		var notFound *serviceerror.NotFound
+		if err != nil && (isRoot || !errors.As(err, &notFound)) {
+			return fmt.Errorf("failed to %v workflow %v: %w", opName, node.WorkflowId, err)
+		}
+		done = append(done, node)
+		return nil
+	}
+	if err := apply(root, true); err != nil {
+		// On interrupt, say what was done. Running again picks up the rest since
+		// only still-pending children are collected.
+		if cctx.Err() != nil {
+			cctx.Printer.Printlnf("Interrupted after requesting %v for %v workflow(s):", opName, len(done))
+			for _, node := range done {
+				cctx.Printer.Printlnf("  %v (run ID: %v)", node.WorkflowId, node.RunId)
+			}
+		}
+		return err
 	}
 
-	return nil, &workflowservice.StartBatchOperationRequest{
-		Namespace:       namespace,
-		JobId:           uuid.NewString(),
-		VisibilityQuery: s.Query,
-		Reason:          reason,
-	}, nil
+	if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(root, printer.StructuredOptions{})
+	}
+	cctx.Printer.Printlnf("Requested %v for workflow and its children:", opName)
+	printWorkflowTree(cctx, root, "  ")
+	return nil
+}
+
+// Long polls until the execution is closed and fails if it closed with a
+// status other than the expected one. A zero timeout waits indefinitely.
+func waitWorkflowClosed(
+	cctx *CommandContext,
+	cl client.Client,
+	exec *common.WorkflowExecution,
+	timeout time.Duration,
+	expected enums.WorkflowExecutionStatus,
+) error {
+	status, err := waitWorkflowCloseStatus(cctx, cl, exec, timeout)
+	if err != nil {
+		return err
+	} else if status != expected {
+		return fmt.Errorf("workflow closed with status %v", status)
+	}
+	cctx.Printer.Printlnf("Workflow closed with status %v", status)
+	return nil
+}
+
+// Long polls until the execution is closed and returns the status it closed
+// with. A zero timeout waits indefinitely.
+func waitWorkflowCloseStatus(
+	cctx *CommandContext,
+	cl client.Client,
+	exec *common.WorkflowExecution,
+	timeout time.Duration,
+) (enums.WorkflowExecutionStatus, error) {
+	ctx := context.Context(cctx)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	// The status comes from the close event instead of describing, since with
+	// no run ID a describe could see a newer run started since
+	var status enums.WorkflowExecutionStatus
+	iter := cl.GetWorkflowHistory(ctx, exec.WorkflowId, exec.RunId, true, enums.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			if ctx.Err() != nil && cctx.Err() == nil {
+				return 0, fmt.Errorf("timed out after %v waiting for workflow to close", timeout)
+			}
+			return 0, fmt.Errorf("failed waiting for workflow to close: %w", err)
+		}
+		if closeStatus, ok := closeEventStatuses[event.EventType]; ok {
+			status = closeStatus
+		}
+	}
+	if status == enums.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED {
+		return 0, fmt.Errorf("workflow history has no close event")
+	}
+	return status, nil
+}
+
+var closeEventStatuses = map[enums.EventType]enums.WorkflowExecutionStatus{
+	enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:        enums.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+	enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:           enums.WORKFLOW_EXECUTION_STATUS_FAILED,
+	enums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:        enums.WORKFLOW_EXECUTION_STATUS_TIMED_OUT,
+	enums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:         enums.WORKFLOW_EXECUTION_STATUS_CANCELED,
+	enums.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:       enums.WORKFLOW_EXECUTION_STATUS_TERMINATED,
+	enums.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW: enums.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW,
+}
+
+func printWorkflowTree(cctx *CommandContext, node *workflowTreeNode, indent string) {
+	cctx.Printer.Printlnf("%v%v (type: %v, run ID: %v)", indent, node.WorkflowId, node.Type, node.RunId)
+	for _, child := range node.Children {
+		printWorkflowTree(cctx, child, indent+"  ")
+	}
 }
 
 func startBatchJob(cctx *CommandContext, cl client.Client, req *workflowservice.StartBatchOperationRequest) error {
@@ -335,15 +906,9 @@ func queryHelper(cctx *CommandContext,
 		return err
 	}
 
-	queryRejectCond := enums.QUERY_REJECT_CONDITION_UNSPECIFIED
-	switch rejectCondition.Value {
-	case "":
-	case "not_open":
-		queryRejectCond = enums.QUERY_REJECT_CONDITION_NOT_OPEN
-	case "not_completed_cleanly":
-		queryRejectCond = enums.QUERY_REJECT_CONDITION_NOT_COMPLETED_CLEANLY
-	default:
-		return fmt.Errorf("invalid query reject condition: %v, valid values are: 'not_open', 'not_completed_cleanly'", rejectCondition)
+	queryRejectCond, err := queryRejectCondition(rejectCondition)
+	if err != nil {
+		return err
 	}
 
 	result, err := cl.WorkflowService().QueryWorkflow(cctx, &workflowservice.QueryWorkflowRequest{
@@ -357,6 +922,8 @@ func queryHelper(cctx *CommandContext,
 	})
 
 	if err != nil {
+		err = withHandlerNameSuggestions(cctx, cl, parent.Namespace,
+			&common.WorkflowExecution{WorkflowId: execution.WorkflowId, RunId: execution.RunId}, "query", queryType, err)
 		return fmt.Errorf("querying workflow failed: %w", err)
 	}
 
@@ -379,3 +946,204 @@ func queryHelper(cctx *CommandContext,
 
 	return cctx.Printer.PrintStructured(output, printer.StructuredOptions{})
 }
+
+// Matches the known names SDKs list in their errors, e.g. Go's
+// "KnownQueryTypes=[a b]" and "KnownUpdates=[a b]".
+var knownHandlerNamesRegex = regexp.MustCompile(`Known(?:QueryTypes|Updates)=\[([^\]]*)\]`)
+
+// Adds the closest registered names to the error if it is a workflow rejecting
+// an unknown query or update name. The names come from the workflow's
+// metadata, or from the error itself for SDKs without metadata.
+func withHandlerNameSuggestions(
+	cctx *CommandContext,
+	cl client.Client,
+	namespace string,
+	exec *common.WorkflowExecution,
+	kind string,
+	name string,
+	err error,
+) error {
+	msg := strings.ToLower(err.Error())
+	if !strings.Contains(msg, "unknown") && !strings.Contains(msg, "not found") &&
+		!strings.Contains(msg, "no handler") && !strings.Contains(msg, "did not register") {
+		return err
+	}
+	var names []string
+	resp, metadataErr := cl.WorkflowService().QueryWorkflow(cctx, &workflowservice.QueryWorkflowRequest{
+		Namespace: namespace,
+		Execution: exec,
+		Query:     &query.WorkflowQuery{QueryType: "__temporal_workflow_metadata"},
+	})
+	var metadata sdk.WorkflowMetadata
+	if metadataErr == nil && len(resp.QueryResult.GetPayloads()) > 0 &&
+		converter.GetDefaultDataConverter().FromPayload(resp.QueryResult.Payloads[0], &metadata) == nil {
+		definitions := metadata.Definition.GetQueryDefinitions()
+		if kind == "update" {
+			definitions = metadata.Definition.GetUpdateDefinitions()
+		}
+		for _, definition := range definitions {
+			names = append(names, definition.Name)
+		}
+	} else if match := knownHandlerNamesRegex.FindStringSubmatch(err.Error()); match != nil {
+		names = strings.Fields(match[1])
+	}
+	// Internal handlers are not suggested
+	names = slices.DeleteFunc(names, func(n string) bool { return strings.HasPrefix(n, "__") || n == name })
+	if len(names) == 0 {
+		return err
+	}
+	slices.Sort(names)
+	if closest := closestNames(name, names, 3); len(closest) > 0 {
+		return fmt.Errorf("%w (did you mean %v? registered %v names: %v)",
+			err, strings.Join(closest, " or "), kind, strings.Join(names, ", "))
+	}
+	return fmt.Errorf("%w (registered %v names: %v)", err, kind, strings.Join(names, ", "))
+}
+
+// Returns up to limit names, quoted, that are a small edit distance from the
+// given one, closest first.
+func closestNames(name string, names []string, limit int) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for _, n := range names {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(n))
+		// Allow about a third of the name to differ
+		if distance <= len(name)/3+1 || strings.Contains(strings.ToLower(n), strings.ToLower(name)) {
+			candidates = append(candidates, candidate{n, distance})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int { return a.distance - b.distance })
+	var closest []string
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		closest = append(closest, strconv.Quote(candidates[i].name))
+	}
+	return closest
+}
+
+// Levenshtein distance between the strings.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr := make([]int, len(br)+1)
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(br)]
+}
+
+func listOpenExecutions(cctx *CommandContext, cl client.Client, filter string) ([]*common.WorkflowExecution, error) {
+	return sampleExecutions(cctx, cl, openExecutionsQuery(filter), 0, 0)
+}
+
+// Lists executions matching the query, keeping each with the given percent
+// chance (all if zero) and stopping after max count (unlimited if zero).
+func sampleExecutions(
+	cctx *CommandContext,
+	cl client.Client,
+	query string,
+	samplePercent float64,
+	maxCount int,
+) ([]*common.WorkflowExecution, error) {
+	if samplePercent < 0 || samplePercent > 100 {
+		return nil, fmt.Errorf("sample percent must be between 0 and 100")
+	} else if maxCount < 0 {
+		return nil, fmt.Errorf("max count cannot be negative")
+	}
+	var execs []*common.WorkflowExecution
+	var nextPageToken []byte
+	for {
+		resp, err := cl.ListWorkflow(cctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed listing workflows: %w", err)
+		}
+		for _, info := range resp.Executions {
+			if samplePercent == 0 || rand.Float64()*100 < samplePercent {
+				execs = append(execs, info.Execution)
+				if maxCount > 0 && len(execs) >= maxCount {
+					return execs, nil
+				}
+			}
+		}
+		if nextPageToken = resp.NextPageToken; len(nextPageToken) == 0 {
+			return execs, nil
+		}
+	}
+}
+
+func openExecutionsQuery(filter string) string {
+	return "(" + filter + ") AND ExecutionStatus = 'Running'"
+}
+
+// Calls fn for each execution with at most concurrency calls at once and, if
+// perSecond is non-zero, at most that many calls started per second. Stops
+// starting calls if the context is done.
+func forEachExecution(
+	cctx *CommandContext,
+	execs []*common.WorkflowExecution,
+	concurrency int,
+	perSecond float64,
+	fn func(int, *common.WorkflowExecution),
+) {
+	var tick <-chan time.Time
+	if perSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / perSecond))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, exec := range execs {
+		if i > 0 && tick != nil {
+			select {
+			case <-tick:
+			case <-cctx.Done():
+			}
+		}
+		// Checked once a slot is free since waiting for one may outlast an
+		// interrupt
+		sem <- struct{}{}
+		if cctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i, exec)
+		}()
+	}
+	wg.Wait()
+}
+
+func queryRejectCondition(rejectCondition StringEnum) (enums.QueryRejectCondition, error) {
+	switch rejectCondition.Value {
+	case "":
+		return enums.QUERY_REJECT_CONDITION_UNSPECIFIED, nil
+	case "not_open":
+		return enums.QUERY_REJECT_CONDITION_NOT_OPEN, nil
+	case "not_completed_cleanly":
+		return enums.QUERY_REJECT_CONDITION_NOT_COMPLETED_CLEANLY, nil
+	default:
+		return 0, fmt.Errorf("invalid query reject condition: %v, valid values are: 'not_open', 'not_completed_cleanly'", rejectCondition)
+	}
+}
@@ -3,9 +3,13 @@ package temporalcli
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,9 +18,15 @@ import (
 	"go.temporal.io/api/common/v1"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/temporalproto"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
 )
 
 func (c *TemporalWorkflowStartCommand) run(cctx *CommandContext, args []string) error {
@@ -43,41 +53,138 @@ func (c *TemporalWorkflowExecuteCommand) run(cctx *CommandContext, args []string
 	// Separate newline
 	cctx.Printer.Println()
 
+	// Stop following and detach if the limit is reached before close
+	ctx := context.Context(cctx)
+	if c.DetachAfter > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.DetachAfter.Duration())
+		defer cancel()
+	}
+	closeEvent, lastRunID, err := followWorkflowToClose(cctx, ctx, cl, run.GetID(), run.GetRunID(), c.EventDetails)
+	if err != nil {
+		return err
+	} else if closeEvent == nil {
+		return c.printDetached(cctx, run.GetID(), lastRunID)
+	}
+	return printWorkflowResult(cctx, cl, workflowResultInfo{
+		WorkflowId: run.GetID(),
+		RunId:      run.GetRunID(),
+		Type:       c.SharedWorkflowStartOptions.Type,
+		Namespace:  c.Parent.Namespace,
+		TaskQueue:  c.SharedWorkflowStartOptions.TaskQueue,
+	}, closeEvent, time.Since(startTime), c.EventDetails)
+}
+
+func (c *TemporalWorkflowAttachCommand) run(cctx *CommandContext, args []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+	desc, err := cl.DescribeWorkflowExecution(cctx, c.WorkflowId, c.RunId)
+	if err != nil {
+		return fmt.Errorf("failed describing workflow: %w", err)
+	}
+	info := desc.WorkflowExecutionInfo
+	if !cctx.JSONOutput {
+		cctx.Printer.Printlnf("Attached to workflow %v (run %v)", info.Execution.GetWorkflowId(), info.Execution.GetRunId())
+		// Separate newline
+		cctx.Printer.Println()
+	}
+
+	closeEvent, _, err := followWorkflowToClose(
+		cctx, cctx, cl, info.Execution.GetWorkflowId(), info.Execution.GetRunId(), c.EventDetails)
+	if err != nil {
+		return err
+	} else if closeEvent == nil {
+		return fmt.Errorf("interrupted")
+	}
+	var duration time.Duration
+	if info.StartTime != nil && closeEvent.EventTime != nil {
+		duration = closeEvent.EventTime.AsTime().Sub(info.StartTime.AsTime())
+	}
+	return printWorkflowResult(cctx, cl, workflowResultInfo{
+		WorkflowId: info.Execution.GetWorkflowId(),
+		RunId:      info.Execution.GetRunId(),
+		Type:       info.Type.GetName(),
+		Namespace:  c.Parent.Namespace,
+		TaskQueue:  info.TaskQueue,
+	}, closeEvent, duration, c.EventDetails)
+}
+
+// Prints progress of the workflow in text mode until it closes and returns
+// the close event, following continue as new. Returns a nil close event and
+// the last run ID seen if ctx ends before the workflow closes.
+func followWorkflowToClose(
+	cctx *CommandContext,
+	ctx context.Context,
+	cl client.Client,
+	workflowID string,
+	runID string,
+	eventDetails bool,
+) (closeEvent *history.HistoryEvent, lastRunID string, err error) {
+	ended := func() bool { return ctx.Err() != nil && cctx.Err() == nil }
+
 	// Print history only if not JSON
 	if !cctx.JSONOutput {
 		cctx.Printer.Println(color.MagentaString("Progress:"))
 		iter := &structuredHistoryIter{
-			ctx:            cctx,
+			ctx:            ctx,
 			client:         cl,
-			workflowID:     run.GetID(),
-			runID:          run.GetRunID(),
-			includeDetails: c.EventDetails,
+			workflowID:     workflowID,
+			runID:          runID,
+			includeDetails: eventDetails,
 			follow:         true,
 		}
-		if err := iter.print(cctx.Printer); err != nil && cctx.Err() == nil {
-			return fmt.Errorf("displaying history failed: %w", err)
+		if err := iter.print(cctx.Printer); err != nil && ctx.Err() == nil {
+			return nil, "", fmt.Errorf("displaying history failed: %w", err)
 		}
 		// Separate newline
 		cctx.Printer.Println()
 	}
 
 	// Get the close event, following continue as new
-	var closeEvent *history.HistoryEvent
-	for runID := run.GetRunID(); closeEvent == nil; {
-		iter := cl.GetWorkflowHistory(cctx, run.GetID(), runID, true, enums.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT)
+	for closeEvent == nil {
+		if ended() {
+			return nil, runID, nil
+		}
+		iter := cl.GetWorkflowHistory(ctx, workflowID, runID, true, enums.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT)
 		if !iter.HasNext() {
-			return fmt.Errorf("missing close event")
+			return nil, "", fmt.Errorf("missing close event")
 		} else if closeEvent, err = iter.Next(); err != nil {
-			return fmt.Errorf("failed getting close event: %w", err)
+			if ended() {
+				return nil, runID, nil
+			}
+			return nil, "", fmt.Errorf("failed getting close event: %w", err)
 		} else if canAttr := closeEvent.GetWorkflowExecutionContinuedAsNewEventAttributes(); canAttr != nil {
 			closeEvent, runID = nil, canAttr.NewExecutionRunId
 		}
 	}
-	duration := time.Since(startTime)
+	return closeEvent, runID, nil
+}
+
+type workflowResultInfo struct {
+	WorkflowId string
+	RunId      string
+	Type       string
+	Namespace  string
+	TaskQueue  string
+}
 
-	// Print result
-	if cctx.JSONOutput {
-		err = c.printJSONResult(cctx, cl, run, closeEvent, duration)
+// Prints the result of a closed workflow, returning an error if the workflow
+// did not complete successfully.
+func printWorkflowResult(
+	cctx *CommandContext,
+	cl client.Client,
+	info workflowResultInfo,
+	closeEvent *history.HistoryEvent,
+	duration time.Duration,
+	eventDetails bool,
+) (err error) {
+	if cctx.testReportFormat != "" {
+		err = printTestReport(cctx, info, closeEvent, duration)
+	} else if cctx.JSONOutput {
+		err = printJSONResult(cctx, cl, info, closeEvent, duration, eventDetails)
 	} else {
 		err = printTextResult(cctx, closeEvent, duration)
 	}
@@ -91,12 +198,35 @@ func (c *TemporalWorkflowExecuteCommand) run(cctx *CommandContext, args []string
 	return err
 }
 
-func (c *TemporalWorkflowExecuteCommand) printJSONResult(
+func (c *TemporalWorkflowExecuteCommand) printDetached(cctx *CommandContext, workflowID, runID string) error {
+	if cctx.testReportFormat != "" {
+		return printTestReport(cctx, workflowResultInfo{
+			WorkflowId: workflowID,
+			RunId:      runID,
+			Type:       c.SharedWorkflowStartOptions.Type,
+			Namespace:  c.Parent.Namespace,
+			TaskQueue:  c.SharedWorkflowStartOptions.TaskQueue,
+		}, nil, c.DetachAfter.Duration())
+	} else if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(struct {
+			WorkflowId string `json:"workflowId"`
+			RunId      string `json:"runId"`
+			Detached   bool   `json:"detached"`
+		}{WorkflowId: workflowID, RunId: runID, Detached: true}, printer.StructuredOptions{})
+	}
+	cctx.Printer.Printlnf("Detached after %v, the workflow is still running. To follow it again, run:",
+		c.DetachAfter.Duration())
+	cctx.Printer.Printlnf("  temporal workflow attach --workflow-id %v --run-id %v", workflowID, runID)
+	return nil
+}
+
+func printJSONResult(
 	cctx *CommandContext,
 	client client.Client,
-	run client.WorkflowRun,
+	info workflowResultInfo,
 	closeEvent *history.HistoryEvent,
 	duration time.Duration,
+	eventDetails bool,
 ) error {
 	result := struct {
 		WorkflowId     string          `json:"workflowId"`
@@ -110,11 +240,11 @@ func (c *TemporalWorkflowExecuteCommand) printJSONResult(
 		Result         json.RawMessage `json:"result,omitempty"`
 		History        json.RawMessage `json:"history,omitempty"`
 	}{
-		WorkflowId:     run.GetID(),
-		RunId:          run.GetRunID(),
-		Type:           c.SharedWorkflowStartOptions.Type,
-		Namespace:      c.Parent.Namespace,
-		TaskQueue:      c.SharedWorkflowStartOptions.TaskQueue,
+		WorkflowId:     info.WorkflowId,
+		RunId:          info.RunId,
+		Type:           info.Type,
+		Namespace:      info.Namespace,
+		TaskQueue:      info.TaskQueue,
 		DurationMillis: int64(duration / time.Millisecond),
 		Status:         "<unknown>",
 		CloseEvent:     json.RawMessage("null"),
@@ -140,9 +270,9 @@ func (c *TemporalWorkflowExecuteCommand) printJSONResult(
 	}
 
 	// Build history if requested
-	if c.EventDetails {
+	if eventDetails {
 		var histProto history.History
-		iter := client.GetWorkflowHistory(cctx, run.GetID(), run.GetRunID(), false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+		iter := client.GetWorkflowHistory(cctx, info.WorkflowId, info.RunId, false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
 		for iter.HasNext() {
 			event, err := iter.Next()
 			if err != nil {
@@ -209,6 +339,143 @@ func printTextResult(
 	return cctx.Printer.PrintStructured(result, printer.StructuredOptions{})
 }
 
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Details string `xml:",chardata"`
+}
+
+// Prints the result of a workflow as a single JUnit or TAP test case. A nil
+// close event means the workflow was detached from and is reported as skipped.
+func printTestReport(
+	cctx *CommandContext,
+	info workflowResultInfo,
+	closeEvent *history.HistoryEvent,
+	duration time.Duration,
+) error {
+	// Collect status, failure message/details, and result
+	var status, message, details, output string
+	switch closeEvent.GetEventType() {
+	case enums.EVENT_TYPE_UNSPECIFIED:
+		status, message = "DETACHED", fmt.Sprintf("detached after %v, the workflow is still running", duration)
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		status = "COMPLETED"
+		result, err := cctx.MarshalFriendlyJSONPayloads(
+			closeEvent.GetWorkflowExecutionCompletedEventAttributes().GetResult())
+		if err != nil {
+			return fmt.Errorf("failed marshaling result: %w", err)
+		}
+		output = string(result)
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		failure := closeEvent.GetWorkflowExecutionFailedEventAttributes().GetFailure()
+		status, message = "FAILED", failure.GetMessage()
+		details = strings.TrimPrefix(cctx.MarshalFriendlyFailureBodyText(failure, ""), "\n")
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		status, message = "TIMEOUT", "workflow timed out"
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		status, message = "CANCELED", "workflow canceled"
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		status, message = "TERMINATED", "workflow terminated"
+		if reason := closeEvent.GetWorkflowExecutionTerminatedEventAttributes().GetReason(); reason != "" {
+			message += ": " + reason
+		}
+	default:
+		status, message = "<unknown>", fmt.Sprintf("workflow closed with %v", closeEvent.EventType)
+	}
+	name := info.WorkflowId
+	if info.Type != "" {
+		name = info.Type + " " + info.WorkflowId
+	}
+	seconds := strconv.FormatFloat(duration.Seconds(), 'f', 3, 64)
+
+	if cctx.testReportFormat == "tap" {
+		// TAP version 13 with a YAML diagnostic block
+		diag, err := yaml.Marshal(struct {
+			WorkflowId     string `yaml:"workflowId"`
+			RunId          string `yaml:"runId"`
+			Namespace      string `yaml:"namespace"`
+			TaskQueue      string `yaml:"taskQueue"`
+			Status         string `yaml:"status"`
+			DurationMillis int64  `yaml:"durationMillis"`
+			Message        string `yaml:"message,omitempty"`
+			Details        string `yaml:"details,omitempty"`
+			Result         string `yaml:"result,omitempty"`
+		}{
+			WorkflowId:     info.WorkflowId,
+			RunId:          info.RunId,
+			Namespace:      info.Namespace,
+			TaskQueue:      info.TaskQueue,
+			Status:         status,
+			DurationMillis: int64(duration / time.Millisecond),
+			Message:        message,
+			Details:        details,
+			Result:         output,
+		})
+		if err != nil {
+			return fmt.Errorf("failed marshaling TAP diagnostics: %w", err)
+		}
+		var b strings.Builder
+		b.WriteString("TAP version 13\n1..1\n")
+		switch status {
+		case "COMPLETED":
+			fmt.Fprintf(&b, "ok 1 - %v\n", name)
+		case "DETACHED":
+			fmt.Fprintf(&b, "ok 1 - %v # SKIP %v\n", name, message)
+		default:
+			fmt.Fprintf(&b, "not ok 1 - %v\n", name)
+		}
+		b.WriteString("  ---\n")
+		for _, line := range strings.Split(strings.TrimSuffix(string(diag), "\n"), "\n") {
+			b.WriteString("  " + line + "\n")
+		}
+		b.WriteString("  ...\n")
+		_, err = io.WriteString(cctx.Printer.Output, b.String())
+		return err
+	}
+
+	testCase := junitTestCase{Name: name, Classname: info.TaskQueue, Time: seconds, SystemOut: output}
+	suite := junitTestSuite{Name: info.Namespace, Tests: 1, Time: seconds}
+	switch status {
+	case "COMPLETED":
+	case "DETACHED":
+		testCase.Skipped = &junitMessage{Message: message}
+		suite.Skipped = 1
+	default:
+		testCase.Failure = &junitMessage{Message: message, Type: status, Details: details}
+		suite.Failures = 1
+	}
+	suite.Cases = append(suite.Cases, testCase)
+	b, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed marshaling JUnit XML: %w", err)
+	}
+	_, err = io.WriteString(cctx.Printer.Output, xml.Header+string(b)+"\n")
+	return err
+}
+
 func (c *TemporalWorkflowCommand) startWorkflow(
 	cctx *CommandContext,
 	cl client.Client,
@@ -221,6 +488,12 @@ func (c *TemporalWorkflowCommand) startWorkflow(
 	if err != nil {
 		return nil, err
 	}
+	if err := cctx.checkRequiredLabels(startOpts.SearchAttributes, startOpts.Memo); err != nil {
+		return nil, err
+	}
+	if err := cctx.validateWorkflowInput(sharedWorkflowOpts.Type, inputOpts); err != nil {
+		return nil, err
+	}
 	input, err := inputOpts.buildRawInput()
 	if err != nil {
 		return nil, err
@@ -272,9 +545,14 @@ func buildStartOptions(sw *SharedWorkflowStartOptions, w *WorkflowStartOptions)
 			return o, fmt.Errorf("invalid workflow ID reuse policy: %w", err)
 		}
 	}
-	if len(sw.Memo) > 0 {
+	if retryPolicy, err := w.buildRetryPolicy(); err != nil {
+		return o, err
+	} else if retryPolicy != nil {
+		o.RetryPolicy = retryPolicy
+	}
+	if len(sw.Memo) > 0 || len(sw.MemoFile) > 0 {
 		var err error
-		if o.Memo, err = stringKeysJSONValues(sw.Memo, false); err != nil {
+		if o.Memo, err = stringKeysJSONValuesWithFiles(sw.Memo, sw.MemoFile, false); err != nil {
 			return o, fmt.Errorf("invalid memo values: %w", err)
 		}
 	}
@@ -287,6 +565,34 @@ func buildStartOptions(sw *SharedWorkflowStartOptions, w *WorkflowStartOptions)
 	return o, nil
 }
 
+// Returns nil if no retry options are set
+func (w *WorkflowStartOptions) buildRetryPolicy() (*temporal.RetryPolicy, error) {
+	if w.RetryInitialInterval == 0 && w.RetryMaximumInterval == 0 && w.RetryBackoffCoefficient == 0 &&
+		w.RetryMaximumAttempts == 0 && len(w.RetryNonRetryableErrorType) == 0 {
+		return nil, nil
+	}
+	switch {
+	case w.RetryInitialInterval < 0:
+		return nil, fmt.Errorf("retry initial interval cannot be negative")
+	case w.RetryMaximumInterval < 0:
+		return nil, fmt.Errorf("retry maximum interval cannot be negative")
+	case w.RetryBackoffCoefficient != 0 && w.RetryBackoffCoefficient < 1:
+		return nil, fmt.Errorf("retry backoff coefficient must be at least 1")
+	case w.RetryMaximumAttempts < 0:
+		return nil, fmt.Errorf("retry maximum attempts cannot be negative")
+	case w.RetryInitialInterval > 0 && w.RetryMaximumInterval > 0 &&
+		w.RetryMaximumInterval < w.RetryInitialInterval:
+		return nil, fmt.Errorf("retry maximum interval cannot be less than initial interval")
+	}
+	return &temporal.RetryPolicy{
+		InitialInterval:        w.RetryInitialInterval.Duration(),
+		BackoffCoefficient:     w.RetryBackoffCoefficient,
+		MaximumInterval:        w.RetryMaximumInterval.Duration(),
+		MaximumAttempts:        int32(w.RetryMaximumAttempts),
+		NonRetryableErrorTypes: w.RetryNonRetryableErrorType,
+	}, nil
+}
+
 func (p *PayloadInputOptions) buildRawInput() ([]any, error) {
 	payloads, err := p.buildRawInputPayloads()
 	if err != nil {
@@ -337,7 +643,7 @@ func (p *PayloadInputOptions) buildRawInputPayloads() (*common.Payloads, error)
 //	Completed - green
 //	Started - blue
 //	Others - default (white/black)
-func coloredEventType(e enums.EventType) string {
+func coloredEventType(e enums.EventType, raw bool) string {
 	fn := func(s string, ignore ...any) string { return s }
 	switch e {
 	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED,
@@ -364,6 +670,9 @@ func coloredEventType(e enums.EventType) string {
 		enums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
 		fn = color.BlueString
 	}
+	if raw {
+		return fn(enums.EventType_name[int32(e)])
+	}
 	return fn(e.String())
 }
 
@@ -373,16 +682,22 @@ type structuredHistoryIter struct {
 	workflowID     string
 	runID          string
 	includeDetails bool
+	// If set true, payloads in details are shown decoded in shorthand form
+	// instead of as raw protobuf JSON
+	jsonShorthandPayloads bool
 	// If set true, long poll the history for updates
 	follow bool
 	// If and when the iterator encounters a workflow-terminating event, it will store it here
 	wfResult *history.HistoryEvent
 
 	// Internal
-	iter client.HistoryEventIterator
+	iter        client.HistoryEventIterator
+	lastEventID int64
+	printer     *printer.Printer
 }
 
 func (s *structuredHistoryIter) print(p *printer.Printer) error {
+	s.printer = p
 	options := printer.StructuredOptions{Table: &printer.TableOptions{}}
 	if !s.includeDetails {
 		options.ExcludeFields = []string{"Details"}
@@ -417,15 +732,21 @@ func (s *structuredHistoryIter) Next() (any, error) {
 	data := structuredHistoryEvent{
 		ID:   event.EventId,
 		Time: event.EventTime.AsTime().Format(time.RFC3339),
-		Type: coloredEventType(event.EventType),
+		Type: coloredEventType(event.EventType, s.printer.RawEnums),
 	}
 	if s.includeDetails {
 		// First field in the attributes
 		attrs := reflect.ValueOf(event.Attributes).Elem().Field(0).Interface().(proto.Message)
-		if b, err := protojson.Marshal(attrs); err != nil {
+		marshal := protojson.Marshal
+		if s.jsonShorthandPayloads {
+			marshal = temporalproto.CustomJSONMarshalOptions{
+				Metadata: map[string]any{common.EnablePayloadShorthandMetadataKey: true},
+			}.Marshal
+		}
+		if b, err := marshal(attrs); err != nil {
 			data.Details = "<failed serializing details>"
 		} else {
-			data.Details = string(b)
+			data.Details = string(s.printer.ShortenEnums(b, attrs))
 		}
 	}
 
@@ -433,27 +754,80 @@ func (s *structuredHistoryIter) Next() (any, error) {
 	if attr := event.GetWorkflowExecutionContinuedAsNewEventAttributes(); attr != nil {
 		s.runID = attr.NewExecutionRunId
 		s.iter = nil
+		s.lastEventID = 0
 	}
 	return data, nil
 }
 
+const (
+	historyReconnectInitialBackoff = 1 * time.Second
+	historyReconnectMaxBackoff     = 30 * time.Second
+)
+
 func (s *structuredHistoryIter) NextRawEvent() (*history.HistoryEvent, error) {
-	// Load iter
-	if s.iter == nil {
-		s.iter = s.client.GetWorkflowHistory(
-			s.ctx, s.workflowID, s.runID, s.follow, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	backoff := historyReconnectInitialBackoff
+	for attempt := 1; ; attempt++ {
+		event, err := s.nextRawEventNoReconnect()
+		// Only reconnect on transient errors when following
+		if err == nil || !s.follow || s.ctx.Err() != nil || !isTransientError(err) {
+			if err == nil && attempt > 1 && s.printer != nil {
+				s.printer.Printlnf("--- Reconnected, resuming after event %v ---", s.lastEventID)
+			}
+			return event, err
+		}
+		if s.printer != nil {
+			s.printer.Printlnf("--- Connection lost (%v), reconnecting in %v (attempt %v) ---", err, backoff, attempt)
+		}
+		select {
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, historyReconnectMaxBackoff)
+		// Start the history over, skipping what has already been seen
+		s.iter = nil
 	}
-	if !s.iter.HasNext() {
-		return nil, nil
+}
+
+func (s *structuredHistoryIter) nextRawEventNoReconnect() (*history.HistoryEvent, error) {
+	for {
+		// Load iter
+		if s.iter == nil {
+			s.iter = s.client.GetWorkflowHistory(
+				s.ctx, s.workflowID, s.runID, s.follow, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+		}
+		if !s.iter.HasNext() {
+			return nil, nil
+		}
+		event, err := s.iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		// Skip events already seen before a reconnect
+		if event.EventId <= s.lastEventID {
+			continue
+		}
+		s.lastEventID = event.EventId
+		if isWorkflowTerminatingEvent(event.EventType) {
+			s.wfResult = event
+		}
+		return event, nil
 	}
-	event, err := s.iter.Next()
-	if err != nil {
-		return nil, err
+}
+
+// Whether the error is likely due to a connection problem that may resolve on
+// retry
+func isTransientError(err error) bool {
+	var unavailable *serviceerror.Unavailable
+	var deadlineExceeded *serviceerror.DeadlineExceeded
+	if errors.As(err, &unavailable) || errors.As(err, &deadlineExceeded) {
+		return true
 	}
-	if isWorkflowTerminatingEvent(event.EventType) {
-		s.wfResult = event
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
 	}
-	return event, nil
+	return false
 }
 
 func isWorkflowTerminatingEvent(t enums.EventType) bool {
@@ -5,9 +5,11 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"time"
@@ -103,6 +105,267 @@ func (s *SharedServerSuite) TestWorkflow_Start_StartDelay() {
 	)
 }
 
+func (s *SharedServerSuite) TestWorkflow_Start_RetryPolicy() {
+	// Capture request
+	var lastRequest any
+	var lastRequestLock sync.Mutex
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+		) error {
+			lastRequestLock.Lock()
+			lastRequest = req
+			lastRequestLock.Unlock()
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+
+	res := s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", "my-id1",
+		"--retry-initial-interval", "2s",
+		"--retry-maximum-interval", "1m",
+		"--retry-backoff-coefficient", "1.5",
+		"--retry-maximum-attempts", "5",
+		"--retry-non-retryable-error-type", "MyError1",
+		"--retry-non-retryable-error-type", "MyError2",
+	)
+	s.NoError(res.Err)
+	policy := lastRequest.(*workflowservice.StartWorkflowExecutionRequest).RetryPolicy
+	s.Equal(2*time.Second, policy.InitialInterval.AsDuration())
+	s.Equal(1*time.Minute, policy.MaximumInterval.AsDuration())
+	s.Equal(1.5, policy.BackoffCoefficient)
+	s.Equal(int32(5), policy.MaximumAttempts)
+	s.Equal([]string{"MyError1", "MyError2"}, policy.NonRetryableErrorTypes)
+
+	// Invalid values are rejected before reaching the server
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", "my-id2",
+		"--retry-backoff-coefficient", "0.5",
+	)
+	s.ErrorContains(res.Err, "retry backoff coefficient must be at least 1")
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", "my-id2",
+		"--retry-initial-interval", "1m",
+		"--retry-maximum-interval", "1s",
+	)
+	s.ErrorContains(res.Err, "retry maximum interval cannot be less than initial interval")
+}
+
+func (s *SharedServerSuite) TestWorkflow_Start_TypeRegistry() {
+	dir := s.T().TempDir()
+	registryFile := filepath.Join(dir, "workflow-types.yaml")
+	s.NoError(os.WriteFile(registryFile, []byte(`
+workflowTypes:
+  DevWorkflow:
+    taskQueue: `+s.Worker().Options.TaskQueue+`
+    inputSchema: input.schema.json
+`), 0o644))
+	s.NoError(os.WriteFile(filepath.Join(dir, "input.schema.json"), []byte(`{
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string", "minLength": 1},
+    "count": {"type": "integer", "minimum": 0},
+    "email": {"type": "string", "format": "email"}
+  },
+  "additionalProperties": false
+}`), 0o644))
+	s.CommandHarness.Options.EnvConfigFile = filepath.Join(dir, "env.yaml")
+	res := s.Execute("env", "set", "-k", "workflow-type-registry", "-v", registryFile)
+	s.NoError(res.Err)
+
+	// Task queue comes from the registry
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--type", "DevWorkflow",
+		"--workflow-id", "registry-id1",
+		"-i", `{"name": "foo", "count": 2}`,
+	)
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "TaskQueue", s.Worker().Options.TaskQueue)
+
+	// Invalid input is not sent
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--type", "DevWorkflow",
+		"--workflow-id", "registry-id2",
+		"-i", `{"count": 1.5, "email": "not-an-email", "other": true}`,
+	)
+	s.ErrorContains(res.Err, "input does not match schema for workflow type DevWorkflow")
+	s.ErrorContains(res.Err, "at '': missing property 'name'")
+	s.ErrorContains(res.Err, "at '/count': got number, want integer")
+	s.ErrorContains(res.Err, "at '/email': 'not-an-email' is not valid email")
+	s.ErrorContains(res.Err, "at '': additional properties 'other' not allowed")
+	_, err := s.Client.DescribeWorkflowExecution(s.Context, "registry-id2", "")
+	s.Error(err)
+
+	// Types not in the registry still need a task queue
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--type", "OtherWorkflow",
+	)
+	s.ErrorContains(res.Err, `required flag(s) "task-queue" not set`)
+}
+
+func (s *SharedServerSuite) TestWorkflow_Start_RequiredLabels() {
+	s.CommandHarness.Options.EnvConfigFile = filepath.Join(s.T().TempDir(), "env.yaml")
+	res := s.Execute("env", "set", "-k", "required-search-attributes", "-v", "CustomKeywordField")
+	s.NoError(res.Err)
+	res = s.Execute("env", "set", "-k", "required-memo-keys", "-v", "owner, service")
+	s.NoError(res.Err)
+
+	// Missing labels fail without starting the workflow
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", "labels-id1",
+		"--memo", `owner="team-a"`,
+	)
+	s.EqualError(res.Err, `environment "default" requires workflows to have search attributes CustomKeywordField `+
+		`(set with --search-attribute KEY=VALUE) and memo keys service (set with --memo KEY=VALUE)`)
+	_, err := s.Client.DescribeWorkflowExecution(s.Context, "labels-id1", "")
+	s.Error(err)
+
+	// Schedules are checked too
+	res = s.Execute(
+		"schedule", "create",
+		"--address", s.Address(),
+		"--schedule-id", "labels-schedule",
+		"--interval", "1h",
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--memo", `owner="team-a"`,
+		"--memo", `service="service-a"`,
+	)
+	s.EqualError(res.Err, `environment "default" requires workflows to have search attributes CustomKeywordField `+
+		`(set with --search-attribute KEY=VALUE)`)
+
+	// All labels present
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", "labels-id2",
+		"--search-attribute", `CustomKeywordField="team-a"`,
+		"--memo", `owner="team-a"`,
+		"--memo", `service="service-a"`,
+	)
+	s.NoError(res.Err)
+}
+
+func (s *SharedServerSuite) TestWorkflow_Execute_DetachAfter() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, input any) (any, error) {
+		workflow.GetSignalChannel(ctx, "done").Receive(ctx, nil)
+		return nil, nil
+	})
+
+	// Text
+	res := s.Execute(
+		"workflow", "execute",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", "detach-id1",
+		"--detach-after", "1s",
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "1", "WorkflowExecutionStarted")
+	s.Contains(out, "Detached after 1s")
+	s.Contains(out, "temporal workflow attach --workflow-id detach-id1")
+
+	// JSON
+	res = s.Execute(
+		"workflow", "execute",
+		"-o", "json",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", "detach-id2",
+		"--detach-after", "1s",
+	)
+	s.NoError(res.Err)
+	var jsonOut map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal("detach-id2", jsonOut["workflowId"])
+	s.Equal(true, jsonOut["detached"])
+
+	for _, id := range []string{"detach-id1", "detach-id2"} {
+		s.NoError(s.Client.SignalWorkflow(s.Context, id, "", "done", nil))
+	}
+}
+
+func (s *SharedServerSuite) TestWorkflow_Attach() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, input any) (any, error) {
+		var fail bool
+		workflow.GetSignalChannel(ctx, "done").Receive(ctx, &fail)
+		if fail {
+			return nil, fmt.Errorf("intentional failure")
+		}
+		return "done", nil
+	})
+	start := func() client.WorkflowRun {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			"ignored",
+		)
+		s.NoError(err)
+		return run
+	}
+
+	// Text, completes when the workflow does
+	run := start()
+	go func() {
+		time.Sleep(time.Second)
+		s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "done", false))
+	}()
+	res := s.Execute("workflow", "attach", "--address", s.Address(), "-w", run.GetID())
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.Contains(out, "Attached to workflow "+run.GetID())
+	s.ContainsOnSameLine(out, "1", "WorkflowExecutionStarted")
+	s.ContainsOnSameLine(out, "WorkflowExecutionSignaled")
+	s.ContainsOnSameLine(out, "Status", "COMPLETED")
+	s.ContainsOnSameLine(out, "Result", `"done"`)
+
+	// JSON, fails when the workflow does
+	run = start()
+	go func() {
+		time.Sleep(time.Second)
+		s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "done", true))
+	}()
+	res = s.Execute("workflow", "attach", "-o", "json", "--address", s.Address(), "-w", run.GetID())
+	s.ErrorContains(res.Err, "workflow failed")
+	var jsonOut map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal(run.GetRunID(), jsonOut["runId"])
+	s.Equal("DevWorkflow", jsonOut["type"])
+	s.Equal("FAILED", jsonOut["status"])
+}
+
 func (s *SharedServerSuite) TestWorkflow_Execute_SimpleSuccess() {
 	// Text
 	s.Worker().OnDevWorkflow(func(ctx workflow.Context, input any) (any, error) {
@@ -184,6 +447,81 @@ func (s *SharedServerSuite) TestWorkflow_Execute_SimpleFailure() {
 		jsonPath(jsonOut, "closeEvent", "workflowExecutionFailedEventAttributes", "failure", "message"))
 }
 
+func (s *SharedServerSuite) TestWorkflow_Execute_TestReport() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, input any) (any, error) {
+		if input == "fail" {
+			return nil, fmt.Errorf("intentional failure")
+		}
+		return "done", nil
+	})
+	execute := func(output, workflowID, input string) *CommandResult {
+		return s.Execute(
+			"workflow", "execute",
+			"-o", output,
+			"--address", s.Address(),
+			"--task-queue", s.Worker().Options.TaskQueue,
+			"--type", "DevWorkflow",
+			"--workflow-id", workflowID,
+			"--input", `"`+input+`"`,
+		)
+	}
+
+	// JUnit success
+	res := execute("junit", "report-id1", "succeed")
+	s.NoError(res.Err)
+	var suites struct {
+		Suites []struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Cases    []struct {
+				Name      string `xml:"name,attr"`
+				Classname string `xml:"classname,attr"`
+				Time      string `xml:"time,attr"`
+				Failure   *struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+				} `xml:"failure"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	s.NoError(xml.Unmarshal(res.Stdout.Bytes(), &suites))
+	s.Len(suites.Suites, 1)
+	s.Equal("default", suites.Suites[0].Name)
+	s.Equal(1, suites.Suites[0].Tests)
+	s.Equal(0, suites.Suites[0].Failures)
+	s.Len(suites.Suites[0].Cases, 1)
+	s.Equal("DevWorkflow report-id1", suites.Suites[0].Cases[0].Name)
+	s.Equal(s.Worker().Options.TaskQueue, suites.Suites[0].Cases[0].Classname)
+	s.NotEmpty(suites.Suites[0].Cases[0].Time)
+	s.Nil(suites.Suites[0].Cases[0].Failure)
+	s.Equal(`"done"`, suites.Suites[0].Cases[0].SystemOut)
+
+	// JUnit failure
+	res = execute("junit", "report-id2", "fail")
+	s.ErrorContains(res.Err, "workflow failed")
+	suites.Suites = nil
+	s.NoError(xml.Unmarshal(res.Stdout.Bytes(), &suites))
+	s.Equal(1, suites.Suites[0].Failures)
+	s.Equal("intentional failure", suites.Suites[0].Cases[0].Failure.Message)
+	s.Equal("FAILED", suites.Suites[0].Cases[0].Failure.Type)
+
+	// TAP success and failure
+	res = execute("tap", "report-id3", "succeed")
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "TAP version 13\n1..1\nok 1 - DevWorkflow report-id3\n")
+	s.Contains(res.Stdout.String(), "  status: COMPLETED\n")
+	res = execute("tap", "report-id4", "fail")
+	s.ErrorContains(res.Err, "workflow failed")
+	s.Contains(res.Stdout.String(), "not ok 1 - DevWorkflow report-id4\n")
+	s.Contains(res.Stdout.String(), "  message: intentional failure\n")
+
+	// Not supported by other commands
+	res = s.Execute("workflow", "describe", "-o", "junit", "--address", s.Address(), "-w", "report-id1")
+	s.ErrorContains(res.Err, "junit output is only supported by workflow attach and workflow execute")
+}
+
 func (s *SharedServerSuite) TestWorkflow_Execute_NestedFailure() {
 	// Text
 	s.Worker().OnDevWorkflow(func(ctx workflow.Context, input any) (any, error) {
@@ -432,6 +770,38 @@ func (s *SharedServerSuite) TestWorkflow_Execute_ClientHeaders() {
 	s.Equal("temporal-cli", lastHeadersClient["client-name"][0])
 }
 
+func (s *SharedServerSuite) TestWorkflow_Execute_RoutingHeaders() {
+	var lastHeaders metadata.MD
+	var lastHeadersLock sync.Mutex
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+		) error {
+			lastHeadersLock.Lock()
+			lastHeaders, _ = metadata.FromOutgoingContext(ctx)
+			lastHeadersLock.Unlock()
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+
+	res := s.Execute(
+		"workflow", "execute",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--target-cluster", "my-cluster",
+		"--routing-key", "my-key",
+	)
+	s.NoError(res.Err)
+	lastHeadersLock.Lock()
+	defer lastHeadersLock.Unlock()
+	s.Equal([]string{"my-cluster"}, lastHeaders["temporal-target-cluster"])
+	s.Equal([]string{"my-key"}, lastHeaders["temporal-routing-key"])
+}
+
 func (s *SharedServerSuite) TestWorkflow_Execute_EnvVars() {
 	s.CommandHarness.Options.LookupEnv = func(key string) (string, bool) {
 		if key == "TEMPORAL_ADDRESS" {
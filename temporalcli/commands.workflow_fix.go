@@ -2,26 +2,27 @@ package temporalcli
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 
+	"go.temporal.io/api/history/v1"
 	"go.temporal.io/sdk/client"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func (c *TemporalWorkflowFixHistoryJsonCommand) run(cctx *CommandContext, args []string) error {
-	raw, err := os.ReadFile(c.Source)
+	raw, err := readFileDecompressed(c.Source)
 	if err != nil {
 		return err
 	}
 
-	hjo := client.HistoryJSONOptions{}
-	history, err := client.HistoryFromJSON(bytes.NewReader(raw), hjo)
+	hist, err := historyFromJSON(raw)
 	if err != nil {
 		return err
 	}
 
 	mo := protojson.MarshalOptions{Indent: "  "}
-	raw, err = mo.Marshal(history)
+	raw, err = mo.Marshal(hist)
 	if err != nil {
 		return err
 	}
@@ -35,3 +36,22 @@ func (c *TemporalWorkflowFixHistoryJsonCommand) run(cctx *CommandContext, args [
 		return os.WriteFile(c.Target, raw, 0o666)
 	}
 }
+
+// Parses history JSON as written by workflow show, either a history object or
+// JSON Lines with one event per line from --output jsonl.
+func historyFromJSON(raw []byte) (*history.History, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if firstLine, _, _ := bytes.Cut(trimmed, []byte("\n")); len(firstLine) > 0 {
+		var first map[string]json.RawMessage
+		if json.Unmarshal(firstLine, &first) == nil && first["eventId"] != nil {
+			var events [][]byte
+			for _, line := range bytes.Split(trimmed, []byte("\n")) {
+				if line = bytes.TrimSpace(line); len(line) > 0 {
+					events = append(events, line)
+				}
+			}
+			raw = append(append([]byte(`{"events":[`), bytes.Join(events, []byte(","))...), "]}"...)
+		}
+	}
+	return client.HistoryFromJSON(bytes.NewReader(raw), client.HistoryJSONOptions{})
+}
@@ -0,0 +1,229 @@
+package temporalcli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+func (c *TemporalWorkflowRedactCommand) run(cctx *CommandContext, args []string) error {
+	raw, err := readFileDecompressed(c.HistoryFile)
+	if err != nil {
+		return err
+	}
+	hist, err := historyFromJSON(raw)
+	if err != nil {
+		return fmt.Errorf("failed reading history: %w", err)
+	}
+	r, err := newHistoryRedactor(c.Rules)
+	if err != nil {
+		return err
+	}
+	r.redactHistory(hist)
+
+	raw, err = protojson.MarshalOptions{Indent: "  "}.Marshal(hist)
+	if err != nil {
+		return err
+	}
+	switch c.Target {
+	case "", "-":
+		_, err = cctx.Options.Stdout.Write(raw)
+		return err
+	default:
+		if err := os.WriteFile(c.Target, raw, 0o666); err != nil {
+			return err
+		}
+		cctx.Printer.Printlnf("Redacted %v payload(s) into %v", r.redacted, c.Target)
+		return nil
+	}
+}
+
+type redactRules struct {
+	Rules []*redactRule `yaml:"rules"`
+}
+
+type redactRule struct {
+	ActivityTypes    []string `yaml:"activityTypes"`
+	SearchAttributes []string `yaml:"searchAttributes"`
+	Headers          []string `yaml:"headers"`
+	Pattern          string   `yaml:"pattern"`
+	Action           string   `yaml:"action"`
+
+	pattern *regexp.Regexp
+}
+
+// Where a payload was found in the history.
+type redactPayloadLocation struct {
+	activityType    string
+	searchAttribute string
+	header          string
+}
+
+func (r *redactRule) matches(p *common.Payload, loc redactPayloadLocation) bool {
+	if len(r.ActivityTypes) > 0 && !slices.Contains(r.ActivityTypes, loc.activityType) {
+		return false
+	}
+	if len(r.SearchAttributes) > 0 && !slices.Contains(r.SearchAttributes, loc.searchAttribute) {
+		return false
+	}
+	if len(r.Headers) > 0 && !slices.Contains(r.Headers, loc.header) {
+		return false
+	}
+	if r.pattern != nil && !r.pattern.Match(p.Data) {
+		return false
+	}
+	return true
+}
+
+type historyRedactor struct {
+	rules []*redactRule
+	// Activity type by scheduled event ID
+	activityTypes map[int64]string
+	redacted      int
+}
+
+func newHistoryRedactor(rulesFile string) (*historyRedactor, error) {
+	b, err := os.ReadFile(rulesFile)
+	if err != nil {
+		return nil, err
+	}
+	var rules redactRules
+	if err := yaml.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("failed reading rules: %w", err)
+	}
+	for i, rule := range rules.Rules {
+		switch rule.Action {
+		case "":
+			rule.Action = "mask"
+		case "mask", "remove":
+		default:
+			return nil, fmt.Errorf("rule %v has invalid action %q", i+1, rule.Action)
+		}
+		if len(rule.ActivityTypes) == 0 && len(rule.SearchAttributes) == 0 &&
+			len(rule.Headers) == 0 && rule.Pattern == "" {
+			return nil, fmt.Errorf("rule %v has no conditions", i+1)
+		}
+		if rule.Pattern != "" {
+			if rule.pattern, err = regexp.Compile(rule.Pattern); err != nil {
+				return nil, fmt.Errorf("rule %v has invalid pattern: %w", i+1, err)
+			}
+		}
+	}
+	return &historyRedactor{rules: rules.Rules, activityTypes: map[int64]string{}}, nil
+}
+
+func (r *historyRedactor) redactHistory(hist *history.History) {
+	for _, event := range hist.Events {
+		if event.EventType == enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED {
+			r.activityTypes[event.EventId] = event.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()
+		}
+		var loc redactPayloadLocation
+		if scheduledID, ok := activityScheduledEventID(event); ok {
+			loc.activityType = r.activityTypes[scheduledID]
+		}
+		r.redactMessage(event.ProtoReflect(), loc)
+	}
+}
+
+// Returns true if the payload should be removed. Masked payloads are updated
+// in place.
+func (r *historyRedactor) redactPayload(p *common.Payload, loc redactPayloadLocation) (remove bool) {
+	for _, rule := range r.rules {
+		if !rule.matches(p, loc) {
+			continue
+		}
+		r.redacted++
+		if rule.Action == "remove" {
+			return true
+		}
+		p.Metadata = map[string][]byte{"encoding": []byte("json/plain")}
+		p.Data = []byte(`"REDACTED"`)
+		return false
+	}
+	return false
+}
+
+var (
+	payloadDescriptorName          = (&common.Payload{}).ProtoReflect().Descriptor().FullName()
+	searchAttributesDescriptorName = (&common.SearchAttributes{}).ProtoReflect().Descriptor().FullName()
+	headerDescriptorName           = (&common.Header{}).ProtoReflect().Descriptor().FullName()
+)
+
+func isPayloadDescriptor(d protoreflect.MessageDescriptor) bool {
+	return d != nil && d.FullName() == payloadDescriptorName
+}
+
+func (r *historyRedactor) redactMessage(m protoreflect.Message, loc redactPayloadLocation) {
+	// Fields are cleared after ranging since mutating during range is undefined
+	var clearFields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			var removeKeys []protoreflect.MapKey
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				if !isPayloadDescriptor(fd.MapValue().Message()) {
+					r.redactMessage(v.Message(), loc)
+					return true
+				}
+				entryLoc := loc
+				switch m.Descriptor().FullName() {
+				case searchAttributesDescriptorName:
+					entryLoc.searchAttribute = k.String()
+				case headerDescriptorName:
+					entryLoc.header = k.String()
+				}
+				if r.redactPayload(v.Message().Interface().(*common.Payload), entryLoc) {
+					removeKeys = append(removeKeys, k)
+				}
+				return true
+			})
+			for _, k := range removeKeys {
+				v.Map().Clear(k)
+			}
+		case fd.IsList():
+			if fd.Message() == nil {
+				return true
+			}
+			list := v.List()
+			if !isPayloadDescriptor(fd.Message()) {
+				for i := 0; i < list.Len(); i++ {
+					r.redactMessage(list.Get(i).Message(), loc)
+				}
+				return true
+			}
+			var keep []protoreflect.Value
+			for i := 0; i < list.Len(); i++ {
+				if !r.redactPayload(list.Get(i).Message().Interface().(*common.Payload), loc) {
+					keep = append(keep, list.Get(i))
+				}
+			}
+			if len(keep) < list.Len() {
+				list.Truncate(0)
+				for _, v := range keep {
+					list.Append(v)
+				}
+			}
+		case fd.Message() != nil:
+			if !isPayloadDescriptor(fd.Message()) {
+				r.redactMessage(v.Message(), loc)
+			} else if r.redactPayload(v.Message().Interface().(*common.Payload), loc) {
+				clearFields = append(clearFields, fd)
+			}
+		}
+		return true
+	})
+	for _, fd := range clearFields {
+		m.Clear(fd)
+	}
+}
@@ -0,0 +1,110 @@
+package temporalcli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestWorkflow_Redact(t *testing.T) {
+	h := NewCommandHarness(t)
+	defer h.Close()
+	dir := t.TempDir()
+
+	payload := func(v any) *common.Payload {
+		p, err := converter.GetDefaultDataConverter().ToPayload(v)
+		h.NoError(err)
+		return p
+	}
+	hist := &history.History{Events: []*history.HistoryEvent{
+		{
+			EventId:   1,
+			EventType: enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+			Attributes: &history.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+				WorkflowExecutionStartedEventAttributes: &history.WorkflowExecutionStartedEventAttributes{
+					Input: &common.Payloads{Payloads: []*common.Payload{payload("ssn 123-45-6789"), payload("ok")}},
+					SearchAttributes: &common.SearchAttributes{IndexedFields: map[string]*common.Payload{
+						"CustomerEmail": payload("me@example.com"),
+						"CustomerTier":  payload("gold"),
+					}},
+					Header: &common.Header{Fields: map[string]*common.Payload{"auth-token": payload("secret")}},
+				},
+			},
+		},
+		{
+			EventId:   2,
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+			Attributes: &history.HistoryEvent_ActivityTaskScheduledEventAttributes{
+				ActivityTaskScheduledEventAttributes: &history.ActivityTaskScheduledEventAttributes{
+					ActivityType: &common.ActivityType{Name: "ChargeCard"},
+					Input:        &common.Payloads{Payloads: []*common.Payload{payload("card")}},
+				},
+			},
+		},
+		{
+			EventId:   3,
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED,
+			Attributes: &history.HistoryEvent_ActivityTaskCompletedEventAttributes{
+				ActivityTaskCompletedEventAttributes: &history.ActivityTaskCompletedEventAttributes{
+					ScheduledEventId: 2,
+					Result:           &common.Payloads{Payloads: []*common.Payload{payload("receipt")}},
+				},
+			},
+		},
+	}}
+	b, err := protojson.Marshal(hist)
+	h.NoError(err)
+	historyFile := filepath.Join(dir, "history.json")
+	h.NoError(os.WriteFile(historyFile, b, 0o644))
+	rulesFile := filepath.Join(dir, "rules.yaml")
+	h.NoError(os.WriteFile(rulesFile, []byte(`
+rules:
+  - activityTypes: [ChargeCard]
+    action: remove
+  - searchAttributes: [CustomerEmail]
+  - headers: [auth-token]
+    action: remove
+  - pattern: '\d{3}-\d{2}-\d{4}'
+`), 0o644))
+
+	// To stdout
+	res := h.Execute("workflow", "redact", "--history-file", historyFile, "--rules", rulesFile)
+	h.NoError(res.Err)
+	var redacted history.History
+	h.NoError(protojson.Unmarshal(res.Stdout.Bytes(), &redacted))
+
+	decode := func(p *common.Payload) (s string) {
+		h.NoError(converter.GetDefaultDataConverter().FromPayload(p, &s))
+		return
+	}
+	started := redacted.Events[0].GetWorkflowExecutionStartedEventAttributes()
+	h.Len(started.Input.Payloads, 2)
+	h.Equal("REDACTED", decode(started.Input.Payloads[0]))
+	h.Equal("ok", decode(started.Input.Payloads[1]))
+	h.Equal("REDACTED", decode(started.SearchAttributes.IndexedFields["CustomerEmail"]))
+	h.Equal("gold", decode(started.SearchAttributes.IndexedFields["CustomerTier"]))
+	h.Empty(started.Header.Fields)
+	h.Empty(redacted.Events[1].GetActivityTaskScheduledEventAttributes().Input.Payloads)
+	h.Empty(redacted.Events[2].GetActivityTaskCompletedEventAttributes().Result.Payloads)
+	h.Equal("ChargeCard", redacted.Events[1].GetActivityTaskScheduledEventAttributes().ActivityType.Name)
+
+	// To file
+	outFile := filepath.Join(dir, "out.json")
+	res = h.Execute("workflow", "redact", "--history-file", historyFile, "--rules", rulesFile, "-t", outFile)
+	h.NoError(res.Err)
+	h.Contains(res.Stdout.String(), "Redacted 5 payload(s)")
+	b, err = os.ReadFile(outFile)
+	h.NoError(err)
+	h.NotContains(string(b), "InNlY3JldCI") // base64 of JSON "secret"
+
+	// Bad rules
+	h.NoError(os.WriteFile(rulesFile, []byte("rules:\n  - action: mask\n"), 0o644))
+	res = h.Execute("workflow", "redact", "--history-file", historyFile, "--rules", rulesFile)
+	h.ErrorContains(res.Err, "rule 1 has no conditions")
+}
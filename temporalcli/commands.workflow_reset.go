@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/google/uuid"
 	"go.temporal.io/api/batch/v1"
 	"go.temporal.io/api/common/v1"
 	"go.temporal.io/api/enums/v1"
@@ -41,16 +40,36 @@ func (c *TemporalWorkflowResetCommand) getResetOperations() (validate func() err
 }
 
 func (c *TemporalWorkflowResetCommand) validateWorkflowResetArguments() error {
-	if c.Type.Value == "" && c.EventId <= 0 {
-		return errors.New("must specify either valid event id or reset type")
+	targets := 0
+	for _, set := range []bool{c.Type.Value != "", c.EventId > 0, c.ToUpdate != "", c.ToBuildId != ""} {
+		if set {
+			targets++
+		}
+	}
+	if targets == 0 {
+		return errors.New("must specify either valid event id, reset type, update id, or build id")
+	} else if targets > 1 {
+		return errors.New("must specify only one of event id, reset type, update id, or build id")
 	}
 	if c.WorkflowId == "" {
 		return errors.New("must specify workflow id")
 	}
+	if c.SamplePercent != 0 || c.MaxCount != 0 {
+		return errors.New("must not specify sample percent or max count")
+	}
 	return nil
 }
 
 func (c *TemporalWorkflowResetCommand) validateBatchResetArguments() error {
+	if c.ToUpdate != "" {
+		return errors.New("must not specify update Id for batch reset")
+	}
+	if c.ToBuildId != "" {
+		if c.Type.Value != "" {
+			return errors.New("must not specify reset type with build Id")
+		}
+		return nil
+	}
 	if c.Type.Value == "" {
 		return errors.New("must specify reset type")
 	}
@@ -70,7 +89,7 @@ func (c *TemporalWorkflowResetCommand) doWorkflowReset(cctx *CommandContext, cl
 	var err error
 	resetBaseRunID := c.RunId
 	eventID := int64(c.EventId)
-	if c.Type.Value != "" {
+	if c.Type.Value != "" || c.ToUpdate != "" || c.ToBuildId != "" {
 		resetBaseRunID, eventID, err = c.getResetEventIDByType(cctx, cl)
 		if err != nil {
 			return fmt.Errorf("getting reset event ID by type failed: %w", err)
@@ -132,32 +151,30 @@ func (c *TemporalWorkflowResetCommand) doWorkflowReset(cctx *CommandContext, cl
 }
 
 func (c *TemporalWorkflowResetCommand) runBatchReset(cctx *CommandContext, cl client.Client) error {
-	request := workflowservice.StartBatchOperationRequest{
-		Namespace:       c.Parent.Namespace,
-		JobId:           uuid.NewString(),
-		VisibilityQuery: c.Query,
-		Reason:          c.Reason,
+	// We create a faux SingleWorkflowOrBatchOptions to use the shared prompt
+	// and sampling
+	opts := SingleWorkflowOrBatchOptions{
+		Query:         c.Query,
+		Reason:        c.Reason,
+		Yes:           c.Yes,
+		SamplePercent: c.SamplePercent,
+		MaxCount:      c.MaxCount,
+	}
+	_, request, err := opts.workflowExecOrBatch(cctx, c.Parent.Namespace, cl, singleOrBatchOverrides{})
+	if err != nil {
+		return err
+	}
+	resetType := c.Type.Value
+	if c.ToBuildId != "" {
+		resetType = "BuildId"
 	}
 	request.Operation = &workflowservice.StartBatchOperationRequest_ResetOperation{
 		ResetOperation: &batch.BatchOperationReset{
 			Identity: clientIdentity(),
-			Options:  c.batchResetOptions(c.Type.Value),
+			Options:  c.batchResetOptions(resetType),
 		},
 	}
-	count, err := cl.CountWorkflow(cctx, &workflowservice.CountWorkflowExecutionsRequest{Query: c.Query})
-	if err != nil {
-		return fmt.Errorf("failed counting workflows from query: %w", err)
-	}
-	yes, err := cctx.promptYes(
-		fmt.Sprintf("Start batch against approximately %v workflow(s)? y/N", count.Count), c.Yes)
-	if err != nil {
-		return err
-	}
-	if !yes {
-		return fmt.Errorf("user denied confirmation")
-	}
-
-	return startBatchJob(cctx, cl, &request)
+	return startBatchJob(cctx, cl, request)
 }
 
 func (c *TemporalWorkflowResetCommand) batchResetOptions(resetType string) *common.ResetOptions {
@@ -171,9 +188,13 @@ func (c *TemporalWorkflowResetCommand) batchResetOptions(resetType string) *comm
 			Target: &common.ResetOptions_LastWorkflowTask{},
 		}
 	case "BuildId":
+		buildID := c.BuildId
+		if c.ToBuildId != "" {
+			buildID = c.ToBuildId
+		}
 		return &common.ResetOptions{
 			Target: &common.ResetOptions_BuildId{
-				BuildId: c.BuildId,
+				BuildId: buildID,
 			},
 		}
 	default:
@@ -184,6 +205,11 @@ func (c *TemporalWorkflowResetCommand) batchResetOptions(resetType string) *comm
 func (c *TemporalWorkflowResetCommand) getResetEventIDByType(ctx context.Context, cl client.Client) (string, int64, error) {
 	resetType, namespace, wid, rid := c.Type.Value, c.Parent.Namespace, c.WorkflowId, c.RunId
 	wfsvc := cl.WorkflowService()
+	if c.ToUpdate != "" {
+		return getUpdateWorkflowTaskEventID(ctx, namespace, wid, rid, c.ToUpdate, wfsvc)
+	} else if c.ToBuildId != "" {
+		return getBuildIDWorkflowTaskEventID(ctx, namespace, wid, rid, c.ToBuildId, wfsvc)
+	}
 	switch resetType {
 	case "LastWorkflowTask":
 		return getLastWorkflowTaskEventID(ctx, namespace, wid, rid, wfsvc)
@@ -267,6 +293,75 @@ func getFirstWorkflowTaskEventID(ctx context.Context, namespace, wid, rid string
 	return
 }
 
+// Returns id of the last workflow task completed event before the update was
+// admitted or accepted.
+func getUpdateWorkflowTaskEventID(ctx context.Context, namespace, wid, rid, updateID string, wfsvc workflowservice.WorkflowServiceClient) (resetBaseRunID string, workflowTaskEventID int64, err error) {
+	resetBaseRunID = rid
+	req := workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace: namespace,
+		Execution: &common.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		MaximumPageSize: 250,
+		NextPageToken:   nil,
+	}
+	for more := true; more; more = len(req.NextPageToken) != 0 {
+		resp, err := wfsvc.GetWorkflowExecutionHistory(ctx, &req)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to get workflow execution history: %w", err)
+		}
+		for _, e := range resp.GetHistory().GetEvents() {
+			var eventUpdateID string
+			switch e.GetEventType() {
+			case enums.EVENT_TYPE_WORKFLOW_TASK_COMPLETED:
+				workflowTaskEventID = e.GetEventId()
+			case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ADMITTED:
+				eventUpdateID = e.GetWorkflowExecutionUpdateAdmittedEventAttributes().GetRequest().GetMeta().GetUpdateId()
+			case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+				eventUpdateID = e.GetWorkflowExecutionUpdateAcceptedEventAttributes().GetProtocolInstanceId()
+			}
+			if eventUpdateID != updateID {
+				continue
+			} else if workflowTaskEventID == 0 {
+				return "", 0, fmt.Errorf("no completed workflow task before update %v", updateID)
+			}
+			return resetBaseRunID, workflowTaskEventID, nil
+		}
+		req.NextPageToken = resp.NextPageToken
+	}
+	return "", 0, fmt.Errorf("unable to find update %v in workflow history", updateID)
+}
+
+// Returns id of the first workflow task completed event processed by the build
+// id.
+func getBuildIDWorkflowTaskEventID(ctx context.Context, namespace, wid, rid, buildID string, wfsvc workflowservice.WorkflowServiceClient) (resetBaseRunID string, workflowTaskEventID int64, err error) {
+	resetBaseRunID = rid
+	req := workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace: namespace,
+		Execution: &common.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		MaximumPageSize: 250,
+		NextPageToken:   nil,
+	}
+	for more := true; more; more = len(req.NextPageToken) != 0 {
+		resp, err := wfsvc.GetWorkflowExecutionHistory(ctx, &req)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to get workflow execution history: %w", err)
+		}
+		for _, e := range resp.GetHistory().GetEvents() {
+			attrs := e.GetWorkflowTaskCompletedEventAttributes()
+			if attrs != nil && (attrs.GetWorkerVersion().GetBuildId() == buildID || attrs.GetBinaryChecksum() == buildID) {
+				return resetBaseRunID, e.GetEventId(), nil
+			}
+		}
+		req.NextPageToken = resp.NextPageToken
+	}
+	return "", 0, fmt.Errorf("unable to find workflow task processed by build id %v", buildID)
+}
+
 func getLastContinueAsNewID(ctx context.Context, namespace, wid, rid string, wfsvc workflowservice.WorkflowServiceClient) (resetBaseRunID string, workflowTaskCompletedID int64, err error) {
 	// get first event
 	req := &workflowservice.GetWorkflowExecutionHistoryRequest{
@@ -315,6 +315,85 @@ func (s *SharedServerSuite) TestWorkflow_Reset_DoesNotAllowBothApplyKinds() {
 	s.Contains(res.Err.Error(), "cannot specify --reapply-type and --reapply-exclude")
 }
 
+func (s *SharedServerSuite) TestWorkflow_Reset_ToUpdateAndBuildId() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		err := workflow.SetUpdateHandler(ctx, "add", func(ctx workflow.Context, i int) (int, error) {
+			return i, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		workflow.GetSignalChannel(ctx, "done").Receive(ctx, nil)
+		return nil, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	for _, updateID := range []string{"update-1", "update-2"} {
+		res := s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(),
+			"--name", "add", "--update-id", updateID, "-i", "1")
+		s.NoError(res.Err)
+	}
+
+	// Find the expected reset points from history
+	var lastTaskCompleted, beforeUpdate2, firstTaskCompleted int64
+	var buildID string
+	iter := s.Client.GetWorkflowHistory(s.Context, run.GetID(), run.GetRunID(), false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		s.NoError(err)
+		switch event.EventType {
+		case enums.EVENT_TYPE_WORKFLOW_TASK_COMPLETED:
+			lastTaskCompleted = event.EventId
+			if firstTaskCompleted == 0 {
+				firstTaskCompleted = event.EventId
+				attrs := event.GetWorkflowTaskCompletedEventAttributes()
+				buildID = attrs.GetWorkerVersion().GetBuildId()
+				if buildID == "" {
+					buildID = attrs.GetBinaryChecksum()
+				}
+			}
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+			if event.GetWorkflowExecutionUpdateAcceptedEventAttributes().ProtocolInstanceId == "update-2" {
+				beforeUpdate2 = lastTaskCompleted
+			}
+		}
+	}
+	s.NotZero(beforeUpdate2)
+	s.NotEmpty(buildID)
+
+	// Conflicting targets
+	res := s.Execute("workflow", "reset", "--address", s.Address(), "-w", run.GetID(),
+		"--to-update", "update-2", "-t", "LastWorkflowTask", "--reason", "test")
+	s.ErrorContains(res.Err, "must specify only one of")
+
+	// Unknown update
+	res = s.Execute("workflow", "reset", "--address", s.Address(), "-w", run.GetID(),
+		"--to-update", "update-3", "--reason", "test")
+	s.ErrorContains(res.Err, "unable to find update update-3")
+
+	// Update not allowed for batch
+	res = s.Execute("workflow", "reset", "--address", s.Address(), "-q", "WorkflowId = 'foo'",
+		"--to-update", "update-2", "--reason", "test", "-y")
+	s.ErrorContains(res.Err, "must not specify update Id for batch reset")
+
+	// Reset to before update
+	res = s.Execute("workflow", "reset", "--address", s.Address(), "-w", run.GetID(), "-r", run.GetRunID(),
+		"--to-update", "update-2", "--reason", "test")
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), fmt.Sprintf("to event ID %d", beforeUpdate2))
+
+	// Reset to first task processed by build ID
+	res = s.Execute("workflow", "reset", "--address", s.Address(), "-w", run.GetID(), "-r", run.GetRunID(),
+		"--to-build-id", buildID, "--reason", "test")
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), fmt.Sprintf("to event ID %d", firstTaskCompleted))
+}
+
 const (
 	badActivity = iota
 	firstActivity
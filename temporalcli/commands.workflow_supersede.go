@@ -0,0 +1,111 @@
+package temporalcli
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/google/uuid"
+	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+func (c *TemporalWorkflowSupersedeCommand) run(cctx *CommandContext, args []string) error {
+	if c.Reason != "" && !c.Cancel {
+		return fmt.Errorf("cannot use --reason without --cancel")
+	}
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	desc, err := cl.DescribeWorkflowExecution(cctx, c.WorkflowId, c.RunId)
+	if err != nil {
+		return fmt.Errorf("failed describing workflow: %w", err)
+	}
+	exec := desc.WorkflowExecutionInfo.Execution
+	if status := desc.WorkflowExecutionInfo.Status; status != enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		return fmt.Errorf("workflow run %v is not running, status is %v", exec.RunId, status)
+	}
+
+	// Everything about the new run comes from the start event of the old one
+	iter := cl.GetWorkflowHistory(cctx, exec.WorkflowId, exec.RunId, false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	if !iter.HasNext() {
+		return fmt.Errorf("workflow run %v has no history", exec.RunId)
+	}
+	event, err := iter.Next()
+	if err != nil {
+		return fmt.Errorf("failed getting history: %w", err)
+	}
+	started := event.GetWorkflowExecutionStartedEventAttributes()
+	if started == nil {
+		return fmt.Errorf("first event of workflow run %v is %v, not workflow started", exec.RunId, event.EventType)
+	}
+	input := started.Input
+	if len(c.Input) > 0 || len(c.InputFile) > 0 {
+		if err := cctx.validateWorkflowInput(started.WorkflowType.GetName(), &c.PayloadInputOptions); err != nil {
+			return err
+		}
+		if input, err = c.buildRawInputPayloads(); err != nil {
+			return err
+		}
+	}
+
+	// Terminating happens as part of the start so there is no gap between runs,
+	// but canceling has to wait for the workflow to finish its cleanup
+	conflictPolicy := enums.WORKFLOW_ID_CONFLICT_POLICY_TERMINATE_EXISTING
+	if c.Cancel {
+		reason := c.Reason
+		if reason == "" {
+			reason = defaultReason()
+		}
+		status, err := cancelWorkflowAndWait(cctx, cl, c.Parent.Namespace, exec, reason, c.WaitTimeout.Duration())
+		if err != nil {
+			return err
+		}
+		cctx.Printer.Printlnf("Workflow closed with status %v", status)
+		conflictPolicy = enums.WORKFLOW_ID_CONFLICT_POLICY_FAIL
+	}
+	resp, err := cl.WorkflowService().StartWorkflowExecution(cctx, &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:                c.Parent.Namespace,
+		WorkflowId:               exec.WorkflowId,
+		WorkflowType:             started.WorkflowType,
+		TaskQueue:                started.TaskQueue,
+		Input:                    input,
+		WorkflowExecutionTimeout: started.WorkflowExecutionTimeout,
+		WorkflowRunTimeout:       started.WorkflowRunTimeout,
+		WorkflowTaskTimeout:      started.WorkflowTaskTimeout,
+		Identity:                 clientIdentity(),
+		RequestId:                uuid.NewString(),
+		// The superseded run has closed by now when canceling, so it must not
+		// prevent the ID from being reused
+		WorkflowIdReusePolicy:    enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		WorkflowIdConflictPolicy: conflictPolicy,
+		RetryPolicy:              started.RetryPolicy,
+		CronSchedule:             started.CronSchedule,
+		Memo:                     started.Memo,
+		SearchAttributes:         started.SearchAttributes,
+		Header:                   started.Header,
+	})
+	if err != nil {
+		return fmt.Errorf("failed starting workflow: %w", err)
+	}
+
+	cctx.Printer.Println(color.MagentaString("Running execution:"))
+	return cctx.Printer.PrintStructured(struct {
+		WorkflowId    string `json:"workflowId"`
+		RunId         string `json:"runId"`
+		PreviousRunId string `json:"previousRunId"`
+		Type          string `json:"type"`
+		Namespace     string `json:"namespace"`
+		TaskQueue     string `json:"taskQueue"`
+	}{
+		WorkflowId:    exec.WorkflowId,
+		RunId:         resp.RunId,
+		PreviousRunId: exec.RunId,
+		Type:          started.WorkflowType.GetName(),
+		Namespace:     c.Parent.Namespace,
+		TaskQueue:     started.TaskQueue.GetName(),
+	}, printer.StructuredOptions{})
+}
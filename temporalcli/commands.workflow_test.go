@@ -1,10 +1,14 @@
 package temporalcli_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"slices"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +16,7 @@ import (
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/workflow"
+	"google.golang.org/grpc"
 )
 
 func (s *SharedServerSuite) TestWorkflow_Signal_SingleWorkflowSuccess() {
@@ -47,6 +52,52 @@ func (s *SharedServerSuite) TestWorkflow_Signal_SingleWorkflowSuccess() {
 	s.Equal(map[string]any{"foo": "bar"}, actual)
 }
 
+func (s *SharedServerSuite) TestWorkflow_Signal_DedupeKey() {
+	// Start without a worker so signals just accumulate in history
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: "no-worker-" + uuid.NewString()},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+
+	signal := func(dedupeKey string) {
+		res := s.Execute(
+			"workflow", "signal",
+			"--address", s.Address(),
+			"-w", run.GetID(),
+			"--name", "my-signal",
+			"--dedupe-key", dedupeKey,
+		)
+		s.NoError(res.Err)
+	}
+	signal("key1")
+	signal("key1")
+	signal("key2")
+
+	// Only one signal per key is recorded
+	var signaled int
+	iter := s.Client.GetWorkflowHistory(s.Context, run.GetID(), run.GetRunID(), false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		s.NoError(err)
+		if event.EventType == enums.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED {
+			signaled++
+		}
+	}
+	s.Equal(2, signaled)
+
+	res := s.Execute(
+		"workflow", "signal",
+		"--address", s.Address(),
+		"-q", "WorkflowId = 'foo'",
+		"--name", "my-signal",
+		"--dedupe-key", "key1",
+	)
+	s.ErrorContains(res.Err, "cannot use dedupe key when query is set")
+}
+
 func (s *SharedServerSuite) TestWorkflow_Signal_BatchWorkflowSuccess() {
 	res := s.testSignalBatchWorkflow(false)
 	s.Contains(res.Stdout.String(), "approximately 5 workflow(s)")
@@ -261,6 +312,182 @@ func (s *SharedServerSuite) TestWorkflow_Terminate_SingleWorkflowSuccess_WithRea
 	s.True(foundReason)
 }
 
+func (s *SharedServerSuite) TestWorkflow_Terminate_FollowChildren() {
+	// Parent starts one child that follows the parent and one abandoned child,
+	// all of which wait forever
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		if a == "parent" {
+			info := workflow.GetInfo(ctx)
+			followedCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID: info.WorkflowExecution.ID + "-followed",
+			})
+			followed := workflow.ExecuteChildWorkflow(followedCtx, DevWorkflow, "child")
+			abandonedCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID:        info.WorkflowExecution.ID + "-abandoned",
+				ParentClosePolicy: enums.PARENT_CLOSE_POLICY_ABANDON,
+			})
+			abandoned := workflow.ExecuteChildWorkflow(abandonedCtx, DevWorkflow, "child")
+			if err := followed.GetChildWorkflowExecution().Get(ctx, nil); err != nil {
+				return nil, err
+			} else if err := abandoned.GetChildWorkflowExecution().Get(ctx, nil); err != nil {
+				return nil, err
+			}
+		}
+		ctx.Done().Receive(ctx, nil)
+		return nil, ctx.Err()
+	})
+
+	// Start the workflow and wait for both children to be running
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"parent",
+	)
+	s.NoError(err)
+	s.Eventually(func() bool {
+		resp, err := s.Client.DescribeWorkflowExecution(s.Context, run.GetID(), "")
+		s.NoError(err)
+		started := 0
+		for _, child := range resp.PendingChildren {
+			if child.RunId != "" {
+				started++
+			}
+		}
+		return started == 2
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Send terminate
+	res := s.Execute(
+		"workflow", "terminate",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--follow-children",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), run.GetID()+"-followed")
+	s.NotContains(res.Stdout.String(), run.GetID()+"-abandoned")
+
+	// Confirm parent and followed child terminated, but abandoned child running
+	s.Contains(run.Get(s.Context, nil).Error(), "terminated")
+	resp, err := s.Client.DescribeWorkflowExecution(s.Context, run.GetID()+"-followed", "")
+	s.NoError(err)
+	s.Equal(enums.WORKFLOW_EXECUTION_STATUS_TERMINATED, resp.WorkflowExecutionInfo.Status)
+	resp, err = s.Client.DescribeWorkflowExecution(s.Context, run.GetID()+"-abandoned", "")
+	s.NoError(err)
+	s.Equal(enums.WORKFLOW_EXECUTION_STATUS_RUNNING, resp.WorkflowExecutionInfo.Status)
+	s.NoError(s.Client.TerminateWorkflow(s.Context, run.GetID()+"-abandoned", "", ""))
+
+	// Not allowed with query
+	res = s.Execute(
+		"workflow", "terminate",
+		"--address", s.Address(),
+		"--query", "WorkflowType = 'DevWorkflow'",
+		"--follow-children",
+	)
+	s.ErrorContains(res.Err, "cannot follow children when query is set")
+}
+
+func (s *SharedServerSuite) TestWorkflow_CancelTree() {
+	// Parent starts one child that honors cancellation and, if asked, one that
+	// ignores it
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		switch a {
+		case "parent", "parent-stubborn":
+			info := workflow.GetInfo(ctx)
+			childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID: info.WorkflowExecution.ID + "-child",
+			})
+			child := workflow.ExecuteChildWorkflow(childCtx, DevWorkflow, "child")
+			if err := child.GetChildWorkflowExecution().Get(ctx, nil); err != nil {
+				return nil, err
+			}
+			if a == "parent-stubborn" {
+				stubbornCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+					WorkflowID: info.WorkflowExecution.ID + "-stubborn",
+				})
+				stubborn := workflow.ExecuteChildWorkflow(stubbornCtx, DevWorkflow, "stubborn")
+				if err := stubborn.GetChildWorkflowExecution().Get(ctx, nil); err != nil {
+					return nil, err
+				}
+			}
+		case "stubborn":
+			disconnectedCtx, _ := workflow.NewDisconnectedContext(ctx)
+			workflow.GetSignalChannel(ctx, "never").Receive(disconnectedCtx, nil)
+		}
+		ctx.Done().Receive(ctx, nil)
+		return nil, ctx.Err()
+	})
+	startParent := func(arg string, children int) client.WorkflowRun {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			arg,
+		)
+		s.NoError(err)
+		s.Eventually(func() bool {
+			resp, err := s.Client.DescribeWorkflowExecution(s.Context, run.GetID(), "")
+			s.NoError(err)
+			started := 0
+			for _, child := range resp.PendingChildren {
+				if child.RunId != "" {
+					started++
+				}
+			}
+			return started == children
+		}, 5*time.Second, 100*time.Millisecond)
+		return run
+	}
+
+	// Child is canceled and closed before the parent is canceled
+	run := startParent("parent", 1)
+	res := s.Execute(
+		"workflow", "cancel-tree",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var results []map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &results))
+	s.Len(results, 2)
+	s.Equal(run.GetID()+"-child", results[0]["workflowId"])
+	s.Equal(float64(1), results[0]["depth"])
+	s.Equal("Canceled", results[0]["status"])
+	s.Equal(run.GetID(), results[1]["workflowId"])
+	s.Equal("Canceled", results[1]["status"])
+	var eventTypes []enums.EventType
+	iter := s.Client.GetWorkflowHistory(s.Context, run.GetID(), "", false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		s.NoError(err)
+		eventTypes = append(eventTypes, event.EventType)
+	}
+	s.Less(
+		slices.Index(eventTypes, enums.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED),
+		slices.Index(eventTypes, enums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCEL_REQUESTED),
+	)
+
+	// Child that does not close causes the parent to be skipped, but its
+	// sibling is still canceled
+	run = startParent("parent-stubborn", 2)
+	res = s.Execute(
+		"workflow", "cancel-tree",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--wait-timeout", "1s",
+	)
+	s.ErrorContains(res.Err, "not all workflows in the tree were canceled")
+	s.ContainsOnSameLine(res.Stdout.String(), run.GetID()+"-child", "Canceled")
+	s.ContainsOnSameLine(res.Stdout.String(), run.GetID()+"-stubborn", "timed out after 1s")
+	s.ContainsOnSameLine(res.Stdout.String(), run.GetID(), "skipped, not all children closed")
+	resp, err := s.Client.DescribeWorkflowExecution(s.Context, run.GetID(), "")
+	s.NoError(err)
+	s.Equal(enums.WORKFLOW_EXECUTION_STATUS_RUNNING, resp.WorkflowExecutionInfo.Status)
+	s.NoError(s.Client.TerminateWorkflow(s.Context, run.GetID(), "", ""))
+}
+
 func (s *SharedServerSuite) TestWorkflow_Terminate_BatchWorkflowSuccess() {
 	res := s.testTerminateBatchWorkflow(false)
 	s.Contains(res.Stdout.String(), "approximately 5 workflow(s)")
@@ -274,6 +501,63 @@ func (s *SharedServerSuite) TestWorkflow_Terminate_BatchWorkflowSuccessJSON() {
 	s.NotEmpty(jsonRes["batchJobId"])
 }
 
+func (s *SharedServerSuite) TestWorkflow_Terminate_BatchMaxCount() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		ctx.Done().Receive(ctx, nil)
+		return nil, ctx.Err()
+	})
+
+	// Start 5 workflows and wait for them to be visible
+	searchAttr := "keyword-" + uuid.NewString()
+	query := "CustomKeywordField = '" + searchAttr + "'"
+	for i := 0; i < 5; i++ {
+		_, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{
+				TaskQueue:        s.Worker().Options.TaskQueue,
+				SearchAttributes: map[string]any{"CustomKeywordField": searchAttr},
+			},
+			DevWorkflow,
+			"ignored",
+		)
+		s.NoError(err)
+	}
+	s.Eventually(func() bool {
+		resp, err := s.Client.ListWorkflow(s.Context, &workflowservice.ListWorkflowExecutionsRequest{Query: query})
+		s.NoError(err)
+		return len(resp.Executions) == 5
+	}, 3*time.Second, 100*time.Millisecond)
+
+	// Invalid sample percent
+	res := s.Execute("workflow", "terminate", "--address", s.Address(), "--query", query,
+		"--sample-percent", "150", "--yes")
+	s.ErrorContains(res.Err, "sample percent must be between 0 and 100")
+
+	// Too many for one batch request
+	res = s.Execute("workflow", "terminate", "--address", s.Address(), "--query", query,
+		"--max-count", "1001", "--yes")
+	s.ErrorContains(res.Err, "max count cannot be more than 1000")
+
+	// Only two are terminated
+	s.CommandHarness.Stdin.WriteString("y\n")
+	res = s.Execute("workflow", "terminate", "--address", s.Address(), "--query", query,
+		"--max-count", "2", "--sample-percent", "100")
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Start batch against 2 selected workflow(s)")
+	s.Eventually(func() bool {
+		resp, err := s.Client.CountWorkflow(s.Context, &workflowservice.CountWorkflowExecutionsRequest{
+			Query: query + " AND ExecutionStatus = 'Terminated'",
+		})
+		s.NoError(err)
+		return resp.Count == 2
+	}, 10*time.Second, 100*time.Millisecond)
+	resp, err := s.Client.CountWorkflow(s.Context, &workflowservice.CountWorkflowExecutionsRequest{
+		Query: query + " AND ExecutionStatus = 'Running'",
+	})
+	s.NoError(err)
+	s.Equal(int64(3), resp.Count)
+}
+
 func (s *SharedServerSuite) testTerminateBatchWorkflow(json bool) *CommandResult {
 	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
 		ctx.Done().Receive(ctx, nil)
@@ -368,6 +652,261 @@ func (s *SharedServerSuite) TestWorkflow_Cancel_SingleWorkflowSuccess() {
 	s.Error(workflow.ErrCanceled, run.Get(s.Context, nil))
 }
 
+func (s *SharedServerSuite) TestWorkflow_Cancel_Wait() {
+	// Input decides how the workflow reacts to cancel
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		switch a {
+		case "ignore":
+			workflow.GetSignalChannel(ctx, "never").Receive(ctx, nil)
+			return nil, nil
+		case "complete":
+			ctx.Done().Receive(ctx, nil)
+			return "done", nil
+		default:
+			ctx.Done().Receive(ctx, nil)
+			return nil, ctx.Err()
+		}
+	})
+	start := func(input string) client.WorkflowRun {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			input,
+		)
+		s.NoError(err)
+		return run
+	}
+
+	// Canceled
+	run := start("cancel")
+	res := s.Execute(
+		"workflow", "cancel",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--wait",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Workflow closed with status")
+
+	// A run started with the same ID once it closes does not change the status
+	run = start("cancel")
+	var startedNext atomic.Bool
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+		) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			resp, ok := reply.(*workflowservice.GetWorkflowExecutionHistoryResponse)
+			if err == nil && ok && len(resp.History.GetEvents()) > 0 && !startedNext.Swap(true) {
+				_, err = s.Client.ExecuteWorkflow(
+					s.Context,
+					client.StartWorkflowOptions{ID: run.GetID(), TaskQueue: s.Worker().Options.TaskQueue},
+					DevWorkflow,
+					"ignore",
+				)
+			}
+			return err
+		}),
+	)
+	res = s.Execute(
+		"workflow", "cancel",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--wait",
+	)
+	s.NoError(res.Err)
+	s.True(startedNext.Load())
+	s.NoError(s.Client.TerminateWorkflow(s.Context, run.GetID(), "", "test done"))
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = nil
+
+	// Completed instead of canceled fails
+	run = start("complete")
+	res = s.Execute(
+		"workflow", "cancel",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--wait",
+	)
+	s.ErrorContains(res.Err, "workflow closed with status")
+
+	// Never closes, so times out
+	run = start("ignore")
+	res = s.Execute(
+		"workflow", "cancel",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--wait",
+		"--wait-timeout", "1s",
+	)
+	s.ErrorContains(res.Err, "timed out after 1s waiting for workflow to close")
+
+	// Terminate waits for terminated status
+	res = s.Execute(
+		"workflow", "terminate",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--wait",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Workflow closed with status")
+
+	// Not allowed with query
+	res = s.Execute(
+		"workflow", "cancel",
+		"--address", s.Address(),
+		"-q", "WorkflowId = 'foo'",
+		"--wait",
+	)
+	s.ErrorContains(res.Err, "cannot wait when query is set")
+}
+
+func (s *SharedServerSuite) TestWorkflow_Supersede() {
+	// Return the input once signaled, or the context's error on cancel
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		sel := workflow.NewSelector(ctx)
+		sel.AddReceive(workflow.GetSignalChannel(ctx, "finish"), func(workflow.ReceiveChannel, bool) {})
+		sel.AddReceive(ctx.Done(), func(workflow.ReceiveChannel, bool) {})
+		sel.Select(ctx)
+		return a, ctx.Err()
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue, Memo: map[string]any{"note": "kept"}},
+		DevWorkflow,
+		"original",
+	)
+	s.NoError(err)
+
+	// Terminate and start with the same input and memo
+	res := s.Execute(
+		"workflow", "supersede",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var out struct {
+		WorkflowId    string `json:"workflowId"`
+		RunId         string `json:"runId"`
+		PreviousRunId string `json:"previousRunId"`
+	}
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &out))
+	s.Equal(run.GetID(), out.WorkflowId)
+	s.Equal(run.GetRunID(), out.PreviousRunId)
+	s.NotEqual(run.GetRunID(), out.RunId)
+	s.Contains(run.Get(s.Context, nil).Error(), "terminated")
+	desc, err := s.Client.DescribeWorkflowExecution(s.Context, run.GetID(), out.RunId)
+	s.NoError(err)
+	s.Equal(enums.WORKFLOW_EXECUTION_STATUS_RUNNING, desc.WorkflowExecutionInfo.Status)
+	s.Contains(string(desc.WorkflowExecutionInfo.Memo.Fields["note"].Data), "kept")
+
+	// Cancel and start with overridden input
+	res = s.Execute(
+		"workflow", "supersede",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--cancel",
+		"--reason", "supersede-test",
+		"-i", `"override"`,
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "Workflow closed with status Canceled")
+	s.ContainsOnSameLine(res.Stdout.String(), "PreviousRunId", out.RunId)
+	var result string
+	s.ErrorContains(s.Client.GetWorkflow(s.Context, run.GetID(), out.RunId).Get(s.Context, &result), "canceled")
+	s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "finish", nil))
+	s.NoError(s.Client.GetWorkflow(s.Context, run.GetID(), "").Get(s.Context, &result))
+	s.Equal("override", result)
+
+	// Closed workflows cannot be superseded
+	res = s.Execute("workflow", "supersede", "--address", s.Address(), "-w", run.GetID())
+	s.ErrorContains(res.Err, "is not running")
+	res = s.Execute("workflow", "supersede", "--address", s.Address(), "-w", run.GetID(), "--reason", "foo")
+	s.ErrorContains(res.Err, "cannot use --reason without --cancel")
+}
+
+func (s *SharedServerSuite) TestWorkflow_Update_Query() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, val any) (any, error) {
+		var migrated string
+		err := workflow.SetUpdateHandlerWithOptions(
+			ctx,
+			"migrate",
+			func(ctx workflow.Context, version string) (string, error) {
+				migrated = version
+				return workflow.GetInfo(ctx).WorkflowExecution.ID + "@" + version, nil
+			},
+			workflow.UpdateHandlerOptions{
+				Validator: func(ctx workflow.Context, version string) error {
+					if version == "" {
+						return fmt.Errorf("version required")
+					}
+					return nil
+				}},
+		)
+		if err != nil {
+			return nil, err
+		}
+		workflow.GetSignalChannel(ctx, "done").Receive(ctx, nil)
+		return migrated, nil
+	})
+
+	// Start a few workflows and wait for them to be visible
+	var runs []client.WorkflowRun
+	for i := 0; i < 3; i++ {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			"ignored",
+		)
+		s.NoError(err)
+		runs = append(runs, run)
+	}
+	query := "TaskQueue = '" + s.Worker().Options.TaskQueue + "'"
+	s.Eventually(func() bool {
+		resp, err := s.Client.ListWorkflow(s.Context, &workflowservice.ListWorkflowExecutionsRequest{Query: query})
+		s.NoError(err)
+		return len(resp.Executions) == 3
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Rejected by validator for all
+	res := s.Execute("workflow", "update", "--address", s.Address(), "--query", query, "--yes",
+		"--name", "migrate", "-i", `""`)
+	s.ErrorContains(res.Err, "3 of 3 updates failed")
+	s.Contains(res.Stdout.String(), `Send Update "migrate" to approximately 3 workflow(s)? y/N yes`)
+	s.Contains(res.Stdout.String(), "version required")
+
+	// Successful, rate limited
+	res = s.Execute("workflow", "update", "--address", s.Address(), "--query", query, "--yes",
+		"--name", "migrate", "-i", `"v2"`, "--update-id", "migrate-v2", "--concurrency", "2", "--rps", "50",
+		"-o", "json")
+	s.NoError(res.Err)
+	var jsonOut []map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Len(jsonOut, 3)
+	for _, result := range jsonOut {
+		s.Equal("migrate-v2", result["updateId"])
+		s.Equal(result["workflowId"].(string)+"@v2", result["result"])
+		s.NotContains(result, "error")
+	}
+
+	// Cannot mix with workflow ID
+	res = s.Execute("workflow", "update", "--address", s.Address(), "--query", query, "--yes",
+		"-w", runs[0].GetID(), "--name", "migrate", "-i", `"v2"`)
+	s.ErrorContains(res.Err, "cannot set workflow ID")
+
+	for _, run := range runs {
+		s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "done", nil))
+		var migrated string
+		s.NoError(run.Get(s.Context, &migrated))
+		s.Equal("v2", migrated)
+	}
+}
+
 func (s *SharedServerSuite) TestWorkflow_Update() {
 	updateName := "test-update"
 
@@ -445,6 +984,17 @@ func (s *SharedServerSuite) TestWorkflow_Update() {
 	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(), "-i", strconv.Itoa(input))
 	s.ErrorContains(res.Err, "required flag(s) \"name\" not set")
 
+	// update rejected, batch-only options with workflow ID
+	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(),
+		"--name", updateName, "--max-count", "1")
+	s.ErrorContains(res.Err, "cannot set sample percent or max count when workflow ID is set")
+	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(),
+		"--name", updateName, "--concurrency", "5")
+	s.ErrorContains(res.Err, "cannot set concurrency or rps when workflow ID is set")
+	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(),
+		"--name", updateName, "--rps", "20")
+	s.ErrorContains(res.Err, "cannot set concurrency or rps when workflow ID is set")
+
 	// update rejected, wrong workflowID
 	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", "nonexistent-wf-id", "--name", updateName, "-i", strconv.Itoa(input))
 	s.ErrorContains(res.Err, "unable to update workflow")
@@ -454,6 +1004,70 @@ func (s *SharedServerSuite) TestWorkflow_Update() {
 	s.ErrorContains(res.Err, "unable to update workflow")
 }
 
+func (s *SharedServerSuite) TestWorkflow_UnknownHandlerNameSuggestions() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		for _, name := range []string{"my-query", "other-query"} {
+			if err := workflow.SetQueryHandler(ctx, name, func() (string, error) { return "", nil }); err != nil {
+				return nil, err
+			}
+		}
+		err := workflow.SetUpdateHandler(ctx, "my-update", func(ctx workflow.Context) error { return nil })
+		if err != nil {
+			return nil, err
+		}
+		workflow.GetSignalChannel(ctx, "done").Receive(ctx, nil)
+		return nil, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	defer func() { s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "done", nil)) }()
+
+	res := s.Execute("workflow", "query", "--address", s.Address(), "-w", run.GetID(), "--name", "my-qeury")
+	s.ErrorContains(res.Err, `did you mean "my-query"? registered query names: my-query, other-query`)
+
+	res = s.Execute("workflow", "query", "--address", s.Address(), "-w", run.GetID(), "--name", "something-else")
+	s.ErrorContains(res.Err, "(registered query names: my-query, other-query)")
+
+	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(), "--name", "my-updat")
+	s.ErrorContains(res.Err, `did you mean "my-update"? registered update names: my-update`)
+
+	// Other failures are left alone
+	res = s.Execute("workflow", "query", "--address", s.Address(), "-w", "does-not-exist", "--name", "my-qeury")
+	s.Error(res.Err)
+	s.NotContains(res.Err.Error(), "did you mean")
+}
+
+func (s *SharedServerSuite) TestWorkflow_Update_CapabilityCheck() {
+	// Pretend the server is older than update support
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+		) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if info, ok := reply.(*workflowservice.GetSystemInfoResponse); ok && err == nil {
+				info.ServerVersion = "1.20.3"
+			}
+			return err
+		}),
+	)
+
+	res := s.Execute("workflow", "update", "--address", s.Address(), "-w", "nonexistent-wf-id", "--name", "some-update")
+	s.ErrorContains(res.Err, "server 1.20.3 doesn't support workflow update, requires >= 1.21.0")
+
+	// Skipping the check makes the call which fails for other reasons
+	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", "nonexistent-wf-id", "--name", "some-update",
+		"--skip-capability-check")
+	s.ErrorContains(res.Err, "unable to update workflow")
+}
+
 func (s *SharedServerSuite) TestWorkflow_Cancel_BatchWorkflowSuccess() {
 	res := s.testCancelBatchWorkflow(false)
 	s.Contains(res.Stdout.String(), "approximately 5 workflow(s)")
@@ -597,6 +1211,126 @@ func (s *SharedServerSuite) testQueryWorkflow(json bool) {
 	s.Contains(res.Err.Error(), "query was rejected, workflow has status: Completed")
 }
 
+func (s *SharedServerSuite) TestWorkflow_Query_Filter() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		err := workflow.SetQueryHandler(ctx, "my-query", func() (string, error) {
+			return "status-of-" + workflow.GetInfo(ctx).WorkflowExecution.ID, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		workflow.GetSignalChannel(ctx, "my-signal").Receive(ctx, nil)
+		return nil, nil
+	})
+
+	// Start a few workflows and wait for them to be visible
+	var runs []client.WorkflowRun
+	for i := 0; i < 3; i++ {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			"ignored",
+		)
+		s.NoError(err)
+		runs = append(runs, run)
+	}
+	filter := "TaskQueue = '" + s.Worker().Options.TaskQueue + "'"
+	s.Eventually(func() bool {
+		resp, err := s.Client.ListWorkflow(s.Context, &workflowservice.ListWorkflowExecutionsRequest{Query: filter})
+		s.NoError(err)
+		return len(resp.Executions) == 3
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Text
+	res := s.Execute(
+		"workflow", "query",
+		"--address", s.Address(),
+		"--query-filter", filter,
+		"--name", "my-query",
+		"--concurrency", "2",
+	)
+	s.NoError(res.Err)
+	for _, run := range runs {
+		s.ContainsOnSameLine(res.Stdout.String(), run.GetID(), run.GetRunID(), `"status-of-`+run.GetID()+`"`)
+	}
+
+	// JSON
+	res = s.Execute(
+		"workflow", "query",
+		"--address", s.Address(),
+		"--query-filter", filter,
+		"--name", "my-query",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var jsonOut []map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Len(jsonOut, 3)
+	for _, result := range jsonOut {
+		s.Equal("status-of-"+result["workflowId"].(string), result["result"])
+	}
+
+	// Unknown query fails per workflow
+	res = s.Execute(
+		"workflow", "query",
+		"--address", s.Address(),
+		"--query-filter", filter,
+		"--name", "not-a-query",
+	)
+	s.ErrorContains(res.Err, "3 of 3 queries failed")
+	s.Contains(res.Stdout.String(), "unknown queryType not-a-query")
+
+	// Cannot mix with workflow ID
+	res = s.Execute(
+		"workflow", "query",
+		"--address", s.Address(),
+		"--query-filter", filter,
+		"-w", runs[0].GetID(),
+		"--name", "my-query",
+	)
+	s.ErrorContains(res.Err, "cannot set workflow ID or run ID when query filter is set")
+
+	// Simulate an interrupt during the first query, the rest are not sent
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption,
+		) error {
+			if strings.HasSuffix(method, "/QueryWorkflow") {
+				s.CommandHarness.CancelContext()
+				return context.Canceled
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+	res = s.Execute(
+		"workflow", "query",
+		"--address", s.Address(),
+		"--query-filter", filter,
+		"--name", "my-query",
+		"--concurrency", "1",
+		"-o", "json",
+	)
+	s.ErrorContains(res.Err, "interrupted, workflows marked as not sent were not queried")
+	jsonOut = nil
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Len(jsonOut, 3)
+	s.NotEqual("not sent", jsonOut[0]["error"])
+	s.Equal("not sent", jsonOut[1]["error"])
+	s.Equal("not sent", jsonOut[2]["error"])
+
+	// The command context is canceled now
+	for _, run := range runs {
+		s.NoError(s.Client.SignalWorkflow(context.Background(), run.GetID(), "", "my-signal", nil))
+		s.NoError(run.Get(context.Background(), nil))
+	}
+}
+
 func (s *SharedServerSuite) TestWorkflow_Stack_SingleWorkflowSuccess() {
 	s.testStackWorkflow(false)
 }
@@ -2,19 +2,43 @@ package temporalcli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
+	"github.com/google/uuid"
+	"github.com/temporalio/cli/temporalcli/devserver"
 	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"github.com/temporalio/cli/temporalcli/internal/progress"
+	"github.com/temporalio/cli/temporalcli/internal/tracer"
 	"go.temporal.io/api/common/v1"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/failure/v1"
 	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/server/api/adminservice/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 func (c *TemporalWorkflowDescribeCommand) run(cctx *CommandContext, args []string) error {
@@ -68,13 +92,20 @@ func (c *TemporalWorkflowDescribeCommand) run(cctx *CommandContext, args []strin
 		}
 	}
 
+	var extendedInfo *workflowExtendedInfo
+	if c.IncludeRawHistoryStats {
+		if extendedInfo, err = getWorkflowExtendedInfo(cctx, cl, c.Parent.Namespace, resp.WorkflowExecutionInfo); err != nil {
+			return err
+		}
+	}
+
 	// Print JSON
 	if cctx.JSONOutput {
-		// We want to inject the "closeEvent" and "result" into the same structure,
-		// and in order to do that, we need to serialize the protojson to a map, add
-		// the fields, then re-serialize.
+		// We want to inject the "closeEvent", "result", and "extendedInfo" into the
+		// same structure, and in order to do that, we need to serialize the
+		// protojson to a map, add the fields, then re-serialize.
 		var toPrint any = resp
-		if closeEvent != nil {
+		if closeEvent != nil || extendedInfo != nil {
 			var respObj map[string]any
 			b, err := cctx.MarshalProtoJSON(resp)
 			if err != nil {
@@ -83,18 +114,23 @@ func (c *TemporalWorkflowDescribeCommand) run(cctx *CommandContext, args []strin
 			if err := json.Unmarshal(b, &respObj); err != nil {
 				return fmt.Errorf("failed unmarshaling: %w", err)
 			}
-			b, err = cctx.MarshalProtoJSON(closeEvent)
-			if err != nil {
-				return fmt.Errorf("failed marshaling close event: %w", err)
-			}
-			respObj["closeEvent"] = json.RawMessage(b)
-			if attr := closeEvent.GetWorkflowExecutionCompletedEventAttributes(); attr != nil {
-				respObj["result"], err = cctx.MarshalFriendlyJSONPayloads(
-					closeEvent.GetWorkflowExecutionCompletedEventAttributes().GetResult())
+			if closeEvent != nil {
+				b, err = cctx.MarshalProtoJSON(closeEvent)
 				if err != nil {
-					return fmt.Errorf("failed marshaling result: %w", err)
+					return fmt.Errorf("failed marshaling close event: %w", err)
+				}
+				respObj["closeEvent"] = json.RawMessage(b)
+				if attr := closeEvent.GetWorkflowExecutionCompletedEventAttributes(); attr != nil {
+					respObj["result"], err = cctx.MarshalFriendlyJSONPayloads(
+						closeEvent.GetWorkflowExecutionCompletedEventAttributes().GetResult())
+					if err != nil {
+						return fmt.Errorf("failed marshaling result: %w", err)
+					}
 				}
 			}
+			if extendedInfo != nil {
+				respObj["extendedInfo"] = extendedInfo
+			}
 			toPrint = respObj
 		}
 		return cctx.Printer.PrintStructured(toPrint, printer.StructuredOptions{})
@@ -111,7 +147,7 @@ func (c *TemporalWorkflowDescribeCommand) run(cctx *CommandContext, args []strin
 		StartTime            time.Time
 		CloseTime            time.Time                  `cli:",cardOmitEmpty"`
 		ExecutionTime        time.Time                  `cli:",cardOmitEmpty"`
-		Memo                 map[string]*common.Payload `cli:",cardOmitEmpty"`
+		Memo                 *common.Memo               `cli:",cardOmitEmpty"`
 		SearchAttributes     map[string]*common.Payload `cli:",cardOmitEmpty"`
 		StateTransitionCount int64
 		HistoryLength        int64
@@ -125,13 +161,31 @@ func (c *TemporalWorkflowDescribeCommand) run(cctx *CommandContext, args []strin
 		StartTime:            timestampToTime(info.StartTime),
 		CloseTime:            timestampToTime(info.CloseTime),
 		ExecutionTime:        timestampToTime(info.ExecutionTime),
-		Memo:                 info.Memo.GetFields(),
+		Memo:                 info.Memo,
 		SearchAttributes:     info.SearchAttributes.GetIndexedFields(),
 		StateTransitionCount: info.StateTransitionCount,
 		HistoryLength:        info.HistoryLength,
 		HistorySize:          info.HistorySizeBytes,
 	}, printer.StructuredOptions{})
 
+	if extendedInfo != nil {
+		cctx.Printer.Println()
+		cctx.Printer.Println(color.MagentaString("Extended Info:"))
+		_ = cctx.Printer.PrintStructured(struct {
+			HistorySizeBytes  int64
+			HistoryLength     int64
+			ExecutionDuration time.Duration
+			FirstRunId        string
+			ResetFromRunId    string `cli:",cardOmitEmpty"`
+		}{
+			HistorySizeBytes:  extendedInfo.HistorySizeBytes,
+			HistoryLength:     extendedInfo.HistoryLength,
+			ExecutionDuration: extendedInfo.executionDuration,
+			FirstRunId:        extendedInfo.FirstRunId,
+			ResetFromRunId:    extendedInfo.ResetFromRunId,
+		}, printer.StructuredOptions{})
+	}
+
 	if running {
 		cctx.Printer.Println()
 		cctx.Printer.Println(color.MagentaString("Pending Activities: %v", len(resp.PendingActivities)))
@@ -187,6 +241,55 @@ func (c *TemporalWorkflowDescribeCommand) run(cctx *CommandContext, args []strin
 	return nil
 }
 
+type workflowExtendedInfo struct {
+	HistorySizeBytes  int64  `json:"historySizeBytes"`
+	HistoryLength     int64  `json:"historyLength"`
+	ExecutionDuration string `json:"executionDuration"`
+	FirstRunId        string `json:"firstRunId"`
+	ResetFromRunId    string `json:"resetFromRunId,omitempty"`
+
+	executionDuration time.Duration
+}
+
+// Gets extended info for the execution, which needs the first event for the
+// run IDs it was started with.
+func getWorkflowExtendedInfo(
+	cctx *CommandContext,
+	cl client.Client,
+	namespace string,
+	info *workflow.WorkflowExecutionInfo,
+) (*workflowExtendedInfo, error) {
+	resp, err := cl.WorkflowService().GetWorkflowExecutionHistory(cctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:       namespace,
+		Execution:       info.Execution,
+		MaximumPageSize: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed getting first event: %w", err)
+	} else if len(resp.GetHistory().GetEvents()) == 0 {
+		return nil, fmt.Errorf("missing first event")
+	}
+	started := resp.History.Events[0].GetWorkflowExecutionStartedEventAttributes()
+	extended := &workflowExtendedInfo{
+		HistorySizeBytes: info.HistorySizeBytes,
+		HistoryLength:    info.HistoryLength,
+		FirstRunId:       started.GetFirstExecutionRunId(),
+	}
+	// The original run ID is kept on reset, so if it differs, this run was reset
+	// from it
+	if orig := started.GetOriginalExecutionRunId(); orig != "" && orig != info.Execution.RunId {
+		extended.ResetFromRunId = orig
+	}
+	// The server only sets the duration once closed
+	if info.ExecutionDuration != nil {
+		extended.executionDuration = info.ExecutionDuration.AsDuration()
+	} else if info.StartTime != nil {
+		extended.executionDuration = time.Since(info.StartTime.AsTime()).Truncate(time.Millisecond)
+	}
+	extended.ExecutionDuration = extended.executionDuration.String()
+	return extended, nil
+}
+
 func (c *TemporalWorkflowListCommand) run(cctx *CommandContext, args []string) error {
 	cl, err := c.Parent.ClientOptions.dialClient(cctx)
 	if err != nil {
@@ -198,12 +301,34 @@ func (c *TemporalWorkflowListCommand) run(cctx *CommandContext, args []string) e
 	cctx.Printer.StartList()
 	defer cctx.Printer.EndList()
 
+	if c.GroupBy.Value != "" {
+		return c.runGrouped(cctx, cl)
+	}
+
 	// Build request and start looping. We always use default page size regardless
 	// of user-defined limit, because we're ok w/ extra page data and the default
 	// is not clearly defined.
 	pageFetcher := c.pageFetcher(cctx, cl)
 	var nextPageToken []byte
 	var execsProcessed int
+	// Only show progress when exporting, otherwise the output is the progress
+	var bar *progress.Bar
+	if cctx.outputFile != nil {
+		bar = cctx.startProgress("Exporting workflows", int64(c.Limit))
+		defer bar.Finish()
+	}
+	fields := []string{"Status", "WorkflowId", "Type", "StartTime"}
+	// Selected fields may include any of the long columns
+	long := c.Long || len(cctx.Printer.Fields) > 0
+	if long {
+		fields = append(fields, "RunId", "TaskQueue", "CloseTime")
+	}
+	for _, key := range c.MemoKey {
+		fields = append(fields, "Memo."+key)
+	}
+	for _, key := range c.SearchAttributeKey {
+		fields = append(fields, "SearchAttributes."+key)
+	}
 	for pageIndex := 0; ; pageIndex++ {
 		page, err := pageFetcher(nextPageToken)
 		if err != nil {
@@ -220,21 +345,39 @@ func (c *TemporalWorkflowListCommand) run(cctx *CommandContext, args []string) e
 				_ = cctx.Printer.PrintStructured(exec, printer.StructuredOptions{})
 			} else {
 				// For non-JSON, we are doing a table for each page
-				textTable = append(textTable, map[string]any{
+				row := map[string]any{
 					"Status":     exec.Status,
 					"WorkflowId": exec.Execution.WorkflowId,
 					"Type":       exec.Type.GetName(),
 					"StartTime":  exec.StartTime.AsTime(),
-				})
+				}
+				if long {
+					row["RunId"] = exec.Execution.RunId
+					row["TaskQueue"] = exec.TaskQueue
+					// Zero time prints as empty for open executions
+					row["CloseTime"] = time.Time{}
+					if exec.CloseTime != nil {
+						row["CloseTime"] = exec.CloseTime.AsTime()
+					}
+				}
+				// Memo payloads have already been decoded by any codec interceptor
+				for _, key := range c.MemoKey {
+					row["Memo."+key] = payloadColumnText(exec.GetMemo().GetFields()[key])
+				}
+				for _, key := range c.SearchAttributeKey {
+					row["SearchAttributes."+key] = payloadColumnText(exec.GetSearchAttributes().GetIndexedFields()[key])
+				}
+				textTable = append(textTable, row)
 			}
 		}
 		// Print table, headers only on first table
 		if len(textTable) > 0 {
 			_ = cctx.Printer.PrintStructured(textTable, printer.StructuredOptions{
-				Fields: []string{"Status", "WorkflowId", "Type", "StartTime"},
+				Fields: fields,
 				Table:  &printer.TableOptions{NoHeader: pageIndex > 0},
 			})
 		}
+		bar.Set(int64(execsProcessed), int64(c.Limit))
 		// Stop if next page token non-existing or executions reached limit
 		nextPageToken = page.GetNextPageToken()
 		if len(nextPageToken) == 0 || (c.Limit > 0 && execsProcessed >= c.Limit) {
@@ -243,6 +386,266 @@ func (c *TemporalWorkflowListCommand) run(cctx *CommandContext, args []string) e
 	}
 }
 
+// Converts a memo or search attribute payload to text for a table column,
+// empty if the payload is not present.
+func payloadColumnText(payload *common.Payload) string {
+	if payload == nil {
+		return ""
+	}
+	var value any
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &value); err != nil {
+		return fmt.Sprintf("<failed converting: %v>", err)
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("<failed converting: %v>", err)
+	}
+	return string(b)
+}
+
+type workflowListGroup struct {
+	Group   string            `json:"group"`
+	Count   int               `json:"count"`
+	Samples []json.RawMessage `json:"samples"`
+
+	sampleIDs []string
+}
+
+func (c *TemporalWorkflowListCommand) runGrouped(cctx *CommandContext, cl client.Client) error {
+	var groupKey func(*workflow.WorkflowExecutionInfo) string
+	switch c.GroupBy.Value {
+	case "WorkflowType":
+		groupKey = func(e *workflow.WorkflowExecutionInfo) string { return e.GetType().GetName() }
+	case "ExecutionStatus":
+		groupKey = func(e *workflow.WorkflowExecutionInfo) string { return e.GetStatus().String() }
+	case "TaskQueue":
+		groupKey = func(e *workflow.WorkflowExecutionInfo) string { return e.GetTaskQueue() }
+	default:
+		return fmt.Errorf("unrecognized group by field %q", c.GroupBy.Value)
+	}
+
+	// Group every execution up to the limit
+	groups := map[string]*workflowListGroup{}
+	pageFetcher := c.pageFetcher(cctx, cl)
+	var nextPageToken []byte
+	var execsProcessed int
+	bar := cctx.startProgress("Grouping workflows", int64(c.Limit))
+	defer bar.Finish()
+	for {
+		page, err := pageFetcher(nextPageToken)
+		if err != nil {
+			return fmt.Errorf("failed listing workflows: %w", err)
+		}
+		for _, exec := range page.GetExecutions() {
+			if c.Limit > 0 && execsProcessed >= c.Limit {
+				break
+			}
+			execsProcessed++
+			key := groupKey(exec)
+			group := groups[key]
+			if group == nil {
+				group = &workflowListGroup{Group: key, Samples: []json.RawMessage{}}
+				groups[key] = group
+			}
+			group.Count++
+			if len(group.sampleIDs) < c.GroupSamples {
+				group.sampleIDs = append(group.sampleIDs, exec.GetExecution().GetWorkflowId())
+				if cctx.JSONOutput {
+					b, err := cctx.MarshalProtoJSON(exec)
+					if err != nil {
+						return fmt.Errorf("failed marshaling execution: %w", err)
+					}
+					group.Samples = append(group.Samples, b)
+				}
+			}
+		}
+		bar.Set(int64(execsProcessed), int64(c.Limit))
+		nextPageToken = page.GetNextPageToken()
+		if len(nextPageToken) == 0 || (c.Limit > 0 && execsProcessed >= c.Limit) {
+			break
+		}
+	}
+	bar.Finish()
+
+	// Largest groups first
+	sorted := make([]*workflowListGroup, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Group < sorted[j].Group
+	})
+
+	if cctx.JSONOutput {
+		for _, group := range sorted {
+			_ = cctx.Printer.PrintStructured(group, printer.StructuredOptions{})
+		}
+		return nil
+	}
+	textTable := make([]map[string]any, len(sorted))
+	for i, group := range sorted {
+		samples := strings.Join(group.sampleIDs, ", ")
+		if group.Count > len(group.sampleIDs) && len(group.sampleIDs) > 0 {
+			samples += ", ..."
+		}
+		textTable[i] = map[string]any{c.GroupBy.Value: group.Group, "Count": group.Count, "Samples": samples}
+	}
+	return cctx.Printer.PrintStructured(textTable, printer.StructuredOptions{
+		Fields: []string{c.GroupBy.Value, "Count", "Samples"},
+		Table:  &printer.TableOptions{},
+	})
+}
+
+type workflowEventCount struct {
+	Group        string `json:"group"`
+	Count        int    `json:"count"`
+	Bytes        int    `json:"bytes"`
+	PayloadBytes int    `json:"payloadBytes"`
+}
+
+func (c *TemporalWorkflowCountEventsCommand) run(cctx *CommandContext, args []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	// Activity events after scheduling only reference the scheduled event
+	activityTypes := map[int64]string{}
+	groups := map[string]*workflowEventCount{}
+	var total workflowEventCount
+	iter := cl.GetWorkflowHistory(cctx, c.WorkflowId, c.RunId, false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("failed getting history: %w", err)
+		}
+		if attrs := event.GetActivityTaskScheduledEventAttributes(); attrs != nil {
+			activityTypes[event.EventId] = attrs.GetActivityType().GetName()
+		}
+		size, payloadSize := proto.Size(event), payloadsSize(event.ProtoReflect())
+		total.Count++
+		total.Bytes += size
+		total.PayloadBytes += payloadSize
+
+		var key string
+		switch c.GroupBy.Value {
+		case "event-type":
+			key = event.EventType.String()
+		case "activity-type":
+			if id, ok := activityScheduledEventID(event); ok {
+				key = activityTypes[id]
+			} else {
+				continue
+			}
+		case "signal-name":
+			if attrs := event.GetWorkflowExecutionSignaledEventAttributes(); attrs != nil {
+				key = attrs.SignalName
+			} else {
+				continue
+			}
+		default:
+			return fmt.Errorf("unrecognized group by %q", c.GroupBy.Value)
+		}
+		group := groups[key]
+		if group == nil {
+			group = &workflowEventCount{Group: key}
+			groups[key] = group
+		}
+		group.Count++
+		group.Bytes += size
+		group.PayloadBytes += payloadSize
+	}
+
+	// Largest first
+	sorted := make([]*workflowEventCount, 0, len(groups))
+	for _, group := range groups {
+		sorted = append(sorted, group)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Bytes != sorted[j].Bytes {
+			return sorted[i].Bytes > sorted[j].Bytes
+		}
+		return sorted[i].Group < sorted[j].Group
+	})
+
+	if cctx.JSONOutput {
+		return cctx.Printer.PrintStructured(sorted, printer.StructuredOptions{})
+	}
+	textTable := make([]map[string]any, len(sorted))
+	for i, group := range sorted {
+		textTable[i] = map[string]any{
+			"Group":        group.Group,
+			"Count":        group.Count,
+			"Bytes":        humanize.Bytes(uint64(group.Bytes)),
+			"PayloadBytes": humanize.Bytes(uint64(group.PayloadBytes)),
+		}
+	}
+	if err := cctx.Printer.PrintStructured(textTable, printer.StructuredOptions{
+		Fields: []string{"Group", "Count", "Bytes", "PayloadBytes"},
+		Table:  &printer.TableOptions{},
+	}); err != nil {
+		return err
+	}
+	cctx.Printer.Printlnf("Total: %v event(s), %v, %v in payloads", total.Count,
+		humanize.Bytes(uint64(total.Bytes)), humanize.Bytes(uint64(total.PayloadBytes)))
+	return nil
+}
+
+func activityScheduledEventID(event *history.HistoryEvent) (int64, bool) {
+	switch event.EventType {
+	case enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		return event.EventId, true
+	case enums.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+		return event.GetActivityTaskStartedEventAttributes().GetScheduledEventId(), true
+	case enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		return event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId(), true
+	case enums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		return event.GetActivityTaskFailedEventAttributes().GetScheduledEventId(), true
+	case enums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		return event.GetActivityTaskTimedOutEventAttributes().GetScheduledEventId(), true
+	case enums.EVENT_TYPE_ACTIVITY_TASK_CANCEL_REQUESTED:
+		return event.GetActivityTaskCancelRequestedEventAttributes().GetScheduledEventId(), true
+	case enums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		return event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(), true
+	}
+	return 0, false
+}
+
+// Total serialized size of all payloads anywhere in the message
+func payloadsSize(m protoreflect.Message) (size int) {
+	if m.Descriptor().FullName() == (&common.Payload{}).ProtoReflect().Descriptor().FullName() {
+		return proto.Size(m.Interface())
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					size += payloadsSize(v.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					size += payloadsSize(v.List().Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			size += payloadsSize(v.Message())
+		}
+		return true
+	})
+	return
+}
+
 type workflowPage interface {
 	GetExecutions() []*workflow.WorkflowExecutionInfo
 	GetNextPageToken() []byte
@@ -313,6 +716,136 @@ func (c *TemporalWorkflowCountCommand) run(cctx *CommandContext, _ []string) err
 	return nil
 }
 
+type workflowChildRow struct {
+	Depth            int    `json:"depth"`
+	Status           string `json:"status"`
+	WorkflowId       string `json:"workflowId"`
+	RunId            string `json:"runId"`
+	Type             string `json:"type"`
+	ParentWorkflowId string `json:"parentWorkflowId"`
+}
+
+func (c *TemporalWorkflowChildrenCommand) run(cctx *CommandContext, _ []string) error {
+	cl, err := c.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	rows, err := collectChildWorkflows(cctx, cl, &common.WorkflowExecution{WorkflowId: c.WorkflowId, RunId: c.RunId},
+		1, c.Depth)
+	if err != nil {
+		return err
+	}
+
+	// This is a listing command subject to json vs jsonl rules
+	if cctx.JSONOutput {
+		cctx.Printer.StartList()
+		defer cctx.Printer.EndList()
+		for _, row := range rows {
+			_ = cctx.Printer.PrintStructured(row, printer.StructuredOptions{})
+		}
+		return nil
+	} else if len(rows) == 0 {
+		cctx.Printer.Println("No child workflows found")
+		return nil
+	}
+	// Indent workflow IDs in text to show the tree
+	for _, row := range rows {
+		row.WorkflowId = strings.Repeat("  ", row.Depth-1) + row.WorkflowId
+	}
+	return cctx.Printer.PrintStructured(rows, printer.StructuredOptions{
+		Fields: []string{"Status", "WorkflowId", "RunId", "Type"},
+		Table:  &printer.TableOptions{},
+	})
+}
+
+// Walks history of the given execution, and the runs it continued as new to,
+// for started children, describing each for its status and recursing until max
+// depth (negative for unlimited). Results are in depth-first order.
+func collectChildWorkflows(
+	cctx *CommandContext,
+	cl client.Client,
+	exec *common.WorkflowExecution,
+	depth int,
+	maxDepth int,
+) ([]*workflowChildRow, error) {
+	if maxDepth >= 0 && depth > maxDepth {
+		return nil, nil
+	}
+	var rows []*workflowChildRow
+	for runID := exec.RunId; ; {
+		var nextRunID string
+		iter := cl.GetWorkflowHistory(cctx, exec.WorkflowId, runID, false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+		for iter.HasNext() {
+			event, err := iter.Next()
+			if err != nil {
+				return nil, fmt.Errorf("failed getting history of workflow %v: %w", exec.WorkflowId, err)
+			}
+			if continued := event.GetWorkflowExecutionContinuedAsNewEventAttributes(); continued != nil {
+				nextRunID = continued.NewExecutionRunId
+			}
+			started := event.GetChildWorkflowExecutionStartedEventAttributes()
+			if started == nil {
+				continue
+			}
+			row := &workflowChildRow{
+				Depth:            depth,
+				WorkflowId:       started.WorkflowExecution.GetWorkflowId(),
+				RunId:            started.WorkflowExecution.GetRunId(),
+				Type:             started.WorkflowType.GetName(),
+				ParentWorkflowId: exec.WorkflowId,
+			}
+			rows = append(rows, row)
+			// Children past retention are common in large trees, so they are
+			// listed without their descendants instead of failing the listing
+			info, err := describeLatestRun(cctx, cl, started.WorkflowExecution)
+			var notFound *serviceerror.NotFound
+			if errors.As(err, &notFound) {
+				row.Status = "NotFound"
+				continue
+			} else if err != nil {
+				cctx.Logger.Warn("Failed describing child workflow", "workflowId", row.WorkflowId, "error", err)
+				row.Status = "Unknown"
+				continue
+			}
+			row.Status = info.GetStatus().String()
+			row.RunId = info.GetExecution().GetRunId()
+			descendants, err := collectChildWorkflows(cctx, cl, started.WorkflowExecution, depth+1, maxDepth)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, descendants...)
+		}
+		if nextRunID == "" {
+			return rows, nil
+		}
+		runID = nextRunID
+	}
+}
+
+// Describes the given run, or the last run of the chain it continued as new to.
+func describeLatestRun(
+	cctx *CommandContext,
+	cl client.Client,
+	exec *common.WorkflowExecution,
+) (*workflow.WorkflowExecutionInfo, error) {
+	for runID := exec.RunId; ; {
+		resp, err := cl.DescribeWorkflowExecution(cctx, exec.WorkflowId, runID)
+		if err != nil {
+			return nil, err
+		} else if resp.WorkflowExecutionInfo.GetStatus() != enums.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW {
+			return resp.WorkflowExecutionInfo, nil
+		}
+		event, err := cl.GetWorkflowHistory(
+			cctx, exec.WorkflowId, runID, false, enums.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT).Next()
+		if err != nil {
+			return nil, err
+		}
+		runID = event.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId()
+	}
+}
+
 func (c *TemporalWorkflowShowCommand) run(cctx *CommandContext, _ []string) error {
 	// Call describe
 	cl, err := c.Parent.ClientOptions.dialClient(cctx)
@@ -323,14 +856,27 @@ func (c *TemporalWorkflowShowCommand) run(cctx *CommandContext, _ []string) erro
 
 	// Print history
 	iter := &structuredHistoryIter{
-		ctx:            cctx,
-		client:         cl,
-		workflowID:     c.WorkflowId,
-		runID:          c.RunId,
-		includeDetails: c.EventDetails,
-		follow:         c.Follow,
-	}
-	if !cctx.JSONOutput {
+		ctx:                   cctx,
+		client:                cl,
+		workflowID:            c.WorkflowId,
+		runID:                 c.RunId,
+		includeDetails:        c.EventDetails || c.DecodedEventDetails,
+		jsonShorthandPayloads: c.DecodedEventDetails && cctx.JSONShorthandPayloads,
+		follow:                c.Follow,
+	}
+	if c.Parent.Parent.Output.Value == "timeline" {
+		state := tracer.NewWorkflowExecutionState(c.WorkflowId, c.RunId)
+		for {
+			e, err := iter.NextRawEvent()
+			if err != nil {
+				return fmt.Errorf("failed getting next history event: %w", err)
+			} else if e == nil {
+				break
+			}
+			state.Update(e)
+		}
+		return tracer.PrintTimeline(cctx.Printer.Output, state, tracer.TerminalWidth(), time.Now())
+	} else if !cctx.JSONOutput {
 		cctx.Printer.Println(color.MagentaString("Progress:"))
 		if err := iter.print(cctx.Printer); err != nil {
 			return fmt.Errorf("displaying history failed: %w", err)
@@ -338,6 +884,26 @@ func (c *TemporalWorkflowShowCommand) run(cctx *CommandContext, _ []string) erro
 		if err := printTextResult(cctx, iter.wfResult, 0); err != nil {
 			return err
 		}
+	} else if c.Parent.Parent.Output.Value == "jsonl" {
+		// Stream one event per line as they arrive instead of waiting for the
+		// whole history, without shorthand for the same reason as below
+		cctx.Printer.StartList()
+		defer cctx.Printer.EndList()
+		jsonPayloadShorthand := false
+		for {
+			e, err := iter.NextRawEvent()
+			if err != nil {
+				return fmt.Errorf("failed getting next history event: %w", err)
+			} else if e == nil {
+				break
+			}
+			err = cctx.Printer.PrintStructured(e, printer.StructuredOptions{
+				OverrideJSONPayloadShorthand: &jsonPayloadShorthand,
+			})
+			if err != nil {
+				return fmt.Errorf("failed printing structured output: %w", err)
+			}
+		}
 	} else {
 		events := make([]*history.HistoryEvent, 0)
 		for {
@@ -364,3 +930,237 @@ func (c *TemporalWorkflowShowCommand) run(cctx *CommandContext, _ []string) erro
 	}
 	return nil
 }
+
+type workflowUpdateRow struct {
+	UpdateId string `json:"updateId"`
+	Name     string `json:"name"`
+	Stage    string `json:"stage"`
+	Outcome  string `json:"outcome,omitempty"`
+	// Event ID of the most recent event for the update
+	EventId int64 `json:"eventId"`
+}
+
+func (c *TemporalWorkflowUpdatesListCommand) run(cctx *CommandContext, _ []string) error {
+	cl, err := c.Parent.Parent.ClientOptions.dialClient(cctx)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	rows, err := collectWorkflowUpdates(cctx, cl, c.WorkflowId, c.RunId)
+	if err != nil {
+		return err
+	}
+
+	// This is a listing command subject to json vs jsonl rules
+	if cctx.JSONOutput {
+		cctx.Printer.StartList()
+		defer cctx.Printer.EndList()
+		for _, row := range rows {
+			_ = cctx.Printer.PrintStructured(row, printer.StructuredOptions{})
+		}
+		return nil
+	} else if len(rows) == 0 {
+		cctx.Printer.Println("No updates found")
+		return nil
+	}
+	return cctx.Printer.PrintStructured(rows, printer.StructuredOptions{
+		Fields: []string{"UpdateId", "Name", "Stage", "Outcome"},
+		Table:  &printer.TableOptions{},
+	})
+}
+
+// Walks history of the given execution for update events, returning one row
+// per update in the order they first appear.
+func collectWorkflowUpdates(
+	cctx *CommandContext,
+	cl client.Client,
+	workflowID string,
+	runID string,
+) ([]*workflowUpdateRow, error) {
+	var rows []*workflowUpdateRow
+	rowsByID := map[string]*workflowUpdateRow{}
+	rowFor := func(updateID string) *workflowUpdateRow {
+		row := rowsByID[updateID]
+		if row == nil {
+			row = &workflowUpdateRow{UpdateId: updateID}
+			rowsByID[updateID] = row
+			rows = append(rows, row)
+		}
+		return row
+	}
+	iter := cl.GetWorkflowHistory(cctx, workflowID, runID, false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed getting history: %w", err)
+		}
+		switch event.EventType {
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ADMITTED:
+			req := event.GetWorkflowExecutionUpdateAdmittedEventAttributes().GetRequest()
+			row := rowFor(req.GetMeta().GetUpdateId())
+			row.Name, row.Stage, row.EventId = req.GetInput().GetName(), "Admitted", event.EventId
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+			req := event.GetWorkflowExecutionUpdateAcceptedEventAttributes().GetAcceptedRequest()
+			row := rowFor(req.GetMeta().GetUpdateId())
+			row.Name, row.Stage, row.EventId = req.GetInput().GetName(), "Accepted", event.EventId
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_REJECTED:
+			attrs := event.GetWorkflowExecutionUpdateRejectedEventAttributes()
+			req := attrs.GetRejectedRequest()
+			row := rowFor(req.GetMeta().GetUpdateId())
+			row.Name, row.Stage, row.EventId = req.GetInput().GetName(), "Rejected", event.EventId
+			row.Outcome = "Failure: " + attrs.GetFailure().GetMessage()
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_COMPLETED:
+			attrs := event.GetWorkflowExecutionUpdateCompletedEventAttributes()
+			row := rowFor(attrs.GetMeta().GetUpdateId())
+			row.Stage, row.EventId = "Completed", event.EventId
+			if failure := attrs.GetOutcome().GetFailure(); failure != nil {
+				row.Outcome = "Failure: " + failure.GetMessage()
+			} else {
+				row.Outcome = "Success"
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (c *TemporalWorkflowViewCommand) run(cctx *CommandContext, args []string) error {
+	raw, err := readFileDecompressed(c.HistoryFile)
+	if err != nil {
+		return err
+	}
+	hist, err := historyFromJSON(raw)
+	if err != nil {
+		return fmt.Errorf("failed reading history: %w", err)
+	} else if len(hist.Events) == 0 {
+		return fmt.Errorf("history has no events")
+	}
+	workflowID := c.WorkflowId
+	if workflowID == "" {
+		workflowID = strings.TrimSuffix(filepath.Base(c.HistoryFile), filepath.Ext(c.HistoryFile))
+	}
+	// Keep the original run ID so links within the history still make sense
+	runID := hist.Events[0].GetWorkflowExecutionStartedEventAttributes().GetOriginalExecutionRunId()
+	if runID == "" {
+		runID = uuid.NewString()
+	}
+
+	// History import requires global namespace mode
+	opts := devserver.StartOptions{
+		FrontendIP:             "127.0.0.1",
+		FrontendPort:           c.Port,
+		UIIP:                   "127.0.0.1",
+		UIPort:                 c.UiPort,
+		Namespaces:             []string{"default"},
+		Logger:                 cctx.Logger,
+		ClusterID:              uuid.NewString(),
+		MasterClusterName:      "active",
+		CurrentClusterName:     "active",
+		InitialFailoverVersion: 1,
+		EnableGlobalNamespace:  true,
+		// The server is noisy and only here to serve the UI
+		LogLevel: slog.LevelError,
+	}
+	if opts.FrontendPort == 0 {
+		opts.FrontendPort = devserver.MustGetFreePort(opts.FrontendIP)
+	}
+	if opts.UIPort == 0 {
+		opts.UIPort = devserver.MustGetFreePort(opts.UIIP)
+	}
+	s, err := devserver.Start(opts)
+	if err != nil {
+		return fmt.Errorf("failed starting server: %w", err)
+	}
+	defer s.Stop()
+
+	cctx.Logger.Info("Importing history", "workflowId", workflowID, "runId", runID, "events", len(hist.Events))
+	if err := importWorkflowHistory(cctx, net.JoinHostPort(opts.FrontendIP, strconv.Itoa(opts.FrontendPort)),
+		&common.WorkflowExecution{WorkflowId: workflowID, RunId: runID}, hist.Events); err != nil {
+		return err
+	}
+
+	uiURL := fmt.Sprintf("http://localhost:%v/namespaces/default/workflows/%v/%v/history",
+		opts.UIPort, url.PathEscape(workflowID), url.PathEscape(runID))
+	cctx.Printer.Printlnf("%-16s %v", "Temporal server:", net.JoinHostPort("localhost", strconv.Itoa(opts.FrontendPort)))
+	cctx.Printer.Printlnf("%-16s %v", "Web UI:", uiURL)
+	if !c.NoBrowser {
+		if err := openBrowser(uiURL); err != nil {
+			cctx.Logger.Warn("Failed opening browser, open the Web UI address instead", "error", err)
+		}
+	}
+	cctx.Printer.Println("Press Ctrl+C to stop the server and discard the history")
+	<-cctx.Done()
+	cctx.Printer.Println("Stopping server...")
+	return nil
+}
+
+// Imports the events as a closed or running execution using the admin
+// service, one event per batch since exported histories do not record how
+// events were batched.
+func importWorkflowHistory(
+	cctx *CommandContext,
+	address string,
+	execution *common.WorkflowExecution,
+	events []*history.HistoryEvent,
+) error {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed connecting to server: %w", err)
+	}
+	defer conn.Close()
+	adminClient := adminservice.NewAdminServiceClient(conn)
+
+	serializer := serialization.NewSerializer()
+	versionHistory := &historyspb.VersionHistory{}
+	batches := make([]*common.DataBlob, 0, len(events))
+	for _, event := range events {
+		item := versionhistory.NewVersionHistoryItem(event.EventId, event.Version)
+		if err := versionhistory.AddOrUpdateVersionHistoryItem(versionHistory, item); err != nil {
+			return fmt.Errorf("invalid version of event %v: %w", event.EventId, err)
+		}
+		blob, err := serializer.SerializeEvents([]*history.HistoryEvent{event}, enums.ENCODING_TYPE_PROTO3)
+		if err != nil {
+			return fmt.Errorf("failed serializing event %v: %w", event.EventId, err)
+		}
+		batches = append(batches, blob)
+	}
+	resp, err := adminClient.ImportWorkflowExecution(cctx, &adminservice.ImportWorkflowExecutionRequest{
+		Namespace:      "default",
+		Execution:      execution,
+		HistoryBatches: batches,
+		VersionHistory: versionHistory,
+	})
+	if err != nil {
+		return fmt.Errorf("failed importing history: %w", err)
+	}
+	// An import without events commits it
+	_, err = adminClient.ImportWorkflowExecution(cctx, &adminservice.ImportWorkflowExecutionRequest{
+		Namespace:      "default",
+		Execution:      execution,
+		HistoryBatches: []*common.DataBlob{},
+		VersionHistory: versionHistory,
+		Token:          resp.Token,
+	})
+	if err != nil {
+		return fmt.Errorf("failed committing imported history: %w", err)
+	}
+	return nil
+}
+
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Do not leave a zombie behind
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -3,18 +3,30 @@ package temporalcli_test
 import (
 	"context"
 	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"go.temporal.io/api/common/v1"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/temporalio/cli/temporalcli"
+	"github.com/temporalio/cli/temporalcli/devserver"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/workflow"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
 )
 
 func (s *SharedServerSuite) TestWorkflow_Describe_ActivityFailing() {
@@ -106,6 +118,114 @@ func (s *SharedServerSuite) TestWorkflow_Describe_Completed() {
 	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
 	s.NotNil(jsonOut["closeEvent"])
 	s.Equal(map[string]any{"foo": "bar"}, jsonOut["result"])
+
+	// YAML has the same structure as JSON
+	res = s.Execute(
+		"workflow", "describe",
+		"-o", "yaml",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+	)
+	s.NoError(res.Err)
+	var yamlOut map[string]any
+	s.NoError(yaml.Unmarshal(res.Stdout.Bytes(), &yamlOut))
+	s.Equal(jsonOut["result"], yamlOut["result"])
+	s.Equal(jsonPath(jsonOut, "closeEvent", "eventId"), jsonPath(yamlOut, "closeEvent", "eventId"))
+	s.Contains(res.Stdout.String(), "\nresult:\n  foo: bar\n")
+}
+
+func (s *SharedServerSuite) TestWorkflow_Describe_ExtendedInfo() {
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Reset so the new run has a different run than the one it started with
+	resetResp, err := s.Client.ResetWorkflowExecution(s.Context, &workflowservice.ResetWorkflowExecutionRequest{
+		Namespace:                 "default",
+		WorkflowExecution:         &common.WorkflowExecution{WorkflowId: run.GetID(), RunId: run.GetRunID()},
+		Reason:                    "test",
+		WorkflowTaskFinishEventId: 4,
+	})
+	s.NoError(err)
+
+	// Text
+	res := s.Execute(
+		"workflow", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-r", resetResp.RunId,
+		"--include-raw-history-stats",
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.Contains(out, "Extended Info:")
+	s.ContainsOnSameLine(out, "FirstRunId", run.GetRunID())
+	s.ContainsOnSameLine(out, "ResetFromRunId", run.GetRunID())
+	s.ContainsOnSameLine(out, "ExecutionDuration")
+
+	// JSON
+	res = s.Execute(
+		"workflow", "describe",
+		"-o", "json",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-r", run.GetRunID(),
+		"--include-raw-history-stats",
+	)
+	s.NoError(res.Err)
+	var jsonOut struct {
+		ExtendedInfo map[string]any `json:"extendedInfo"`
+	}
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Equal(run.GetRunID(), jsonOut.ExtendedInfo["firstRunId"])
+	s.NotContains(jsonOut.ExtendedInfo, "resetFromRunId")
+	s.Greater(jsonOut.ExtendedInfo["historyLength"], float64(0))
+	s.Greater(jsonOut.ExtendedInfo["historySizeBytes"], float64(0))
+	s.NotEmpty(jsonOut.ExtendedInfo["executionDuration"])
+}
+
+func (s *SharedServerSuite) TestWorkflow_Describe_Memo() {
+	memoFile := filepath.Join(s.T().TempDir(), "memo.json")
+	s.NoError(os.WriteFile(memoFile, []byte(`{"nested": [1, 2]}`+"\n"), 0644))
+
+	// Start with inline and file memo
+	workflowID := "memo-" + uuid.NewString()
+	res := s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--workflow-id", workflowID,
+		"--memo", `inlineMemo="some value"`,
+		"--memo-file", "fileMemo="+memoFile,
+	)
+	s.NoError(res.Err)
+
+	// Text shows decoded values
+	res = s.Execute(
+		"workflow", "describe",
+		"--address", s.Address(),
+		"-w", workflowID,
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "Memo", "inlineMemo", `"some value"`)
+	s.ContainsOnSameLine(out, "Memo", "fileMemo", `{"nested":[1,2]}`)
+
+	// Bad file fails start
+	res = s.Execute(
+		"workflow", "start",
+		"--address", s.Address(),
+		"--task-queue", s.Worker().Options.TaskQueue,
+		"--type", "DevWorkflow",
+		"--memo-file", "fileMemo="+filepath.Join(s.T().TempDir(), "missing.json"),
+	)
+	s.ErrorContains(res.Err, `failed reading file for key "fileMemo"`)
 }
 
 func (s *SharedServerSuite) TestWorkflow_Describe_NotDecodable() {
@@ -249,6 +369,94 @@ func (s *SharedServerSuite) testWorkflowShowFollow(eventDetails bool) {
 	s.NoError(run.Get(s.Context, nil))
 }
 
+func (s *SharedServerSuite) TestWorkflow_Show_RawEnums() {
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Short names by default
+	res := s.Execute(
+		"workflow", "show",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--event-details",
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.Contains(out, "WorkflowExecutionStarted")
+	s.Contains(out, `"Normal"`)
+	s.NotContains(out, "TASK_QUEUE_KIND_NORMAL")
+
+	// Full names with raw enums
+	res = s.Execute(
+		"workflow", "show",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--event-details",
+		"--raw-enums",
+	)
+	s.NoError(res.Err)
+	out = res.Stdout.String()
+	s.Contains(out, "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED")
+	s.Contains(out, "TASK_QUEUE_KIND_NORMAL")
+}
+
+func (s *SharedServerSuite) TestWorkflow_Show_FollowReconnect() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		workflow.GetSignalChannel(ctx, "my-signal").Receive(ctx, nil)
+		return "hi!", nil
+	})
+
+	// Fail the second history call, which is the first long poll, with an error
+	// the SDK does not retry itself
+	var historyCalls atomic.Int32
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption,
+		) error {
+			if strings.HasSuffix(method, "/GetWorkflowExecutionHistory") && historyCalls.Add(1) == 2 {
+				return status.Error(codes.DeadlineExceeded, "intentional error")
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+
+	outputCh := make(chan *CommandResult, 1)
+	go func() {
+		outputCh <- s.Execute("workflow", "show", "--address", s.Address(), "-w", run.GetID(), "--follow")
+	}()
+	s.Eventually(func() bool { return historyCalls.Load() > 2 }, 10*time.Second, 100*time.Millisecond)
+	s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "my-signal", nil))
+
+	res := <-outputCh
+	s.NoError(res.Err)
+	output := res.Stdout.String()
+	s.Contains(output, "Connection lost")
+	s.Contains(output, "Reconnected, resuming after event")
+	// Events seen before reconnect are not repeated
+	s.Equal(1, strings.Count(output, "WorkflowExecutionStarted"))
+	s.Equal(1, strings.Count(output, "WorkflowExecutionSignaled"))
+	s.ContainsOnSameLine(output, "Result", `"hi!"`)
+}
+
 func (s *SharedServerSuite) TestWorkflow_Show_NoFollow() {
 	s.testWorkflowShowNoFollow(true)
 	s.testWorkflowShowNoFollow(false)
@@ -354,6 +562,87 @@ func (s *SharedServerSuite) TestWorkflow_Show_JSON() {
 	s.NotContains(out, "Results:")
 }
 
+func (s *SharedServerSuite) TestWorkflow_Show_JSONL() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"workflow-param",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// One event per line, without shorthand payloads
+	res := s.Execute(
+		"workflow", "show",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "jsonl",
+	)
+	s.NoError(res.Err)
+	lines := strings.Split(strings.TrimSpace(res.Stdout.String()), "\n")
+	s.Len(lines, 5)
+	for i, line := range lines {
+		var event map[string]any
+		s.NoError(json.Unmarshal([]byte(line), &event))
+		s.Equal(strconv.Itoa(i+1), event["eventId"])
+	}
+	s.Contains(lines[0], `"eventType":"EVENT_TYPE_WORKFLOW_EXECUTION_STARTED"`)
+	s.Contains(lines[0], base64.StdEncoding.EncodeToString([]byte(`"workflow-param"`)))
+
+	// History file commands accept it
+	historyFile := filepath.Join(s.T().TempDir(), "history.jsonl")
+	s.NoError(os.WriteFile(historyFile, res.Stdout.Bytes(), 0o644))
+	res = s.Execute("workflow", "fix-history-json", "--source", historyFile)
+	s.NoError(res.Err)
+	var hist map[string][]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &hist))
+	s.Len(hist["events"], 5)
+}
+
+func (s *SharedServerSuite) TestWorkflow_Show_Timeline() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: 10 * time.Second})
+		if err := workflow.ExecuteActivity(ctx, DevActivity, a).Get(ctx, nil); err != nil {
+			return nil, err
+		}
+		return nil, workflow.Sleep(ctx, 100*time.Millisecond)
+	})
+
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	res := s.Execute(
+		"workflow", "show",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "timeline",
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "DevWorkflow", "#", "completed")
+	s.ContainsOnSameLine(out, "DevActivity", "#", "completed")
+	s.ContainsOnSameLine(out, "Timer (100ms)", "#", "fired")
+
+	// Other commands do not support it
+	res = s.Execute(
+		"workflow", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "timeline",
+	)
+	s.ErrorContains(res.Err, "only supported by workflow show")
+}
+
 func (s *SharedServerSuite) TestWorkflow_List() {
 	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
 		return a, nil
@@ -394,6 +683,474 @@ func (s *SharedServerSuite) TestWorkflow_List() {
 	s.ContainsOnSameLine(out, "status", "WORKFLOW_EXECUTION_STATUS_COMPLETED")
 }
 
+func (s *SharedServerSuite) TestWorkflow_List_Long() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{
+			TaskQueue:        s.Worker().Options.TaskQueue,
+			Memo:             map[string]any{"customer": "acme", "order": map[string]any{"items": 2}},
+			SearchAttributes: map[string]any{"CustomKeywordField": "list-long"},
+		},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Visibility may lag, so wait until it is listed
+	var res *CommandResult
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "list",
+			"--address", s.Address(),
+			"--query", "CustomKeywordField = 'list-long'",
+			"--long",
+			"--memo-key", "customer",
+			"--memo-key", "order",
+			"--memo-key", "missing",
+			"--search-attribute-key", "CustomKeywordField",
+		)
+		s.NoError(res.Err)
+		return strings.Contains(res.Stdout.String(), run.GetID())
+	}, 10*time.Second, 200*time.Millisecond)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "RunId", "TaskQueue", "CloseTime", "Memo.customer", "Memo.order", "Memo.missing",
+		"SearchAttributes.CustomKeywordField")
+	s.ContainsOnSameLine(out, run.GetID(), run.GetRunID(), s.Worker().Options.TaskQueue, "acme", `{"items":2}`,
+		"list-long")
+}
+
+func (s *SharedServerSuite) TestWorkflow_List_GoTemplate() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{
+			TaskQueue:        s.Worker().Options.TaskQueue,
+			SearchAttributes: map[string]any{"CustomKeywordField": "go-template"},
+		},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Each listed execution is rendered on its own line
+	var res *CommandResult
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "list",
+			"--address", s.Address(),
+			"--query", "CustomKeywordField = 'go-template'",
+			"-o", "go-template={{.execution.workflowId}} {{.execution.runId}} {{.taskQueue}}",
+		)
+		s.NoError(res.Err)
+		return strings.Contains(res.Stdout.String(), run.GetID())
+	}, 10*time.Second, 200*time.Millisecond)
+	s.Equal(run.GetID()+" "+run.GetRunID()+" "+s.Worker().Options.TaskQueue+"\n", res.Stdout.String())
+
+	// Describe renders the whole response
+	res = s.Execute(
+		"workflow", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "go-template={{.workflowExecutionInfo.execution.workflowId}}",
+	)
+	s.NoError(res.Err)
+	s.Equal(run.GetID()+"\n", res.Stdout.String())
+
+	// Bad template
+	res = s.Execute(
+		"workflow", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "go-template={{.foo",
+	)
+	s.ErrorContains(res.Err, "invalid go-template")
+}
+
+func (s *SharedServerSuite) TestWorkflow_List_JQ() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{
+			TaskQueue:        s.Worker().Options.TaskQueue,
+			SearchAttributes: map[string]any{"CustomKeywordField": "jq"},
+		},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Each listed execution is filtered on its own, implying JSON
+	var res *CommandResult
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "list",
+			"--address", s.Address(),
+			"--query", "CustomKeywordField = 'jq'",
+			"--jq", ".execution.workflowId",
+		)
+		s.NoError(res.Err)
+		return strings.Contains(res.Stdout.String(), run.GetID())
+	}, 10*time.Second, 200*time.Millisecond)
+	s.Equal(run.GetID()+"\n", res.Stdout.String())
+
+	// Describe filters the whole response, compact with jsonl
+	res = s.Execute(
+		"workflow", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "jsonl",
+		"--jq", "{id: .workflowExecutionInfo.execution.workflowId, status: .workflowExecutionInfo.status}",
+	)
+	s.NoError(res.Err)
+	s.Equal(`{"id":"`+run.GetID()+`","status":"WORKFLOW_EXECUTION_STATUS_COMPLETED"}`+"\n", res.Stdout.String())
+
+	// Bad expression and output
+	res = s.Execute("workflow", "describe", "--address", s.Address(), "-w", run.GetID(), "--jq", ".foo[")
+	s.ErrorContains(res.Err, "invalid jq expression")
+	res = s.Execute("workflow", "describe", "--address", s.Address(), "-w", run.GetID(), "--jq", ".", "-o", "text")
+	s.ErrorContains(res.Err, "--jq can only be used with json or jsonl output")
+}
+
+func (s *SharedServerSuite) TestWorkflow_List_CSV() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{
+			TaskQueue:        s.Worker().Options.TaskQueue,
+			Memo:             map[string]any{"note": "has, comma"},
+			SearchAttributes: map[string]any{"CustomKeywordField": "list-csv"},
+		},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Same columns as text, with times as iso
+	var res *CommandResult
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "list",
+			"--address", s.Address(),
+			"--query", "CustomKeywordField = 'list-csv'",
+			"-o", "csv",
+		)
+		s.NoError(res.Err)
+		return strings.Contains(res.Stdout.String(), run.GetID())
+	}, 10*time.Second, 200*time.Millisecond)
+	records, err := csv.NewReader(&res.Stdout).ReadAll()
+	s.NoError(err)
+	s.Len(records, 2)
+	s.Equal([]string{"Status", "WorkflowId", "Type", "StartTime"}, records[0])
+	s.Equal([]string{"Completed", run.GetID(), "DevWorkflow"}, records[1][:3])
+	_, err = time.Parse(time.RFC3339, records[1][3])
+	s.NoError(err)
+
+	// Selected fields
+	res = s.Execute(
+		"workflow", "list",
+		"--address", s.Address(),
+		"--query", "CustomKeywordField = 'list-csv'",
+		"--memo-key", "note",
+		"-o", "csv",
+		"--fields", "memo.note,workflowid",
+	)
+	s.NoError(res.Err)
+	s.Equal("Memo.note,WorkflowId\n\"has, comma\","+run.GetID()+"\n", res.Stdout.String())
+
+	// Selected fields in text, including long ones
+	res = s.Execute(
+		"workflow", "list",
+		"--address", s.Address(),
+		"--query", "CustomKeywordField = 'list-csv'",
+		"--fields", "WorkflowId,TaskQueue",
+	)
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "WorkflowId", "TaskQueue")
+	s.ContainsOnSameLine(res.Stdout.String(), run.GetID(), s.Worker().Options.TaskQueue)
+	s.NotContains(res.Stdout.String(), "Status")
+
+	// Sections without the fields are skipped, but some must have them
+	res = s.Execute("workflow", "describe", "--address", s.Address(), "-w", run.GetID(), "--fields", "Status")
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "Status", "COMPLETED")
+	res = s.Execute("workflow", "describe", "--address", s.Address(), "-w", run.GetID(), "--fields", "Nope")
+	s.ErrorContains(res.Err, `unknown field "Nope"`)
+
+	// Fields are not used for JSON
+	res = s.Execute("workflow", "list", "--address", s.Address(), "--fields", "workflowid", "-o", "json")
+	s.ErrorContains(res.Err, "--fields can only be used with text, csv, or markdown output")
+}
+
+func (s *SharedServerSuite) TestWorkflow_List_Markdown() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{
+			TaskQueue:        s.Worker().Options.TaskQueue,
+			SearchAttributes: map[string]any{"CustomKeywordField": "list-markdown"},
+		},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Same columns as text, with times as iso
+	var res *CommandResult
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "list",
+			"--address", s.Address(),
+			"--query", "CustomKeywordField = 'list-markdown'",
+			"-o", "markdown",
+		)
+		s.NoError(res.Err)
+		return strings.Contains(res.Stdout.String(), run.GetID())
+	}, 10*time.Second, 200*time.Millisecond)
+	lines := strings.Split(strings.TrimSpace(res.Stdout.String()), "\n")
+	s.Len(lines, 3)
+	s.Regexp(`^\| Status +\| WorkflowId +\| Type +\| StartTime +\|$`, lines[0])
+	s.Regexp(`^\| -+ \| -+ \| -+ \| -+ \|$`, lines[1])
+	s.Regexp(`^\| Completed \| `+run.GetID()+` \| DevWorkflow \| \d{4}-\d{2}-\d{2}T\S+ \|$`, lines[2])
+
+	// Describe cards are name and value tables
+	res = s.Execute(
+		"workflow", "describe",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "markdown",
+	)
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "| Name ")
+	s.Regexp(`\| WorkflowId +\| `+run.GetID()+` +\|`, res.Stdout.String())
+}
+
+func (s *SharedServerSuite) TestWorkflow_List_GroupBy() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	for i := 0; i < 3; i++ {
+		run, err := s.Client.ExecuteWorkflow(
+			s.Context,
+			client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+			DevWorkflow,
+			strconv.Itoa(i),
+		)
+		s.NoError(err)
+		s.NoError(run.Get(s.Context, nil))
+	}
+	query := fmt.Sprintf(`TaskQueue="%s"`, s.Worker().Options.TaskQueue)
+
+	// Visibility may lag, so wait until all are counted
+	var res *CommandResult
+	s.Eventually(func() bool {
+		res = s.Execute(
+			"workflow", "list",
+			"--address", s.Address(),
+			"--query", query,
+			"--group-by", "WorkflowType",
+			"--group-samples", "2",
+		)
+		s.NoError(res.Err)
+		return AssertContainsOnSameLine(res.Stdout.String(), "DevWorkflow", "3", ", ...") == nil
+	}, 10*time.Second, 200*time.Millisecond)
+	s.ContainsOnSameLine(res.Stdout.String(), "WorkflowType", "Count", "Samples")
+
+	// JSON has nested samples
+	res = s.Execute(
+		"workflow", "list",
+		"--address", s.Address(),
+		"--query", query,
+		"--group-by", "ExecutionStatus",
+		"--group-samples", "2",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var groups []struct {
+		Group   string `json:"group"`
+		Count   int    `json:"count"`
+		Samples []struct {
+			Execution struct {
+				WorkflowId string `json:"workflowId"`
+			} `json:"execution"`
+		} `json:"samples"`
+	}
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &groups))
+	s.Len(groups, 1)
+	s.Equal("Completed", groups[0].Group)
+	s.Equal(3, groups[0].Count)
+	s.Len(groups[0].Samples, 2)
+	s.NotEmpty(groups[0].Samples[0].Execution.WorkflowId)
+}
+
+func (s *SharedServerSuite) TestWorkflow_CountEvents() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: 10 * time.Second})
+		for i := 0; i < 2; i++ {
+			if err := workflow.ExecuteActivity(ctx, DevActivity, "input").Get(ctx, nil); err != nil {
+				return nil, err
+			}
+		}
+		workflow.GetSignalChannel(ctx, "my-signal").Receive(ctx, nil)
+		return nil, nil
+	})
+	s.Worker().OnDevActivity(func(ctx context.Context, a any) (any, error) {
+		return strings.Repeat("a", 1000), nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "my-signal", "signal-arg"))
+	s.NoError(run.Get(s.Context, nil))
+
+	// Event types by default
+	res := s.Execute(
+		"workflow", "count-events",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+	)
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "ActivityTaskCompleted", "2", "kB")
+	s.ContainsOnSameLine(res.Stdout.String(), "WorkflowExecutionSignaled", "1")
+	s.Contains(res.Stdout.String(), "Total:")
+
+	// Activity type
+	res = s.Execute(
+		"workflow", "count-events",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--group-by", "activity-type",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var groups []struct {
+		Group        string `json:"group"`
+		Count        int    `json:"count"`
+		Bytes        int    `json:"bytes"`
+		PayloadBytes int    `json:"payloadBytes"`
+	}
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &groups))
+	s.Len(groups, 1)
+	s.Equal("DevActivity", groups[0].Group)
+	s.Equal(6, groups[0].Count)
+	s.Greater(groups[0].PayloadBytes, 2000)
+	s.Greater(groups[0].Bytes, groups[0].PayloadBytes)
+
+	// Signal name
+	res = s.Execute(
+		"workflow", "count-events",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--group-by", "signal-name",
+	)
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "my-signal", "1")
+	s.NotContains(res.Stdout.String(), "DevActivity")
+}
+
+func (s *SharedServerSuite) TestWorkflow_Children() {
+	// Parent starts a child which continues as new and then starts a grandchild
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		var next string
+		switch a {
+		case "parent":
+			next = "child"
+		case "child":
+			return nil, workflow.NewContinueAsNewError(ctx, DevWorkflow, "child-continued")
+		case "child-continued":
+			next = "grandchild"
+		default:
+			return a, nil
+		}
+		ctx = workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID + "-" + next,
+		})
+		return nil, workflow.ExecuteChildWorkflow(ctx, DevWorkflow, next).Get(ctx, nil)
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"parent",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+
+	// Text
+	res := s.Execute(
+		"workflow", "children",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+	)
+	s.NoError(res.Err)
+	out := res.Stdout.String()
+	s.ContainsOnSameLine(out, "Completed", run.GetID()+"-child", "DevWorkflow")
+	s.ContainsOnSameLine(out, "Completed", run.GetID()+"-child-grandchild", "DevWorkflow")
+
+	// JSON with limited depth
+	res = s.Execute(
+		"workflow", "children",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"--depth", "1",
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	var jsonOut []map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Len(jsonOut, 1)
+	s.Equal(run.GetID()+"-child", jsonOut[0]["workflowId"])
+	s.Equal(run.GetID(), jsonOut[0]["parentWorkflowId"])
+	s.Equal("Completed", jsonOut[0]["status"])
+
+	// Children that are gone, e.g. past retention, are listed as not found
+	s.CommandHarness.Options.AdditionalClientGRPCDialOptions = append(
+		s.CommandHarness.Options.AdditionalClientGRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+		) error {
+			if describe, ok := req.(*workflowservice.DescribeWorkflowExecutionRequest); ok &&
+				describe.Execution.GetWorkflowId() == run.GetID()+"-child" {
+				return status.Error(codes.NotFound, "workflow not found")
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+	)
+	res = s.Execute(
+		"workflow", "children",
+		"--address", s.Address(),
+		"-w", run.GetID(),
+		"-o", "json",
+	)
+	s.NoError(res.Err)
+	jsonOut = nil
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &jsonOut))
+	s.Len(jsonOut, 1)
+	s.Equal(run.GetID()+"-child", jsonOut[0]["workflowId"])
+	s.Equal("NotFound", jsonOut[0]["status"])
+}
+
 func (s *SharedServerSuite) TestWorkflow_Count() {
 	s.Worker().OnDevWorkflow(func(ctx workflow.Context, shouldComplete any) (any, error) {
 		// Only complete if shouldComplete is a true bool
@@ -480,3 +1237,110 @@ func (s *SharedServerSuite) TestWorkflow_Count() {
 	s.Contains(out, `{"groupValues":["Running"],"count":"2"}`)
 	s.Contains(out, `{"groupValues":["Completed"],"count":"3"}`)
 }
+
+func (s *SharedServerSuite) TestWorkflow_Updates_List() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, val any) (any, error) {
+		err := workflow.SetUpdateHandler(ctx, "add", func(ctx workflow.Context, i int) (int, error) {
+			if i < 0 {
+				return 0, fmt.Errorf("negative value")
+			}
+			return i, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		workflow.GetSignalChannel(ctx, "done").Receive(ctx, nil)
+		return nil, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+
+	// No updates yet
+	res := s.Execute("workflow", "updates", "list", "--address", s.Address(), "-w", run.GetID())
+	s.NoError(res.Err)
+	s.Contains(res.Stdout.String(), "No updates found")
+
+	// One successful and one failed update
+	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(),
+		"--name", "add", "--update-id", "good-update", "-i", "1")
+	s.NoError(res.Err)
+	res = s.Execute("workflow", "update", "--address", s.Address(), "-w", run.GetID(),
+		"--name", "add", "--update-id", "bad-update", "-i", "-1")
+	s.Error(res.Err)
+
+	res = s.Execute("workflow", "updates", "list", "--address", s.Address(), "-w", run.GetID())
+	s.NoError(res.Err)
+	s.ContainsOnSameLine(res.Stdout.String(), "good-update", "add", "Completed", "Success")
+	s.ContainsOnSameLine(res.Stdout.String(), "bad-update", "add", "Completed", "Failure: negative value")
+
+	res = s.Execute("workflow", "updates", "list", "--address", s.Address(), "-w", run.GetID(), "-o", "json")
+	s.NoError(res.Err)
+	var rows []map[string]any
+	s.NoError(json.Unmarshal(res.Stdout.Bytes(), &rows))
+	s.Len(rows, 2)
+	s.Equal("good-update", rows[0]["updateId"])
+	s.Equal("Success", rows[0]["outcome"])
+
+	s.NoError(s.Client.SignalWorkflow(s.Context, run.GetID(), "", "done", nil))
+}
+
+func (s *SharedServerSuite) TestWorkflow_View() {
+	s.Worker().OnDevWorkflow(func(ctx workflow.Context, a any) (any, error) {
+		return a, nil
+	})
+	run, err := s.Client.ExecuteWorkflow(
+		s.Context,
+		client.StartWorkflowOptions{TaskQueue: s.Worker().Options.TaskQueue},
+		DevWorkflow,
+		"ignored",
+	)
+	s.NoError(err)
+	s.NoError(run.Get(s.Context, nil))
+	res := s.Execute("workflow", "show", "--address", s.Address(), "-w", run.GetID(), "-o", "json")
+	s.NoError(res.Err)
+	historyFile := filepath.Join(s.T().TempDir(), "my-run.json")
+	s.NoError(os.WriteFile(historyFile, res.Stdout.Bytes(), 0644))
+
+	// Run on its own server in the background
+	h := NewCommandHarness(s.T())
+	defer h.Close()
+	port := strconv.Itoa(devserver.MustGetFreePort("127.0.0.1"))
+	resCh := make(chan *CommandResult, 1)
+	go func() {
+		resCh <- h.Execute("workflow", "view", "--history-file", historyFile, "--port", port, "--no-browser")
+	}()
+
+	// The history is imported under the file name and original run ID
+	var desc *workflowservice.DescribeWorkflowExecutionResponse
+	s.EventuallyWithT(func(t *assert.CollectT) {
+		select {
+		case res := <-resCh:
+			require.NoError(t, res.Err)
+			require.Fail(t, "got early view result")
+		default:
+		}
+		cl, err := client.Dial(client.Options{HostPort: "127.0.0.1:" + port})
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer cl.Close()
+		desc, err = cl.DescribeWorkflowExecution(context.Background(), "my-run", run.GetRunID())
+		assert.NoError(t, err)
+	}, 10*time.Second, 200*time.Millisecond)
+	s.Equal(enums.WORKFLOW_EXECUTION_STATUS_COMPLETED, desc.WorkflowExecutionInfo.Status)
+	s.Equal("DevWorkflow", desc.WorkflowExecutionInfo.Type.Name)
+
+	h.CancelContext()
+	select {
+	case <-time.After(20 * time.Second):
+		s.FailNow("didn't cleanup after 20 seconds")
+	case res = <-resCh:
+		s.NoError(res.Err)
+	}
+	s.Contains(res.Stdout.String(), "/namespaces/default/workflows/my-run/"+run.GetRunID()+"/history")
+}
@@ -194,10 +194,18 @@ func (c *Command) writeCode(w *codeWriter) error {
 	} else {
 		w.writeLinef("s.Command.Args = %v.NoArgs", w.importCobra())
 	}
-	if c.IgnoreMissingEnv {
+	if c.IgnoreMissingEnv || c.OfflineAllowed || len(c.OutputFormats) > 0 {
 		w.writeLinef("s.Command.Annotations = make(map[string]string)")
+	}
+	if c.IgnoreMissingEnv {
 		w.writeLinef("s.Command.Annotations[\"ignoresMissingEnv\"] = \"true\"")
 	}
+	if c.OfflineAllowed {
+		w.writeLinef("s.Command.Annotations[\"offlineAllowed\"] = \"true\"")
+	}
+	if len(c.OutputFormats) > 0 {
+		w.writeLinef("s.Command.Annotations[\"outputFormats\"] = %q", strings.Join(c.OutputFormats, ","))
+	}
 	// Add subcommands
 	for _, subCommand := range subCommands {
 		w.writeLinef("s.Command.AddCommand(&New%v(cctx, &s).Command)", subCommand.structName())
@@ -304,6 +312,8 @@ func (c *CommandOption) writeStructField(w *codeWriter) error {
 		goDataType = c.DataType
 	case "duration":
 		goDataType = "Duration"
+	case "float":
+		goDataType = "float64"
 	case "timestamp":
 		goDataType = "Timestamp"
 	case "string[]":
@@ -345,6 +355,11 @@ func (c *CommandOption) writeFlagBuilding(selfVar, flagVar string, w *codeWriter
 		if c.DefaultValue == "" {
 			defaultLit = ", 0"
 		}
+	case "float":
+		flagMeth, defaultLit = "Float64Var", ", "+c.DefaultValue
+		if c.DefaultValue == "" {
+			defaultLit = ", 0"
+		}
 	case "string":
 		flagMeth, defaultLit = "StringVar", fmt.Sprintf(", %q", c.DefaultValue)
 	case "string[]":
@@ -27,6 +27,8 @@ type Command struct {
 	ExactArgs        int
 	MaximumArgs      int
 	IgnoreMissingEnv bool
+	OfflineAllowed   bool
+	OutputFormats    []string
 }
 
 type CommandOptions struct {
@@ -121,6 +123,10 @@ func (c *Command) parseSection(section string) error {
 				}
 			case strings.HasPrefix(bullet, "* ignores-missing-env"):
 				c.IgnoreMissingEnv = true
+			case bullet == "* offline-allowed":
+				c.OfflineAllowed = true
+			case strings.HasPrefix(bullet, "* output-formats="):
+				c.OutputFormats = strings.Split(strings.TrimPrefix(bullet, "* output-formats="), ",")
 			default:
 				return fmt.Errorf("unrecognized attribute bullet: %q", bullet)
 			}
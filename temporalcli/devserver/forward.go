@@ -0,0 +1,69 @@
+package devserver
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+)
+
+// Forwards TCP connections accepted on a listener to a target address. This is
+// used to expose the frontend on more than the single IP the server binds to.
+type tcpForwarder struct {
+	listener net.Listener
+	target   string
+	logger   *slog.Logger
+	wg       sync.WaitGroup
+}
+
+func startTCPForwarder(listenAddr, target string, logger *slog.Logger) (*tcpForwarder, error) {
+	l, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, err
+	}
+	f := &tcpForwarder{listener: l, target: target, logger: logger}
+	f.wg.Add(1)
+	go f.acceptLoop()
+	return f, nil
+}
+
+func (f *tcpForwarder) acceptLoop() {
+	defer f.wg.Done()
+	for {
+		conn, err := f.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		} else if err != nil {
+			f.logger.Warn("Failed accepting forwarded connection", "address", f.listener.Addr(), "error", err)
+			continue
+		}
+		go f.forward(conn)
+	}
+}
+
+func (f *tcpForwarder) forward(conn net.Conn) {
+	defer conn.Close()
+	targetConn, err := net.Dial("tcp", f.target)
+	if err != nil {
+		f.logger.Warn("Failed dialing forward target", "target", f.target, "error", err)
+		return
+	}
+	defer targetConn.Close()
+	// Copy both ways, closing both sides once either is done
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(targetConn, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, targetConn)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+func (f *tcpForwarder) stop() {
+	_ = f.listener.Close()
+	f.wg.Wait()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"runtime"
+	"strconv"
 )
 
 // Returns a TCP port that is available to listen on, for the given (local) host.
@@ -21,7 +22,7 @@ import (
 // in this regard; on that platform, `SO_REUSEADDR` has a different meaning and
 // should not be set (setting it may have unpredictable consequences).
 func GetFreePort(host string) (int, error) {
-	l, err := net.Listen("tcp", host+":0")
+	l, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
 	if err != nil {
 		return 0, fmt.Errorf("failed to assign a free port: %v", err)
 	}
@@ -86,7 +87,7 @@ func MustGetFreePort(host string) int {
 // Asserts that the given TCP port is available to listen on, for the given
 // (local) host; return an error if it is not.
 func CheckPortFree(host string, port int) error {
-	l, err := net.Listen("tcp", fmt.Sprintf("%s:%d", host, port))
+	l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
 	if err != nil {
 		return err
 	}
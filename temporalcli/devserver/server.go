@@ -30,6 +30,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -48,6 +49,7 @@ import (
 	sqliteschema "go.temporal.io/server/schema/sqlite"
 	"go.temporal.io/server/temporal"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"gopkg.in/yaml.v3"
 )
 
@@ -77,11 +79,19 @@ type StartOptions struct {
 	DynamicConfigValues   map[string]any
 	LogConfig             func([]byte)
 	GRPCInterceptors      []grpc.UnaryServerInterceptor
+	// Keyed by namespace, only applied when the namespace is created
+	NamespaceRetention map[string]time.Duration
+	// Keyed by namespace
+	NamespaceMaxHistoryEvents map[string]int
+	// Additional IPs that accept frontend connections on the frontend port and
+	// forward them to the frontend IP
+	AdditionalFrontendIPs []string
 }
 
 type Server struct {
-	server temporal.Server
-	ui     *uiserver.Server
+	server     temporal.Server
+	ui         *uiserver.Server
+	forwarders []*tcpForwarder
 }
 
 func Start(options StartOptions) (*Server, error) {
@@ -116,6 +126,28 @@ func Start(options StartOptions) (*Server, error) {
 		return nil, err
 	}
 
+	s := &Server{server: server, ui: ui}
+
+	// Forward additional IPs to the frontend, connecting via loopback if the
+	// frontend is bound to all interfaces
+	target := options.FrontendIP
+	if ip := net.ParseIP(target); ip != nil && ip.IsUnspecified() {
+		if ip.To4() != nil {
+			target = "127.0.0.1"
+		} else {
+			target = "::1"
+		}
+	}
+	target = net.JoinHostPort(target, strconv.Itoa(options.FrontendPort))
+	for _, ip := range options.AdditionalFrontendIPs {
+		f, err := startTCPForwarder(net.JoinHostPort(ip, strconv.Itoa(options.FrontendPort)), target, options.Logger)
+		if err != nil {
+			s.stopForwarders()
+			return nil, fmt.Errorf("failed listening on %v: %w", ip, err)
+		}
+		s.forwarders = append(s.forwarders, f)
+	}
+
 	// Start. We have to start UI server in background because it's start call is
 	// blocking. Therefore we have no way to relay error out to users, so we just
 	// log and panic.
@@ -128,13 +160,14 @@ func Start(options StartOptions) (*Server, error) {
 		}()
 	}
 	if err := server.Start(); err != nil {
-		// Stop UI before returning to avoid leaks
+		// Stop UI and forwarders before returning to avoid leaks
 		if ui != nil {
 			ui.Stop()
 		}
+		s.stopForwarders()
 		return nil, err
 	}
-	return &Server{server, ui}, nil
+	return s, nil
 }
 
 func (s *Server) Stop() {
@@ -142,13 +175,20 @@ func (s *Server) Stop() {
 		s.ui.Stop()
 	}
 	s.server.Stop()
+	s.stopForwarders()
+}
+
+func (s *Server) stopForwarders() {
+	for _, f := range s.forwarders {
+		f.stop()
+	}
 }
 
 func (s *StartOptions) buildUIServer() *uiserver.Server {
 	return uiserver.NewServer(uiserveroptions.WithConfigProvider(&uiconfig.Config{
 		Host:                s.UIIP,
 		Port:                s.UIPort,
-		TemporalGRPCAddress: fmt.Sprintf("%v:%v", s.FrontendIP, s.FrontendPort),
+		TemporalGRPCAddress: net.JoinHostPort(s.FrontendIP, strconv.Itoa(s.FrontendPort)),
 		EnableUI:            true,
 		UIAssetPath:         s.UIAssetPath,
 		Codec:               uiconfig.Codec{Endpoint: s.UICodecEndpoint},
@@ -210,6 +250,21 @@ func (s *StartOptions) buildServerOptions() ([]temporal.ServerOption, error) {
 	for k, v := range s.DynamicConfigValues {
 		dynConf[dynamicconfig.Key(k)] = v
 	}
+	// Per-namespace history event limits, keeping any unconstrained value as the
+	// fallback
+	if len(s.NamespaceMaxHistoryEvents) > 0 {
+		values := make([]dynamicconfig.ConstrainedValue, 0, len(s.NamespaceMaxHistoryEvents)+1)
+		for ns, count := range s.NamespaceMaxHistoryEvents {
+			values = append(values, dynamicconfig.ConstrainedValue{
+				Constraints: dynamicconfig.Constraints{Namespace: ns},
+				Value:       count,
+			})
+		}
+		if v, ok := dynConf[dynamicconfig.HistoryCountLimitError]; ok {
+			values = append(values, dynamicconfig.ConstrainedValue{Value: v})
+		}
+		dynConf[dynamicconfig.HistoryCountLimitError] = values
+	}
 	opts = append(opts, temporal.WithDynamicConfigClient(dynConf))
 
 	// gRPC interceptors if set
@@ -228,7 +283,7 @@ func (s *StartOptions) buildServerConfig() (*config.Config, error) {
 	if conf.Global.Metrics == nil && s.MetricsPort > 0 {
 		conf.Global.Metrics = &metrics.Config{
 			Prometheus: &metrics.PrometheusConfig{
-				ListenAddress: fmt.Sprintf("%v:%v", s.FrontendIP, s.MetricsPort),
+				ListenAddress: net.JoinHostPort(s.FrontendIP, strconv.Itoa(s.MetricsPort)),
 				HandlerPath:   "/metrics",
 			},
 		}
@@ -313,6 +368,9 @@ func (s *StartOptions) buildSQLConfig() (*config.SQL, error) {
 	namespaces := make([]*sqliteschema.NamespaceConfig, len(s.Namespaces))
 	for i, ns := range s.Namespaces {
 		namespaces[i] = sqlite.NewNamespaceConfig(s.CurrentClusterName, ns, false)
+		if retention, ok := s.NamespaceRetention[ns]; ok {
+			namespaces[i].Detail.Config.Retention = durationpb.New(retention)
+		}
 	}
 	if err := sqliteschema.CreateNamespaces(&conf, namespaces...); err != nil {
 		return nil, fmt.Errorf("failed creating namespaces: %w", err)
@@ -0,0 +1,179 @@
+package temporalcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/pflag"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/temporalproto"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Opens the message as YAML in the user's editor and returns the edited
+// message, or nil if nothing was changed. The edited document must be a valid
+// message with no unknown fields. The changes are printed as a diff.
+func (c *CommandContext) editAsYAML(kind string, msg proto.Message) (proto.Message, error) {
+	before, err := c.protoToYAML(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting %v to YAML: %w", kind, err)
+	}
+	f, err := os.CreateTemp("", "temporal-edit-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed creating file to edit: %w", err)
+	}
+	header := fmt.Sprintf("# Edit the %v below, then save and close the editor to apply the changes.\n"+
+		"# Leave it unchanged to cancel.\n", kind)
+	_, err = f.WriteString(header + string(before))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("failed writing file to edit: %w", err)
+	}
+	// The file is kept if the edit is invalid so the work is not lost
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(f.Name())
+		}
+	}()
+
+	if err := c.runEditor(f.Name()); err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed reading edited file: %w", err)
+	}
+	edited := msg.ProtoReflect().New().Interface()
+	if err := c.yamlToProto(b, edited); err != nil {
+		keep = true
+		return nil, fmt.Errorf("invalid %v, edited file kept at %v: %w", kind, f.Name(), err)
+	}
+	after, err := c.protoToYAML(edited)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting %v to YAML: %w", kind, err)
+	}
+	if bytes.Equal(before, after) {
+		return nil, nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        yamlLines(before),
+		B:        yamlLines(after),
+		FromFile: "current",
+		ToFile:   "edited",
+		Context:  3,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed diffing %v: %w", kind, err)
+	}
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			c.Printer.Print(color.New(color.Bold).Sprint(line))
+		case strings.HasPrefix(line, "+"):
+			c.Printer.Print(color.GreenString("%s", line))
+		case strings.HasPrefix(line, "-"):
+			c.Printer.Print(color.RedString("%s", line))
+		case strings.HasPrefix(line, "@@"):
+			c.Printer.Print(color.CyanString("%s", line))
+		default:
+			c.Printer.Print(line)
+		}
+	}
+	return edited, nil
+}
+
+// Splits marshaled YAML, which always ends in a newline, into lines.
+func yamlLines(b []byte) []string {
+	lines := strings.SplitAfter(string(b), "\n")
+	return lines[:len(lines)-1]
+}
+
+// Runs $VISUAL or $EDITOR, defaulting to vi (or notepad on Windows), on the
+// file. The editor may include arguments, e.g. "code --wait".
+func (c *CommandContext) runEditor(file string) error {
+	editor, _ := c.Options.LookupEnv("VISUAL")
+	if editor == "" {
+		editor, _ = c.Options.LookupEnv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	args := append(strings.Fields(editor), file)
+	cmd := exec.CommandContext(c, args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = c.Options.Stdin, c.Options.Stderr, c.Options.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %v failed: %w", args[0], err)
+	}
+	return nil
+}
+
+// Converts through protobuf JSON, keeping the field order but using block
+// style throughout.
+func (c *CommandContext) protoToYAML(msg proto.Message) ([]byte, error) {
+	b, err := c.MarshalProtoJSONWithOptions(msg, c.JSONShorthandPayloads)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	var clearStyle func(*yaml.Node)
+	clearStyle = func(n *yaml.Node) {
+		n.Style = 0
+		for _, child := range n.Content {
+			clearStyle(child)
+		}
+	}
+	clearStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+func (c *CommandContext) yamlToProto(b []byte, msg proto.Message) error {
+	var v any
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	// An emptied document is an empty message
+	if v == nil {
+		v = map[string]any{}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	opts := temporalproto.CustomJSONUnmarshalOptions{}
+	if c.JSONShorthandPayloads {
+		opts.Metadata = map[string]any{common.EnablePayloadShorthandMetadataKey: true}
+	}
+	return opts.Unmarshal(b, msg)
+}
+
+// Fails if any of the flags other than --edit and the allowed ones were given
+// on the command line, since --edit replaces them.
+func checkOnlyEditFlags(cctx *CommandContext, flags *pflag.FlagSet, allowed ...string) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err == nil && flag.Name != "edit" && !slices.Contains(allowed, flag.Name) && cctx.flagSource(flag) == "flag" {
+			err = fmt.Errorf("--%v cannot be used with --edit", flag.Name)
+		}
+	})
+	return err
+}
@@ -1,6 +1,8 @@
 package printer
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,13 +10,18 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/itchyny/gojq"
 	"github.com/olekukonko/tablewriter"
 	"go.temporal.io/api/common/v1"
 	"go.temporal.io/api/temporalproto"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gopkg.in/yaml.v3"
 )
 
 type Colorer func(string, ...interface{}) string
@@ -30,26 +37,65 @@ type Printer struct {
 	FormatTime func(time.Time) string
 	// Only used for non-JSON, defaults to color.Magenta
 	TableHeaderColorer Colorer
+	// Only used for non-JSON. If set, enums are shown with their full proto
+	// names instead of their short names.
+	RawEnums bool
+	// If set, JSON must also be set and each structured value is decoded from
+	// its JSON form and rendered through this template instead of printed as
+	// JSON. JSONIndent should be empty.
+	Template *template.Template
+	// If set, JSON must also be set and each structured value is decoded from its
+	// JSON form and run through this jq program. String results are printed
+	// as-is, other results as JSON with JSONIndent.
+	JQ *gojq.Code
+	// If set, JSON must also be set and each structured value is converted from
+	// its JSON form to a YAML document. Lists are multiple documents.
+	YAML bool
+	// If set, structured values are printed as CSV with the same columns as text
+	// tables, and plain text printing is ignored. JSON must not be set.
+	CSV bool
+	// If set, JSON must not be set and tables and cards are printed as Markdown
+	// tables with escaped cells. Plain text is printed as-is, with a blank line
+	// between it and any table.
+	Markdown bool
+	// Ignored for JSON. If set, the columns of tables and cards to print in this
+	// order, matched case-insensitively against the columns that would otherwise
+	// be printed. For text output, fields a structure does not have are skipped,
+	// and so are tables and cards that have none of them. FieldsErr reports if
+	// none of the printed ones had any.
+	Fields []string
 
 	listMode          bool
 	listModeFirstJSON bool // True until first JSON printed
+	listModeCSVHeader bool // True once CSV header printed
+
+	fieldsMatched bool  // True once any table or card had one of Fields
+	fieldsErr     error // Set for the first table or card with none of Fields
+
+	markdownWritten bool // True once anything printed
+	markdownInTable bool // True after a table row until plain text printed
 }
 
-// Ignored during JSON output
+// Ignored during JSON and CSV output
 func (p *Printer) Print(s ...string) {
-	if !p.JSON {
+	if !p.JSON && !p.CSV {
+		// Text right after a Markdown table would be another row
+		if p.markdownInTable {
+			p.markdownInTable = false
+			p.writeStr("\n")
+		}
 		for _, v := range s {
 			p.writeStr(v)
 		}
 	}
 }
 
-// Ignored during JSON output
+// Ignored during JSON and CSV output
 func (p *Printer) Println(s ...string) {
 	p.Print(append(append([]string{}, s...), "\n")...)
 }
 
-// Ignored during JSON output
+// Ignored during JSON and CSV output
 func (p *Printer) Printlnf(s string, v ...any) {
 	p.Println(fmt.Sprintf(s, v...))
 }
@@ -67,9 +113,9 @@ func (p *Printer) StartList() {
 	if p.listMode {
 		panic("already in list mode")
 	}
-	p.listMode, p.listModeFirstJSON = true, true
+	p.listMode, p.listModeFirstJSON, p.listModeCSVHeader = true, true, false
 	// Write initial bracket when non-jsonl
-	if p.JSON && p.JSONIndent != "" {
+	if p.JSON && p.JSONIndent != "" && p.JQ == nil {
 		// Don't need newline, we count on initial object to do that
 		p.Output.Write([]byte("["))
 	}
@@ -81,9 +127,9 @@ func (p *Printer) EndList() {
 	if !p.listMode {
 		panic("not in list mode")
 	}
-	p.listMode, p.listModeFirstJSON = false, false
+	p.listMode, p.listModeFirstJSON, p.listModeCSVHeader = false, false, false
 	// Write ending bracket when non-jsonl
-	if p.JSON && p.JSONIndent != "" {
+	if p.JSON && p.JSONIndent != "" && p.JQ == nil {
 		// We prepend a newline because non-jsonl list mode doesn't do so after each
 		// line to help with commas
 		p.Output.Write([]byte("\n]\n"))
@@ -126,13 +172,32 @@ func (p *Printer) PrintStructured(v any, options StructuredOptions) error {
 		return p.printJSON(v, options)
 	}
 
+	// CSV
+	if p.CSV {
+		return p.printCSV(v, options)
+	}
+
 	// Get data
 	cols := options.toPredefinedCols()
+	if len(p.Fields) > 0 {
+		typ := reflect.TypeOf(v)
+		if typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		var err error
+		if cols, err = p.selectCols(typ, options); err != nil {
+			return err
+		} else if len(cols) == 0 {
+			return nil
+		}
+	}
 	cols, rows, err := p.tableData(cols, v)
 	if err != nil {
 		return err
 	}
-	cols = adjustColsToOptions(cols, options)
+	if len(p.Fields) == 0 {
+		cols = adjustColsToOptions(cols, options)
+	}
 
 	// Text table
 	if options.Table != nil {
@@ -154,7 +219,38 @@ type PrintStructuredIter interface {
 // Fields must be present for table
 func (p *Printer) PrintStructuredIter(typ reflect.Type, iter PrintStructuredIter, options StructuredOptions) error {
 	cols := options.toPredefinedCols()
-	if !p.JSON {
+	if p.CSV {
+		var err error
+		if cols, err = p.selectCols(typ, options); err != nil {
+			return err
+		}
+		w := csv.NewWriter(p.Output)
+		if options.Table == nil || !options.Table.NoHeader {
+			p.writeCSVHeader(w, cols)
+		}
+		for {
+			v, err := iter.Next()
+			if v == nil || err != nil {
+				w.Flush()
+				return err
+			}
+			row, err := p.tableRowData(cols, v)
+			if err != nil {
+				return err
+			}
+			p.writeCSVRow(w, cols, row)
+		}
+	} else if !p.JSON && len(p.Fields) > 0 {
+		var err error
+		if cols, err = p.selectCols(typ, options); err != nil {
+			return err
+		} else if len(cols) == 0 {
+			return nil
+		}
+		if options.Table != nil {
+			p.printHeader(cols)
+		}
+	} else if !p.JSON {
 		if len(cols) == 0 {
 			var err error
 			if cols, err = deriveCols(typ); err != nil {
@@ -173,7 +269,19 @@ func (p *Printer) PrintStructuredIter(typ reflect.Type, iter PrintStructuredIter
 		if v == nil || err != nil {
 			return err
 		}
-		if p.JSON {
+		if p.Template != nil {
+			if err := p.printTemplate(v, p.JSONPayloadShorthand); err != nil {
+				return err
+			}
+		} else if p.JQ != nil {
+			if err := p.printJQ(v, p.JSONPayloadShorthand); err != nil {
+				return err
+			}
+		} else if p.YAML {
+			if err := p.printYAML(v, p.JSONPayloadShorthand); err != nil {
+				return err
+			}
+		} else if p.JSON {
 			b, err := json.Marshal(v)
 			if err != nil {
 				return err
@@ -194,10 +302,123 @@ func (p *Printer) PrintStructuredIter(typ reflect.Type, iter PrintStructuredIter
 	}
 }
 
+func (p *Printer) printCSV(v any, options StructuredOptions) error {
+	typ := reflect.TypeOf(v)
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	cols, err := p.selectCols(typ, options)
+	if err != nil {
+		return err
+	}
+	cols, rows, err := p.tableData(cols, v)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(p.Output)
+	// Lists only have a header once, regardless of how many tables they print
+	if !p.listModeCSVHeader && (options.Table == nil || !options.Table.NoHeader) {
+		p.writeCSVHeader(w, cols)
+	}
+	for _, row := range rows {
+		p.writeCSVRow(w, cols, row)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// Columns are the same as text output would have, unless Fields is set in
+// which case those are used, including ones text output excludes. Table widths
+// and alignment are applied to the selected columns.
+func (p *Printer) selectCols(typ reflect.Type, options StructuredOptions) ([]*col, error) {
+	available := options.toPredefinedCols()
+	if len(p.Fields) > 0 && len(options.Fields) > 0 {
+		available = make([]*col, len(options.Fields))
+		for i, field := range options.Fields {
+			available[i] = &col{name: field}
+		}
+	} else if len(available) == 0 && len(p.Fields) > 0 && typ.Kind() == reflect.Map {
+		// Maps have no known columns, so take the fields as given
+		cols := make([]*col, len(p.Fields))
+		for i, field := range p.Fields {
+			cols[i] = &col{name: field}
+		}
+		return cols, nil
+	} else if len(available) == 0 {
+		var err error
+		if available, err = deriveCols(typ); err != nil {
+			return nil, fmt.Errorf("unable to derive columns: %w", err)
+		}
+	}
+	if len(p.Fields) == 0 {
+		return adjustColsToOptions(available, options), nil
+	}
+	cols := make([]*col, 0, len(p.Fields))
+	var unknown string
+	for _, field := range p.Fields {
+		idx := slices.IndexFunc(available, func(c *col) bool { return strings.EqualFold(c.name, field) })
+		if idx >= 0 {
+			cols = append(cols, available[idx])
+		} else if unknown == "" {
+			unknown = field
+		}
+	}
+	// Text output often has several tables or cards, so only CSV requires every
+	// field to be present. Text ones without any are skipped.
+	if unknown != "" && (p.CSV || len(cols) == 0) {
+		names := make([]string, len(available))
+		for i, c := range available {
+			names[i] = c.name
+		}
+		err := fmt.Errorf("unknown field %q, expected one of: %v", unknown, strings.Join(names, ", "))
+		if p.CSV {
+			return nil, err
+		} else if p.fieldsErr == nil {
+			p.fieldsErr = err
+		}
+		return nil, nil
+	}
+	p.fieldsMatched = true
+	return adjustColsToOptions(cols, StructuredOptions{Table: options.Table}), nil
+}
+
+// FieldsErr returns an error if Fields was set and tables or cards were printed
+// without any of them having any of the fields.
+func (p *Printer) FieldsErr() error {
+	if p.fieldsMatched {
+		return nil
+	}
+	return p.fieldsErr
+}
+
+func (p *Printer) writeCSVHeader(w *csv.Writer, cols []*col) {
+	record := make([]string, len(cols))
+	for i, col := range cols {
+		record[i] = col.name
+	}
+	if err := w.Write(record); err != nil {
+		panic(err)
+	}
+	if p.listMode {
+		p.listModeCSVHeader = true
+	}
+}
+
+func (p *Printer) writeCSVRow(w *csv.Writer, cols []*col, row map[string]colVal) {
+	record := make([]string, len(cols))
+	for i, col := range cols {
+		record[i] = row[col.name].text
+	}
+	if err := w.Write(record); err != nil {
+		panic(err)
+	}
+}
+
 func (p *Printer) write(b []byte) {
 	if _, err := p.Output.Write(b); err != nil {
 		panic(err)
 	}
+	p.markdownWritten = true
 }
 
 func (p *Printer) writeStr(s string) {
@@ -213,7 +434,7 @@ func (p *Printer) writef(s string, v ...any) {
 func (p *Printer) printJSON(v any, options StructuredOptions) error {
 	// Before printing, if we're in non-jsonl list mode, we must append a comma
 	// and a newline if we're not the first JSON seen.
-	nonJSONLListMode := p.listMode && p.JSON && p.JSONIndent != ""
+	nonJSONLListMode := p.listMode && p.JSON && p.JSONIndent != "" && p.JQ == nil
 	if nonJSONLListMode {
 		var prepend string
 		if p.listModeFirstJSON {
@@ -232,6 +453,15 @@ func (p *Printer) printJSON(v any, options StructuredOptions) error {
 	if options.OverrideJSONPayloadShorthand != nil {
 		shorthandPayloads = *options.OverrideJSONPayloadShorthand
 	}
+	if p.Template != nil {
+		return p.printTemplate(v, shorthandPayloads)
+	}
+	if p.JQ != nil {
+		return p.printJQ(v, shorthandPayloads)
+	}
+	if p.YAML {
+		return p.printYAML(v, shorthandPayloads)
+	}
 	if b, err := p.jsonVal(v, p.JSONIndent, shorthandPayloads); err != nil {
 		return err
 	} else if _, err := p.Output.Write(b); err != nil {
@@ -247,6 +477,101 @@ func (p *Printer) printJSON(v any, options StructuredOptions) error {
 	return nil
 }
 
+// Renders the JSON form of the value through the template. Slices are
+// rendered one item at a time so templates can always address item fields.
+func (p *Printer) printTemplate(v any, shorthandPayloads bool) error {
+	b, err := p.jsonVal(v, "", shorthandPayloads)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var data any
+	if err := dec.Decode(&data); err != nil {
+		return err
+	}
+	items, ok := data.([]any)
+	if !ok {
+		items = []any{data}
+	}
+	for _, item := range items {
+		if err := p.Template.Execute(p.Output, item); err != nil {
+			return fmt.Errorf("failed executing template: %w", err)
+		}
+		p.writeStr("\n")
+	}
+	return nil
+}
+
+// Runs the JSON form of the value through the jq program, printing each
+// result on its own.
+func (p *Printer) printJQ(v any, shorthandPayloads bool) error {
+	b, err := p.jsonVal(v, "", shorthandPayloads)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var data any
+	if err := dec.Decode(&data); err != nil {
+		return err
+	}
+	iter := p.JQ.Run(data)
+	for {
+		res, ok := iter.Next()
+		if !ok {
+			return nil
+		} else if err, ok := res.(error); ok {
+			return fmt.Errorf("failed running jq: %w", err)
+		}
+		// Strings are unquoted so they can be used directly in scripts
+		if str, ok := res.(string); ok {
+			p.writeStr(str + "\n")
+			continue
+		}
+		enc := json.NewEncoder(p.Output)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", p.JSONIndent)
+		if err := enc.Encode(res); err != nil {
+			return err
+		}
+	}
+}
+
+// Converts the JSON form of the value to YAML, keeping field order. In list
+// mode, each value after the first is a new document.
+func (p *Printer) printYAML(v any, shorthandPayloads bool) error {
+	b, err := p.jsonVal(v, "", shorthandPayloads)
+	if err != nil {
+		return err
+	}
+	// JSON is YAML, so parse it as such and drop the flow style
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	clearYAMLStyle(&doc)
+	if p.listMode {
+		if !p.listModeFirstJSON {
+			p.writeStr("---\n")
+		}
+		p.listModeFirstJSON = false
+	}
+	enc := yaml.NewEncoder(p.Output)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		clearYAMLStyle(child)
+	}
+}
+
 func (p *Printer) jsonVal(v any, indent string, shorthandPayloads bool) ([]byte, error) {
 	// Use proto JSON if a proto message
 	if protoMessage, ok := v.(proto.Message); ok {
@@ -336,6 +661,10 @@ func (p *Printer) printTable(options *TableOptions, cols []*col, rows []map[stri
 }
 
 func (p *Printer) printHeader(cols []*col) {
+	if p.Markdown {
+		p.printMarkdownHeader(cols)
+		return
+	}
 	colorer := p.TableHeaderColorer
 	if colorer == nil {
 		colorer = color.MagentaString
@@ -355,6 +684,10 @@ func (p *Printer) printRows(cols []*col, rows []map[string]colVal) {
 }
 
 func (p *Printer) printRow(cols []*col, row map[string]colVal) {
+	if p.Markdown {
+		p.printMarkdownRow(cols, row)
+		return
+	}
 	for _, col := range cols {
 		// We want to indent even the first field
 		p.writeStr("  ")
@@ -364,15 +697,63 @@ func (p *Printer) printRow(cols []*col, row map[string]colVal) {
 }
 
 func (p *Printer) printCol(col *col, data string) {
-	switch col.align {
+	p.writeStr(padCol(col.align, data, col.width))
+}
+
+func padCol(align Align, data string, width int) string {
+	switch align {
 	case AlignCenter:
-		data = tablewriter.Pad(data, " ", col.width)
+		return tablewriter.Pad(data, " ", width)
 	case AlignRight:
-		data = tablewriter.PadLeft(data, " ", col.width)
+		return tablewriter.PadLeft(data, " ", width)
 	default:
-		data = tablewriter.PadRight(data, " ", col.width)
+		return tablewriter.PadRight(data, " ", width)
 	}
-	p.writeStr(data)
+}
+
+// Tables need a blank line before them to not be part of a paragraph.
+func (p *Printer) printMarkdownHeader(cols []*col) {
+	if p.markdownWritten && !p.markdownInTable {
+		p.writeStr("\n")
+	}
+	names, separators := make([]string, len(cols)), make([]string, len(cols))
+	for i, col := range cols {
+		width := max(col.width, 3)
+		names[i] = tablewriter.PadRight(markdownCell(col.name), " ", width)
+		switch col.align {
+		case AlignCenter:
+			separators[i] = ":" + strings.Repeat("-", width-2) + ":"
+		case AlignRight:
+			separators[i] = strings.Repeat("-", width-1) + ":"
+		default:
+			separators[i] = strings.Repeat("-", width)
+		}
+	}
+	p.writeStr("| " + strings.Join(names, " | ") + " |\n")
+	p.writeStr("| " + strings.Join(separators, " | ") + " |\n")
+	p.markdownInTable = true
+}
+
+func (p *Printer) printMarkdownRow(cols []*col, row map[string]colVal) {
+	cells := make([]string, len(cols))
+	for i, col := range cols {
+		cells[i] = padCol(col.align, row[col.name].text, max(col.width, 3))
+	}
+	p.writeStr("| " + strings.Join(cells, " | ") + " |\n")
+	p.markdownInTable = true
+}
+
+// Text of a table or card value. For Markdown, pipes are escaped and line
+// breaks replaced so the value stays in its cell.
+func (p *Printer) cellText(v any) string {
+	if p.Markdown {
+		return markdownCell(p.textVal(v))
+	}
+	return p.textVal(v)
+}
+
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>").Replace(s)
 }
 
 func (p *Printer) printCards(cols []*col, rows []map[string]colVal) {
@@ -401,13 +782,83 @@ func (p *Printer) printCard(cols []*col, row map[string]colVal) {
 		// as it needs to the right
 		{name: "Value", width: 1},
 	}
-	p.calculateUnsetColWidths(nameValueCols, nameValueRows)
+	// Markdown tables have a header and pad every column to keep pipes aligned
+	if p.Markdown {
+		nameValueCols[1].width = 0
+		p.calculateUnsetColWidths(nameValueCols, nameValueRows)
+		p.printHeader(nameValueCols)
+	} else {
+		p.calculateUnsetColWidths(nameValueCols, nameValueRows)
+	}
 	p.printRows(nameValueCols, nameValueRows)
 }
 
 var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
 
+// ShortenEnums replaces the full enum names in the given proto JSON of m with
+// their short names (e.g. "WORKFLOW_EXECUTION_STATUS_COMPLETED" becomes
+// "Completed"). This is a no-op if RawEnums is set.
+func (p *Printer) ShortenEnums(b []byte, m proto.Message) []byte {
+	if p.RawEnums || m == nil {
+		return b
+	}
+	names := map[string]string{}
+	collectEnumNames(m.ProtoReflect(), names)
+	if len(names) == 0 {
+		return b
+	}
+	oldNew := make([]string, 0, len(names)*2)
+	for name, short := range names {
+		oldNew = append(oldNew, strconv.Quote(name), strconv.Quote(short))
+	}
+	return []byte(strings.NewReplacer(oldNew...).Replace(string(b)))
+}
+
+func collectEnumNames(m protoreflect.Message, names map[string]string) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				collectEnumValueNames(fd, v.List().Get(i), names)
+			}
+		case fd.IsMap():
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				collectEnumValueNames(fd.MapValue(), v, names)
+				return true
+			})
+		default:
+			collectEnumValueNames(fd, v, names)
+		}
+		return true
+	})
+}
+
+func collectEnumValueNames(fd protoreflect.FieldDescriptor, v protoreflect.Value, names map[string]string) {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		val := fd.Enum().Values().ByNumber(v.Enum())
+		if val == nil {
+			return
+		}
+		// Temporal enums have String() return the short name
+		enumType, err := protoregistry.GlobalTypes.FindEnumByName(fd.Enum().FullName())
+		if err != nil {
+			return
+		}
+		if short := fmt.Sprint(enumType.New(v.Enum())); short != string(val.Name()) {
+			names[string(val.Name())] = short
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		collectEnumNames(v.Message(), names)
+	}
+}
+
 func (p *Printer) textVal(v any) string {
+	if enum, ok := v.(protoreflect.Enum); ok && p.RawEnums {
+		if val := enum.Descriptor().Values().ByNumber(enum.Number()); val != nil {
+			return string(val.Name())
+		}
+	}
 	if ref := reflect.Indirect(reflect.ValueOf(v)); ref.IsValid() {
 		if ref.Type() == reflect.TypeOf(time.Time{}) {
 			if ref.IsZero() {
@@ -422,6 +873,9 @@ func (p *Printer) textVal(v any) string {
 			if err != nil {
 				return fmt.Sprintf("<failed converting to string: %v>", err)
 			}
+			if m, ok := v.(proto.Message); ok {
+				b = p.ShortenEnums(b, m)
+			}
 			return string(b)
 		} else if ref.Kind() == reflect.Slice {
 			// We don't want to reimplement all of fmt.Sprintf, but expanding one level of
@@ -472,7 +926,7 @@ func (p *Printer) tableData(predefinedCols []*col, v any) (cols []*col, rows []m
 		row := make(map[string]colVal, len(cols))
 		for _, col := range cols {
 			colVal := colVal{val: colValGetter(col, itemVal)}
-			colVal.text = p.textVal(colVal.val)
+			colVal.text = p.cellText(colVal.val)
 			row[col.name] = colVal
 		}
 		rows[i] = row
@@ -489,7 +943,7 @@ func (p *Printer) tableRowData(cols []*col, v any) (map[string]colVal, error) {
 	itemVal := reflect.ValueOf(v)
 	for _, col := range cols {
 		colVal := colVal{val: colValGetter(col, itemVal)}
-		colVal.text = p.textVal(colVal.val)
+		colVal.text = p.cellText(colVal.val)
 		row[col.name] = colVal
 	}
 	return row, nil
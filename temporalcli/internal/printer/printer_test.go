@@ -7,10 +7,14 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"text/template"
 	"unicode"
 
+	"github.com/itchyny/gojq"
 	"github.com/stretchr/testify/require"
 	"github.com/temporalio/cli/temporalcli/internal/printer"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/taskqueue/v1"
 )
 
 // TODO(cretz): Test:
@@ -60,6 +64,33 @@ func TestPrinter_Text(t *testing.T) {
 	// TODO(cretz): Tables and more options
 }
 
+func TestPrinter_TextEnums(t *testing.T) {
+	type MyStruct struct {
+		Kind      enums.TaskQueueKind
+		TaskQueue *taskqueue.TaskQueue
+	}
+	v := &MyStruct{
+		Kind:      enums.TASK_QUEUE_KIND_STICKY,
+		TaskQueue: &taskqueue.TaskQueue{Name: "my-task-queue", Kind: enums.TASK_QUEUE_KIND_NORMAL},
+	}
+
+	// Short names by default. Proto JSON may randomly have spaces after colons,
+	// so we just check the values.
+	var buf bytes.Buffer
+	p := printer.Printer{Output: &buf}
+	require.NoError(t, p.PrintStructured(v, printer.StructuredOptions{}))
+	require.Contains(t, buf.String(), "Sticky")
+	require.Contains(t, buf.String(), `"Normal"`)
+	require.NotContains(t, buf.String(), "TASK_QUEUE_KIND_")
+
+	// Full names if raw
+	buf.Reset()
+	p.RawEnums = true
+	require.NoError(t, p.PrintStructured(v, printer.StructuredOptions{}))
+	require.Contains(t, buf.String(), "TASK_QUEUE_KIND_STICKY")
+	require.Contains(t, buf.String(), `"TASK_QUEUE_KIND_NORMAL"`)
+}
+
 func normalizeMultiline(s string) string {
 	// Split lines, trim trailing space on each (also removes \r), remove empty
 	// lines, re-join
@@ -77,6 +108,50 @@ func normalizeMultiline(s string) string {
 	return ret
 }
 
+func TestPrinter_TextFields(t *testing.T) {
+	type row struct {
+		Name  string
+		Notes string
+		Count int
+	}
+	rows := []row{{Name: "foo", Notes: "first", Count: 1}, {Name: "bar", Notes: "second", Count: 22}}
+
+	// Selected fields in order for tables, including excluded ones
+	var buf bytes.Buffer
+	p := printer.Printer{Output: &buf, Fields: []string{"count", "name"}}
+	require.NoError(t, p.PrintStructured(rows, printer.StructuredOptions{
+		ExcludeFields: []string{"Count"},
+		Table:         &printer.TableOptions{},
+	}))
+	require.Equal(t, normalizeMultiline(`
+  Count  Name
+      1  foo
+     22  bar`), normalizeMultiline(buf.String()))
+
+	// Cards skip fields they do not have
+	buf.Reset()
+	p = printer.Printer{Output: &buf, Fields: []string{"Notes", "Missing"}}
+	require.NoError(t, p.PrintStructured(rows[0], printer.StructuredOptions{}))
+	require.Equal(t, normalizeMultiline(`
+  Notes  first`), normalizeMultiline(buf.String()))
+
+	// Tables and cards without any of the fields are skipped, but at least one
+	// must have one
+	type other struct{ Id string }
+	buf.Reset()
+	p = printer.Printer{Output: &buf, Fields: []string{"Name"}}
+	require.NoError(t, p.PrintStructured(rows[0], printer.StructuredOptions{}))
+	require.NoError(t, p.PrintStructured(other{Id: "x"}, printer.StructuredOptions{}))
+	require.NoError(t, p.PrintStructured(rows[1], printer.StructuredOptions{}))
+	require.Equal(t, normalizeMultiline(`
+  Name  foo
+  Name  bar`), normalizeMultiline(buf.String()))
+	require.NoError(t, p.FieldsErr())
+	p = printer.Printer{Output: &buf, Fields: []string{"Missing"}}
+	require.NoError(t, p.PrintStructured(rows, printer.StructuredOptions{}))
+	require.ErrorContains(t, p.FieldsErr(), `unknown field "Missing"`)
+}
+
 func TestPrinter_JSON(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -144,6 +219,159 @@ func TestPrinter_JSONList(t *testing.T) {
 	require.Equal(t, "", buf.String())
 }
 
+func TestPrinter_Template(t *testing.T) {
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("test").Parse("{{.name}}={{.count}}"))
+	p := printer.Printer{Output: &buf, JSON: true, Template: tmpl}
+	p.StartList()
+	p.Println("should not print")
+	require.NoError(t, p.PrintStructured(map[string]any{"name": "foo", "count": 12345678901}, printer.StructuredOptions{}))
+	require.NoError(t, p.PrintStructured([]map[string]any{
+		{"name": "bar", "count": 1},
+		{"name": "baz", "count": 2},
+	}, printer.StructuredOptions{}))
+	p.EndList()
+	require.Equal(t, "foo=12345678901\nbar=1\nbaz=2\n", buf.String())
+}
+
+func TestPrinter_JQ(t *testing.T) {
+	query, err := gojq.Parse(".name, {count}")
+	require.NoError(t, err)
+	code, err := gojq.Compile(query)
+	require.NoError(t, err)
+
+	// No list brackets, strings unquoted, and large numbers kept
+	var buf bytes.Buffer
+	p := printer.Printer{Output: &buf, JSON: true, JSONIndent: "  ", JQ: code}
+	p.StartList()
+	p.Println("should not print")
+	require.NoError(t, p.PrintStructured(map[string]any{"name": "foo", "count": 12345678901234567}, printer.StructuredOptions{}))
+	require.NoError(t, p.PrintStructured(map[string]any{"name": "bar", "count": 1}, printer.StructuredOptions{}))
+	p.EndList()
+	require.Equal(t, "foo\n{\n  \"count\": 12345678901234567\n}\nbar\n{\n  \"count\": 1\n}\n", buf.String())
+
+	// Runtime errors are returned
+	query, err = gojq.Parse(".name | error")
+	require.NoError(t, err)
+	code, err = gojq.Compile(query)
+	require.NoError(t, err)
+	p = printer.Printer{Output: &buf, JSON: true, JQ: code}
+	require.ErrorContains(t, p.PrintStructured(map[string]any{"name": "foo"}, printer.StructuredOptions{}), "failed running jq")
+}
+
+func TestPrinter_CSV(t *testing.T) {
+	type row struct {
+		Name  string
+		Notes string
+		Count int
+	}
+	rows := []row{{Name: "foo", Notes: `has "quotes", commas`, Count: 1}, {Name: "bar", Notes: "multi\nline", Count: 2}}
+
+	// Same columns as text, header only once in list mode
+	var buf bytes.Buffer
+	p := printer.Printer{Output: &buf, CSV: true}
+	p.StartList()
+	p.Println("should not print")
+	require.NoError(t, p.PrintStructured(rows[:1], printer.StructuredOptions{
+		ExcludeFields: []string{"Count"},
+		Table:         &printer.TableOptions{},
+	}))
+	require.NoError(t, p.PrintStructured(rows[1:], printer.StructuredOptions{
+		ExcludeFields: []string{"Count"},
+		Table:         &printer.TableOptions{},
+	}))
+	p.EndList()
+	require.Equal(t, "Name,Notes\nfoo,\"has \"\"quotes\"\", commas\"\nbar,\"multi\nline\"\n", buf.String())
+
+	// Selected fields in order, including excluded ones
+	buf.Reset()
+	p = printer.Printer{Output: &buf, CSV: true, Fields: []string{"count", "name"}}
+	require.NoError(t, p.PrintStructured(rows, printer.StructuredOptions{
+		ExcludeFields: []string{"Count"},
+		Table:         &printer.TableOptions{},
+	}))
+	require.Equal(t, "Count,Name\n1,foo\n2,bar\n", buf.String())
+
+	// Unknown field
+	p = printer.Printer{Output: &buf, CSV: true, Fields: []string{"missing"}}
+	require.ErrorContains(t, p.PrintStructured(rows, printer.StructuredOptions{}), `unknown field "missing"`)
+}
+
+func TestPrinter_Markdown(t *testing.T) {
+	type row struct {
+		Name  string
+		Notes string
+		Count int
+	}
+	rows := []row{{Name: "foo", Notes: "has | pipe", Count: 1}, {Name: "bar", Notes: "multi\nline", Count: 22}}
+
+	// Tables have aligned pipes and escaped cells, with blank lines around them,
+	// and can be continued without a header
+	var buf bytes.Buffer
+	p := printer.Printer{Output: &buf, Markdown: true}
+	p.Println("Heading:")
+	require.NoError(t, p.PrintStructured(rows[:1], printer.StructuredOptions{Table: &printer.TableOptions{}}))
+	require.NoError(t, p.PrintStructured(rows[1:], printer.StructuredOptions{Table: &printer.TableOptions{NoHeader: true}}))
+	p.Println("After")
+	require.Equal(t, `Heading:
+
+| Name | Notes       | Count |
+| ---- | ----------- | ----: |
+| foo  | has \| pipe |     1 |
+| bar  | multi<br>line |    22 |
+
+After
+`, buf.String())
+
+	// Cards are name and value tables
+	buf.Reset()
+	p = printer.Printer{Output: &buf, Markdown: true}
+	require.NoError(t, p.PrintStructured(rows, printer.StructuredOptions{ExcludeFields: []string{"Notes"}}))
+	require.Equal(t, `| Name  | Value |
+| ----- | ----- |
+| Name  | foo   |
+| Count | 1     |
+
+| Name  | Value |
+| ----- | ----- |
+| Name  | bar   |
+| Count | 22    |
+`, buf.String())
+}
+
+func TestPrinter_YAML(t *testing.T) {
+	var buf bytes.Buffer
+	p := printer.Printer{Output: &buf, JSON: true, YAML: true}
+	p.Println("should not print")
+	require.NoError(t, p.PrintStructured(struct {
+		Name  string         `json:"name"`
+		Count int            `json:"count"`
+		ID    string         `json:"id"`
+		Tags  []string       `json:"tags"`
+		Inner map[string]any `json:"inner"`
+	}{Name: "foo", Count: 2, ID: "123", Tags: []string{"a", "b"}, Inner: map[string]any{"multi": "line1\nline2"}},
+		printer.StructuredOptions{}))
+	require.Equal(t, `name: foo
+count: 2
+id: "123"
+tags:
+  - a
+  - b
+inner:
+  multi: |-
+    line1
+    line2
+`, buf.String())
+
+	// List is multiple documents
+	buf.Reset()
+	p.StartList()
+	require.NoError(t, p.PrintStructured(map[string]string{"foo": "bar"}, printer.StructuredOptions{}))
+	require.NoError(t, p.PrintStructured(map[string]string{"baz": "qux"}, printer.StructuredOptions{}))
+	p.EndList()
+	require.Equal(t, "foo: bar\n---\nbaz: qux\n", buf.String())
+}
+
 // Asserts the printer package don't panic if the CLI is run without a STDOUT.
 // This is a tricky thing to validate, as it must be done in a subprocess and as
 // `go test` has its own internal fix for improper STDOUT. This was fixed in
@@ -0,0 +1,111 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ansiClearLine = "\r\x1b[2K"
+	barWidth      = 20
+	renderEvery   = 100 * time.Millisecond
+)
+
+// Bar reports the progress of a long-running operation on a single, constantly
+// rewritten terminal line. A nil Bar is valid and does nothing, so callers do
+// not need to check whether progress is enabled.
+type Bar struct {
+	out   io.Writer
+	label string
+	now   func() time.Time
+
+	mtx        sync.Mutex
+	total      int64
+	done       int64
+	start      time.Time
+	lastRender time.Time
+}
+
+// New creates a bar that writes to out, which should be a terminal. Total may
+// be 0 if unknown, in which case only the count and rate are shown.
+func New(out io.Writer, label string, total int64) *Bar {
+	return NewWithClock(out, label, total, time.Now)
+}
+
+// NewWithClock is New with a custom clock, for testing.
+func NewWithClock(out io.Writer, label string, total int64, now func() time.Time) *Bar {
+	b := &Bar{out: out, label: label, now: now, total: total, start: now()}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.render(true)
+	return b
+}
+
+// Add adds to the number of items done.
+func (b *Bar) Add(n int64) {
+	if b == nil {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.done += n
+	b.render(false)
+}
+
+// Set sets the number of items done and the total, which may be 0 if unknown.
+func (b *Bar) Set(done, total int64) {
+	if b == nil {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.done, b.total = done, total
+	b.render(false)
+}
+
+// Finish clears the progress line. The bar should not be used afterwards.
+func (b *Bar) Finish() {
+	if b == nil {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	_, _ = io.WriteString(b.out, ansiClearLine)
+}
+
+// Must be called with lock held. Renders are throttled unless forced.
+func (b *Bar) render(force bool) {
+	now := b.now()
+	if !force && now.Sub(b.lastRender) < renderEvery {
+		return
+	}
+	b.lastRender = now
+	_, _ = io.WriteString(b.out, ansiClearLine+b.line(now.Sub(b.start)))
+}
+
+func (b *Bar) line(elapsed time.Duration) string {
+	var sb strings.Builder
+	if b.label != "" {
+		sb.WriteString(b.label + " ")
+	}
+	if b.total <= 0 {
+		fmt.Fprintf(&sb, "%d", b.done)
+		if secs := elapsed.Seconds(); secs >= 1 {
+			fmt.Fprintf(&sb, " (%.0f/s)", float64(b.done)/secs)
+		}
+		return sb.String()
+	}
+	done := min(b.done, b.total)
+	filled := int(done * barWidth / b.total)
+	fmt.Fprintf(&sb, "[%s%s] %d/%d %d%%",
+		strings.Repeat("=", filled), strings.Repeat(" ", barWidth-filled), done, b.total, done*100/b.total)
+	// Only estimate once there is something to estimate from
+	if done > 0 && done < b.total {
+		eta := time.Duration(float64(elapsed) * float64(b.total-done) / float64(done))
+		fmt.Fprintf(&sb, " ETA %v", eta.Round(time.Second))
+	}
+	return sb.String()
+}
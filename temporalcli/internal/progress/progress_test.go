@@ -0,0 +1,53 @@
+package progress_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/temporalio/cli/temporalcli/internal/progress"
+)
+
+func TestBar(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	lastLine := func() string {
+		lines := strings.Split(out.String(), "\r\x1b[2K")
+		return lines[len(lines)-1]
+	}
+
+	// Known total
+	bar := progress.NewWithClock(&out, "Fetching", 100, clock)
+	require.Equal(t, "Fetching [                    ] 0/100 0%", lastLine())
+	now = now.Add(10 * time.Second)
+	bar.Add(25)
+	require.Equal(t, "Fetching [=====               ] 25/100 25% ETA 30s", lastLine())
+
+	// Throttled
+	bar.Add(25)
+	require.Equal(t, "Fetching [=====               ] 25/100 25% ETA 30s", lastLine())
+	now = now.Add(time.Second)
+	bar.Add(0)
+	require.Equal(t, "Fetching [==========          ] 50/100 50% ETA 11s", lastLine())
+
+	// Finish clears
+	bar.Finish()
+	require.Equal(t, "", lastLine())
+
+	// Unknown total
+	out.Reset()
+	bar = progress.NewWithClock(&out, "Listing", 0, clock)
+	require.Equal(t, "Listing 0", lastLine())
+	now = now.Add(2 * time.Second)
+	bar.Set(300, 0)
+	require.Equal(t, "Listing 300 (150/s)", lastLine())
+
+	// Nil is a no-op
+	var nilBar *progress.Bar
+	nilBar.Add(1)
+	nilBar.Set(1, 2)
+	nilBar.Finish()
+}
@@ -0,0 +1,159 @@
+package tracer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.temporal.io/api/enums/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	timelineDefaultWidth = 100
+	timelineMinBarWidth  = 10
+	timelineMaxNameWidth = 30
+	timelineDurWidth     = 10
+	timelineStatusWidth  = 10
+)
+
+// Characters used to draw a timeline bar based on how the execution ended.
+const (
+	timelineBarCompleted = '#'
+	timelineBarFailed    = '!'
+	timelineBarRunning   = '~'
+)
+
+// TerminalWidth returns the number of columns in the terminal attached to stdout, or 0 if unknown.
+func TerminalWidth() int {
+	width, _ := getTerminalSize()
+	return width
+}
+
+// PrintTimeline writes a gantt-style timeline of a workflow's activities, timers and child workflows, in the order
+// they were started, scaled to fit in the given width (0 means a default width). Executions that are still open are
+// drawn up to now.
+func PrintTimeline(w io.Writer, state *WorkflowExecutionState, width int, now time.Time) error {
+	if state.StartTime == nil {
+		return fmt.Errorf("workflow has not started")
+	}
+	if width <= 0 {
+		width = timelineDefaultWidth
+	}
+
+	type row struct {
+		name       string
+		start, end time.Time
+		bar        rune
+		status     string
+	}
+	rows := make([]row, 0, len(state.ChildStates)+1)
+	addRow := func(s ExecutionState) {
+		r := row{name: s.GetName(), start: s.GetStartTime()}
+		var closeTime *timestamppb.Timestamp
+		r.bar, r.status, closeTime = timelineStatus(s)
+		r.end = now
+		if closeTime != nil {
+			r.end = closeTime.AsTime()
+		}
+		rows = append(rows, r)
+	}
+	addRow(state)
+	for _, child := range state.ChildStates {
+		addRow(child)
+	}
+
+	nameWidth := 0
+	for _, r := range rows {
+		nameWidth = max(nameWidth, utf8.RuneCountInString(r.name))
+	}
+	nameWidth = min(nameWidth, timelineMaxNameWidth)
+	// Name, space, bar between two pipes, space, duration, space, status
+	barWidth := max(width-nameWidth-timelineDurWidth-timelineStatusWidth-len(" || "+" "), timelineMinBarWidth)
+
+	// Everything is scaled to the workflow's own span
+	origin, total := rows[0].start, rows[0].end.Sub(rows[0].start)
+	if total <= 0 {
+		total = time.Nanosecond
+	}
+	col := func(t time.Time) int {
+		c := int(float64(t.Sub(origin)) / float64(total) * float64(barWidth))
+		return min(max(c, 0), barWidth)
+	}
+
+	var sb strings.Builder
+	for _, r := range rows {
+		name := r.name
+		if runes := []rune(name); len(runes) > nameWidth {
+			name = string(runes[:nameWidth-3]) + "..."
+		}
+		bar := []rune(strings.Repeat(" ", barWidth))
+		dur := "-"
+		if !r.start.IsZero() {
+			from, to := col(r.start), col(r.end)
+			// Always show at least one character so short executions are visible
+			if to <= from {
+				to = min(from+1, barWidth)
+				from = to - 1
+			}
+			for i := from; i < to; i++ {
+				bar[i] = r.bar
+			}
+			dur = r.end.Sub(r.start).Round(time.Millisecond).String()
+		}
+		line := fmt.Sprintf("%-*s |%s| %*s %s", nameWidth, name, string(bar), timelineDurWidth, dur, r.status)
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	// Scale from the workflow's start to its total duration
+	fmt.Fprintf(&sb, "%-*s  0s%*s\n", nameWidth, "", barWidth-len("0s"), total.Round(time.Millisecond))
+	fmt.Fprintf(&sb, "%-*s  %c completed  %c failed  %c running\n",
+		nameWidth, "", timelineBarCompleted, timelineBarFailed, timelineBarRunning)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// timelineStatus returns the bar character, status text and close time (nil if still open) of an execution.
+func timelineStatus(s ExecutionState) (rune, string, *timestamppb.Timestamp) {
+	switch s := s.(type) {
+	case *WorkflowExecutionState:
+		switch s.Status {
+		case enums.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED:
+			return timelineBarRunning, "initiated", s.CloseTime
+		case enums.WORKFLOW_EXECUTION_STATUS_RUNNING:
+			return timelineBarRunning, "running", s.CloseTime
+		case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+			return timelineBarCompleted, "completed", s.CloseTime
+		case enums.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW:
+			return timelineBarCompleted, "continued", s.CloseTime
+		default:
+			return timelineBarFailed, strings.ToLower(strings.TrimPrefix(s.Status.String(), "WORKFLOW_EXECUTION_STATUS_")), s.CloseTime
+		}
+	case *ActivityExecutionState:
+		switch s.Status {
+		case ACTIVITY_EXECUTION_STATUS_SCHEDULED:
+			return timelineBarRunning, "scheduled", s.CloseTime
+		case ACTIVITY_EXECUTION_STATUS_RUNNING, ACTIVITY_EXECUTION_STATUS_CANCEL_REQUESTED:
+			return timelineBarRunning, "running", s.CloseTime
+		case ACTIVITY_EXECUTION_STATUS_COMPLETED:
+			return timelineBarCompleted, "completed", s.CloseTime
+		case ACTIVITY_EXECUTION_STATUS_FAILED:
+			return timelineBarFailed, "failed", s.CloseTime
+		case ACTIVITY_EXECUTION_STATUS_TIMED_OUT:
+			return timelineBarFailed, "timed out", s.CloseTime
+		case ACTIVITY_EXECUTION_STATUS_CANCELED:
+			return timelineBarFailed, "canceled", s.CloseTime
+		}
+		return timelineBarRunning, "", s.CloseTime
+	case *TimerExecutionState:
+		switch s.Status {
+		case TIMER_STATUS_FIRED:
+			return timelineBarCompleted, "fired", s.CloseTime
+		case TIMER_STATUS_CANCELED:
+			return timelineBarFailed, "canceled", s.CloseTime
+		}
+		return timelineBarRunning, "waiting", s.CloseTime
+	}
+	return timelineBarRunning, "", nil
+}
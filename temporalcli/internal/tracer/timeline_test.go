@@ -0,0 +1,108 @@
+package tracer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPrintTimeline(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(secs int) *timestamppb.Timestamp {
+		return timestamppb.New(start.Add(time.Duration(secs) * time.Second))
+	}
+	state := NewWorkflowExecutionState("wid", "rid")
+	for _, event := range []*history.HistoryEvent{
+		{
+			EventId:   1,
+			EventTime: at(0),
+			EventType: enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+			Attributes: &history.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+				WorkflowExecutionStartedEventAttributes: &history.WorkflowExecutionStartedEventAttributes{
+					WorkflowType: &common.WorkflowType{Name: "MyWorkflow"},
+				},
+			},
+		},
+		{
+			EventId:   5,
+			EventTime: at(0),
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+			Attributes: &history.HistoryEvent_ActivityTaskScheduledEventAttributes{
+				ActivityTaskScheduledEventAttributes: &history.ActivityTaskScheduledEventAttributes{
+					ActivityType: &common.ActivityType{Name: "MyActivity"},
+				},
+			},
+		},
+		{
+			EventId:   6,
+			EventTime: at(2),
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_STARTED,
+			Attributes: &history.HistoryEvent_ActivityTaskStartedEventAttributes{
+				ActivityTaskStartedEventAttributes: &history.ActivityTaskStartedEventAttributes{ScheduledEventId: 5},
+			},
+		},
+		{
+			EventId:   7,
+			EventTime: at(6),
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+			Attributes: &history.HistoryEvent_ActivityTaskFailedEventAttributes{
+				ActivityTaskFailedEventAttributes: &history.ActivityTaskFailedEventAttributes{ScheduledEventId: 5},
+			},
+		},
+		{
+			EventId:   11,
+			EventTime: at(6),
+			EventType: enums.EVENT_TYPE_TIMER_STARTED,
+			Attributes: &history.HistoryEvent_TimerStartedEventAttributes{
+				TimerStartedEventAttributes: &history.TimerStartedEventAttributes{
+					TimerId:            "11",
+					StartToFireTimeout: durationpb.New(2 * time.Second),
+				},
+			},
+		},
+		{
+			EventId:   12,
+			EventTime: at(8),
+			EventType: enums.EVENT_TYPE_TIMER_FIRED,
+			Attributes: &history.HistoryEvent_TimerFiredEventAttributes{
+				TimerFiredEventAttributes: &history.TimerFiredEventAttributes{StartedEventId: 11},
+			},
+		},
+		{
+			EventId:   15,
+			EventTime: at(8),
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+			Attributes: &history.HistoryEvent_ActivityTaskScheduledEventAttributes{
+				ActivityTaskScheduledEventAttributes: &history.ActivityTaskScheduledEventAttributes{
+					ActivityType: &common.ActivityType{Name: "AnActivityWithAVeryLongNameIndeed"},
+				},
+			},
+		},
+	} {
+		state.Update(event)
+	}
+
+	// Still running, so drawn up to now
+	var out bytes.Buffer
+	assert.NoError(t, PrintTimeline(&out, state, 75, start.Add(10*time.Second)))
+	assert.Equal(t, ""+
+		"MyWorkflow                     |~~~~~~~~~~~~~~~~~~~~|        10s running\n"+
+		"MyActivity                     |    !!!!!!!!        |         4s failed\n"+
+		"Timer (2s)                     |            ####    |         2s fired\n"+
+		"AnActivityWithAVeryLongName... |                    |          - scheduled\n"+
+		"                                0s               10s\n"+
+		"                                # completed  ! failed  ~ running\n",
+		out.String())
+
+	// Too narrow still has a minimum bar
+	out.Reset()
+	assert.NoError(t, PrintTimeline(&out, state, 20, start.Add(10*time.Second)))
+	assert.Contains(t, out.String(), "MyActivity                     |  !!!!    | ")
+}
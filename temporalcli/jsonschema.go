@@ -0,0 +1,94 @@
+package temporalcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"gopkg.in/yaml.v3"
+)
+
+// Compiles a JSON Schema. The schema may be a path to a JSON or YAML file,
+// relative to dir, or the schema itself. Formats are asserted, and $refs to
+// other JSON or YAML files are resolved relative to the schema.
+func compileJSONSchema(dir string, schema any) (*jsonschema.Schema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+	c.UseLoader(jsonschema.SchemeURLLoader{"file": yamlSchemaLoader{}})
+	path, ok := schema.(string)
+	if ok {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+	} else {
+		doc, err := toJSONSchemaValue(schema)
+		if err != nil {
+			return nil, err
+		}
+		// Inline schemas are placed in dir so their relative $refs resolve the
+		// same as file ones
+		path = filepath.Join(dir, "inline.schema.json")
+		if err := c.AddResource(path, doc); err != nil {
+			return nil, err
+		}
+	}
+	return c.Compile(path)
+}
+
+// Validates JSON against a compiled schema. The error has one line per
+// failure, without the schema location the library starts with.
+func validateJSONSchema(schema *jsonschema.Schema, value []byte) error {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(value))
+	if err != nil {
+		return err
+	}
+	err = schema.Validate(doc)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	lines := strings.Split(validationErr.Error(), "\n")
+	if len(lines) > 1 {
+		lines = lines[1:]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(strings.TrimSpace(line), "- ")
+	}
+	return errors.New(strings.Join(lines, "; "))
+}
+
+// Loads file $refs as YAML, which JSON is a subset of.
+type yamlSchemaLoader struct{}
+
+func (yamlSchemaLoader) Load(url string) (any, error) {
+	path, err := jsonschema.FileLoader{}.ToFile(url)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed parsing %v: %w", path, err)
+	}
+	return toJSONSchemaValue(doc)
+}
+
+// Converts a decoded YAML value to the JSON value types the library expects.
+func toJSONSchemaValue(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(b))
+}
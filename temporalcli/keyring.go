@@ -0,0 +1,61 @@
+package temporalcli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Keyring stores secrets referenced from env config. The OS keychain is used by
+// default, embedders can implement this to keep secrets elsewhere.
+type Keyring interface {
+	// Get returns ErrKeyringItemNotFound if the item does not exist.
+	Get(item string) (string, error)
+	Set(item, secret string) error
+	// Delete returns ErrKeyringItemNotFound if the item does not exist.
+	Delete(item string) error
+}
+
+// ErrKeyringItemNotFound is returned by a Keyring for items that do not exist.
+var ErrKeyringItemNotFound = errors.New("keyring item not found")
+
+// OSKeyring is a Keyring for the macOS Keychain, Windows Credential Manager, or
+// the Secret Service (e.g. GNOME Keyring) via libsecret's secret-tool on other
+// systems. Items are stored under the "temporal" service.
+type OSKeyring struct{}
+
+const keyringService = "temporal"
+
+// Env config values with this prefix are the name of the keyring item that
+// holds the actual value.
+const keyringValuePrefix = "keyring:"
+
+// Returns the env config value, read from the keyring if it references an
+// item there.
+func (c *CommandContext) resolveKeyringValue(value string) (string, error) {
+	item, ok := strings.CutPrefix(value, keyringValuePrefix)
+	if !ok {
+		return value, nil
+	} else if item == "" {
+		return "", fmt.Errorf("missing keyring item name")
+	}
+	secret, err := c.Options.Keyring.Get(item)
+	if errors.Is(err, ErrKeyringItemNotFound) {
+		return "", fmt.Errorf("keyring item %q not found", item)
+	} else if err != nil {
+		return "", fmt.Errorf("failed reading keyring item %q: %w", item, err)
+	}
+	return secret, nil
+}
+
+// Deletes the keyring item the env config value references, if any. Failures
+// are only logged since the env config no longer references the item.
+func (c *CommandContext) deleteEnvConfigKeyringItem(value string) {
+	item, ok := strings.CutPrefix(value, keyringValuePrefix)
+	if !ok || item == "" {
+		return
+	}
+	if err := c.Options.Keyring.Delete(item); err != nil && !errors.Is(err, ErrKeyringItemNotFound) {
+		c.Logger.Warn("Failed deleting keyring item", "item", item, "error", err)
+	}
+}
@@ -0,0 +1,81 @@
+//go:build !windows
+
+package temporalcli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+func (OSKeyring) Get(item string) (string, error) {
+	if runtime.GOOS == "darwin" {
+		out, err := runKeyringTool(nil, "security", "find-generic-password", "-s", keyringService, "-a", item, "-w")
+		return strings.TrimSuffix(out, "\n"), err
+	}
+	out, err := runKeyringTool(nil, "secret-tool", "lookup", "service", keyringService, "item", item)
+	// Nothing is written if it doesn't exist
+	if err == nil && out == "" {
+		return "", ErrKeyringItemNotFound
+	}
+	return out, err
+}
+
+func (OSKeyring) Set(item, secret string) error {
+	if runtime.GOOS == "darwin" {
+		// The secret is given as hex in interactive mode so it never appears in
+		// the process arguments
+		cmd := fmt.Sprintf("add-generic-password -U -s %v -a %v -X %v\n",
+			securityQuote(keyringService), securityQuote(item), hex.EncodeToString([]byte(secret)))
+		_, err := runKeyringTool(strings.NewReader(cmd), "security", "-i")
+		return err
+	}
+	_, err := runKeyringTool(strings.NewReader(secret), "secret-tool", "store",
+		"--label", "Temporal CLI "+item, "service", keyringService, "item", item)
+	return err
+}
+
+func (k OSKeyring) Delete(item string) error {
+	if runtime.GOOS == "darwin" {
+		_, err := runKeyringTool(nil, "security", "delete-generic-password", "-s", keyringService, "-a", item)
+		return err
+	}
+	// Clearing a missing item is not an error
+	if _, err := k.Get(item); err != nil {
+		return err
+	}
+	_, err := runKeyringTool(nil, "secret-tool", "clear", "service", keyringService, "item", item)
+	return err
+}
+
+// Quotes an argument for a command of the macOS security tool's interactive
+// mode.
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func runKeyringTool(stdin *strings.Reader, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	// The macOS security tool exits with 44 for missing items
+	if errors.As(err, &exitErr) && name == "security" && exitErr.ExitCode() == 44 {
+		return "", ErrKeyringItemNotFound
+	} else if errors.As(err, &exitErr) && name == "secret-tool" && exitErr.ExitCode() == 1 && stderr.Len() == 0 {
+		return "", ErrKeyringItemNotFound
+	} else if errors.Is(err, exec.ErrNotFound) {
+		return "", fmt.Errorf("%v not found, it is needed to use the OS keyring", name)
+	} else if err != nil {
+		return "", fmt.Errorf("%v failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,97 @@
+//go:build windows
+
+package temporalcli
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	advapi32      = windows.NewLazySystemDLL("advapi32.dll")
+	procCredRead  = advapi32.NewProc("CredReadW")
+	procCredWrite = advapi32.NewProc("CredWriteW")
+	procCredDel   = advapi32.NewProc("CredDeleteW")
+	procCredFree  = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+// CREDENTIALW
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func keyringTarget(item string) (*uint16, error) {
+	return windows.UTF16PtrFromString(keyringService + ":" + item)
+}
+
+func (OSKeyring) Get(item string) (string, error) {
+	target, err := keyringTarget(item)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	r, _, err := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if r == 0 {
+		return "", keyringError(err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func (OSKeyring) Set(item, secret string) error {
+	target, err := keyringTarget(item)
+	if err != nil {
+		return err
+	}
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(secret)),
+		Persist:            credPersistLocalMachine,
+	}
+	if len(secret) > 0 {
+		blob := []byte(secret)
+		cred.CredentialBlob = &blob[0]
+	}
+	if r, _, err := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0); r == 0 {
+		return keyringError(err)
+	}
+	return nil
+}
+
+func (OSKeyring) Delete(item string) error {
+	target, err := keyringTarget(item)
+	if err != nil {
+		return err
+	}
+	if r, _, err := procCredDel.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0); r == 0 {
+		return keyringError(err)
+	}
+	return nil
+}
+
+func keyringError(err error) error {
+	if errors.Is(err, windows.ERROR_NOT_FOUND) {
+		return ErrKeyringItemNotFound
+	}
+	return fmt.Errorf("credential manager failed: %w", err)
+}
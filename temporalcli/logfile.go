@@ -0,0 +1,160 @@
+package temporalcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Number of rotated log files kept next to the log file, as <file>.1 (the
+// newest) through <file>.3.
+const logFileBackups = 3
+
+// Log file that is appended to and rotated once writing would make it larger
+// than maxSize. A maxSize of 0 never rotates.
+type rotatingLogFile struct {
+	path    string
+	maxSize int64
+
+	mtx  sync.Mutex
+	file *os.File
+	size int64
+}
+
+func openRotatingLogFile(path string, maxSize int64) (*rotatingLogFile, error) {
+	l := &rotatingLogFile{path: path, maxSize: maxSize}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed making log file parent dirs: %w", err)
+	} else if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *rotatingLogFile) open() error {
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed opening log file: %w", err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed opening log file: %w", err)
+	}
+	l.file, l.size = f, stat.Size()
+	return nil
+}
+
+func (l *rotatingLogFile) Write(b []byte) (int, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.file == nil {
+		return 0, os.ErrClosed
+	}
+	// Records are never split, so a single record larger than the max still
+	// goes in its own file
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(b)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := l.file.Write(b)
+	l.size += int64(n)
+	return n, err
+}
+
+func (l *rotatingLogFile) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+	for i := logFileBackups - 1; i >= 1; i-- {
+		err := os.Rename(fmt.Sprintf("%v.%v", l.path, i), fmt.Sprintf("%v.%v", l.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed rotating log file: %w", err)
+		}
+	}
+	if err := os.Rename(l.path, l.path+".1"); err != nil {
+		return fmt.Errorf("failed rotating log file: %w", err)
+	}
+	return l.open()
+}
+
+func (l *rotatingLogFile) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Creates a text or JSON log handler. Text drops the time zone to keep lines
+// short.
+func newLogHandler(format string, w io.Writer, level slog.Level) (slog.Handler, error) {
+	switch format {
+	// We have a "pretty" alias for compatibility
+	case "", "text", "pretty":
+		return slog.NewTextHandler(w, &slog.HandlerOptions{
+			Level: level,
+			// Remove the TZ
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
+					a.Value = slog.StringValue(a.Value.Time().Format("2006-01-02T15:04:05.000"))
+				}
+				return a
+			},
+		}), nil
+	case "json":
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+}
+
+// Sends records to every handler that has their level enabled, so each can
+// have its own level.
+type multiLogHandler []slog.Handler
+
+func (m multiLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m multiLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range m {
+		if h.Enabled(ctx, r.Level) {
+			if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (m multiLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(multiLogHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (m multiLogHandler) WithGroup(name string) slog.Handler {
+	handlers := make(multiLogHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
@@ -0,0 +1,157 @@
+package temporalcli
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+// Returns the proxy to dial the server through, or nil if there is none. An
+// explicit --proxy is always used, otherwise HTTPS_PROXY then ALL_PROXY are
+// used for non-local addresses not excluded by NO_PROXY.
+func (c *ClientOptions) proxyURL(cctx *CommandContext) (*url.URL, error) {
+	if c.Proxy != "" {
+		return parseProxyURL(c.Proxy)
+	}
+	env := func(names ...string) string {
+		for _, name := range names {
+			if v, _ := cctx.Options.LookupEnv(name); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	conf := httpproxy.Config{
+		HTTPSProxy: env("HTTPS_PROXY", "https_proxy", "ALL_PROXY", "all_proxy"),
+		NoProxy:    env("NO_PROXY", "no_proxy"),
+	}
+	if conf.HTTPSProxy == "" {
+		return nil, nil
+	} else if !strings.Contains(conf.HTTPSProxy, "://") {
+		// Commonly set without a scheme, meaning HTTP
+		conf.HTTPSProxy = "http://" + conf.HTTPSProxy
+	}
+	// Validate the proxy ourselves since the proxy func is lenient
+	if _, err := parseProxyURL(conf.HTTPSProxy); err != nil {
+		return nil, err
+	}
+	// Addresses in a list are expected to be alike, so the first decides
+	address := c.Address
+	if addresses := addressList(c.Address); len(addresses) > 0 {
+		address = addressHostPort(addresses[0])
+	}
+	u, err := conf.ProxyFunc()(&url.URL{Scheme: "https", Host: address})
+	if err != nil || u == nil {
+		return nil, err
+	}
+	return parseProxyURL(u.String())
+}
+
+func parseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	var defaultPort string
+	switch u.Scheme {
+	case "http":
+		defaultPort = "80"
+	case "socks5", "socks5h":
+		defaultPort = "1080"
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, must be http, socks5, or socks5h", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q missing host", s)
+	} else if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	return u, nil
+}
+
+// Returns a gRPC context dialer that connects through the proxy.
+func proxyDialer(proxyURL *url.URL) (func(ctx context.Context, addr string) (net.Conn, error), error) {
+	if proxyURL.Scheme != "http" {
+		var auth *proxy.Auth
+		if proxyURL.User != nil {
+			auth = &proxy.Auth{User: proxyURL.User.Username()}
+			auth.Password, _ = proxyURL.User.Password()
+		}
+		dialer, err := proxy.SOCKS5("tcp", proxyURL.Host, auth, &net.Dialer{})
+		if err != nil {
+			return nil, fmt.Errorf("failed creating SOCKS5 dialer: %w", err)
+		}
+		return func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+		}, nil
+	}
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		return dialHTTPConnect(ctx, proxyURL, addr)
+	}, nil
+}
+
+func dialHTTPConnect(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed connecting to proxy: %w", err)
+	}
+	// Stop the handshake if the context is closed
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		creds := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+creds)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed writing proxy CONNECT: %w", err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed reading proxy CONNECT response: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT failed: %v", resp.Status)
+	}
+	if !stop() {
+		return nil, ctx.Err()
+	}
+	_ = conn.SetDeadline(time.Time{})
+	// The server doesn't speak first, but don't lose anything it did send
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (b *bufferedConn) Read(p []byte) (int, error) { return b.r.Read(p) }
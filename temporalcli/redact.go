@@ -0,0 +1,177 @@
+package temporalcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// Flags whose values are secrets. They are redacted anywhere they show up in
+// logs, RPC debug output, and errors unless --no-redact is set.
+var redactedFlags = []string{"api-key", "oauth-client-secret", "tls-key-data", "codec-auth", "grpc-meta"}
+
+const redactedValue = "<redacted>"
+
+var (
+	// PEM private keys of any kind, e.g. from --tls-key-data in an error
+	redactPrivateKeyRegex = regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]*)PRIVATE KEY-----.*?-----END ([A-Z ]*)PRIVATE KEY-----`)
+	// Header or field names that hold credentials, then their value in any of
+	// the forms they are printed in: key=value, "key":"value", key: [Bearer
+	// value] for metadata, and so on. The key itself is left so it's clear
+	// what was removed.
+	redactKeyValueRegex = regexp.MustCompile(`(?i)(\b(?:authorization|proxy-authorization|x-api-key|api[-_]?key|` +
+		`client[-_]?secret|password|passwd|secret|cookie|set-cookie|(?:access|refresh|id|bearer)[-_]?token)"?\s*[:=]\s*)` +
+		`((?:bearer|basic)\s+[^\s"',;}\]]+|"[^"]*"|\[[^\]]*\]|[^\s"',;}\]]+)`)
+	// Credentials that show up without a key, e.g. in a printed header value
+	redactAuthSchemeRegex = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]{8,}=*`)
+	// User info in URLs, e.g. the username and password in a --proxy URL
+	redactURLUserInfoRegex = regexp.MustCompile(`(?i)\b([a-z][a-z0-9+.\-]*://)[^/\s?#@]+@`)
+	// --grpc-meta names whose values are secrets
+	redactMetaNameRegex = regexp.MustCompile(`(?i)authorization|api[-_]?key|secret|password|token|cookie`)
+)
+
+// Removes credentials from text that is printed or logged. Besides patterns
+// for common credential forms, the values of secret flags are removed wherever
+// they show up.
+type redactor struct {
+	disabled bool
+	secrets  []string
+}
+
+// Adds the values of the secret flags that are set.
+func (r *redactor) addFlagSecrets(flags *pflag.FlagSet) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if !flag.Changed || !slices.Contains(redactedFlags, flag.Name) {
+			return
+		}
+		if sliceVal, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, v := range sliceVal.GetSlice() {
+				// Only metadata with secret-looking names, other values like
+				// routing headers are useful to see
+				if name, value, ok := strings.Cut(v, "="); ok && redactMetaNameRegex.MatchString(name) {
+					r.addSecret(value)
+				}
+			}
+		} else {
+			r.addSecret(flag.Value.String())
+		}
+	})
+}
+
+func (r *redactor) addSecret(secret string) {
+	secret = strings.TrimSpace(secret)
+	if secret != "" && !slices.Contains(r.secrets, secret) {
+		r.secrets = append(r.secrets, secret)
+		// Multi-line secrets like keys may be printed with escaped newlines
+		if escaped := strings.ReplaceAll(secret, "\n", `\n`); escaped != secret {
+			r.secrets = append(r.secrets, escaped)
+		}
+	}
+}
+
+func (r *redactor) redact(s string) string {
+	if r == nil || r.disabled {
+		return s
+	}
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redactedValue)
+	}
+	s = redactPrivateKeyRegex.ReplaceAllString(s, "-----BEGIN ${1}PRIVATE KEY-----"+redactedValue+"-----END ${2}PRIVATE KEY-----")
+	s = redactURLUserInfoRegex.ReplaceAllString(s, "${1}"+redactedValue+"@")
+	s = redactKeyValueRegex.ReplaceAllString(s, "${1}"+redactedValue)
+	s = redactAuthSchemeRegex.ReplaceAllString(s, "${1} "+redactedValue)
+	return s
+}
+
+// Returns an error with a redacted message that still unwraps to the original.
+func (r *redactor) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := r.redact(err.Error()); msg != err.Error() {
+		return &redactedError{err: err, msg: msg}
+	}
+	return err
+}
+
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// Writer that redacts each write before passing it on.
+type redactWriter struct {
+	w io.Writer
+	r *redactor
+}
+
+func (w redactWriter) Write(b []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.r.redact(string(b))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Log handler that redacts messages and attributes. Non-string attribute
+// values are redacted in their printed form.
+type redactLogHandler struct {
+	slog.Handler
+	r *redactor
+}
+
+func (h redactLogHandler) Handle(ctx context.Context, rec slog.Record) error {
+	if h.r.disabled {
+		return h.Handler.Handle(ctx, rec)
+	}
+	redacted := slog.NewRecord(rec.Time, rec.Level, h.r.redact(rec.Message), rec.PC)
+	rec.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h redactLogHandler) redactAttr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, h.r.redact(v.String()))
+	case slog.KindGroup:
+		attrs := v.Group()
+		redacted := make([]any, len(attrs))
+		for i, attr := range attrs {
+			redacted[i] = h.redactAttr(attr)
+		}
+		return slog.Group(a.Key, redacted...)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return slog.String(a.Key, h.r.redact(err.Error()))
+		}
+		s := fmt.Sprintf("%+v", v.Any())
+		if redacted := h.r.redact(s); redacted != s {
+			return slog.String(a.Key, redacted)
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
+
+func (h redactLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactAttr(a)
+	}
+	return redactLogHandler{Handler: h.Handler.WithAttrs(redacted), r: h.r}
+}
+
+func (h redactLogHandler) WithGroup(name string) slog.Handler {
+	return redactLogHandler{Handler: h.Handler.WithGroup(name), r: h.r}
+}
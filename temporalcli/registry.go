@@ -0,0 +1,131 @@
+package temporalcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Env config property with the path of a workflow type registry file.
+const workflowTypeRegistryProperty = "workflow-type-registry"
+
+// Defaults and input validation for workflow types, loaded from the file the
+// env config's workflow-type-registry property points to. YAML or JSON, e.g.:
+//
+//	workflowTypes:
+//	  MyWorkflow:
+//	    taskQueue: my-task-queue
+//	    inputSchema: my-workflow-input.schema.json
+//
+// The input schema is a JSON Schema for the first workflow argument, either
+// inline or a path relative to the registry file.
+type workflowTypeRegistry struct {
+	WorkflowTypes map[string]*workflowTypeRegistryEntry `yaml:"workflowTypes"`
+}
+
+type workflowTypeRegistryEntry struct {
+	TaskQueue   string `yaml:"taskQueue"`
+	InputSchema any    `yaml:"inputSchema"`
+
+	inputSchema *jsonschema.Schema
+}
+
+// Returns nil if the env config has no registry. Loaded once.
+func (c *CommandContext) workflowTypeRegistry() (*workflowTypeRegistry, error) {
+	if c.typeRegistryLoaded {
+		return c.typeRegistry, nil
+	}
+	if file := c.EnvConfigValues[c.Options.EnvConfigName][workflowTypeRegistryProperty]; file != "" {
+		file, err := c.resolveEnvConfigValue(c.Options.EnvConfigName, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed getting workflow type registry file: %w", err)
+		}
+		reg, err := loadWorkflowTypeRegistry(file)
+		if err != nil {
+			return nil, err
+		}
+		c.typeRegistry = reg
+	}
+	c.typeRegistryLoaded = true
+	return c.typeRegistry, nil
+}
+
+func loadWorkflowTypeRegistry(file string) (*workflowTypeRegistry, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading workflow type registry: %w", err)
+	}
+	var reg workflowTypeRegistry
+	if err := yaml.Unmarshal(b, &reg); err != nil {
+		return nil, fmt.Errorf("failed parsing workflow type registry %v: %w", file, err)
+	}
+	for name, entry := range reg.WorkflowTypes {
+		if entry == nil {
+			continue
+		} else if entry.inputSchema, err = compileJSONSchema(filepath.Dir(file), entry.InputSchema); err != nil {
+			return nil, fmt.Errorf("invalid input schema for workflow type %v in registry %v: %w", name, file, err)
+		}
+	}
+	return &reg, nil
+}
+
+// Returns nil if the type is not in the registry or there is no registry.
+func (c *CommandContext) workflowTypeRegistryEntry(workflowType string) (*workflowTypeRegistryEntry, error) {
+	reg, err := c.workflowTypeRegistry()
+	if err != nil || reg == nil {
+		return nil, err
+	}
+	return reg.WorkflowTypes[workflowType], nil
+}
+
+// Sets --task-queue from the registry for commands that start workflows of a
+// --type, unless it was set some other way.
+func (c *CommandContext) populateTaskQueueFromRegistry(flags *pflag.FlagSet) error {
+	typeFlag, taskQueueFlag := flags.Lookup("type"), flags.Lookup("task-queue")
+	if typeFlag == nil || taskQueueFlag == nil || taskQueueFlag.Changed || typeFlag.Value.Type() != "string" ||
+		typeFlag.Value.String() == "" {
+		return nil
+	}
+	entry, err := c.workflowTypeRegistryEntry(typeFlag.Value.String())
+	if err != nil || entry == nil || entry.TaskQueue == "" {
+		return err
+	}
+	if err := taskQueueFlag.Value.Set(entry.TaskQueue); err != nil {
+		return err
+	}
+	taskQueueFlag.Changed = true
+	c.setFlagSource(taskQueueFlag.Name, "workflow type registry")
+	return nil
+}
+
+// Fails if the first input is JSON that does not match the registry's input
+// schema for the workflow type.
+func (c *CommandContext) validateWorkflowInput(workflowType string, inputOpts *PayloadInputOptions) error {
+	entry, err := c.workflowTypeRegistryEntry(workflowType)
+	if err != nil || entry == nil || entry.inputSchema == nil {
+		return err
+	}
+	payloads, err := inputOpts.buildRawInputPayloads()
+	if err != nil {
+		return err
+	}
+	input := []byte("null")
+	if len(payloads.Payloads) > 0 {
+		// Only JSON can be checked
+		if string(payloads.Payloads[0].Metadata["encoding"]) != "json/plain" {
+			return nil
+		} else if !json.Valid(payloads.Payloads[0].Data) {
+			return fmt.Errorf("input is not valid JSON")
+		}
+		input = payloads.Payloads[0].Data
+	}
+	if err := validateJSONSchema(entry.inputSchema, input); err != nil {
+		return fmt.Errorf("input does not match schema for workflow type %v: %w", workflowType, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package temporalcli
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	"google.golang.org/grpc"
+)
+
+// X.509 SVID and trust bundles from the SPIFFE Workload API. They are fetched
+// again on new connections once the SVID is halfway to expiring, the same as
+// SPIFFE agents rotate them, so nothing watches the API in the background.
+type spiffeX509Source struct {
+	addr string
+
+	mtx       sync.Mutex
+	x509Ctx   *workloadapi.X509Context
+	refreshAt time.Time
+}
+
+var (
+	_ x509svid.Source   = (*spiffeX509Source)(nil)
+	_ x509bundle.Source = (*spiffeX509Source)(nil)
+)
+
+func (s *spiffeX509Source) get() (*workloadapi.X509Context, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.x509Ctx != nil && time.Now().Before(s.refreshAt) {
+		return s.x509Ctx, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	x509Ctx, err := workloadapi.FetchX509Context(ctx,
+		workloadapi.WithAddr(s.addr), workloadapi.WithDialOptions(grpc.WithNoProxy()))
+	if err != nil {
+		// Keep using the current one while it is valid
+		if s.x509Ctx != nil && time.Now().Before(s.x509Ctx.DefaultSVID().Certificates[0].NotAfter) {
+			return s.x509Ctx, nil
+		}
+		return nil, fmt.Errorf("failed fetching SPIFFE SVID: %w", err)
+	}
+	leaf := x509Ctx.DefaultSVID().Certificates[0]
+	s.x509Ctx = x509Ctx
+	s.refreshAt = leaf.NotBefore.Add(leaf.NotAfter.Sub(leaf.NotBefore) / 2)
+	return x509Ctx, nil
+}
+
+// GetX509SVID returns the default SVID, which is the first one.
+func (s *spiffeX509Source) GetX509SVID() (*x509svid.SVID, error) {
+	x509Ctx, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	return x509Ctx.DefaultSVID(), nil
+}
+
+func (s *spiffeX509Source) GetX509BundleForTrustDomain(td spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	x509Ctx, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	return x509Ctx.Bundles.GetX509BundleForTrustDomain(td)
+}
+
+// Authorizes the server's SPIFFE ID, which must be serverID if set or else be
+// in the same trust domain as our own. If serverName is set, the server
+// certificate must also be valid for it.
+func (s *spiffeX509Source) serverAuthorizer(serverID spiffeid.ID, serverName string) tlsconfig.Authorizer {
+	return func(id spiffeid.ID, verifiedChains [][]*x509.Certificate) error {
+		if !serverID.IsZero() {
+			if id != serverID {
+				return fmt.Errorf("server SPIFFE ID %v is not the expected %v", id, serverID)
+			}
+		} else if svid, err := s.GetX509SVID(); err != nil {
+			return err
+		} else if !id.MemberOf(svid.ID.TrustDomain()) {
+			return fmt.Errorf("server SPIFFE ID %v is not in trust domain %v", id, svid.ID.TrustDomain())
+		}
+		if serverName != "" {
+			if err := verifiedChains[0][0].VerifyHostname(serverName); err != nil {
+				return fmt.Errorf("server certificate is not valid for server name: %w", err)
+			}
+		}
+		return nil
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
+	"slices"
 	"sort"
 	"strings"
+	"unicode"
 )
 
 type StringEnum struct {
@@ -33,6 +36,61 @@ func (s *StringEnum) Set(p string) error {
 
 func (*StringEnum) Type() string { return "string" }
 
+// Splits a command line into arguments like a shell, so arguments such as paths
+// with spaces can be quoted. Single quotes are literal, double quotes allow
+// backslash escapes of quotes and backslashes, and outside of quotes a
+// backslash only escapes quotes, backslashes, and whitespace so Windows paths
+// need no escaping.
+func splitCommandLine(s string) ([]string, error) {
+	var args []string
+	var curr strings.Builder
+	inArg := false
+	var quote rune
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				curr.WriteRune(r)
+			}
+		case r == '\\' && i+1 < len(runes) &&
+			(runes[i+1] == '"' || runes[i+1] == '\\' ||
+				(quote == 0 && (runes[i+1] == '\'' || unicode.IsSpace(runes[i+1])))):
+			i++
+			curr.WriteRune(runes[i])
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				curr.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, curr.String())
+				curr.Reset()
+				inArg = false
+			}
+		default:
+			curr.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, curr.String())
+	}
+	return args, nil
+}
+
 func stringToProtoEnum[T ~int32](s string, maps ...map[string]int32) (T, error) {
 	// Go over each map looking, if not there, use first map to build set of
 	// strings required
@@ -87,3 +145,21 @@ func stringKeysJSONValues(s []string, useJSONNumber bool) (map[string]any, error
 	}
 	return ret, nil
 }
+
+// Same as stringKeysJSONValues but also accepts key=path items whose files
+// contain the JSON values. File values win over inline values for the same key.
+func stringKeysJSONValuesWithFiles(s []string, files []string, useJSONNumber bool) (map[string]any, error) {
+	items := slices.Clone(s)
+	for _, item := range files {
+		pieces := strings.SplitN(item, "=", 2)
+		if len(pieces) != 2 {
+			return nil, fmt.Errorf("missing expected '=' in %q", item)
+		}
+		b, err := os.ReadFile(pieces[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed reading file for key %q: %w", pieces[0], err)
+		}
+		items = append(items, pieces[0]+"="+string(bytes.TrimSpace(b)))
+	}
+	return stringKeysJSONValues(items, useJSONNumber)
+}